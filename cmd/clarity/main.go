@@ -5,6 +5,7 @@ import (
         "strings"
 
         "clarity-cli/internal/cli"
+        "clarity-cli/internal/store"
 )
 
 func isItemID(s string) bool {
@@ -36,7 +37,8 @@ func rewriteDirectItemLookupArgs(argv []string) []string {
                 "--format":    true,
         }
         boolFlags := map[string]bool{
-                "--pretty": true,
+                "--pretty":   true,
+                "--no-hooks": true,
         }
 
         for i := 1; i < len(argv); i++ {
@@ -89,7 +91,10 @@ func main() {
         os.Args = rewriteDirectItemLookupArgs(os.Args)
 
         cmd := cli.NewRootCmd()
-        if err := cmd.Execute(); err != nil {
+        err := cmd.Execute()
+        // Hooks are delivered in the background; give them a (bounded) chance to finish.
+        store.WaitForHooks()
+        if err != nil {
                 os.Exit(1)
         }
 }
@@ -5,61 +5,61 @@ go 1.24
 toolchain go1.24.11
 
 require (
-        github.com/charmbracelet/bubbles v0.20.0
-        github.com/charmbracelet/bubbletea v1.3.5
-        github.com/charmbracelet/glamour v0.10.0
-        github.com/charmbracelet/lipgloss v1.1.1-0.20250404203927-76690c660834
-        github.com/charmbracelet/x/ansi v0.8.0
-        github.com/muesli/termenv v0.16.0
-        github.com/spf13/cobra v1.8.1
-        modernc.org/sqlite v1.39.0
+	github.com/charmbracelet/bubbles v0.20.0
+	github.com/charmbracelet/bubbletea v1.3.5
+	github.com/charmbracelet/glamour v0.10.0
+	github.com/charmbracelet/lipgloss v1.1.1-0.20250404203927-76690c660834
+	github.com/charmbracelet/x/ansi v0.8.0
+	github.com/creack/pty v1.1.21
+	github.com/gorilla/websocket v1.5.1
+	github.com/muesli/termenv v0.16.0
+	github.com/spf13/cobra v1.8.1
+	modernc.org/sqlite v1.39.0
 )
 
 require (
-        github.com/CAFxX/httpcompression v0.0.9 // indirect
-        github.com/alecthomas/chroma/v2 v2.14.0 // indirect
-        github.com/andybalholm/brotli v1.0.5 // indirect
-        github.com/atotto/clipboard v0.1.4 // indirect
-        github.com/aymanbagabas/go-osc52/v2 v2.0.1 // indirect
-        github.com/aymerick/douceur v0.2.0 // indirect
-        github.com/charmbracelet/colorprofile v0.2.3-0.20250311203215-f60798e515dc // indirect
-        github.com/charmbracelet/x/cellbuf v0.0.13 // indirect
-        github.com/charmbracelet/x/exp/slice v0.0.0-20250327172914-2fdc97757edf // indirect
-        github.com/charmbracelet/x/term v0.2.1 // indirect
-        github.com/creack/pty v1.1.21 // indirect
-        github.com/dlclark/regexp2 v1.11.0 // indirect
-        github.com/dustin/go-humanize v1.0.1 // indirect
-        github.com/erikgeiser/coninput v0.0.0-20211004153227-1c3628e74d0f // indirect
-        github.com/google/uuid v1.6.0 // indirect
-        github.com/gorilla/css v1.0.1 // indirect
-        github.com/gorilla/websocket v1.5.1 // indirect
-        github.com/inconshreveable/mousetrap v1.1.0 // indirect
-        github.com/klauspost/compress v1.18.0 // indirect
-        github.com/lucasb-eyer/go-colorful v1.2.0 // indirect
-        github.com/mattn/go-isatty v0.0.20 // indirect
-        github.com/mattn/go-localereader v0.0.1 // indirect
-        github.com/mattn/go-runewidth v0.0.16 // indirect
-        github.com/microcosm-cc/bluemonday v1.0.27 // indirect
-        github.com/muesli/ansi v0.0.0-20230316100256-276c6243b2f6 // indirect
-        github.com/muesli/cancelreader v0.2.2 // indirect
-        github.com/muesli/reflow v0.3.0 // indirect
-        github.com/ncruces/go-strftime v0.1.9 // indirect
-        github.com/remyoudompheng/bigfft v0.0.0-20230129092748-24d4a6f8daec // indirect
-        github.com/rivo/uniseg v0.4.7 // indirect
-        github.com/sahilm/fuzzy v0.1.1 // indirect
-        github.com/spf13/pflag v1.0.5 // indirect
-        github.com/starfederation/datastar-go v1.0.0 // indirect
-        github.com/valyala/bytebufferpool v1.0.0 // indirect
-        github.com/xo/terminfo v0.0.0-20220910002029-abceb7e1c41e // indirect
-        github.com/yuin/goldmark v1.7.8 // indirect
-        github.com/yuin/goldmark-emoji v1.0.5 // indirect
-        golang.org/x/exp v0.0.0-20250620022241-b7579e27df2b // indirect
-        golang.org/x/net v0.33.0 // indirect
-        golang.org/x/sync v0.15.0 // indirect
-        golang.org/x/sys v0.34.0 // indirect
-        golang.org/x/term v0.31.0 // indirect
-        golang.org/x/text v0.24.0 // indirect
-        modernc.org/libc v1.66.3 // indirect
-        modernc.org/mathutil v1.7.1 // indirect
-        modernc.org/memory v1.11.0 // indirect
+	github.com/CAFxX/httpcompression v0.0.9 // indirect
+	github.com/alecthomas/chroma/v2 v2.14.0 // indirect
+	github.com/andybalholm/brotli v1.0.5 // indirect
+	github.com/atotto/clipboard v0.1.4 // indirect
+	github.com/aymanbagabas/go-osc52/v2 v2.0.1 // indirect
+	github.com/aymerick/douceur v0.2.0 // indirect
+	github.com/charmbracelet/colorprofile v0.2.3-0.20250311203215-f60798e515dc // indirect
+	github.com/charmbracelet/x/cellbuf v0.0.13 // indirect
+	github.com/charmbracelet/x/exp/slice v0.0.0-20250327172914-2fdc97757edf // indirect
+	github.com/charmbracelet/x/term v0.2.1 // indirect
+	github.com/dlclark/regexp2 v1.11.0 // indirect
+	github.com/dustin/go-humanize v1.0.1 // indirect
+	github.com/erikgeiser/coninput v0.0.0-20211004153227-1c3628e74d0f // indirect
+	github.com/google/uuid v1.6.0 // indirect
+	github.com/gorilla/css v1.0.1 // indirect
+	github.com/inconshreveable/mousetrap v1.1.0 // indirect
+	github.com/klauspost/compress v1.18.0 // indirect
+	github.com/lucasb-eyer/go-colorful v1.2.0 // indirect
+	github.com/mattn/go-isatty v0.0.20 // indirect
+	github.com/mattn/go-localereader v0.0.1 // indirect
+	github.com/mattn/go-runewidth v0.0.16 // indirect
+	github.com/microcosm-cc/bluemonday v1.0.27 // indirect
+	github.com/muesli/ansi v0.0.0-20230316100256-276c6243b2f6 // indirect
+	github.com/muesli/cancelreader v0.2.2 // indirect
+	github.com/muesli/reflow v0.3.0 // indirect
+	github.com/ncruces/go-strftime v0.1.9 // indirect
+	github.com/remyoudompheng/bigfft v0.0.0-20230129092748-24d4a6f8daec // indirect
+	github.com/rivo/uniseg v0.4.7 // indirect
+	github.com/sahilm/fuzzy v0.1.1 // indirect
+	github.com/spf13/pflag v1.0.5 // indirect
+	github.com/starfederation/datastar-go v1.0.0 // indirect
+	github.com/valyala/bytebufferpool v1.0.0 // indirect
+	github.com/xo/terminfo v0.0.0-20220910002029-abceb7e1c41e // indirect
+	github.com/yuin/goldmark v1.7.8 // indirect
+	github.com/yuin/goldmark-emoji v1.0.5 // indirect
+	golang.org/x/exp v0.0.0-20250620022241-b7579e27df2b // indirect
+	golang.org/x/net v0.33.0 // indirect
+	golang.org/x/sync v0.15.0 // indirect
+	golang.org/x/sys v0.34.0 // indirect
+	golang.org/x/term v0.31.0 // indirect
+	golang.org/x/text v0.24.0 // indirect
+	modernc.org/libc v1.66.3 // indirect
+	modernc.org/mathutil v1.7.1 // indirect
+	modernc.org/memory v1.11.0 // indirect
 )
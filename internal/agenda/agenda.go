@@ -0,0 +1,385 @@
+package agenda
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"clarity-cli/internal/model"
+	"clarity-cli/internal/repeat"
+	"clarity-cli/internal/statusutil"
+	"clarity-cli/internal/store"
+)
+
+const dateLayout = "2006-01-02"
+
+// DefaultDeadlineWarningDays matches Org's default org-deadline-warning-days.
+const DefaultDeadlineWarningDays = 14
+
+type Span string
+
+const (
+	SpanDay  Span = "day"
+	SpanWeek Span = "week"
+)
+
+func ParseSpan(s string) (Span, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "", "day", "d":
+		return SpanDay, nil
+	case "week", "w":
+		return SpanWeek, nil
+	default:
+		return "", fmt.Errorf("invalid span: %q (expected day|week)", s)
+	}
+}
+
+// Days returns the number of calendar days covered by the span.
+func (s Span) Days() int {
+	if s == SpanWeek {
+		return 7
+	}
+	return 1
+}
+
+// Kind describes why an item shows up on a given agenda day.
+type Kind string
+
+const (
+	// KindScheduled: the item is scheduled on this day.
+	KindScheduled Kind = "scheduled"
+	// KindScheduledPast: the item was scheduled earlier and is still open (shown on today).
+	KindScheduledPast Kind = "scheduled_past"
+	// KindDeadline: the item is due on this day.
+	KindDeadline Kind = "deadline"
+	// KindDeadlineUpcoming: the item is due within the warning period (shown on today).
+	KindDeadlineUpcoming Kind = "deadline_upcoming"
+	// KindDeadlineOverdue: the item's deadline has passed and it is still open (shown on today).
+	KindDeadlineOverdue Kind = "deadline_overdue"
+)
+
+// kindOrder controls the order of untimed rows within a day (most urgent first).
+var kindOrder = map[Kind]int{
+	KindDeadlineOverdue:  0,
+	KindDeadline:         1,
+	KindScheduledPast:    2,
+	KindScheduled:        3,
+	KindDeadlineUpcoming: 4,
+}
+
+type Options struct {
+	// From is the first day of the agenda (only the calendar date is used).
+	From time.Time
+	Span Span
+	// Today controls carry-forward and deadline warnings. Defaults to time.Now().
+	Today time.Time
+	// WarningDays is how many days ahead deadlines are announced on today.
+	// nil uses DefaultDeadlineWarningDays; 0 or negative disables warnings.
+	WarningDays *int
+}
+
+type Row struct {
+	Date string `json:"date"`
+	// Time is the HH:MM time-of-day (only set on the item's own date).
+	Time  string `json:"time,omitempty"`
+	Kind  Kind   `json:"kind"`
+	Label string `json:"label"`
+	// Days is the distance in days from the item's date to the row date:
+	// days since scheduled (scheduled_past), days until due (deadline_upcoming),
+	// or days overdue (deadline_overdue). 0 otherwise.
+	Days int `json:"days"`
+	// Repeat is the repeater of the schedule/due behind the row (e.g. "+1w"). Rows on a later
+	// occurrence than the item's current date have Occurrence set.
+	Repeat     string `json:"repeat,omitempty"`
+	Occurrence bool   `json:"occurrence,omitempty"`
+
+	ItemID    string          `json:"itemId"`
+	Title     string          `json:"title"`
+	StatusID  string          `json:"status,omitempty"`
+	ProjectID string          `json:"projectId"`
+	OutlineID string          `json:"outlineId"`
+	Priority  bool            `json:"priority"`
+	Due       *model.DateTime `json:"due,omitempty"`
+	Schedule  *model.DateTime `json:"schedule,omitempty"`
+}
+
+type Day struct {
+	Date    string `json:"date"`
+	Weekday string `json:"weekday"`
+	Today   bool   `json:"today,omitempty"`
+	Rows    []Row  `json:"rows"`
+}
+
+type Result struct {
+	Span        Span   `json:"span"`
+	From        string `json:"from"`
+	To          string `json:"to"`
+	Today       string `json:"today"`
+	WarningDays int    `json:"warningDays"`
+	Days        []Day  `json:"days"`
+}
+
+// DefaultFrom returns the default first day for a span: today for day agendas,
+// and the Monday of today's week for week agendas (like Org's default).
+func DefaultFrom(span Span, today time.Time) time.Time {
+	d := dateOnly(today)
+	if span != SpanWeek {
+		return d
+	}
+	offset := (int(d.Weekday()) + 6) % 7 // Monday=0 ... Sunday=6
+	return d.AddDate(0, 0, -offset)
+}
+
+// ParseDate parses a YYYY-MM-DD date.
+func ParseDate(s string) (time.Time, error) {
+	t, err := time.Parse(dateLayout, strings.TrimSpace(s))
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date %q (expected YYYY-MM-DD)", s)
+	}
+	return t, nil
+}
+
+// Build computes a date-driven agenda over open items (not archived, not on hold, not in an
+// end-state, and not in an archived project/outline).
+//
+// Rules (Org-style):
+// - scheduled items show on their scheduled date
+// - deadlines show on their due date
+// - on today, open items scheduled in the past are carried forward, overdue deadlines are
+//   repeated, and deadlines within WarningDays are announced
+// - repeating dates (see internal/repeat) also show on their upcoming occurrences (from today on)
+// - within a day, rows with a time of day come first (ordered by time)
+func Build(db *store.DB, opts Options) Result {
+	span := opts.Span
+	if span == "" {
+		span = SpanDay
+	}
+	today := opts.Today
+	if today.IsZero() {
+		today = time.Now()
+	}
+	today = dateOnly(today)
+	from := opts.From
+	if from.IsZero() {
+		from = DefaultFrom(span, today)
+	}
+	from = dateOnly(from)
+	warn := DefaultDeadlineWarningDays
+	if opts.WarningDays != nil {
+		warn = *opts.WarningDays
+	}
+	if warn < 0 {
+		warn = 0
+	}
+
+	n := span.Days()
+	res := Result{
+		Span:        span,
+		From:        from.Format(dateLayout),
+		To:          from.AddDate(0, 0, n-1).Format(dateLayout),
+		Today:       today.Format(dateLayout),
+		WarningDays: warn,
+		Days:        make([]Day, 0, n),
+	}
+	byDate := map[string]int{}
+	for i := 0; i < n; i++ {
+		d := from.AddDate(0, 0, i)
+		key := d.Format(dateLayout)
+		byDate[key] = i
+		res.Days = append(res.Days, Day{
+			Date:    key,
+			Weekday: d.Weekday().String(),
+			Today:   d.Equal(today),
+			Rows:    []Row{},
+		})
+	}
+	if db == nil {
+		return res
+	}
+	_, todayInSpan := byDate[res.Today]
+
+	add := func(date string, r Row) {
+		idx, ok := byDate[date]
+		if !ok {
+			return
+		}
+		r.Date = date
+		res.Days[idx].Rows = append(res.Days[idx].Rows, r)
+	}
+
+	to := from.AddDate(0, 0, n-1)
+	upcomingFrom := from
+	if today.After(upcomingFrom) {
+		upcomingFrom = today
+	}
+
+	// addOccurrences adds r on each upcoming occurrence of a repeating date.
+	addOccurrences := func(date time.Time, dt *model.DateTime, r Row) {
+		if dt == nil || strings.TrimSpace(dt.Repeat) == "" {
+			return
+		}
+		sp, err := repeat.Parse(dt.Repeat)
+		if err != nil {
+			return
+		}
+		for _, d := range sp.Occurrences(date, upcomingFrom, to) {
+			occ := r
+			occ.Occurrence = true
+			add(d.Format(dateLayout), occ)
+		}
+	}
+
+	for _, it := range db.Items {
+		if it.Due == nil && it.Schedule == nil {
+			continue
+		}
+		if !isOpen(db, it) {
+			continue
+		}
+		base := Row{
+			ItemID:    it.ID,
+			Title:     it.Title,
+			StatusID:  it.StatusID,
+			ProjectID: it.ProjectID,
+			OutlineID: it.OutlineID,
+			Priority:  it.Priority,
+			Due:       it.Due,
+			Schedule:  it.Schedule,
+		}
+
+		if sched, ok := parseDateTime(it.Schedule); ok {
+			key := sched.Format(dateLayout)
+			r := base
+			r.Kind = KindScheduled
+			r.Label = "Scheduled"
+			r.Time = timeOf(it.Schedule)
+			r.Repeat = strings.TrimSpace(it.Schedule.Repeat)
+			if _, inSpan := byDate[key]; inSpan {
+				add(key, r)
+			}
+			addOccurrences(sched, it.Schedule, r)
+			if todayInSpan && sched.Before(today) {
+				r := base
+				r.Repeat = strings.TrimSpace(it.Schedule.Repeat)
+				r.Kind = KindScheduledPast
+				r.Days = daysBetween(sched, today)
+				r.Label = fmt.Sprintf("Sched. %dx", r.Days)
+				add(res.Today, r)
+			}
+		}
+
+		if due, ok := parseDateTime(it.Due); ok {
+			key := due.Format(dateLayout)
+			r := base
+			r.Kind = KindDeadline
+			r.Label = "Deadline"
+			r.Time = timeOf(it.Due)
+			r.Repeat = strings.TrimSpace(it.Due.Repeat)
+			if _, inSpan := byDate[key]; inSpan {
+				add(key, r)
+			}
+			addOccurrences(due, it.Due, r)
+			if todayInSpan {
+				days := daysBetween(today, due)
+				switch {
+				case days < 0:
+					r := base
+					r.Repeat = strings.TrimSpace(it.Due.Repeat)
+					r.Kind = KindDeadlineOverdue
+					r.Days = -days
+					r.Label = fmt.Sprintf("%d d. ago", r.Days)
+					add(res.Today, r)
+				case days > 0 && days <= warn:
+					r := base
+					r.Repeat = strings.TrimSpace(it.Due.Repeat)
+					r.Kind = KindDeadlineUpcoming
+					r.Days = days
+					r.Label = fmt.Sprintf("In %d d.", days)
+					add(res.Today, r)
+				}
+			}
+		}
+	}
+
+	for i := range res.Days {
+		sortRows(res.Days[i].Rows)
+	}
+	return res
+}
+
+func sortRows(rows []Row) {
+	sort.SliceStable(rows, func(i, j int) bool {
+		a, b := rows[i], rows[j]
+		if (a.Time != "") != (b.Time != "") {
+			return a.Time != ""
+		}
+		if a.Time != b.Time {
+			return a.Time < b.Time
+		}
+		if kindOrder[a.Kind] != kindOrder[b.Kind] {
+			return kindOrder[a.Kind] < kindOrder[b.Kind]
+		}
+		if a.Kind == b.Kind && a.Days != b.Days {
+			// More overdue / sooner due first.
+			if a.Kind == KindDeadlineUpcoming {
+				return a.Days < b.Days
+			}
+			return a.Days > b.Days
+		}
+		if a.Priority != b.Priority {
+			return a.Priority
+		}
+		ta := strings.ToLower(strings.TrimSpace(a.Title))
+		tb := strings.ToLower(strings.TrimSpace(b.Title))
+		if ta != tb {
+			return ta < tb
+		}
+		return a.ItemID < b.ItemID
+	})
+}
+
+func isOpen(db *store.DB, it model.Item) bool {
+	if it.Archived || it.OnHold {
+		return false
+	}
+	if p, ok := db.FindProject(it.ProjectID); ok && p != nil && p.Archived {
+		return false
+	}
+	o, ok := db.FindOutline(it.OutlineID)
+	if !ok || o == nil {
+		return !statusutil.IsEndState(model.Outline{}, it.StatusID)
+	}
+	if o.Archived {
+		return false
+	}
+	return !statusutil.IsEndState(*o, it.StatusID)
+}
+
+func parseDateTime(dt *model.DateTime) (time.Time, bool) {
+	if dt == nil {
+		return time.Time{}, false
+	}
+	t, err := time.Parse(dateLayout, strings.TrimSpace(dt.Date))
+	if err != nil {
+		return time.Time{}, false
+	}
+	return t, true
+}
+
+func timeOf(dt *model.DateTime) string {
+	if dt == nil || dt.Time == nil {
+		return ""
+	}
+	return strings.TrimSpace(*dt.Time)
+}
+
+// dateOnly returns t's calendar date (in t's location) as midnight UTC.
+func dateOnly(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+func daysBetween(a, b time.Time) int {
+	return int(b.Sub(a).Hours() / 24)
+}
@@ -0,0 +1,206 @@
+package agenda
+
+import (
+	"testing"
+	"time"
+
+	"clarity-cli/internal/model"
+	"clarity-cli/internal/store"
+)
+
+func dt(date string) *model.DateTime { return &model.DateTime{Date: date} }
+
+func dtAt(date, hm string) *model.DateTime { return &model.DateTime{Date: date, Time: &hm} }
+
+func mustDate(t *testing.T, s string) time.Time {
+	t.Helper()
+	d, err := ParseDate(s)
+	if err != nil {
+		t.Fatalf("parse date: %v", err)
+	}
+	return d
+}
+
+func TestBuild_WeekPlacesScheduledAndDeadlinesOnTheirDays(t *testing.T) {
+	db := &store.DB{
+		Outlines: []model.Outline{{ID: "out-a", ProjectID: "proj-a", StatusDefs: store.DefaultOutlineStatusDefs()}},
+		Items: []model.Item{
+			{ID: "item-sched", Title: "Scheduled", OutlineID: "out-a", StatusID: "todo", Schedule: dt("2026-10-21")},
+			{ID: "item-due", Title: "Due", OutlineID: "out-a", StatusID: "todo", Due: dt("2026-10-23")},
+			{ID: "item-outside", Title: "Later", OutlineID: "out-a", StatusID: "todo", Schedule: dt("2026-11-30")},
+			{ID: "item-done", Title: "Done", OutlineID: "out-a", StatusID: "done", Schedule: dt("2026-10-21")},
+			{ID: "item-archived", Title: "Archived", OutlineID: "out-a", StatusID: "todo", Archived: true, Due: dt("2026-10-21")},
+		},
+	}
+
+	res := Build(db, Options{From: mustDate(t, "2026-10-19"), Span: SpanWeek, Today: mustDate(t, "2026-10-01")})
+	if res.From != "2026-10-19" || res.To != "2026-10-25" || len(res.Days) != 7 {
+		t.Fatalf("unexpected range: from=%s to=%s days=%d", res.From, res.To, len(res.Days))
+	}
+	if res.Days[0].Weekday != "Monday" {
+		t.Fatalf("expected Monday, got %s", res.Days[0].Weekday)
+	}
+	wed := res.Days[2]
+	if len(wed.Rows) != 1 || wed.Rows[0].ItemID != "item-sched" || wed.Rows[0].Kind != KindScheduled {
+		t.Fatalf("expected scheduled row on Wednesday, got %+v", wed.Rows)
+	}
+	fri := res.Days[4]
+	if len(fri.Rows) != 1 || fri.Rows[0].ItemID != "item-due" || fri.Rows[0].Kind != KindDeadline {
+		t.Fatalf("expected deadline row on Friday, got %+v", fri.Rows)
+	}
+	total := 0
+	for _, d := range res.Days {
+		total += len(d.Rows)
+	}
+	if total != 2 {
+		t.Fatalf("expected 2 rows in the week, got %d", total)
+	}
+}
+
+func TestBuild_TodayCarriesForwardAndWarns(t *testing.T) {
+	db := &store.DB{
+		Outlines: []model.Outline{{ID: "out-a", ProjectID: "proj-a", StatusDefs: store.DefaultOutlineStatusDefs()}},
+		Items: []model.Item{
+			{ID: "item-late-sched", Title: "Late scheduled", OutlineID: "out-a", StatusID: "todo", Schedule: dt("2026-10-15")},
+			{ID: "item-overdue", Title: "Overdue", OutlineID: "out-a", StatusID: "todo", Due: dt("2026-10-17")},
+			{ID: "item-soon", Title: "Soon", OutlineID: "out-a", StatusID: "todo", Due: dt("2026-10-22")},
+			{ID: "item-far", Title: "Far", OutlineID: "out-a", StatusID: "todo", Due: dt("2026-12-22")},
+		},
+	}
+
+	today := mustDate(t, "2026-10-19")
+	res := Build(db, Options{From: today, Span: SpanDay, Today: today})
+	if len(res.Days) != 1 || !res.Days[0].Today {
+		t.Fatalf("expected single today day, got %+v", res.Days)
+	}
+	rows := res.Days[0].Rows
+	if len(rows) != 3 {
+		t.Fatalf("expected 3 rows, got %+v", rows)
+	}
+	if rows[0].ItemID != "item-overdue" || rows[0].Kind != KindDeadlineOverdue || rows[0].Days != 2 || rows[0].Label != "2 d. ago" {
+		t.Fatalf("unexpected overdue row: %+v", rows[0])
+	}
+	if rows[1].ItemID != "item-late-sched" || rows[1].Kind != KindScheduledPast || rows[1].Days != 4 {
+		t.Fatalf("unexpected carried-forward row: %+v", rows[1])
+	}
+	if rows[2].ItemID != "item-soon" || rows[2].Kind != KindDeadlineUpcoming || rows[2].Label != "In 3 d." {
+		t.Fatalf("unexpected warning row: %+v", rows[2])
+	}
+
+	// --warning-days 0 turns the announcements off.
+	none := 0
+	if rows := Build(db, Options{From: today, Span: SpanDay, Today: today, WarningDays: &none}).Days[0].Rows; len(rows) != 2 {
+		t.Fatalf("expected no warning rows, got %+v", rows)
+	}
+
+	// Viewing a past day must not carry anything forward.
+	past := Build(db, Options{From: mustDate(t, "2026-10-10"), Span: SpanDay, Today: today})
+	if len(past.Days[0].Rows) != 0 {
+		t.Fatalf("expected no rows on a past day, got %+v", past.Days[0].Rows)
+	}
+}
+
+func TestBuild_TimedRowsComeFirstInTimeOrder(t *testing.T) {
+	db := &store.DB{
+		Outlines: []model.Outline{{ID: "out-a", ProjectID: "proj-a", StatusDefs: store.DefaultOutlineStatusDefs()}},
+		Items: []model.Item{
+			{ID: "item-untimed", Title: "A untimed", OutlineID: "out-a", StatusID: "todo", Schedule: dt("2026-10-19")},
+			{ID: "item-late", Title: "B late", OutlineID: "out-a", StatusID: "todo", Schedule: dtAt("2026-10-19", "15:30")},
+			{ID: "item-early", Title: "C early", OutlineID: "out-a", StatusID: "todo", Due: dtAt("2026-10-19", "09:00")},
+		},
+	}
+	day := mustDate(t, "2026-10-19")
+	rows := Build(db, Options{From: day, Span: SpanDay, Today: day}).Days[0].Rows
+	got := []string{}
+	for _, r := range rows {
+		got = append(got, r.ItemID)
+	}
+	want := []string{"item-early", "item-late", "item-untimed"}
+	if len(got) != len(want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("expected %v, got %v", want, got)
+		}
+	}
+	if rows[0].Time != "09:00" {
+		t.Fatalf("expected time on timed row, got %+v", rows[0])
+	}
+}
+
+func TestDefaultFrom_WeekStartsOnMonday(t *testing.T) {
+	sunday := mustDate(t, "2026-10-25")
+	if got := DefaultFrom(SpanWeek, sunday).Format(dateLayout); got != "2026-10-19" {
+		t.Fatalf("expected 2026-10-19, got %s", got)
+	}
+	if got := DefaultFrom(SpanDay, sunday).Format(dateLayout); got != "2026-10-25" {
+		t.Fatalf("expected 2026-10-25, got %s", got)
+	}
+}
+
+func TestBuild_RepeatingDatesShowOnUpcomingOccurrences(t *testing.T) {
+	weekly := dt("2026-10-12")
+	weekly.Repeat = "+1w"
+	daily := dt("2026-10-22")
+	daily.Repeat = "++2d"
+	db := &store.DB{
+		Outlines: []model.Outline{{ID: "out-a", ProjectID: "proj-a", StatusDefs: store.DefaultOutlineStatusDefs()}},
+		Items: []model.Item{
+			{ID: "item-weekly", Title: "Weekly", OutlineID: "out-a", StatusID: "todo", Schedule: weekly},
+			{ID: "item-daily", Title: "Every other day", OutlineID: "out-a", StatusID: "todo", Due: daily},
+		},
+	}
+
+	res := Build(db, Options{From: mustDate(t, "2026-10-19"), Span: SpanWeek, Today: mustDate(t, "2026-10-20")})
+	got := map[string][]string{}
+	for _, d := range res.Days {
+		for _, r := range d.Rows {
+			label := r.ItemID + ":" + string(r.Kind)
+			if r.Occurrence {
+				label += "*"
+			}
+			got[d.Date] = append(got[d.Date], label)
+		}
+	}
+
+	// The weekly item is still scheduled on the 12th: it is carried forward on today, its 19th
+	// occurrence is before today and the 26th is outside the span.
+	if len(got["2026-10-19"]) != 0 {
+		t.Fatalf("expected no past occurrences, got %v", got["2026-10-19"])
+	}
+	if want := []string{"item-weekly:scheduled_past", "item-daily:deadline_upcoming"}; !equalStrings(got["2026-10-20"], want) {
+		t.Fatalf("unexpected today rows: %v", got["2026-10-20"])
+	}
+	if want := []string{"item-daily:deadline"}; !equalStrings(got["2026-10-22"], want) {
+		t.Fatalf("unexpected rows on the 22nd: %v", got["2026-10-22"])
+	}
+	for _, date := range []string{"2026-10-24", "2026-10-25"} {
+		want := []string{}
+		if date == "2026-10-24" {
+			want = []string{"item-daily:deadline*"}
+		}
+		if !equalStrings(got[date], want) {
+			t.Fatalf("unexpected rows on %s: %v", date, got[date])
+		}
+	}
+	for _, d := range res.Days {
+		for _, r := range d.Rows {
+			if r.ItemID == "item-daily" && r.Repeat != "++2d" {
+				t.Fatalf("expected repeat on rows, got %#v", r)
+			}
+		}
+	}
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,45 @@
+// Package blobstore stores attachment file contents by key, in a directory or an S3-compatible
+// bucket. Keys are slash-separated relative paths ("sha256/ab/ab12….png").
+package blobstore
+
+import (
+	"context"
+	"errors"
+	"io"
+	"path"
+	"strings"
+)
+
+var ErrNotFound = errors.New("blob not found")
+
+// Backend is one place blobs live.
+type Backend interface {
+	// Name is the backend kind ("dir", "s3"), used as the scheme of external attachment paths.
+	Name() string
+
+	// Put stores size bytes from r under key; sha256Hex is the content's hash (backends may use it
+	// to let the server verify the upload). Putting an existing key overwrites it.
+	Put(ctx context.Context, key string, r io.Reader, size int64, sha256Hex string) error
+
+	// Get returns the blob's contents, or ErrNotFound.
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+
+	Exists(ctx context.Context, key string) (bool, error)
+
+	// Delete removes the blob; deleting a missing key is not an error.
+	Delete(ctx context.Context, key string) error
+}
+
+// CleanKey validates a key: relative, slash-separated, without "." or ".." segments.
+func CleanKey(key string) (string, error) {
+	key = strings.TrimSpace(key)
+	if key == "" || strings.HasPrefix(key, "/") || strings.Contains(key, "\\") {
+		return "", errors.New("blobstore: invalid key: " + key)
+	}
+	for _, seg := range strings.Split(key, "/") {
+		if seg == "" || seg == "." || seg == ".." {
+			return "", errors.New("blobstore: invalid key: " + key)
+		}
+	}
+	return path.Clean(key), nil
+}
@@ -0,0 +1,92 @@
+package cli
+
+import (
+	"strings"
+	"time"
+
+	"clarity-cli/internal/agenda"
+
+	"github.com/spf13/cobra"
+)
+
+func newAgendaCmd(app *App) *cobra.Command {
+	var span string
+	var from string
+	var today string
+	var warningDays int
+
+	cmd := &cobra.Command{
+		Use:   "agenda",
+		Short: "Show the date-driven agenda (scheduled items and deadlines)",
+		Long: strings.TrimSpace(`
+Show an Org-style agenda over open items (not archived, not on hold, not in an end-state).
+
+- Scheduled items show on their scheduled date.
+- Deadlines show on their due date.
+- On today: open items scheduled in the past are carried forward ("Sched. Nx"),
+  overdue deadlines are repeated ("N d. ago"), and deadlines within --warning-days
+  are announced ("In N d.").
+- Within a day, rows with a time of day come first (ordered by time).
+`),
+		Example: strings.TrimSpace(`
+clarity agenda
+clarity agenda --span week
+clarity agenda --span week --from 2026-10-19
+`),
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			db, _, err := loadDB(app)
+			if err != nil {
+				return writeErr(cmd, err)
+			}
+
+			sp, err := agenda.ParseSpan(span)
+			if err != nil {
+				return writeErr(cmd, err)
+			}
+			opts := agenda.Options{Span: sp, WarningDays: &warningDays, Today: time.Now()}
+			if strings.TrimSpace(today) != "" {
+				d, err := agenda.ParseDate(today)
+				if err != nil {
+					return writeErr(cmd, err)
+				}
+				opts.Today = d
+			}
+			if strings.TrimSpace(from) != "" {
+				d, err := agenda.ParseDate(from)
+				if err != nil {
+					return writeErr(cmd, err)
+				}
+				opts.From = d
+			}
+
+			res := agenda.Build(db, opts)
+			rows := 0
+			for _, d := range res.Days {
+				rows += len(d.Rows)
+			}
+			return writeOut(cmd, app, map[string]any{
+				"data": res,
+				"meta": map[string]any{
+					"span":  string(res.Span),
+					"from":  res.From,
+					"to":    res.To,
+					"today": res.Today,
+					"days":  len(res.Days),
+					"rows":  rows,
+				},
+				"_hints": []string{
+					"clarity <item-id>",
+					"clarity items set-schedule <item-id> --at YYYY-MM-DD",
+					"clarity items set-due <item-id> --at YYYY-MM-DD",
+				},
+			})
+		},
+	}
+
+	cmd.Flags().StringVar(&span, "span", "day", "Agenda span (day|week)")
+	cmd.Flags().StringVar(&from, "from", "", "First day (YYYY-MM-DD; default: today, or Monday of this week for --span week)")
+	cmd.Flags().StringVar(&today, "today", "", "Override today's date (YYYY-MM-DD; affects carry-forward and warnings)")
+	cmd.Flags().IntVar(&warningDays, "warning-days", agenda.DefaultDeadlineWarningDays, "Announce deadlines this many days ahead on today (0 disables)")
+	return cmd
+}
@@ -0,0 +1,396 @@
+package cli
+
+import (
+        "bytes"
+        "crypto/subtle"
+        "encoding/json"
+        "errors"
+        "fmt"
+        "io"
+        "net/http"
+        "sort"
+        "strconv"
+        "strings"
+        "sync"
+        "time"
+
+        "clarity-cli/internal/mutate"
+        "clarity-cli/internal/store"
+)
+
+// HTTP API (`clarity serve --api`).
+//
+// Every REST route maps to a CLI command: path wildcards become its positional args and query
+// parameters (GET) or JSON body fields (POST/DELETE) become its flags. The command runs in-process
+// against a workspace that stays loaded between requests, so responses are byte-for-byte what
+// the CLI prints — the same {data, meta, _hints} envelope and the same permission checks — without
+// paying workspace discovery and a state load per call.
+
+const apiActorHeader = "X-Clarity-Actor"
+
+// apiStreamPollInterval is how often the event stream checks the log for appended events.
+var apiStreamPollInterval = 500 * time.Millisecond
+
+type apiRoute struct {
+        method  string
+        pattern string
+        cmd     []string
+        params  []string // path wildcards passed as positional args, in order
+}
+
+var apiRoutes = []apiRoute{
+        {method: "GET", pattern: "/v1/projects", cmd: []string{"projects", "list"}},
+        {method: "POST", pattern: "/v1/projects", cmd: []string{"projects", "create"}},
+        {method: "POST", pattern: "/v1/projects/{id}/archive", cmd: []string{"projects", "archive"}, params: []string{"id"}},
+
+        {method: "GET", pattern: "/v1/outlines", cmd: []string{"outlines", "list"}},
+        {method: "POST", pattern: "/v1/outlines", cmd: []string{"outlines", "create"}},
+        {method: "GET", pattern: "/v1/outlines/{id}", cmd: []string{"outlines", "show"}, params: []string{"id"}},
+        {method: "POST", pattern: "/v1/outlines/{id}/archive", cmd: []string{"outlines", "archive"}, params: []string{"id"}},
+
+        {method: "GET", pattern: "/v1/items", cmd: []string{"items", "list"}},
+        {method: "POST", pattern: "/v1/items", cmd: []string{"items", "create"}},
+        {method: "GET", pattern: "/v1/items/ready", cmd: []string{"items", "ready"}},
+        {method: "GET", pattern: "/v1/items/{id}", cmd: []string{"items", "show"}, params: []string{"id"}},
+        {method: "GET", pattern: "/v1/items/{id}/events", cmd: []string{"items", "events"}, params: []string{"id"}},
+        {method: "POST", pattern: "/v1/items/{id}/tags/{action}", cmd: []string{"items", "tags"}, params: []string{"action", "id"}},
+        {method: "POST", pattern: "/v1/items/{id}/{action}", cmd: []string{"items"}, params: []string{"action", "id"}},
+
+        {method: "GET", pattern: "/v1/items/{id}/comments", cmd: []string{"comments", "list"}, params: []string{"id"}},
+        {method: "POST", pattern: "/v1/items/{id}/comments", cmd: []string{"comments", "add"}, params: []string{"id"}},
+        {method: "GET", pattern: "/v1/comments/{id}/history", cmd: []string{"comments", "history"}, params: []string{"id"}},
+        {method: "POST", pattern: "/v1/comments/{id}/edit", cmd: []string{"comments", "edit"}, params: []string{"id"}},
+        {method: "POST", pattern: "/v1/comments/{id}/redact", cmd: []string{"comments", "redact"}, params: []string{"id"}},
+
+        {method: "GET", pattern: "/v1/items/{id}/worklog", cmd: []string{"worklog", "list"}, params: []string{"id"}},
+        {method: "POST", pattern: "/v1/items/{id}/worklog", cmd: []string{"worklog", "add"}, params: []string{"id"}},
+
+        {method: "GET", pattern: "/v1/deps", cmd: []string{"deps", "list"}},
+        {method: "GET", pattern: "/v1/deps/cycles", cmd: []string{"deps", "cycles"}},
+        {method: "GET", pattern: "/v1/items/{id}/deps", cmd: []string{"deps", "list"}, params: []string{"id"}},
+        {method: "POST", pattern: "/v1/items/{id}/deps", cmd: []string{"deps", "add"}, params: []string{"id"}},
+        {method: "GET", pattern: "/v1/items/{id}/blockers", cmd: []string{"deps", "blockers"}, params: []string{"id"}},
+        {method: "GET", pattern: "/v1/items/{id}/deps/tree", cmd: []string{"deps", "tree"}, params: []string{"id"}},
+        {method: "POST", pattern: "/v1/deps/{id}/type", cmd: []string{"deps", "set-type"}, params: []string{"id"}},
+        {method: "DELETE", pattern: "/v1/deps/{id}", cmd: []string{"deps", "remove"}, params: []string{"id"}},
+
+        {method: "GET", pattern: "/v1/events", cmd: []string{"events", "list"}},
+}
+
+// apiItemActions are the `items <action> <item-id>` commands reachable via POST /v1/items/{id}/{action}.
+var apiItemActions = map[string]bool{
+        "archive": true, "claim": true, "copy": true, "move": true, "move-outline": true,
+        "set-assign": true, "set-children-checkbox": true, "set-description": true, "set-due": true,
+        "set-item-kind": true, "set-on-hold": true, "set-parent": true, "set-priority": true,
+        "set-schedule": true, "set-status": true, "set-title": true,
+}
+
+var apiTagActions = map[string]bool{"add": true, "remove": true, "set": true}
+
+// Flags a request may not set: the server decides the workspace and output, the header the actor,
+// and hooks always run.
+var apiReservedFlags = map[string]bool{"dir": true, "workspace": true, "actor": true, "format": true, "pretty": true, "help": true, "no-hooks": true}
+
+type apiServer struct {
+        dir     string
+        token   string
+        actorID string // used when a request has no X-Clarity-Actor header
+
+        mu   sync.Mutex // commands share the warm DB, so they run one at a time
+        warm *warmDB
+}
+
+func newAPIServer(dir, token, actorID string) *apiServer {
+        return &apiServer{dir: dir, token: token, actorID: strings.TrimSpace(actorID), warm: &warmDB{}}
+}
+
+func (s *apiServer) Handler() http.Handler {
+        mux := http.NewServeMux()
+        for _, rt := range apiRoutes {
+                mux.HandleFunc(rt.method+" "+rt.pattern, s.handleCommand(rt))
+        }
+        mux.HandleFunc("GET /v1/events/stream", s.handleEventStream)
+        mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+                writeAPIError(w, http.StatusNotFound, "not_found", "no such endpoint: "+r.Method+" "+r.URL.Path)
+        })
+        return s.requireToken(mux)
+}
+
+func (s *apiServer) requireToken(next http.Handler) http.Handler {
+        return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+                got, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+                if !ok || subtle.ConstantTimeCompare([]byte(strings.TrimSpace(got)), []byte(s.token)) != 1 {
+                        writeAPIError(w, http.StatusUnauthorized, "unauthorized", "missing or invalid bearer token")
+                        return
+                }
+                next.ServeHTTP(w, r)
+        })
+}
+
+func (s *apiServer) handleCommand(rt apiRoute) http.HandlerFunc {
+        return func(w http.ResponseWriter, r *http.Request) {
+                args := append([]string{}, rt.cmd...)
+                for _, p := range rt.params {
+                        v := r.PathValue(p)
+                        switch {
+                        case strings.HasPrefix(v, "-"):
+                                // It would be parsed as a flag (e.g. --dir=...), past apiReservedFlags.
+                                writeAPIError(w, http.StatusBadRequest, "bad_request", fmt.Sprintf("invalid %s: %q", p, v))
+                                return
+                        case p == "action" && rt.cmd[len(rt.cmd)-1] == "items" && !apiItemActions[v],
+                                p == "action" && rt.cmd[len(rt.cmd)-1] == "tags" && !apiTagActions[v]:
+                                writeAPIError(w, http.StatusNotFound, "not_found", "no such endpoint: "+r.Method+" "+r.URL.Path)
+                                return
+                        }
+                        args = append(args, v)
+                }
+                flags, err := apiRequestFlags(r)
+                if err != nil {
+                        writeAPIError(w, http.StatusBadRequest, "bad_request", err.Error())
+                        return
+                }
+                args = append(args, flags...)
+
+                actorID := strings.TrimSpace(r.Header.Get(apiActorHeader))
+                if actorID == "" {
+                        actorID = s.actorID
+                }
+                out, err := s.run(actorID, r.Method != http.MethodGet, args)
+                if err != nil {
+                        status, code := apiErrorStatus(err)
+                        writeAPIError(w, status, code, err.Error())
+                        return
+                }
+                w.Header().Set("Content-Type", "application/json")
+                _, _ = w.Write(out)
+        }
+}
+
+// run executes a CLI command in-process and returns its stdout.
+func (s *apiServer) run(actorID string, mutates bool, args []string) ([]byte, error) {
+        s.mu.Lock()
+        defer s.mu.Unlock()
+
+        full := []string{"--dir", s.dir, "--format", "json"}
+        if actorID != "" {
+                full = append(full, "--actor", actorID)
+        }
+        full = append(full, args...)
+
+        root := newRootCmd(&App{warm: s.warm})
+        var out, errOut bytes.Buffer
+        root.SetOut(&out)
+        root.SetErr(&errOut)
+        root.SetArgs(full)
+        root.SilenceErrors = true
+        err := root.Execute()
+        if err != nil || mutates {
+                // Commands edit the loaded DB in place before saving; reload rather than trust it.
+                s.warm.reset()
+        }
+        if err != nil {
+                return nil, err
+        }
+        return out.Bytes(), nil
+}
+
+// apiRequestFlags turns query parameters and JSON body fields into command flags: strings and
+// numbers become `--name=value`, booleans `--name=true|false`, arrays repeat the flag.
+func apiRequestFlags(r *http.Request) ([]string, error) {
+        fields := map[string][]string{}
+        for k, vs := range r.URL.Query() {
+                fields[k] = append(fields[k], vs...)
+        }
+        if r.Method != http.MethodGet && r.Body != nil {
+                b, err := io.ReadAll(io.LimitReader(r.Body, 8<<20))
+                if err != nil {
+                        return nil, err
+                }
+                if len(bytes.TrimSpace(b)) > 0 {
+                        var body map[string]any
+                        if err := json.Unmarshal(b, &body); err != nil {
+                                return nil, fmt.Errorf("request body must be a JSON object: %w", err)
+                        }
+                        for k, v := range body {
+                                vs, err := apiFlagValues(k, v)
+                                if err != nil {
+                                        return nil, err
+                                }
+                                fields[k] = append(fields[k], vs...)
+                        }
+                }
+        }
+
+        names := make([]string, 0, len(fields))
+        for k := range fields {
+                names = append(names, k)
+        }
+        sort.Strings(names)
+        var out []string
+        for _, k := range names {
+                if apiReservedFlags[k] || strings.HasPrefix(k, "-") {
+                        return nil, fmt.Errorf("%q cannot be set through the API", k)
+                }
+                for _, v := range fields[k] {
+                        out = append(out, "--"+k+"="+v)
+                }
+        }
+        return out, nil
+}
+
+func apiFlagValues(name string, v any) ([]string, error) {
+        switch x := v.(type) {
+        case nil:
+                return nil, nil
+        case string:
+                return []string{x}, nil
+        case bool:
+                return []string{strconv.FormatBool(x)}, nil
+        case float64:
+                return []string{strconv.FormatFloat(x, 'f', -1, 64)}, nil
+        case []any:
+                var out []string
+                for _, e := range x {
+                        vs, err := apiFlagValues(name, e)
+                        if err != nil {
+                                return nil, err
+                        }
+                        out = append(out, vs...)
+                }
+                return out, nil
+        default:
+                return nil, fmt.Errorf("unsupported value for %q (expected string, number, boolean or array)", name)
+        }
+}
+
+func apiErrorStatus(err error) (int, string) {
+        var nf notFoundError
+        var mnf mutate.NotFoundError
+        var owner ownerOnlyError
+        var author authorOnlyError
+        switch {
+        case errors.As(err, &nf), errors.As(err, &mnf):
+                return http.StatusNotFound, "not_found"
+        case errors.As(err, &owner), errors.As(err, &author), strings.HasPrefix(err.Error(), "permission denied"):
+                return http.StatusForbidden, "forbidden"
+        default:
+                return http.StatusBadRequest, "bad_request"
+        }
+}
+
+func writeAPIError(w http.ResponseWriter, status int, code, msg string) {
+        w.Header().Set("Content-Type", "application/json")
+        w.WriteHeader(status)
+        _ = json.NewEncoder(w).Encode(map[string]any{"error": map[string]any{"code": code, "message": msg}})
+}
+
+// handleEventStream streams appended events as Server-Sent Events: `id` is the event id,
+// `event` its type and `data` the EventV1 JSON. `type`/`entity` query parameters filter like
+// `events watch`; `Last-Event-ID` (or `since`) replays the events after that one first.
+func (s *apiServer) handleEventStream(w http.ResponseWriter, r *http.Request) {
+        fl, ok := w.(http.Flusher)
+        if !ok {
+                writeAPIError(w, http.StatusInternalServerError, "internal", "streaming unsupported")
+                return
+        }
+        ctx := r.Context()
+        q := r.URL.Query()
+        filter := eventFilter{types: q["type"], entities: q["entity"]}
+        since := strings.TrimSpace(r.Header.Get("Last-Event-ID"))
+        if since == "" {
+                since = strings.TrimSpace(q.Get("since"))
+        }
+        st := store.Store{Dir: s.dir}
+        var backlog []store.EventV1
+        var tail *store.EventTail
+        var err error
+        if since != "" {
+                backlog, tail, err = st.TailEventsAfter(ctx, since)
+        } else {
+                tail, err = st.TailEvents(ctx)
+        }
+        if err != nil {
+                status, code := apiErrorStatus(err)
+                writeAPIError(w, status, code, err.Error())
+                return
+        }
+        send := func(evs []store.EventV1) {
+                n := 0
+                for _, ev := range evs {
+                        if !filter.match(ev) {
+                                continue
+                        }
+                        b, err := json.Marshal(ev)
+                        if err != nil {
+                                continue
+                        }
+                        fmt.Fprintf(w, "id: %s\nevent: %s\ndata: %s\n\n", ev.EventID, ev.Type, b)
+                        n++
+                }
+                if n > 0 {
+                        fl.Flush()
+                }
+        }
+
+        w.Header().Set("Content-Type", "text/event-stream")
+        w.Header().Set("Cache-Control", "no-cache")
+        w.WriteHeader(http.StatusOK)
+        fmt.Fprint(w, ": connected\n\n")
+        fl.Flush()
+        send(backlog)
+
+        poll := time.NewTicker(apiStreamPollInterval)
+        defer poll.Stop()
+        ping := time.NewTicker(15 * time.Second)
+        defer ping.Stop()
+        for {
+                select {
+                case <-ctx.Done():
+                        return
+                case <-ping.C:
+                        fmt.Fprint(w, ": ping\n\n")
+                        fl.Flush()
+                case <-poll.C:
+                        evs, err := tail.Poll(ctx)
+                        if err != nil {
+                                b, _ := json.Marshal(map[string]any{"message": err.Error()})
+                                fmt.Fprintf(w, "event: error\ndata: %s\n\n", b)
+                                fl.Flush()
+                                continue
+                        }
+                        send(evs)
+                }
+        }
+}
+
+// warmDB keeps a loaded workspace between API requests and reloads it when the workspace
+// changed on disk (another process, a sync) or after a mutating command.
+type warmDB struct {
+        db    *store.DB
+        stamp string
+}
+
+func (w *warmDB) load(s store.Store) (*store.DB, error) {
+        stamp, err := s.ChangeStamp()
+        if err != nil {
+                return nil, err
+        }
+        if w.db != nil && stamp == w.stamp {
+                return w.db, nil
+        }
+        db, err := s.Load()
+        if err != nil {
+                w.reset()
+                return nil, err
+        }
+        // Load may have saved caught-up state; stamp what it left behind.
+        if stamp, err = s.ChangeStamp(); err != nil {
+                return nil, err
+        }
+        w.db, w.stamp = db, stamp
+        return db, nil
+}
+
+func (w *warmDB) reset() {
+        w.db, w.stamp = nil, ""
+}
@@ -0,0 +1,179 @@
+package cli
+
+import (
+        "bufio"
+        "bytes"
+        "encoding/json"
+        "net/http"
+        "net/http/httptest"
+        "strings"
+        "testing"
+        "time"
+
+        "clarity-cli/internal/model"
+        "clarity-cli/internal/store"
+)
+
+func newAPITestServer(t *testing.T) (*httptest.Server, string) {
+        t.Helper()
+        dir := t.TempDir()
+        now := time.Date(2025, 12, 20, 0, 0, 0, 0, time.UTC)
+        db := &store.DB{
+                Version:        1,
+                CurrentActorID: "act-owner",
+                NextIDs:        map[string]int{},
+                Actors: []model.Actor{
+                        {ID: "act-owner", Kind: model.ActorKindHuman, Name: "Owner"},
+                        {ID: "act-other", Kind: model.ActorKindHuman, Name: "Other"},
+                },
+                Projects: []model.Project{{ID: "proj-a", Name: "P", CreatedBy: "act-owner", CreatedAt: now}},
+                Outlines: []model.Outline{{ID: "out-a", ProjectID: "proj-a", StatusDefs: store.DefaultOutlineStatusDefs(), CreatedBy: "act-owner", CreatedAt: now}},
+                Items: []model.Item{
+                        {ID: "item-1", ProjectID: "proj-a", OutlineID: "out-a", Rank: "h", Title: "First", StatusID: "todo", OwnerActorID: "act-owner", CreatedBy: "act-owner", CreatedAt: now, UpdatedAt: now},
+                },
+        }
+        if err := (store.Store{Dir: dir}).Save(db); err != nil {
+                t.Fatalf("seed store: %v", err)
+        }
+        srv := httptest.NewServer(newAPIServer(dir, "secret", "act-owner").Handler())
+        t.Cleanup(srv.Close)
+        return srv, dir
+}
+
+func apiDo(t *testing.T, srv *httptest.Server, method, path, actor, body string) (int, []byte) {
+        t.Helper()
+        req, err := http.NewRequest(method, srv.URL+path, strings.NewReader(body))
+        if err != nil {
+                t.Fatalf("new request: %v", err)
+        }
+        req.Header.Set("Authorization", "Bearer secret")
+        if actor != "" {
+                req.Header.Set(apiActorHeader, actor)
+        }
+        resp, err := srv.Client().Do(req)
+        if err != nil {
+                t.Fatalf("%s %s: %v", method, path, err)
+        }
+        defer resp.Body.Close()
+        var buf bytes.Buffer
+        _, _ = buf.ReadFrom(resp.Body)
+        return resp.StatusCode, buf.Bytes()
+}
+
+func TestAPI_MirrorsCLIOutputAndPermissions(t *testing.T) {
+        srv, dir := newAPITestServer(t)
+
+        resp, err := srv.Client().Get(srv.URL + "/v1/items")
+        if err != nil {
+                t.Fatalf("get: %v", err)
+        }
+        resp.Body.Close()
+        if resp.StatusCode != http.StatusUnauthorized {
+                t.Fatalf("expected 401 without token, got %d", resp.StatusCode)
+        }
+
+        code, body := apiDo(t, srv, "GET", "/v1/items/item-1", "", "")
+        if code != http.StatusOK {
+                t.Fatalf("GET item: %d %s", code, body)
+        }
+        cliOut, errOut, err := runCLI(t, []string{"--dir", dir, "--actor", "act-owner", "items", "show", "item-1"})
+        if err != nil {
+                t.Fatalf("items show: %v\n%s", err, errOut)
+        }
+        if !bytes.Equal(body, cliOut) {
+                t.Fatalf("API output differs from CLI:\napi: %s\ncli: %s", body, cliOut)
+        }
+
+        if code, body := apiDo(t, srv, "POST", "/v1/items/item-1/set-title", "act-other", `{"title":"Hijacked"}`); code != http.StatusForbidden {
+                t.Fatalf("expected 403 for non-owner, got %d %s", code, body)
+        }
+        code, body = apiDo(t, srv, "POST", "/v1/items/item-1/set-title", "act-owner", `{"title":"Renamed"}`)
+        if code != http.StatusOK {
+                t.Fatalf("set-title: %d %s", code, body)
+        }
+        var env struct {
+                Data model.Item `json:"data"`
+        }
+        if err := json.Unmarshal(body, &env); err != nil || env.Data.Title != "Renamed" {
+                t.Fatalf("unexpected set-title response: %s (err=%v)", body, err)
+        }
+
+        code, body = apiDo(t, srv, "POST", "/v1/items", "", `{"title":"Second","project":"proj-a","outline":"out-a"}`)
+        if code != http.StatusOK {
+                t.Fatalf("create: %d %s", code, body)
+        }
+        code, body = apiDo(t, srv, "GET", "/v1/items?outline=out-a", "", "")
+        if code != http.StatusOK || !strings.Contains(string(body), "Second") || !strings.Contains(string(body), "Renamed") {
+                t.Fatalf("list after writes: %d %s", code, body)
+        }
+
+        if code, _ := apiDo(t, srv, "GET", "/v1/items/item-missing", "", ""); code != http.StatusNotFound {
+                t.Fatalf("expected 404, got %d", code)
+        }
+        if code, _ := apiDo(t, srv, "POST", "/v1/items/item-1/set-title", "", `{"title":"x","dir":"/tmp"}`); code != http.StatusBadRequest {
+                t.Fatalf("expected 400 for a reserved flag, got %d", code)
+        }
+        if code, _ := apiDo(t, srv, "POST", "/v1/items/item-1/set-title", "", `{"title":"x","no-hooks":true}`); code != http.StatusBadRequest {
+                t.Fatalf("expected 400 for no-hooks, got %d", code)
+        }
+        if code, body := apiDo(t, srv, "GET", "/v1/items/--dir=%2Ftmp", "", ""); code != http.StatusBadRequest {
+                t.Fatalf("expected 400 for a flag-like path value, got %d %s", code, body)
+        }
+        if code, _ := apiDo(t, srv, "POST", "/v1/items/item-1/delete-everything", "", `{}`); code != http.StatusNotFound {
+                t.Fatalf("expected 404 for an unknown action, got %d", code)
+        }
+}
+
+func TestAPI_EventStreamSendsAppendedEvents(t *testing.T) {
+        prev := apiStreamPollInterval
+        apiStreamPollInterval = 20 * time.Millisecond
+        t.Cleanup(func() { apiStreamPollInterval = prev })
+
+        srv, _ := newAPITestServer(t)
+        req, err := http.NewRequest("GET", srv.URL+"/v1/events/stream?type=comment.add", nil)
+        if err != nil {
+                t.Fatalf("new request: %v", err)
+        }
+        req.Header.Set("Authorization", "Bearer secret")
+        resp, err := srv.Client().Do(req)
+        if err != nil {
+                t.Fatalf("stream: %v", err)
+        }
+        defer resp.Body.Close()
+        if ct := resp.Header.Get("Content-Type"); ct != "text/event-stream" {
+                t.Fatalf("content type = %q", ct)
+        }
+        r := bufio.NewReader(resp.Body)
+        if line, _ := r.ReadString('\n'); !strings.HasPrefix(line, ": connected") {
+                t.Fatalf("unexpected first line %q", line)
+        }
+
+        if code, body := apiDo(t, srv, "POST", "/v1/items/item-1/comments", "", `{"body":"hello"}`); code != http.StatusOK {
+                t.Fatalf("comment: %d %s", code, body)
+        }
+
+        done := make(chan string, 1)
+        go func() {
+                for {
+                        line, err := r.ReadString('\n')
+                        if err != nil {
+                                done <- ""
+                                return
+                        }
+                        if strings.HasPrefix(line, "event: ") {
+                                // Filtered by ?type=, so the first event is the comment.
+                                data, _ := r.ReadString('\n')
+                                done <- line + data
+                                return
+                        }
+                }
+        }()
+        select {
+        case data := <-done:
+                if !strings.HasPrefix(data, "event: comment.add") || !strings.Contains(data, `"body":"hello"`) {
+                        t.Fatalf("unexpected event data %q", data)
+                }
+        case <-time.After(5 * time.Second):
+                t.Fatalf("timed out waiting for the comment event")
+        }
+}
@@ -23,6 +23,11 @@ func newAttachmentsCmd(app *App) *cobra.Command {
         cmd.AddCommand(newAttachmentsListCmd(app))
         cmd.AddCommand(newAttachmentsOpenCmd(app))
         cmd.AddCommand(newAttachmentsExportCmd(app))
+        cmd.AddCommand(newAttachmentsReplaceCmd(app))
+        cmd.AddCommand(newAttachmentsRemoveCmd(app))
+        cmd.AddCommand(newAttachmentsGCCmd(app))
+        cmd.AddCommand(newAttachmentsExtractCmd(app))
+        cmd.AddCommand(newAttachmentsStoreCmd(app))
 
         return cmd
 }
@@ -66,6 +71,7 @@ func newAttachmentsAddCmd(app *App) *cobra.Command {
                         }
 
                         // Validate entity existence.
+                        itemID := entityID
                         switch strings.ToLower(strings.TrimSpace(kind)) {
                         case "item":
                                 if _, ok := db.FindItem(entityID); !ok {
@@ -76,6 +82,7 @@ func newAttachmentsAddCmd(app *App) *cobra.Command {
                                 for i := range db.Comments {
                                         if strings.TrimSpace(db.Comments[i].ID) == entityID {
                                                 found = true
+                                                itemID = strings.TrimSpace(db.Comments[i].ItemID)
                                                 break
                                         }
                                 }
@@ -85,6 +92,11 @@ func newAttachmentsAddCmd(app *App) *cobra.Command {
                         default:
                                 return fmt.Errorf("invalid --kind: %q (expected item|comment)", kind)
                         }
+                        if it, ok := db.FindItem(itemID); ok {
+                                if err := checkCanContribute(db, actorID, it.ProjectID, it.OutlineID); err != nil {
+                                        return writeErr(cmd, err)
+                                }
+                        }
 
                         a, err := st.AddAttachment(db, actorID, kind, entityID, path, title, alt, maxBytes)
                         if err != nil {
@@ -168,7 +180,10 @@ func newAttachmentsOpenCmd(app *App) *cobra.Command {
                         if !ok || a == nil {
                                 return writeErr(cmd, fmt.Errorf("attachment not found: %s", id))
                         }
-                        p := st.AttachmentAbsPath(*a)
+                        p, err := st.AttachmentLocalPath(cmd.Context(), *a)
+                        if err != nil {
+                                return writeErr(cmd, err)
+                        }
                         if printPath {
                                 fmt.Fprintln(cmd.OutOrStdout(), p)
                                 return nil
@@ -199,7 +214,10 @@ func newAttachmentsExportCmd(app *App) *cobra.Command {
                         if !ok || a == nil {
                                 return writeErr(cmd, fmt.Errorf("attachment not found: %s", id))
                         }
-                        src := st.AttachmentAbsPath(*a)
+                        src, err := st.AttachmentLocalPath(cmd.Context(), *a)
+                        if err != nil {
+                                return writeErr(cmd, err)
+                        }
                         if err := store.CopyFile(src, dest); err != nil {
                                 return writeErr(cmd, err)
                         }
@@ -211,6 +229,378 @@ func newAttachmentsExportCmd(app *App) *cobra.Command {
         return cmd
 }
 
+func newAttachmentsReplaceCmd(app *App) *cobra.Command {
+        var maxMB int64
+
+        cmd := &cobra.Command{
+                Use:   "replace <attachment-id> <path>",
+                Short: "Attach a new version of a file (keeps the attachment id, title and alt text)",
+                Args:  cobra.ExactArgs(2),
+                RunE: func(cmd *cobra.Command, args []string) error {
+                        id := strings.TrimSpace(args[0])
+                        path := strings.TrimSpace(args[1])
+                        if id == "" || path == "" {
+                                return writeErr(cmd, errors.New("missing attachment id/path"))
+                        }
+                        db, st, err := loadDB(app)
+                        if err != nil {
+                                return writeErr(cmd, err)
+                        }
+                        actorID, err := currentActorID(app, db)
+                        if err != nil {
+                                return writeErr(cmd, err)
+                        }
+                        if err := checkCanEditAttachment(db, actorID, id); err != nil {
+                                return writeErr(cmd, err)
+                        }
+
+                        var maxBytes int64 = store.DefaultAttachmentMaxBytes
+                        if maxMB > 0 {
+                                maxBytes = maxMB * 1024 * 1024
+                        }
+                        a, err := st.ReplaceAttachment(db, actorID, id, path, maxBytes)
+                        if err != nil {
+                                return writeErr(cmd, err)
+                        }
+                        if err := st.AppendEvent(actorID, "attachment.replace", a.ID, a); err != nil {
+                                return writeErr(cmd, err)
+                        }
+                        if err := st.Save(db); err != nil {
+                                return writeErr(cmd, err)
+                        }
+                        return writeOut(cmd, app, map[string]any{
+                                "data": a,
+                                "_hints": []string{
+                                        "clarity attachments open " + a.ID,
+                                },
+                        })
+                },
+        }
+        cmd.Flags().Int64Var(&maxMB, "max-mb", 50, "Max file size in MB")
+        return cmd
+}
+
+func newAttachmentsRemoveCmd(app *App) *cobra.Command {
+        cmd := &cobra.Command{
+                Use:   "remove <attachment-id>",
+                Short: "Remove an attachment (its file is kept for history until attachments gc --removed)",
+                Args:  cobra.ExactArgs(1),
+                RunE: func(cmd *cobra.Command, args []string) error {
+                        id := strings.TrimSpace(args[0])
+                        if id == "" {
+                                return writeErr(cmd, errors.New("missing attachment id"))
+                        }
+                        db, st, err := loadDB(app)
+                        if err != nil {
+                                return writeErr(cmd, err)
+                        }
+                        actorID, err := currentActorID(app, db)
+                        if err != nil {
+                                return writeErr(cmd, err)
+                        }
+                        if err := checkCanEditAttachment(db, actorID, id); err != nil {
+                                return writeErr(cmd, err)
+                        }
+
+                        a, err := st.RemoveAttachment(db, id)
+                        if err != nil {
+                                return writeErr(cmd, err)
+                        }
+                        if err := st.AppendEvent(actorID, "attachment.remove", a.ID, map[string]any{
+                                "id":         a.ID,
+                                "entityKind": a.EntityKind,
+                                "entityId":   a.EntityID,
+                        }); err != nil {
+                                return writeErr(cmd, err)
+                        }
+                        if err := st.Save(db); err != nil {
+                                return writeErr(cmd, err)
+                        }
+                        return writeOut(cmd, app, map[string]any{
+                                "data": map[string]any{"removed": a.ID, "entityId": a.EntityID},
+                                "_hints": []string{
+                                        "clarity attachments list " + a.EntityID,
+                                        "clarity attachments gc --removed --dry-run",
+                                },
+                        })
+                },
+        }
+        return cmd
+}
+
+func newAttachmentsGCCmd(app *App) *cobra.Command {
+        var removed bool
+        var dryRun bool
+
+        cmd := &cobra.Command{
+                Use:   "gc",
+                Short: "Delete attachment files no event references",
+                Long: strings.TrimSpace(`
+Deletes files under resources/attachments/ that no attachment event references (e.g. left behind
+by an interrupted add). Files of removed attachments and of replaced versions are still referenced
+by history and are kept, unless --removed is set; files of current attachments are always kept.
+
+Deleted files remain in Git history; commit the deletion like any other change.
+`),
+                Args: cobra.NoArgs,
+                RunE: func(cmd *cobra.Command, args []string) error {
+                        _, st, err := loadDB(app)
+                        if err != nil {
+                                return writeErr(cmd, err)
+                        }
+                        res, err := st.GCAttachments(removed, dryRun)
+                        if err != nil {
+                                return writeErr(cmd, err)
+                        }
+                        return writeOut(cmd, app, map[string]any{
+                                "data": res,
+                                "_hints": []string{
+                                        "clarity doctor",
+                                },
+                        })
+                },
+        }
+        cmd.Flags().BoolVar(&removed, "removed", false, "Also delete files only referenced by removed attachments or replaced versions")
+        cmd.Flags().BoolVar(&dryRun, "dry-run", false, "List what would be deleted without deleting it")
+        return cmd
+}
+
+func newAttachmentsExtractCmd(app *App) *cobra.Command {
+        cmd := &cobra.Command{
+                Use:   "extract [<attachment-id>]",
+                Short: "Record the searchable text of attachments (text files, archive listings)",
+                Long: strings.TrimSpace(`
+Text is extracted when a file is attached. Use this for attachments added before text extraction
+existed: it records the text of every attachment you can edit (or just the one given), fetching
+files from an external blob store if needed, and appends an attachment.update event for each
+attachment whose text changed.
+`),
+                Args: cobra.MaximumNArgs(1),
+                RunE: func(cmd *cobra.Command, args []string) error {
+                        db, st, err := loadDB(app)
+                        if err != nil {
+                                return writeErr(cmd, err)
+                        }
+                        actorID, err := currentActorID(app, db)
+                        if err != nil {
+                                return writeErr(cmd, err)
+                        }
+
+                        var ids []string
+                        if len(args) == 1 {
+                                id := strings.TrimSpace(args[0])
+                                if err := checkCanEditAttachment(db, actorID, id); err != nil {
+                                        return writeErr(cmd, err)
+                                }
+                                ids = append(ids, id)
+                        } else {
+                                for _, a := range db.Attachments {
+                                        if checkCanEditAttachment(db, actorID, a.ID) == nil {
+                                                ids = append(ids, a.ID)
+                                        }
+                                }
+                        }
+
+                        updated := []string{}
+                        failed := map[string]string{}
+                        for _, id := range ids {
+                                a, ok := db.FindAttachment(id)
+                                if !ok || a == nil {
+                                        continue
+                                }
+                                changed, err := st.ExtractAttachmentText(cmd.Context(), a)
+                                if err != nil {
+                                        if len(args) == 1 {
+                                                return writeErr(cmd, err)
+                                        }
+                                        failed[id] = err.Error()
+                                        continue
+                                }
+                                if !changed {
+                                        continue
+                                }
+                                if err := st.AppendEvent(actorID, "attachment.update", a.ID, *a); err != nil {
+                                        return writeErr(cmd, err)
+                                }
+                                updated = append(updated, a.ID)
+                        }
+                        if len(updated) > 0 {
+                                if err := st.Save(db); err != nil {
+                                        return writeErr(cmd, err)
+                                }
+                        }
+                        return writeOut(cmd, app, map[string]any{
+                                "data": map[string]any{"checked": len(ids), "updated": updated, "failed": failed},
+                                "_hints": []string{
+                                        "clarity search <query>",
+                                },
+                        })
+                },
+        }
+        return cmd
+}
+
+func newAttachmentsStoreCmd(app *App) *cobra.Command {
+        cmd := &cobra.Command{
+                Use:   "store",
+                Short: "Where attachment files are stored: the repo, a shared directory, or S3 (meta/blobstore.json)",
+                Long: strings.TrimSpace(`
+New attachment files are stored in the workspace repo by default. To keep large files out of Git,
+store them in a shared directory or an S3-compatible bucket instead; attachment metadata stays in
+the event log either way, and files are fetched (and checked against their sha256) on open/export.
+
+The setting lives in meta/blobstore.json: commit it so every replica can fetch the files.
+Per-machine settings come from the environment: CLARITY_BLOBSTORE_DIR overrides the shared
+directory, and S3 credentials are read from CLARITY_S3_ACCESS_KEY_ID / CLARITY_S3_SECRET_ACCESS_KEY
+(or AWS_ACCESS_KEY_ID / AWS_SECRET_ACCESS_KEY).
+`),
+        }
+        cmd.AddCommand(newAttachmentsStoreShowCmd(app))
+        cmd.AddCommand(newAttachmentsStoreSetCmd(app))
+        return cmd
+}
+
+func newAttachmentsStoreShowCmd(app *App) *cobra.Command {
+        return &cobra.Command{
+                Use:   "show",
+                Short: "Show the attachment store settings",
+                Args:  cobra.NoArgs,
+                RunE: func(cmd *cobra.Command, args []string) error {
+                        dir, err := resolveDir(app)
+                        if err != nil {
+                                return writeErr(cmd, err)
+                        }
+                        f, err := store.Store{Dir: dir}.LoadBlobStore()
+                        if err != nil {
+                                return writeErr(cmd, err)
+                        }
+                        meta := map[string]any{}
+                        if f.Backend == store.BlobStoreS3 {
+                                meta["credentialsFromEnv"] = store.S3CredentialsFromEnv()
+                        }
+                        return writeOut(cmd, app, map[string]any{
+                                "data": f,
+                                "meta": meta,
+                                "_hints": []string{
+                                        "clarity attachments store set --backend s3 --endpoint <url> --bucket <name>",
+                                },
+                        })
+                },
+        }
+}
+
+func newAttachmentsStoreSetCmd(app *App) *cobra.Command {
+        var backend string
+        var path string
+        var endpoint string
+        var bucket string
+        var region string
+        var prefix string
+        var minMB int64
+
+        cmd := &cobra.Command{
+                Use:   "set",
+                Short: "Choose where new attachment files are stored",
+                Example: strings.TrimSpace(`
+clarity attachments store set --backend dir --path /mnt/team/clarity-blobs --min-mb 5
+clarity attachments store set --backend s3 --endpoint https://s3.eu-north-1.amazonaws.com --bucket team-clarity --region eu-north-1
+clarity attachments store set --backend repo
+`),
+                Args: cobra.NoArgs,
+                RunE: func(cmd *cobra.Command, args []string) error {
+                        dir, err := resolveDir(app)
+                        if err != nil {
+                                return writeErr(cmd, err)
+                        }
+                        s := store.Store{Dir: dir}
+                        // Settings of other backends are kept: attachments already stored there
+                        // must stay reachable after switching.
+                        f, err := s.LoadBlobStore()
+                        if err != nil {
+                                return writeErr(cmd, err)
+                        }
+                        f.Backend = backend
+                        if cmd.Flags().Changed("min-mb") {
+                                f.MinBytes = minMB * 1024 * 1024
+                        }
+                        if strings.TrimSpace(path) != "" {
+                                abs, err := filepath.Abs(strings.TrimSpace(path))
+                                if err != nil {
+                                        return writeErr(cmd, err)
+                                }
+                                f.Dir = &store.BlobStoreDirConfig{Path: abs}
+                        }
+                        if endpoint != "" || bucket != "" || region != "" || prefix != "" {
+                                if f.S3 == nil {
+                                        f.S3 = &store.BlobStoreS3Config{}
+                                }
+                                if endpoint != "" {
+                                        f.S3.Endpoint = endpoint
+                                }
+                                if bucket != "" {
+                                        f.S3.Bucket = bucket
+                                }
+                                if region != "" {
+                                        f.S3.Region = region
+                                }
+                                if prefix != "" {
+                                        f.S3.Prefix = prefix
+                                }
+                        }
+                        if err := s.SaveBlobStore(f); err != nil {
+                                return writeErr(cmd, err)
+                        }
+                        f, err = s.LoadBlobStore()
+                        if err != nil {
+                                return writeErr(cmd, err)
+                        }
+                        return writeOut(cmd, app, map[string]any{
+                                "data": f,
+                                "_hints": []string{
+                                        "git add meta/blobstore.json",
+                                        "clarity attachments store show",
+                                },
+                        })
+                },
+        }
+        cmd.Flags().StringVar(&backend, "backend", "", "repo|dir|s3")
+        cmd.Flags().StringVar(&path, "path", "", "Shared directory (dir backend)")
+        cmd.Flags().StringVar(&endpoint, "endpoint", "", "S3 endpoint URL, e.g. https://s3.eu-north-1.amazonaws.com (s3 backend)")
+        cmd.Flags().StringVar(&bucket, "bucket", "", "S3 bucket (s3 backend)")
+        cmd.Flags().StringVar(&region, "region", "", "S3 region (default us-east-1)")
+        cmd.Flags().StringVar(&prefix, "prefix", "", "S3 key prefix, e.g. clarity/ (s3 backend)")
+        cmd.Flags().Int64Var(&minMB, "min-mb", 0, "Keep files smaller than this many MB in the repo")
+        _ = cmd.MarkFlagRequired("backend")
+        return cmd
+}
+
+// checkCanEditAttachment applies the item permission rules via the attachment's item (or the item
+// of the comment it's attached to).
+func checkCanEditAttachment(db *store.DB, actorID, attachmentID string) error {
+        a, ok := db.FindAttachment(attachmentID)
+        if !ok || a == nil {
+                return errNotFound("attachment", attachmentID)
+        }
+        itemID := strings.TrimSpace(a.EntityID)
+        if strings.EqualFold(strings.TrimSpace(a.EntityKind), "comment") {
+                itemID = ""
+                for i := range db.Comments {
+                        if strings.TrimSpace(db.Comments[i].ID) == strings.TrimSpace(a.EntityID) {
+                                itemID = strings.TrimSpace(db.Comments[i].ItemID)
+                                break
+                        }
+                }
+        }
+        it, ok := db.FindItem(itemID)
+        if !ok || it == nil {
+                return errNotFound("item", itemID)
+        }
+        if !canEditTask(db, actorID, it) {
+                return errorsOwnerOnly(actorID, it.OwnerActorID, it.ID)
+        }
+        return nil
+}
+
 func openPath(path string) error {
         path = strings.TrimSpace(path)
         if path == "" {
@@ -260,9 +260,20 @@ func TestCLIIntegration_CommandAndFlagCoverage(t *testing.T) {
 
 	// Update commands + their flags.
 	run(t, invocation{name: "items set-title", cmdPath: "items set-title", args: []string{"--dir", dir, "--actor", humanID, "items", "set-title", itemA, "--title", "Item A (renamed)"}, expect: expectJSONEnvelope})
+	// Undo/redo the rename (net no-op).
+	run(t, invocation{name: "undo", cmdPath: "undo", args: []string{"--dir", dir, "--actor", humanID, "undo"}, expect: expectJSONEnvelope})
+	run(t, invocation{name: "redo", cmdPath: "redo", args: []string{"--dir", dir, "--actor", humanID, "redo"}, expect: expectJSONEnvelope})
+	run(t, invocation{name: "undo --n", cmdPath: "undo", args: []string{"--dir", dir, "--actor", humanID, "undo", "--n", "1"}, expect: expectJSONEnvelope})
+	run(t, invocation{name: "redo --n", cmdPath: "redo", args: []string{"--dir", dir, "--actor", humanID, "redo", "--n", "1"}, expect: expectJSONEnvelope})
+	run(t, invocation{name: "redo (nothing to redo)", cmdPath: "redo", args: []string{"--dir", dir, "--actor", humanID, "redo"}, expect: expectError})
 	run(t, invocation{name: "items set-description", cmdPath: "items set-description", args: []string{"--dir", dir, "--actor", humanID, "items", "set-description", itemA, "--description", "Updated description"}, expect: expectJSONEnvelope})
 	run(t, invocation{name: "items set-status", cmdPath: "items set-status", args: []string{"--dir", dir, "--actor", humanID, "items", "set-status", itemA, "--status", "doing"}, expect: expectJSONEnvelope})
 	run(t, invocation{name: "items set-status --note", cmdPath: "items set-status", args: []string{"--dir", dir, "--actor", humanID, "items", "set-status", itemA, "--status", "todo", "--note", "context"}, expect: expectJSONEnvelope})
+	// Time travel: --as-of replays up to a date or event id.
+	run(t, invocation{name: "items show --as-of", cmdPath: "items show", args: []string{"--dir", dir, "--actor", humanID, "items", "show", itemA, "--as-of", "2099-01-01"}, expect: expectJSONEnvelope})
+	run(t, invocation{name: "items show --as-of (unknown event)", cmdPath: "items show", args: []string{"--dir", dir, "--actor", humanID, "items", "show", itemA, "--as-of", "evt-does-not-exist"}, expect: expectError})
+	run(t, invocation{name: "state", cmdPath: "state", args: []string{"--dir", dir, "--actor", humanID, "state"}, expect: expectJSONEnvelope})
+	run(t, invocation{name: "state --as-of --entity", cmdPath: "state", args: []string{"--dir", dir, "--actor", humanID, "state", "--as-of", "2099-01-01", "--entity", itemA}, expect: expectJSONEnvelope})
 	// Negative: invalid status id/label for outline.
 	run(t, invocation{name: "items set-status (invalid)", cmdPath: "items set-status", args: []string{"--dir", dir, "--actor", humanID, "items", "set-status", itemA, "--status", "does-not-exist"}, expect: expectError})
 
@@ -289,6 +300,12 @@ func TestCLIIntegration_CommandAndFlagCoverage(t *testing.T) {
 	run(t, invocation{name: "items set-due --clear", cmdPath: "items set-due", args: []string{"--dir", dir, "--actor", humanID, "items", "set-due", itemA, "--clear"}, expect: expectJSONEnvelope})
 	run(t, invocation{name: "items set-schedule --at", cmdPath: "items set-schedule", args: []string{"--dir", dir, "--actor", humanID, "items", "set-schedule", itemA, "--at", "2025-12-30 09:00"}, expect: expectJSONEnvelope})
 	run(t, invocation{name: "items set-schedule --clear", cmdPath: "items set-schedule", args: []string{"--dir", dir, "--actor", humanID, "items", "set-schedule", itemA, "--clear"}, expect: expectJSONEnvelope})
+	run(t, invocation{name: "items set-due --repeat", cmdPath: "items set-due", args: []string{"--dir", dir, "--actor", humanID, "items", "set-due", itemA, "--at", "2025-12-31", "--repeat", "+1m"}, expect: expectJSONEnvelope})
+	run(t, invocation{name: "items set-due --repeat none", cmdPath: "items set-due", args: []string{"--dir", dir, "--actor", humanID, "items", "set-due", itemA, "--repeat", "none"}, expect: expectJSONEnvelope})
+	run(t, invocation{name: "items set-schedule --repeat", cmdPath: "items set-schedule", args: []string{"--dir", dir, "--actor", humanID, "items", "set-schedule", itemA, "--at", "2025-12-30", "--repeat", "++1w"}, expect: expectJSONEnvelope})
+	run(t, invocation{name: "items set-schedule --repeat (invalid)", cmdPath: "items set-schedule", args: []string{"--dir", dir, "--actor", humanID, "items", "set-schedule", itemA, "--repeat", "weekly"}, expect: expectError})
+	run(t, invocation{name: "items set-schedule --clear (after repeat)", cmdPath: "items set-schedule", args: []string{"--dir", dir, "--actor", humanID, "items", "set-schedule", itemA, "--clear"}, expect: expectJSONEnvelope})
+	run(t, invocation{name: "items set-due --clear (after repeat)", cmdPath: "items set-due", args: []string{"--dir", dir, "--actor", humanID, "items", "set-due", itemA, "--clear"}, expect: expectJSONEnvelope})
 
 	// set-assign: use --assignee, alias --to, and --clear.
 	run(t, invocation{name: "items set-assign --assignee", cmdPath: "items set-assign", args: []string{"--dir", dir, "--actor", humanID, "items", "set-assign", itemB, "--assignee", human2ID}, expect: expectJSONEnvelope})
@@ -310,12 +327,61 @@ func TestCLIIntegration_CommandAndFlagCoverage(t *testing.T) {
 	run(t, invocation{name: "items list (mine)", cmdPath: "items list", args: []string{"--dir", dir, "--actor", humanID, "items", "list", "--mine"}, expect: expectJSONEnvelope})
 	run(t, invocation{name: "items list (status)", cmdPath: "items list", args: []string{"--dir", dir, "--actor", humanID, "items", "list", "--status", "doing"}, expect: expectJSONEnvelope})
 	run(t, invocation{name: "items list (include-archived)", cmdPath: "items list", args: []string{"--dir", dir, "--actor", humanID, "items", "list", "--include-archived"}, expect: expectJSONEnvelope})
+	run(t, invocation{name: "items list (query)", cmdPath: "items list", args: []string{"--dir", dir, "--actor", humanID, "items", "list", "--query", "is:open sort:title"}, expect: expectJSONEnvelope})
+	run(t, invocation{name: "items list (invalid query)", cmdPath: "items list", args: []string{"--dir", dir, "--actor", humanID, "items", "list", "--query", "due<soon"}, expect: expectError})
 
 	// ready (with include-assigned)
 	run(t, invocation{name: "items ready --include-assigned", cmdPath: "items ready", args: []string{"--dir", dir, "--actor", humanID, "items", "ready", "--include-assigned"}, expect: expectJSONEnvelope})
 	// ready (include on-hold items)
 	run(t, invocation{name: "items ready --include-on-hold", cmdPath: "items ready", args: []string{"--dir", dir, "--actor", humanID, "items", "ready", "--include-on-hold"}, expect: expectJSONEnvelope})
 
+	// agenda: day (default) + week span with explicit dates.
+	run(t, invocation{name: "agenda", cmdPath: "agenda", args: []string{"--dir", dir, "--actor", humanID, "agenda"}, expect: expectJSONEnvelope})
+	run(t, invocation{name: "agenda --span week --from --today --warning-days", cmdPath: "agenda", args: []string{"--dir", dir, "--actor", humanID, "agenda", "--span", "week", "--from", "2025-12-29", "--today", "2025-12-30", "--warning-days", "7"}, expect: expectJSONEnvelope})
+	run(t, invocation{name: "agenda (invalid span)", cmdPath: "agenda", args: []string{"--dir", dir, "--actor", humanID, "agenda", "--span", "year"}, expect: expectError})
+
+	// search: terms + qualifiers, limit, and a bad qualifier.
+	run(t, invocation{name: "search", cmdPath: "search", args: []string{"--dir", dir, "--actor", humanID, "search", "item", "assignee:me"}, expect: expectJSONEnvelope})
+	run(t, invocation{name: "search --limit", cmdPath: "search", args: []string{"--dir", dir, "--actor", humanID, "search", "--limit", "1", "is:open"}, expect: expectJSONEnvelope})
+	run(t, invocation{name: "search (invalid qualifier)", cmdPath: "search", args: []string{"--dir", dir, "--actor", humanID, "search", "due<soon"}, expect: expectError})
+
+	run(t, invocation{name: "views save", cmdPath: "views save", args: []string{"--dir", dir, "--actor", humanID, "views", "save", "ready", "--query", "is:ready assignee:me sort:priority", "--description", "Ready for me", "--key", "r"}, expect: expectJSONEnvelope})
+	run(t, invocation{name: "views list", cmdPath: "views list", args: []string{"--dir", dir, "--actor", humanID, "views", "list"}, expect: expectJSONEnvelope})
+	run(t, invocation{name: "views run", cmdPath: "views run", args: []string{"--dir", dir, "--actor", humanID, "views", "run", "ready", "--limit", "1"}, expect: expectJSONEnvelope})
+	run(t, invocation{name: "views run (missing)", cmdPath: "views run", args: []string{"--dir", dir, "--actor", humanID, "views", "run", "nope"}, expect: expectError})
+	run(t, invocation{name: "views remove", cmdPath: "views remove", args: []string{"--dir", dir, "--actor", humanID, "views", "remove", "ready"}, expect: expectJSONEnvelope})
+
+	// hooks: a failing command hook on set-title; untrusted until `hooks trust`, then dead-lettered.
+	hooksPath := filepath.Join(dir, "meta", "hooks.json")
+	if err := os.WriteFile(hooksPath, []byte(`{"version":1,"hooks":[{"name":"fail","on":["item.set_title"],"run":"echo nope >&2; exit 1","retries":0}]}`), 0o644); err != nil {
+		t.Fatalf("write hooks: %v", err)
+	}
+	run(t, invocation{name: "hooks list", cmdPath: "hooks list", args: []string{"--dir", dir, "--actor", humanID, "hooks", "list"}, expect: expectJSONEnvelope})
+	run(t, invocation{name: "hooks trust", cmdPath: "hooks trust", args: []string{"--dir", dir, "--actor", humanID, "hooks", "trust"}, expect: expectJSONEnvelope})
+	run(t, invocation{name: "items set-title (hook fails)", cmdPath: "items set-title", args: []string{"--dir", dir, "--actor", humanID, "items", "set-title", itemA, "--title", "Item A (hooked)"}, expect: expectJSONEnvelope})
+	run(t, invocation{name: "items set-title --no-hooks", cmdPath: "items set-title", args: []string{"--dir", dir, "--actor", humanID, "--no-hooks", "items", "set-title", itemA, "--title", "Item A (renamed)"}, expect: expectJSONEnvelope})
+	if got := run(t, invocation{name: "hooks failures --limit", cmdPath: "hooks failures", args: []string{"--dir", dir, "--actor", humanID, "hooks", "failures", "--limit", "10"}, expect: expectJSONEnvelope}).env["meta"].(map[string]any)["total"]; got != float64(1) {
+		t.Fatalf("expected 1 dead-lettered delivery, got %v", got)
+	}
+	run(t, invocation{name: "hooks retry", cmdPath: "hooks retry", args: []string{"--dir", dir, "--actor", humanID, "hooks", "retry"}, expect: expectJSONEnvelope})
+	if err := os.Remove(hooksPath); err != nil {
+		t.Fatalf("remove hooks: %v", err)
+	}
+
+	// follows + notifications: human2 follows the outline and an item; humanID's edits show up in the feed.
+	run(t, invocation{name: "follows add (outline)", cmdPath: "follows add", args: []string{"--dir", dir, "--actor", human2ID, "follows", "add", out1}, expect: expectJSONEnvelope})
+	run(t, invocation{name: "follows add (item)", cmdPath: "follows add", args: []string{"--dir", dir, "--actor", human2ID, "follows", "add", itemA}, expect: expectJSONEnvelope})
+	run(t, invocation{name: "follows add (not found)", cmdPath: "follows add", args: []string{"--dir", dir, "--actor", human2ID, "follows", "add", "missing-id"}, expect: expectError})
+	run(t, invocation{name: "follows list", cmdPath: "follows list", args: []string{"--dir", dir, "--actor", human2ID, "follows", "list"}, expect: expectJSONEnvelope})
+	run(t, invocation{name: "items set-status (followed)", cmdPath: "items set-status", args: []string{"--dir", dir, "--actor", humanID, "items", "set-status", itemA, "--status", "doing"}, expect: expectJSONEnvelope})
+	run(t, invocation{name: "notifications list", cmdPath: "notifications list", args: []string{"--dir", dir, "--actor", human2ID, "notifications", "list"}, expect: expectJSONEnvelope})
+	run(t, invocation{name: "notifications mark-read", cmdPath: "notifications mark-read", args: []string{"--dir", dir, "--actor", human2ID, "notifications", "mark-read"}, expect: expectJSONEnvelope})
+	run(t, invocation{name: "notifications list --unread --limit", cmdPath: "notifications list", args: []string{"--dir", dir, "--actor", human2ID, "notifications", "list", "--unread", "--limit", "10"}, expect: expectJSONEnvelope})
+	// conflicts: a single-replica workspace has none; resolving an unknown one fails.
+	run(t, invocation{name: "conflicts list", cmdPath: "conflicts list", args: []string{"--dir", dir, "--actor", humanID, "conflicts", "list"}, expect: expectJSONEnvelope})
+	run(t, invocation{name: "conflicts resolve (not found)", cmdPath: "conflicts resolve", args: []string{"--dir", dir, "--actor", humanID, "conflicts", "resolve", itemA, "--take", "evt-missing"}, expect: expectError})
+	run(t, invocation{name: "follows remove", cmdPath: "follows remove", args: []string{"--dir", dir, "--actor", human2ID, "follows", "remove", itemA}, expect: expectJSONEnvelope})
+
 	// move + set-parent + move-outline
 	run(t, invocation{name: "items move --before", cmdPath: "items move", args: []string{"--dir", dir, "--actor", humanID, "items", "move", itemB, "--before", itemA}, expect: expectJSONEnvelope})
 	run(t, invocation{name: "items move --after", cmdPath: "items move", args: []string{"--dir", dir, "--actor", humanID, "items", "move", itemB, "--after", itemA}, expect: expectJSONEnvelope})
@@ -332,7 +398,7 @@ func TestCLIIntegration_CommandAndFlagCoverage(t *testing.T) {
 	run(t, invocation{name: "items move-outline --to", cmdPath: "items move-outline", args: []string{"--dir", dir, "--actor", humanID, "items", "move-outline", itemB, "--to", out2}, expect: expectJSONEnvelope})
 	run(t, invocation{name: "items move-outline --set-status", cmdPath: "items move-outline", args: []string{"--dir", dir, "--actor", humanID, "items", "move-outline", itemB, "--to", out1, "--set-status", "todo"}, expect: expectJSONEnvelope})
 
-	// deps: add blocks + related, list, tree, cycles + an error path.
+	// deps: add blocks + related, list, tree, blockers, cycles, set-type, remove + error paths.
 	run(t, invocation{name: "deps add --blocks", cmdPath: "deps add", args: []string{"--dir", dir, "--actor", humanID, "deps", "add", itemA, "--blocks", itemB}, expect: expectJSONEnvelope})
 	run(t, invocation{name: "deps add --related", cmdPath: "deps add", args: []string{"--dir", dir, "--actor", humanID, "deps", "add", itemA, "--related", itemC}, expect: expectJSONEnvelope})
 	run(t, invocation{name: "deps add (missing flags)", cmdPath: "deps add", args: []string{"--dir", dir, "--actor", humanID, "deps", "add", itemA}, expect: expectError})
@@ -340,12 +406,25 @@ func TestCLIIntegration_CommandAndFlagCoverage(t *testing.T) {
 	run(t, invocation{name: "deps list (all)", cmdPath: "deps list", args: []string{"--dir", dir, "--actor", humanID, "deps", "list"}, expect: expectJSONEnvelope})
 	run(t, invocation{name: "deps list (for item)", cmdPath: "deps list", args: []string{"--dir", dir, "--actor", humanID, "deps", "list", itemA}, expect: expectJSONEnvelope})
 	run(t, invocation{name: "deps tree", cmdPath: "deps tree", args: []string{"--dir", dir, "--actor", humanID, "deps", "tree", itemA}, expect: expectJSONEnvelope})
+	run(t, invocation{name: "deps blockers", cmdPath: "deps blockers", args: []string{"--dir", dir, "--actor", humanID, "deps", "blockers", itemA}, expect: expectJSONEnvelope})
+	run(t, invocation{name: "deps blockers (missing item)", cmdPath: "deps blockers", args: []string{"--dir", dir, "--actor", humanID, "deps", "blockers", "item-missing"}, expect: expectError})
 	run(t, invocation{name: "deps cycles", cmdPath: "deps cycles", args: []string{"--dir", dir, "--actor", humanID, "deps", "cycles"}, expect: expectJSONEnvelope})
+	depBC := mustID(t, run(t, invocation{name: "deps add (to retype/remove)", cmdPath: "deps add", args: []string{"--dir", dir, "--actor", humanID, "deps", "add", itemB, "--related", itemC}, expect: expectJSONEnvelope}).env)
+	run(t, invocation{name: "deps set-type", cmdPath: "deps set-type", args: []string{"--dir", dir, "--actor", humanID, "deps", "set-type", depBC, "--type", "blocks"}, expect: expectJSONEnvelope})
+	run(t, invocation{name: "deps set-type (invalid)", cmdPath: "deps set-type", args: []string{"--dir", dir, "--actor", humanID, "deps", "set-type", depBC, "--type", "nope"}, expect: expectError})
+	run(t, invocation{name: "deps remove", cmdPath: "deps remove", args: []string{"--dir", dir, "--actor", humanID, "deps", "remove", depBC}, expect: expectJSONEnvelope})
+	run(t, invocation{name: "deps remove (missing)", cmdPath: "deps remove", args: []string{"--dir", dir, "--actor", humanID, "deps", "remove", depBC}, expect: expectError})
 
 	// comments: add + list pagination.
 	comment1 := mustID(t, run(t, invocation{name: "comments add", cmdPath: "comments add", args: []string{"--dir", dir, "--actor", humanID, "comments", "add", itemA, "--body", "Comment 1"}, expect: expectJSONEnvelope}).env)
 	assertPaginatedListMeta(t, run(t, invocation{name: "comments list (limit/offset)", cmdPath: "comments list", args: []string{"--dir", dir, "--actor", humanID, "comments", "list", itemA, "--limit", "1", "--offset", "0"}, expect: expectJSONEnvelope}).env)
 	run(t, invocation{name: "comments list (all)", cmdPath: "comments list", args: []string{"--dir", dir, "--actor", humanID, "comments", "list", itemA, "--limit", "0"}, expect: expectJSONEnvelope})
+	comment2 := mustID(t, run(t, invocation{name: "comments add (to edit/redact)", cmdPath: "comments add", args: []string{"--dir", dir, "--actor", humanID, "comments", "add", itemA, "--body", "Typo"}, expect: expectJSONEnvelope}).env)
+	run(t, invocation{name: "comments edit", cmdPath: "comments edit", args: []string{"--dir", dir, "--actor", humanID, "comments", "edit", comment2, "--body", "Fixed"}, expect: expectJSONEnvelope})
+	run(t, invocation{name: "comments edit (missing)", cmdPath: "comments edit", args: []string{"--dir", dir, "--actor", humanID, "comments", "edit", "cmt-missing", "--body", "x"}, expect: expectError})
+	run(t, invocation{name: "comments history", cmdPath: "comments history", args: []string{"--dir", dir, "--actor", humanID, "comments", "history", comment2}, expect: expectJSONEnvelope})
+	run(t, invocation{name: "comments redact", cmdPath: "comments redact", args: []string{"--dir", dir, "--actor", humanID, "comments", "redact", comment2}, expect: expectJSONEnvelope})
+	run(t, invocation{name: "comments edit (redacted)", cmdPath: "comments edit", args: []string{"--dir", dir, "--actor", humanID, "comments", "edit", comment2, "--body", "Again"}, expect: expectError})
 
 	// attachments: add/list/open/export (avoid actually opening GUI by using --print-path).
 	attSrcDir := t.TempDir()
@@ -380,6 +459,23 @@ func TestCLIIntegration_CommandAndFlagCoverage(t *testing.T) {
 	attDestDir := t.TempDir()
 	attDest := filepath.Join(attDestDir, "exported.txt")
 	run(t, invocation{name: "attachments export", cmdPath: "attachments export", args: []string{"--dir", dir, "--actor", humanID, "attachments", "export", att2, attDest}, expect: expectJSONEnvelope})
+	attSrc2 := filepath.Join(attSrcDir, "note-v2.txt")
+	_ = writeFile(t, attSrcDir, "note-v2.txt", []byte("hello attachment, v2"))
+	run(t, invocation{name: "attachments replace --max-mb", cmdPath: "attachments replace", args: []string{"--dir", dir, "--actor", humanID, "attachments", "replace", att1, attSrc2, "--max-mb", "50"}, expect: expectJSONEnvelope})
+	run(t, invocation{name: "attachments extract (one)", cmdPath: "attachments extract", args: []string{"--dir", dir, "--actor", humanID, "attachments", "extract", att1}, expect: expectJSONEnvelope})
+	run(t, invocation{name: "attachments extract (all)", cmdPath: "attachments extract", args: []string{"--dir", dir, "--actor", humanID, "attachments", "extract"}, expect: expectJSONEnvelope})
+	run(t, invocation{name: "attachments remove", cmdPath: "attachments remove", args: []string{"--dir", dir, "--actor", humanID, "attachments", "remove", att2}, expect: expectJSONEnvelope})
+	run(t, invocation{name: "attachments remove (missing)", cmdPath: "attachments remove", args: []string{"--dir", dir, "--actor", humanID, "attachments", "remove", att2}, expect: expectError})
+	run(t, invocation{name: "attachments gc --removed --dry-run", cmdPath: "attachments gc", args: []string{"--dir", dir, "--actor", humanID, "attachments", "gc", "--removed", "--dry-run"}, expect: expectJSONEnvelope})
+	run(t, invocation{name: "attachments gc", cmdPath: "attachments gc", args: []string{"--dir", dir, "--actor", humanID, "attachments", "gc"}, expect: expectJSONEnvelope})
+	run(t, invocation{name: "attachments store show", cmdPath: "attachments store show", args: []string{"--dir", dir, "attachments", "store", "show"}, expect: expectJSONEnvelope})
+	run(t, invocation{name: "attachments store set (s3: --endpoint --bucket --region --prefix)", cmdPath: "attachments store set", args: []string{"--dir", dir, "attachments", "store", "set", "--backend", "s3", "--endpoint", "http://127.0.0.1:9", "--bucket", "b", "--region", "eu-north-1", "--prefix", "clarity/"}, expect: expectJSONEnvelope})
+	run(t, invocation{name: "attachments store set (unknown backend)", cmdPath: "attachments store set", args: []string{"--dir", dir, "attachments", "store", "set", "--backend", "ftp"}, expect: expectError})
+	sharedBlobs := t.TempDir()
+	run(t, invocation{name: "attachments store set (dir: --path --min-mb)", cmdPath: "attachments store set", args: []string{"--dir", dir, "attachments", "store", "set", "--backend", "dir", "--path", sharedBlobs, "--min-mb", "0"}, expect: expectJSONEnvelope})
+	att3 := mustID(t, run(t, invocation{name: "attachments add (shared dir store)", cmdPath: "attachments add", args: []string{"--dir", dir, "--actor", humanID, "attachments", "add", itemA, attSrc2}, expect: expectJSONEnvelope}).env)
+	run(t, invocation{name: "attachments export (shared dir store)", cmdPath: "attachments export", args: []string{"--dir", dir, "--actor", humanID, "attachments", "export", att3, filepath.Join(attDestDir, "exported-v2.txt")}, expect: expectJSONEnvelope})
+	run(t, invocation{name: "attachments store set (repo)", cmdPath: "attachments store set", args: []string{"--dir", dir, "attachments", "store", "set", "--backend", "repo"}, expect: expectJSONEnvelope})
 
 	// worklog: add + list pagination.
 	run(t, invocation{name: "worklog add", cmdPath: "worklog add", args: []string{"--dir", dir, "--actor", humanID, "worklog", "add", itemA, "--body", "Worklog 1"}, expect: expectJSONEnvelope})
@@ -387,12 +483,28 @@ func TestCLIIntegration_CommandAndFlagCoverage(t *testing.T) {
 	run(t, invocation{name: "worklog list (all)", cmdPath: "worklog list", args: []string{"--dir", dir, "--actor", humanID, "worklog", "list", itemA, "--limit", "0"}, expect: expectJSONEnvelope})
 
 	// events: list with limit.
-	run(t, invocation{name: "events list --limit", cmdPath: "events list", args: []string{"--dir", dir, "--actor", humanID, "events", "list", "--limit", "0"}, expect: expectJSONEnvelope})
+	evList := run(t, invocation{name: "events list --limit", cmdPath: "events list", args: []string{"--dir", dir, "--actor", humanID, "events", "list", "--limit", "0"}, expect: expectJSONEnvelope})
+	firstEventID := evList.env["data"].([]any)[0].(map[string]any)["id"].(string)
+
+	// events watch: --once prints what's available and exits.
+	run(t, invocation{name: "events watch (--since, filters, --once)", cmdPath: "events watch", args: []string{"--dir", dir, "--actor", humanID, "events", "watch", "--since", firstEventID, "--type", "item.create", "--entity", itemA, "--interval", "10ms", "--once"}, expect: expectRawText})
+	run(t, invocation{name: "events watch --cursor", cmdPath: "events watch", args: []string{"--dir", dir, "--actor", humanID, "events", "watch", "--cursor", "coverage", "--since", firstEventID, "--once"}, expect: expectRawText})
 
 	// publish: derived Markdown export.
 	pubDir := t.TempDir()
 	run(t, invocation{name: "publish item (--to, flags)", cmdPath: "publish item", args: []string{"--dir", dir, "--actor", humanID, "publish", "item", itemA, "--to", pubDir, "--include-worklog", "--overwrite=false"}, expect: expectJSONEnvelope})
 	run(t, invocation{name: "publish outline (--to, flags)", cmdPath: "publish outline", args: []string{"--dir", dir, "--actor", humanID, "publish", "outline", out1, "--to", pubDir, "--include-archived"}, expect: expectJSONEnvelope})
+	run(t, invocation{name: "publish org (--to, flags)", cmdPath: "publish org", args: []string{"--dir", dir, "--actor", humanID, "publish", "org", out1, "--to", pubDir, "--include-archived"}, expect: expectJSONEnvelope})
+	run(t, invocation{name: "import org --dry-run", cmdPath: "import org", args: []string{"--dir", dir, "--actor", humanID, "import", "org", filepath.Join(pubDir, "outlines", out1+".org"), "--outline", out1, "--dry-run"}, expect: expectJSONEnvelope})
+	run(t, invocation{name: "import org (--parent)", cmdPath: "import org", args: []string{"--dir", dir, "--actor", humanID, "import", "org", filepath.Join(pubDir, "outlines", out1+".org"), "--outline", out1, "--parent", itemB}, expect: expectJSONEnvelope})
+
+	// import: markdown into an outline (dry-run first, then real item.create events).
+	impDir := t.TempDir()
+	impSrc := filepath.Join(impDir, "plan.md")
+	_ = writeFile(t, impDir, "plan.md", []byte("# TODO Imported #import\nBody.\n\n- [ ] step one\n- [x] step two\n"))
+	run(t, invocation{name: "import markdown --dry-run", cmdPath: "import markdown", args: []string{"--dir", dir, "--actor", humanID, "import", "markdown", impSrc, "--outline", out1, "--dry-run"}, expect: expectJSONEnvelope})
+	run(t, invocation{name: "import markdown (--parent)", cmdPath: "import markdown", args: []string{"--dir", dir, "--actor", humanID, "import", "markdown", impSrc, "--outline", out1, "--parent", itemA}, expect: expectJSONEnvelope})
+	run(t, invocation{name: "import markdown (missing outline)", cmdPath: "import markdown", args: []string{"--dir", dir, "--actor", humanID, "import", "markdown", impSrc, "--outline", "out-nope"}, expect: expectError})
 
 	// status: should produce envelope and be stable.
 	run(t, invocation{name: "status", cmdPath: "status", args: []string{"--dir", dir, "--actor", humanID, "status"}, expect: expectJSONEnvelope})
@@ -531,6 +643,11 @@ func TestCLIIntegration_CommandAndFlagCoverage(t *testing.T) {
 	// Negative: exporting to non-empty directory without --force should fail.
 	run(t, invocation{name: "workspace export (non-empty without --force)", cmdPath: "workspace export", args: []string{"--workspace", wsName2, "--actor", wsHuman, "workspace", "export", "--to", exportDir, "--events=false"}, expect: expectError})
 	run(t, invocation{name: "workspace export --force --events=false", cmdPath: "workspace export", args: []string{"--workspace", wsName2, "--actor", wsHuman, "workspace", "export", "--to", exportDir, "--force", "--events=false"}, expect: expectJSONEnvelope})
+	// identity key: signing keys (Git-backed workspaces only).
+	run(t, invocation{name: "identity key list", cmdPath: "identity key list", args: []string{"--workspace", wsName2, "--actor", wsHuman, "identity", "key", "list"}, expect: expectJSONEnvelope})
+	run(t, invocation{name: "identity key add", cmdPath: "identity key add", args: []string{"--workspace", wsName2, "--actor", wsHuman, "identity", "key", "add"}, expect: expectJSONEnvelope})
+	run(t, invocation{name: "identity key list <actor-id>", cmdPath: "identity key list", args: []string{"--workspace", wsName2, "--actor", wsHuman, "identity", "key", "list", wsHuman}, expect: expectJSONEnvelope})
+	run(t, invocation{name: "workspace snapshot", cmdPath: "workspace snapshot", args: []string{"--workspace", wsName2, "--actor", wsHuman, "workspace", "snapshot"}, expect: expectJSONEnvelope})
 	// Import into a new workspace using positional name.
 	importName := "ws-imported"
 	run(t, invocation{name: "workspace import (positional) --from --use", cmdPath: "workspace import", args: []string{"workspace", "import", importName, "--from", exportDir, "--use"}, expect: expectJSONEnvelope})
@@ -551,6 +668,7 @@ func TestCLIIntegration_CommandAndFlagCoverage(t *testing.T) {
 	// Canonical JSONL + derived SQLite maintenance commands.
 	// Use --dir to keep this self-contained and avoid touching ~/.clarity.
 	run(t, invocation{name: "reindex (--dir)", cmdPath: "reindex", args: []string{"--dir", dir, "reindex"}, expect: expectJSONEnvelope})
+	run(t, invocation{name: "reindex --full (--dir)", cmdPath: "reindex", args: []string{"--dir", dir, "reindex", "--full"}, expect: expectJSONEnvelope})
 	run(t, invocation{name: "doctor --fail (--dir)", cmdPath: "doctor", args: []string{"--dir", dir, "doctor", "--fail"}, expect: expectJSONEnvelope})
 	run(t, invocation{name: "sync status (--dir)", cmdPath: "sync status", args: []string{"--dir", dir, "sync", "status"}, expect: expectJSONEnvelope})
 	run(t, invocation{name: "sync pull (non-repo error, --dir)", cmdPath: "sync pull", args: []string{"--dir", dir, "sync", "pull"}, expect: expectError})
@@ -562,6 +680,10 @@ func TestCLIIntegration_CommandAndFlagCoverage(t *testing.T) {
 
 	// webtui: long-running server command; cover flags via --help (no server start).
 	run(t, invocation{name: "webtui --help (--addr)", cmdPath: "webtui", args: []string{"--dir", dir, "webtui", "--addr", "127.0.0.1:0", "--help"}, expect: expectRawText})
+
+	// serve: long-running server command; cover flags via --help (no server start).
+	run(t, invocation{name: "serve --help (--api --addr --token)", cmdPath: "serve", args: []string{"--dir", dir, "serve", "--api", "--addr", "127.0.0.1:0", "--token", "t", "--help"}, expect: expectRawText})
+	run(t, invocation{name: "serve (missing --api)", cmdPath: "serve", args: []string{"--dir", dir, "serve"}, expect: expectError})
 	// capture: interactive TUI; cover flags via --help (no capture start).
 	run(t, invocation{name: "capture --help (--hotkey --no-output --exit-0-on-cancel --url --selection)", cmdPath: "capture", args: []string{"--dir", dir, "capture", "--hotkey", "--no-output", "--exit-0-on-cancel", "--url", "https://example.com", "--selection", "hello", "--help"}, expect: expectRawText})
 
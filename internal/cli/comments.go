@@ -7,6 +7,7 @@ import (
         "time"
 
         "clarity-cli/internal/model"
+        "clarity-cli/internal/mutate"
 
         "github.com/spf13/cobra"
 )
@@ -17,6 +18,9 @@ func newCommentsCmd(app *App) *cobra.Command {
                 Short: "Comment commands",
         }
         cmd.AddCommand(newCommentsAddCmd(app))
+        cmd.AddCommand(newCommentsEditCmd(app))
+        cmd.AddCommand(newCommentsRedactCmd(app))
+        cmd.AddCommand(newCommentsHistoryCmd(app))
         cmd.AddCommand(newCommentsListCmd(app))
         return cmd
 }
@@ -42,9 +46,13 @@ func newCommentsAddCmd(app *App) *cobra.Command {
                         }
 
                         itemID := args[0]
-                        if _, ok := db.FindItem(itemID); !ok {
+                        it, ok := db.FindItem(itemID)
+                        if !ok {
                                 return writeErr(cmd, errNotFound("item", itemID))
                         }
+                        if err := checkCanContribute(db, actorID, it.ProjectID, it.OutlineID); err != nil {
+                                return writeErr(cmd, err)
+                        }
 
                         c := model.Comment{
                                 ID:        s.NextID(db, "cmt"),
@@ -69,6 +77,157 @@ func newCommentsAddCmd(app *App) *cobra.Command {
         return cmd
 }
 
+func newCommentsEditCmd(app *App) *cobra.Command {
+        var body string
+
+        cmd := &cobra.Command{
+                Use:   "edit <comment-id>",
+                Short: "Edit a comment (author-only; prior bodies are kept in history)",
+                Args:  cobra.ExactArgs(1),
+                RunE: func(cmd *cobra.Command, args []string) error {
+                        db, s, err := loadDB(app)
+                        if err != nil {
+                                return writeErr(cmd, err)
+                        }
+                        actorID, err := currentActorID(app, db)
+                        if err != nil {
+                                return writeErr(cmd, err)
+                        }
+
+                        res, err := mutate.EditComment(db, actorID, args[0], body)
+                        if err != nil {
+                                return writeErr(cmd, commentMutationErr(err))
+                        }
+                        if !res.Changed {
+                                return writeOut(cmd, app, map[string]any{"data": res.Comment})
+                        }
+                        if err := s.AppendEvent(actorID, "comment.edit", res.Comment.ID, res.EventPayload); err != nil {
+                                return writeErr(cmd, err)
+                        }
+                        if err := s.Save(db); err != nil {
+                                return writeErr(cmd, err)
+                        }
+                        return writeOut(cmd, app, map[string]any{
+                                "data":   res.Comment,
+                                "_hints": []string{"clarity comments history " + res.Comment.ID},
+                        })
+                },
+        }
+
+        cmd.Flags().StringVar(&body, "body", "", "New comment body")
+        _ = cmd.MarkFlagRequired("body")
+        return cmd
+}
+
+func newCommentsRedactCmd(app *App) *cobra.Command {
+        cmd := &cobra.Command{
+                Use:   "redact <comment-id>",
+                Short: "Redact a comment (author-only; replaces the body and prior revisions)",
+                Long: strings.TrimSpace(`
+Redact a comment: its body and all prior revisions are replaced with "` + model.RedactedCommentBody + `"
+in derived state and in publish output.
+
+Note: the original comment.add / comment.edit events remain in the append-only event log
+(and Git history). Rotate any leaked secret regardless.
+`),
+                Args: cobra.ExactArgs(1),
+                RunE: func(cmd *cobra.Command, args []string) error {
+                        db, s, err := loadDB(app)
+                        if err != nil {
+                                return writeErr(cmd, err)
+                        }
+                        actorID, err := currentActorID(app, db)
+                        if err != nil {
+                                return writeErr(cmd, err)
+                        }
+
+                        res, err := mutate.RedactComment(db, actorID, args[0])
+                        if err != nil {
+                                return writeErr(cmd, commentMutationErr(err))
+                        }
+                        if !res.Changed {
+                                return writeOut(cmd, app, map[string]any{"data": res.Comment})
+                        }
+                        if err := s.AppendEvent(actorID, "comment.redact", res.Comment.ID, res.EventPayload); err != nil {
+                                return writeErr(cmd, err)
+                        }
+                        if err := s.Save(db); err != nil {
+                                return writeErr(cmd, err)
+                        }
+                        return writeOut(cmd, app, map[string]any{"data": res.Comment})
+                },
+        }
+        return cmd
+}
+
+type commentRevisionOut struct {
+        Rev     int       `json:"rev"`
+        Body    string    `json:"body"`
+        At      time.Time `json:"at"`
+        Current bool      `json:"current"`
+}
+
+func newCommentsHistoryCmd(app *App) *cobra.Command {
+        cmd := &cobra.Command{
+                Use:   "history <comment-id>",
+                Short: "Show a comment's revisions (oldest first)",
+                Args:  cobra.ExactArgs(1),
+                RunE: func(cmd *cobra.Command, args []string) error {
+                        db, _, err := loadDB(app)
+                        if err != nil {
+                                return writeErr(cmd, err)
+                        }
+                        commentID := strings.TrimSpace(args[0])
+                        var c *model.Comment
+                        for i := range db.Comments {
+                                if db.Comments[i].ID == commentID {
+                                        c = &db.Comments[i]
+                                        break
+                                }
+                        }
+                        if c == nil {
+                                return writeErr(cmd, errNotFound("comment", commentID))
+                        }
+
+                        out := make([]commentRevisionOut, 0, len(c.Revisions)+1)
+                        for i, r := range c.Revisions {
+                                out = append(out, commentRevisionOut{Rev: i + 1, Body: r.Body, At: r.At})
+                        }
+                        at := c.CreatedAt
+                        if c.EditedAt != nil {
+                                at = *c.EditedAt
+                        }
+                        out = append(out, commentRevisionOut{Rev: len(out) + 1, Body: c.Body, At: at, Current: true})
+
+                        return writeOut(cmd, app, map[string]any{
+                                "data": out,
+                                "meta": map[string]any{
+                                        "commentId": c.ID,
+                                        "itemId":    c.ItemID,
+                                        "authorId":  c.AuthorID,
+                                        "edited":    c.EditedAt != nil,
+                                        "redacted":  c.Redacted,
+                                        "revisions": len(out),
+                                },
+                        })
+                },
+        }
+        return cmd
+}
+
+func commentMutationErr(err error) error {
+        switch e := err.(type) {
+        case mutate.NotFoundError:
+                return errNotFound(e.Kind, e.ID)
+        case mutate.AuthorOnlyError:
+                return errorsAuthorOnly(e.ActorID, e.AuthorID, e.CommentID)
+        case mutate.RoleRequiredError:
+                return errorsRoleRequired(e.ActorID, e.Role, e.ProjectID, e.OutlineID)
+        default:
+                return err
+        }
+}
+
 func newCommentsListCmd(app *App) *cobra.Command {
         var limit int
         var offset int
@@ -6,6 +6,7 @@ import (
         "strings"
 
         "clarity-cli/internal/model"
+        "clarity-cli/internal/readiness"
         "clarity-cli/internal/store"
 )
 
@@ -39,26 +40,7 @@ func hasIncompleteChildren(db *store.DB, taskID string) bool {
 }
 
 func isBlockedByUndoneDeps(db *store.DB, taskID string) bool {
-        for _, d := range db.Deps {
-                if d.Type != model.DependencyBlocks {
-                        continue
-                }
-                if d.FromItemID != taskID {
-                        continue
-                }
-                dep, ok := db.FindItem(d.ToItemID)
-                if !ok {
-                        // If the dep target is missing, treat as blocked until cleaned up.
-                        return true
-                }
-                if dep.Archived {
-                        continue
-                }
-                if !isEndState(db, dep.OutlineID, dep.StatusID) {
-                        return true
-                }
-        }
-        return false
+        return readiness.IsBlocked(db, taskID)
 }
 
 func explainCompletionBlockers(db *store.DB, taskID string) string {
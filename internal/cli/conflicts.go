@@ -0,0 +1,138 @@
+package cli
+
+import (
+        "errors"
+        "strings"
+        "time"
+
+        "clarity-cli/internal/mutate"
+        "clarity-cli/internal/store"
+
+        "github.com/spf13/cobra"
+)
+
+func newConflictsCmd(app *App) *cobra.Command {
+        cmd := &cobra.Command{
+                Use:   "conflicts",
+                Short: "Concurrent edits that need a decision (derived from the event log)",
+        }
+        cmd.AddCommand(newConflictsListCmd(app))
+        cmd.AddCommand(newConflictsResolveCmd(app))
+        return cmd
+}
+
+func newConflictsListCmd(app *App) *cobra.Command {
+        cmd := &cobra.Command{
+                Use:   "list",
+                Short: "List unresolved conflicts",
+                Long: strings.TrimSpace(`
+When two replicas edit the same entity without seeing each other's change, replay merges them
+per field: title and status are last-writer-wins, tags are unioned. Descriptions can't be merged
+automatically: the state shows the last write, and both sides are listed here until one is taken
+with ` + "`clarity conflicts resolve`" + `.
+`),
+                Args: cobra.NoArgs,
+                RunE: func(cmd *cobra.Command, args []string) error {
+                        _, s, err := loadDB(app)
+                        if err != nil {
+                                return writeErr(cmd, err)
+                        }
+                        cs, err := store.ListConflictsV1(s.Dir)
+                        if err != nil {
+                                return writeErr(cmd, err)
+                        }
+                        return writeOut(cmd, app, map[string]any{
+                                "data": cs,
+                                "meta": map[string]any{"count": len(cs)},
+                                "_hints": []string{
+                                        "clarity conflicts resolve <entity-id> --take <event-id>",
+                                },
+                        })
+                },
+        }
+        return cmd
+}
+
+func newConflictsResolveCmd(app *App) *cobra.Command {
+        var take string
+
+        cmd := &cobra.Command{
+                Use:   "resolve <entity-id>",
+                Short: "Resolve a conflict by taking one side's value",
+                Long: strings.TrimSpace(`
+Writes the chosen side's value again as a new event whose parents include every side, so the
+conflict is resolved for everyone once it syncs.
+`),
+                Example: strings.TrimSpace(`
+clarity conflicts list
+clarity conflicts resolve item-abc --take evt-123
+`),
+                Args: cobra.ExactArgs(1),
+                RunE: func(cmd *cobra.Command, args []string) error {
+                        db, s, err := loadDB(app)
+                        if err != nil {
+                                return writeErr(cmd, err)
+                        }
+                        actorID, err := currentActorID(app, db)
+                        if err != nil {
+                                return writeErr(cmd, err)
+                        }
+
+                        entityID := strings.TrimSpace(args[0])
+                        take = strings.TrimSpace(take)
+                        if take == "" {
+                                return writeErr(cmd, errors.New("missing --take <event-id>"))
+                        }
+                        cs, err := store.ListConflictsV1(s.Dir)
+                        if err != nil {
+                                return writeErr(cmd, err)
+                        }
+                        var conflict *store.Conflict
+                        for i := range cs {
+                                if cs[i].EntityID == entityID {
+                                        conflict = &cs[i]
+                                        break
+                                }
+                        }
+                        if conflict == nil {
+                                return writeErr(cmd, errNotFound("conflict", entityID))
+                        }
+
+                        res, err := mutate.ResolveConflict(db, actorID, *conflict, take, time.Now())
+                        if err != nil {
+                                switch e := err.(type) {
+                                case mutate.NotFoundError:
+                                        return writeErr(cmd, errNotFound(e.Kind, e.ID))
+                                case mutate.OwnerOnlyError:
+                                        return writeErr(cmd, errorsOwnerOnly(actorID, e.OwnerActorID, e.ItemID))
+                                case mutate.RoleRequiredError:
+                                        return writeErr(cmd, errorsRoleRequired(e.ActorID, e.Role, e.ProjectID, e.OutlineID))
+                                }
+                                return writeErr(cmd, err)
+                        }
+                        if err := s.AppendEvent(actorID, res.EventType, conflict.EntityID, res.EventPayload); err != nil {
+                                return writeErr(cmd, err)
+                        }
+                        if err := s.Save(db); err != nil {
+                                return writeErr(cmd, err)
+                        }
+                        return writeOut(cmd, app, map[string]any{
+                                "data": map[string]any{
+                                        "entityKind": conflict.EntityKind,
+                                        "entityId":   conflict.EntityID,
+                                        "field":      conflict.Field,
+                                        "took":       res.Side.EventID,
+                                        "value":      res.Side.Value,
+                                },
+                                "_hints": []string{
+                                        "clarity conflicts list",
+                                        "clarity sync push",
+                                },
+                        })
+                },
+        }
+
+        cmd.Flags().StringVar(&take, "take", "", "Event id of the side to keep (see `clarity conflicts list`)")
+        _ = cmd.MarkFlagRequired("take")
+        return cmd
+}
@@ -0,0 +1,67 @@
+package cli
+
+import (
+        "encoding/json"
+        "os"
+        "path/filepath"
+        "testing"
+
+        "clarity-cli/internal/store"
+)
+
+const conflictsBaseEvents = "" +
+        `{"eventId":"evt-1","workspaceId":"ws-1","replicaId":"rep-a","entityKind":"actor","entityId":"act-1","entitySeq":1,"type":"identity.create","issuedAt":"2025-12-31T00:00:00Z","actorId":"act-1","payload":{"id":"act-1","name":"A","kind":"human"}}` + "\n" +
+        `{"eventId":"evt-2","workspaceId":"ws-1","replicaId":"rep-a","entityKind":"project","entityId":"proj-1","entitySeq":1,"type":"project.create","issuedAt":"2025-12-31T00:00:01Z","actorId":"act-1","payload":{"id":"proj-1","name":"P","createdBy":"act-1","createdAt":"2025-12-31T00:00:01Z","archived":false}}` + "\n" +
+        `{"eventId":"evt-3","workspaceId":"ws-1","replicaId":"rep-a","entityKind":"outline","entityId":"out-1","entitySeq":1,"type":"outline.create","issuedAt":"2025-12-31T00:00:02Z","actorId":"act-1","payload":{"id":"out-1","projectId":"proj-1","statusDefs":[{"id":"todo","label":"Todo","isEndState":false}],"createdBy":"act-1","createdAt":"2025-12-31T00:00:02Z","archived":false}}` + "\n" +
+        `{"eventId":"evt-4","workspaceId":"ws-1","replicaId":"rep-a","entityKind":"item","entityId":"item-1","entitySeq":1,"type":"item.create","issuedAt":"2025-12-31T00:00:03Z","actorId":"act-1","payload":{"id":"item-1","projectId":"proj-1","outlineId":"out-1","rank":"h","title":"T","status":"todo","priority":false,"onHold":false,"archived":false,"ownerActorId":"act-1","createdBy":"act-1","createdAt":"2025-12-31T00:00:03Z","updatedAt":"2025-12-31T00:00:03Z"}}` + "\n" +
+        `{"eventId":"evt-a1","workspaceId":"ws-1","replicaId":"rep-a","entityKind":"item","entityId":"item-1","entitySeq":2,"type":"item.set_description","parents":["evt-4"],"issuedAt":"2025-12-31T00:00:04Z","actorId":"act-1","payload":{"description":"from A"}}` + "\n"
+
+func TestConflictsResolve_TakesSideAndClearsConflict(t *testing.T) {
+        dir := t.TempDir()
+        if _, err := store.EnsureGitBackedV1Layout(dir); err != nil {
+                t.Fatalf("layout: %v", err)
+        }
+        mustWriteFile(t, filepath.Join(dir, "events", "events.rep-a.jsonl"), []byte(conflictsBaseEvents))
+        mustWriteFile(t, filepath.Join(dir, "events", "events.rep-b.jsonl"), []byte(
+                `{"eventId":"evt-b1","workspaceId":"ws-1","replicaId":"rep-b","entityKind":"item","entityId":"item-1","entitySeq":2,"type":"item.set_description","parents":["evt-4"],"issuedAt":"2025-12-31T00:00:05Z","actorId":"act-1","payload":{"description":"from B"}}`+"\n"))
+
+        out, errOut, err := runCLI(t, []string{"--dir", dir, "--actor", "act-1", "conflicts", "list"})
+        if err != nil {
+                t.Fatalf("conflicts list: %v\nstderr:\n%s", err, errOut)
+        }
+        var env struct {
+                Data []store.Conflict `json:"data"`
+        }
+        if err := json.Unmarshal(out, &env); err != nil {
+                t.Fatalf("decode: %v\n%s", err, out)
+        }
+        if len(env.Data) != 1 || len(env.Data[0].Sides) != 2 || env.Data[0].Current != "from B" {
+                t.Fatalf("unexpected conflicts: %+v", env.Data)
+        }
+
+        if _, errOut, err := runCLI(t, []string{"--dir", dir, "--actor", "act-1", "conflicts", "resolve", "item-1", "--take", "evt-nope"}); err == nil {
+                t.Fatalf("expected error for unknown side; stderr:\n%s", errOut)
+        }
+        if _, errOut, err := runCLI(t, []string{"--dir", dir, "--actor", "act-1", "conflicts", "resolve", "item-1", "--take", "evt-a1"}); err != nil {
+                t.Fatalf("conflicts resolve: %v\nstderr:\n%s", err, errOut)
+        }
+
+        cs, err := store.ListConflictsV1(dir)
+        if err != nil {
+                t.Fatalf("ListConflictsV1: %v", err)
+        }
+        if len(cs) != 0 {
+                t.Fatalf("expected no conflicts after resolve, got %+v", cs)
+        }
+        // The resolution survives a full replay.
+        if err := os.Remove(filepath.Join(dir, ".clarity", "index.sqlite")); err != nil {
+                t.Fatalf("remove index: %v", err)
+        }
+        db, err := (store.Store{Dir: dir}).Load()
+        if err != nil {
+                t.Fatalf("Load: %v", err)
+        }
+        if it, _ := db.FindItem("item-1"); it == nil || it.Description != "from A" {
+                t.Fatalf("description after resolve = %+v", it)
+        }
+}
@@ -7,6 +7,7 @@ import (
         "time"
 
         "clarity-cli/internal/model"
+        "clarity-cli/internal/repeat"
 )
 
 var (
@@ -52,3 +53,52 @@ func parseDateTime(s string) (*model.DateTime, error) {
 
         return nil, fmt.Errorf("invalid datetime %q (expected YYYY-MM-DD, YYYY-MM-DD HH:MM, or RFC3339)", s)
 }
+
+// applyDateTimeFlags computes the new schedule/due value for set-due/set-schedule.
+// at may end with an Org-style repeater ("2026-01-05 +1w"); repeatFlag ("+1w", "none") overrides it.
+// Changing only the date keeps cur's repeater; passing only --repeat changes the repeater of cur.
+func applyDateTimeFlags(cur *model.DateTime, at string, repeatFlag string, repeatSet bool) (*model.DateTime, error) {
+        at = strings.TrimSpace(at)
+        var next *model.DateTime
+        switch {
+        case at != "":
+                inline := ""
+                if fields := strings.Fields(at); len(fields) > 1 {
+                        if _, err := repeat.Parse(fields[len(fields)-1]); err == nil {
+                                inline = fields[len(fields)-1]
+                                at = strings.Join(fields[:len(fields)-1], " ")
+                        }
+                }
+                dt, err := parseDateTime(at)
+                if err != nil {
+                        return nil, err
+                }
+                if inline != "" {
+                        dt.Repeat, _ = repeat.Normalize(inline)
+                } else if cur != nil {
+                        dt.Repeat = cur.Repeat
+                }
+                next = dt
+        case repeatSet:
+                if cur == nil {
+                        return nil, fmt.Errorf("no date to repeat: pass --at")
+                }
+                tmp := *cur
+                next = &tmp
+        default:
+                return nil, fmt.Errorf("missing --at (or pass --clear)")
+        }
+
+        if repeatSet {
+                r := strings.TrimSpace(repeatFlag)
+                if strings.EqualFold(r, "none") {
+                        r = ""
+                }
+                norm, err := repeat.Normalize(r)
+                if err != nil {
+                        return nil, err
+                }
+                next.Repeat = norm
+        }
+        return next, nil
+}
@@ -6,6 +6,8 @@ import (
         "time"
 
         "clarity-cli/internal/model"
+        "clarity-cli/internal/mutate"
+        "clarity-cli/internal/readiness"
         "clarity-cli/internal/store"
 
         "github.com/spf13/cobra"
@@ -17,8 +19,11 @@ func newDepsCmd(app *App) *cobra.Command {
                 Short: "Dependency commands",
         }
         cmd.AddCommand(newDepsAddCmd(app))
+        cmd.AddCommand(newDepsRemoveCmd(app))
+        cmd.AddCommand(newDepsSetTypeCmd(app))
         cmd.AddCommand(newDepsListCmd(app))
         cmd.AddCommand(newDepsTreeCmd(app))
+        cmd.AddCommand(newDepsBlockersCmd(app))
         cmd.AddCommand(newDepsCyclesCmd(app))
         return cmd
 }
@@ -93,6 +98,98 @@ func newDepsAddCmd(app *App) *cobra.Command {
         return cmd
 }
 
+func newDepsRemoveCmd(app *App) *cobra.Command {
+        cmd := &cobra.Command{
+                Use:   "remove <dep-id>",
+                Short: "Remove a dependency (owner-only on the dependent item)",
+                Args:  cobra.ExactArgs(1),
+                RunE: func(cmd *cobra.Command, args []string) error {
+                        db, s, err := loadDB(app)
+                        if err != nil {
+                                return writeErr(cmd, err)
+                        }
+                        actorID, err := currentActorID(app, db)
+                        if err != nil {
+                                return writeErr(cmd, err)
+                        }
+
+                        depID := args[0]
+                        res, err := mutate.RemoveDependency(db, actorID, depID)
+                        if err != nil {
+                                return writeErr(cmd, depMutationErr(actorID, err))
+                        }
+                        if err := s.AppendEvent(actorID, "dep.remove", res.Dep.ID, res.EventPayload); err != nil {
+                                return writeErr(cmd, err)
+                        }
+                        if err := s.Save(db); err != nil {
+                                return writeErr(cmd, err)
+                        }
+                        return writeOut(cmd, app, map[string]any{
+                                "data": res.Dep,
+                                "_hints": []string{
+                                        "clarity deps list " + res.Dep.FromItemID,
+                                },
+                        })
+                },
+        }
+        return cmd
+}
+
+func newDepsSetTypeCmd(app *App) *cobra.Command {
+        var typ string
+
+        cmd := &cobra.Command{
+                Use:   "set-type <dep-id>",
+                Short: "Change a dependency's type (owner-only on the dependent item)",
+                Args:  cobra.ExactArgs(1),
+                RunE: func(cmd *cobra.Command, args []string) error {
+                        db, s, err := loadDB(app)
+                        if err != nil {
+                                return writeErr(cmd, err)
+                        }
+                        actorID, err := currentActorID(app, db)
+                        if err != nil {
+                                return writeErr(cmd, err)
+                        }
+
+                        if strings.TrimSpace(typ) == "" {
+                                return writeErr(cmd, errors.New("missing --type"))
+                        }
+                        depType := model.DependencyType(strings.ToLower(strings.TrimSpace(typ)))
+
+                        depID := args[0]
+                        res, err := mutate.SetDependencyType(db, actorID, depID, depType)
+                        if err != nil {
+                                return writeErr(cmd, depMutationErr(actorID, err))
+                        }
+                        if !res.Changed {
+                                return writeOut(cmd, app, map[string]any{"data": res.Dep})
+                        }
+                        if err := s.AppendEvent(actorID, "dep.update", res.Dep.ID, res.EventPayload); err != nil {
+                                return writeErr(cmd, err)
+                        }
+                        if err := s.Save(db); err != nil {
+                                return writeErr(cmd, err)
+                        }
+                        return writeOut(cmd, app, map[string]any{"data": res.Dep})
+                },
+        }
+
+        cmd.Flags().StringVar(&typ, "type", "", "New dependency type (blocks|related)")
+        return cmd
+}
+
+func depMutationErr(actorID string, err error) error {
+        switch e := err.(type) {
+        case mutate.NotFoundError:
+                return errNotFound(e.Kind, e.ID)
+        case mutate.OwnerOnlyError:
+                return errorsOwnerOnly(actorID, e.OwnerActorID, e.ItemID)
+        default:
+                return err
+        }
+}
+
 func newDepsListCmd(app *App) *cobra.Command {
         cmd := &cobra.Command{
                 Use:   "list [item-id]",
@@ -125,9 +222,11 @@ func newDepsListCmd(app *App) *cobra.Command {
 }
 
 type depsTreeNode struct {
-        ID       string         `json:"id"`
-        Title    string         `json:"title"`
-        StatusID string         `json:"status"`
+        ID       string `json:"id"`
+        Title    string `json:"title"`
+        StatusID string `json:"status"`
+        // Resolved is true when the item no longer blocks (end-state or archived).
+        Resolved bool           `json:"resolved"`
         BlocksOn []depsTreeNode `json:"blocksOn,omitempty"`
 }
 
@@ -156,6 +255,7 @@ func newDepsTreeCmd(app *App) *cobra.Command {
                                 if t != nil {
                                         node.Title = t.Title
                                         node.StatusID = t.StatusID
+                                        node.Resolved = readiness.IsResolved(db, t)
                                 }
                                 if seen[id] {
                                         return node
@@ -171,12 +271,81 @@ func newDepsTreeCmd(app *App) *cobra.Command {
                                 ID:       rootTask.ID,
                                 Title:    rootTask.Title,
                                 StatusID: rootTask.StatusID,
+                                Resolved: readiness.IsResolved(db, rootTask),
                         }
                         for _, dep := range blocksGraph[rootTask.ID] {
                                 out.BlocksOn = append(out.BlocksOn, build(dep))
                         }
 
-                        return writeOut(cmd, app, map[string]any{"data": out})
+                        openBlockers := len(readiness.Blockers(db, rootTask.ID))
+                        return writeOut(cmd, app, map[string]any{
+                                "data": out,
+                                "meta": map[string]any{
+                                        "blocked":      openBlockers > 0,
+                                        "openBlockers": openBlockers,
+                                },
+                                "_hints": []string{
+                                        "clarity deps blockers " + rootTask.ID,
+                                },
+                        })
+                },
+        }
+        return cmd
+}
+
+func newDepsBlockersCmd(app *App) *cobra.Command {
+        cmd := &cobra.Command{
+                Use:   "blockers <item-id>",
+                Short: "Explain which open items block an item (transitively)",
+                Long: strings.TrimSpace(`
+List the open items that block an item, following "blocks" dependencies transitively.
+
+A blocker stops blocking once it reaches an end-state status or is archived; those are
+listed under "resolved" (direct blockers only). Blockers that no longer exist are
+reported with reason "missing" and keep blocking until the dependency is removed.
+`),
+                Example: strings.TrimSpace(`
+clarity deps blockers <item-id>
+clarity deps remove <dep-id>
+`),
+                Args: cobra.ExactArgs(1),
+                RunE: func(cmd *cobra.Command, args []string) error {
+                        db, _, err := loadDB(app)
+                        if err != nil {
+                                return writeErr(cmd, err)
+                        }
+                        itemID := args[0]
+                        t, ok := db.FindItem(itemID)
+                        if !ok {
+                                return writeErr(cmd, errNotFound("item", itemID))
+                        }
+
+                        open := readiness.Blockers(db, t.ID)
+                        resolved := readiness.ResolvedBlockers(db, t.ID)
+                        direct := 0
+                        for _, b := range open {
+                                if b.Depth == 1 {
+                                        direct++
+                                }
+                        }
+                        return writeOut(cmd, app, map[string]any{
+                                "data": map[string]any{
+                                        "itemId":   t.ID,
+                                        "blocked":  len(open) > 0,
+                                        "blockers": open,
+                                        "resolved": resolved,
+                                },
+                                "meta": map[string]any{
+                                        "open":     len(open),
+                                        "direct":   direct,
+                                        "resolved": len(resolved),
+                                },
+                                "_hints": []string{
+                                        "clarity <blocker-item-id>",
+                                        "clarity items set-status <blocker-item-id> --status <end-status>",
+                                        "clarity deps remove <dep-id>",
+                                },
+                        })
                 },
         }
         return cmd
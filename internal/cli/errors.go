@@ -28,3 +28,35 @@ func (e ownerOnlyError) Error() string {
 func errorsOwnerOnly(actorID, ownerID, taskID string) error {
         return ownerOnlyError{actorID: actorID, ownerID: ownerID, taskID: taskID}
 }
+
+type authorOnlyError struct {
+        actorID   string
+        authorID  string
+        commentID string
+}
+
+func (e authorOnlyError) Error() string {
+        return fmt.Sprintf("permission denied: actor %s is not author %s of comment %s", e.actorID, e.authorID, e.commentID)
+}
+
+func errorsAuthorOnly(actorID, authorID, commentID string) error {
+        return authorOnlyError{actorID: actorID, authorID: authorID, commentID: commentID}
+}
+
+type roleRequiredError struct {
+        actorID string
+        role    string
+        scope   string
+}
+
+func (e roleRequiredError) Error() string {
+        return fmt.Sprintf("permission denied: actor %s needs the %s role on %s (meta/users.json)", e.actorID, e.role, e.scope)
+}
+
+func errorsRoleRequired(actorID, role, projectID, outlineID string) error {
+        scope := "project " + projectID
+        if outlineID != "" {
+                scope = "outline " + outlineID
+        }
+        return roleRequiredError{actorID: actorID, role: role, scope: scope}
+}
@@ -32,5 +32,6 @@ func newEventsCmd(app *App) *cobra.Command {
         listCmd.Flags().IntVar(&limit, "limit", 200, "Max events to return (0 = all)")
 
         cmd.AddCommand(listCmd)
+        cmd.AddCommand(newEventsWatchCmd(app))
         return cmd
 }
@@ -0,0 +1,153 @@
+package cli
+
+import (
+        "context"
+        "encoding/json"
+        "errors"
+        "os"
+        "os/signal"
+        "strings"
+        "time"
+
+        "clarity-cli/internal/store"
+
+        "github.com/spf13/cobra"
+)
+
+func newEventsWatchCmd(app *App) *cobra.Command {
+        var filter eventFilter
+        var since string
+        var cursorName string
+        var interval time.Duration
+        var once bool
+
+        cmd := &cobra.Command{
+                Use:   "watch",
+                Short: "Print new events as newline-delimited JSON (including events from git pull)",
+                Long: strings.TrimSpace(`
+Follows the event log and prints one event JSON object per line as events are appended — by
+this or another clarity process, or by a "git pull" that brings in a teammate's shard.
+
+Without --since or --cursor, only events appended after the command starts are printed.
+--since <event-id> first prints the events after that one (in "events list" order).
+--cursor <name> saves the position under .clarity/ after every batch and resumes from it on the
+next run, so nothing is missed or repeated across restarts (the first run starts at --since, or
+at the end of the log).
+
+--type and --entity can be repeated; an event is printed if it matches any value of each.
+`),
+                Example: strings.TrimSpace(`
+clarity events watch
+clarity events watch --type item.set_status --type item.create
+clarity events watch --entity item-abc --since evt-123
+clarity events watch --cursor deploy-bot --once
+`),
+                Args: cobra.NoArgs,
+                RunE: func(cmd *cobra.Command, args []string) error {
+                        if interval <= 0 {
+                                return writeErr(cmd, errors.New("--interval must be positive"))
+                        }
+                        _, s, err := loadDB(app)
+                        if err != nil {
+                                return writeErr(cmd, err)
+                        }
+                        ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+                        defer stop()
+
+                        var tail *store.EventTail
+                        var backlog []store.EventV1
+                        cursorName = strings.TrimSpace(cursorName)
+                        if cursorName != "" {
+                                cur, ok, err := s.LoadWatchCursor(cursorName)
+                                if err != nil {
+                                        return writeErr(cmd, err)
+                                }
+                                if ok {
+                                        tail = s.TailEventsFrom(cur)
+                                }
+                        }
+                        if tail == nil && strings.TrimSpace(since) != "" {
+                                backlog, tail, err = s.TailEventsAfter(ctx, since)
+                                if err != nil {
+                                        return writeErr(cmd, err)
+                                }
+                        }
+                        if tail == nil {
+                                if tail, err = s.TailEvents(ctx); err != nil {
+                                        return writeErr(cmd, err)
+                                }
+                        }
+
+                        enc := json.NewEncoder(cmd.OutOrStdout())
+                        emit := func(evs []store.EventV1) error {
+                                for _, ev := range evs {
+                                        if !filter.match(ev) {
+                                                continue
+                                        }
+                                        if err := enc.Encode(ev); err != nil {
+                                                return err
+                                        }
+                                }
+                                if cursorName != "" {
+                                        return s.SaveWatchCursor(cursorName, tail.Cursor())
+                                }
+                                return nil
+                        }
+                        if err := emit(backlog); err != nil {
+                                return writeErr(cmd, err)
+                        }
+
+                        for {
+                                evs, err := tail.Poll(ctx)
+                                if err != nil {
+                                        if ctx.Err() != nil {
+                                                return nil
+                                        }
+                                        return writeErr(cmd, err)
+                                }
+                                if err := emit(evs); err != nil {
+                                        return writeErr(cmd, err)
+                                }
+                                if once {
+                                        return nil
+                                }
+                                select {
+                                case <-ctx.Done():
+                                        return nil
+                                case <-time.After(interval):
+                                }
+                        }
+                },
+        }
+
+        cmd.Flags().StringArrayVar(&filter.types, "type", nil, "Only events of this type (repeatable), e.g. item.set_status")
+        cmd.Flags().StringArrayVar(&filter.entities, "entity", nil, "Only events for this entity id (repeatable)")
+        cmd.Flags().StringVar(&since, "since", "", "First print the events after this event id")
+        cmd.Flags().StringVar(&cursorName, "cursor", "", "Resume from (and save) a named position under .clarity/")
+        cmd.Flags().DurationVar(&interval, "interval", time.Second, "How often to check for new events")
+        cmd.Flags().BoolVar(&once, "once", false, "Print what is available and exit instead of following")
+        return cmd
+}
+
+// eventFilter selects events by type and entity id (empty lists match everything).
+type eventFilter struct {
+        types    []string
+        entities []string
+}
+
+func (f eventFilter) match(ev store.EventV1) bool {
+        return matchAny(f.types, ev.Type) && matchAny(f.entities, ev.EntityID)
+}
+
+func matchAny(want []string, v string) bool {
+        if len(want) == 0 {
+                return true
+        }
+        v = strings.TrimSpace(v)
+        for _, w := range want {
+                if strings.TrimSpace(w) == v {
+                        return true
+                }
+        }
+        return false
+}
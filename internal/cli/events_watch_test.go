@@ -0,0 +1,66 @@
+package cli
+
+import (
+        "bufio"
+        "bytes"
+        "encoding/json"
+        "path/filepath"
+        "testing"
+
+        "clarity-cli/internal/store"
+)
+
+func watchedEventIDs(t *testing.T, out []byte) []string {
+        t.Helper()
+        ids := []string{}
+        sc := bufio.NewScanner(bytes.NewReader(out))
+        for sc.Scan() {
+                var ev store.EventV1
+                if err := json.Unmarshal(sc.Bytes(), &ev); err != nil {
+                        t.Fatalf("decode line %q: %v", sc.Text(), err)
+                }
+                ids = append(ids, ev.EventID)
+        }
+        return ids
+}
+
+func TestEventsWatch_FiltersAndResumesFromCursor(t *testing.T) {
+        dir := t.TempDir()
+        if _, err := store.EnsureGitBackedV1Layout(dir); err != nil {
+                t.Fatalf("layout: %v", err)
+        }
+        mustWriteFile(t, filepath.Join(dir, "events", "events.rep-a.jsonl"), []byte(conflictsBaseEvents))
+
+        watch := func(args ...string) []string {
+                t.Helper()
+                out, errOut, err := runCLI(t, append([]string{"--dir", dir, "--actor", "act-1", "events", "watch", "--once"}, args...))
+                if err != nil {
+                        t.Fatalf("events watch %v: %v\nstderr:\n%s", args, err, errOut)
+                }
+                return watchedEventIDs(t, out)
+        }
+
+        if got := watch("--since", "evt-2", "--type", "item.create", "--type", "item.set_description"); len(got) != 2 || got[0] != "evt-4" || got[1] != "evt-a1" {
+                t.Fatalf("--since/--type: got %v", got)
+        }
+        if got := watch("--since", "evt-1", "--entity", "out-1"); len(got) != 1 || got[0] != "evt-3" {
+                t.Fatalf("--entity: got %v", got)
+        }
+        if _, _, err := runCLI(t, []string{"--dir", dir, "--actor", "act-1", "events", "watch", "--once", "--since", "evt-nope"}); err == nil {
+                t.Fatalf("expected error for unknown --since event")
+        }
+
+        if got := watch("--cursor", "bot", "--since", "evt-4"); len(got) != 1 || got[0] != "evt-a1" {
+                t.Fatalf("first cursor run: got %v", got)
+        }
+        // A teammate's shard arrives via git pull while the watcher isn't running.
+        mustWriteFile(t, filepath.Join(dir, "events", "events.rep-b.jsonl"), []byte(
+                `{"eventId":"evt-b1","workspaceId":"ws-1","replicaId":"rep-b","entityKind":"item","entityId":"item-1","entitySeq":2,"type":"item.set_description","parents":["evt-4"],"issuedAt":"2025-12-31T00:00:05Z","actorId":"act-1","payload":{"description":"from B"}}`+"\n"))
+        // The saved cursor wins over --since.
+        if got := watch("--cursor", "bot", "--since", "evt-1"); len(got) != 1 || got[0] != "evt-b1" {
+                t.Fatalf("resumed cursor run: got %v", got)
+        }
+        if got := watch("--cursor", "bot"); len(got) != 0 {
+                t.Fatalf("expected nothing new, got %v", got)
+        }
+}
@@ -0,0 +1,127 @@
+package cli
+
+import (
+        "strings"
+        "time"
+
+        "clarity-cli/internal/mutate"
+
+        "github.com/spf13/cobra"
+)
+
+func newFollowsCmd(app *App) *cobra.Command {
+        cmd := &cobra.Command{
+                Use:   "follows",
+                Short: "Follow outlines and items (feeds `clarity notifications`)",
+        }
+        cmd.AddCommand(newFollowsAddCmd(app))
+        cmd.AddCommand(newFollowsRemoveCmd(app))
+        cmd.AddCommand(newFollowsListCmd(app))
+        return cmd
+}
+
+func newFollowsAddCmd(app *App) *cobra.Command {
+        cmd := &cobra.Command{
+                Use:   "add <outline-or-item-id>",
+                Short: "Follow an outline (all of its items) or a single item",
+                Args:  cobra.ExactArgs(1),
+                RunE: func(cmd *cobra.Command, args []string) error {
+                        db, s, err := loadDB(app)
+                        if err != nil {
+                                return writeErr(cmd, err)
+                        }
+                        actorID, err := currentActorID(app, db)
+                        if err != nil {
+                                return writeErr(cmd, err)
+                        }
+
+                        res, err := mutate.Follow(db, actorID, args[0], s.NextID(db, "fol"), time.Now())
+                        if err != nil {
+                                return writeErr(cmd, followMutationErr(err))
+                        }
+                        if !res.Changed {
+                                return writeOut(cmd, app, map[string]any{"data": res.Follow})
+                        }
+                        if err := s.AppendEvent(actorID, "follow.add", res.Follow.ID, res.EventPayload); err != nil {
+                                return writeErr(cmd, err)
+                        }
+                        if err := s.Save(db); err != nil {
+                                return writeErr(cmd, err)
+                        }
+                        return writeOut(cmd, app, map[string]any{
+                                "data":   res.Follow,
+                                "_hints": []string{"clarity notifications list --unread"},
+                        })
+                },
+        }
+        return cmd
+}
+
+func newFollowsRemoveCmd(app *App) *cobra.Command {
+        cmd := &cobra.Command{
+                Use:   "remove <outline-or-item-id>",
+                Short: "Stop following an outline or item",
+                Args:  cobra.ExactArgs(1),
+                RunE: func(cmd *cobra.Command, args []string) error {
+                        db, s, err := loadDB(app)
+                        if err != nil {
+                                return writeErr(cmd, err)
+                        }
+                        actorID, err := currentActorID(app, db)
+                        if err != nil {
+                                return writeErr(cmd, err)
+                        }
+
+                        res, err := mutate.Unfollow(db, actorID, args[0])
+                        if err != nil {
+                                return writeErr(cmd, followMutationErr(err))
+                        }
+                        if !res.Changed {
+                                return writeOut(cmd, app, map[string]any{"data": map[string]any{"targetId": strings.TrimSpace(args[0]), "following": false}})
+                        }
+                        if err := s.AppendEvent(actorID, "follow.remove", res.Follow.ID, res.EventPayload); err != nil {
+                                return writeErr(cmd, err)
+                        }
+                        if err := s.Save(db); err != nil {
+                                return writeErr(cmd, err)
+                        }
+                        return writeOut(cmd, app, map[string]any{"data": map[string]any{"targetId": res.Follow.TargetID, "following": false}})
+                },
+        }
+        return cmd
+}
+
+func newFollowsListCmd(app *App) *cobra.Command {
+        cmd := &cobra.Command{
+                Use:   "list",
+                Short: "List what the current actor follows",
+                Args:  cobra.NoArgs,
+                RunE: func(cmd *cobra.Command, args []string) error {
+                        db, _, err := loadDB(app)
+                        if err != nil {
+                                return writeErr(cmd, err)
+                        }
+                        actorID, err := currentActorID(app, db)
+                        if err != nil {
+                                return writeErr(cmd, err)
+                        }
+                        return writeOut(cmd, app, map[string]any{
+                                "data": db.FollowsForActor(actorID),
+                                "_hints": []string{
+                                        "clarity follows add <outline-or-item-id>",
+                                        "clarity notifications list --unread",
+                                },
+                        })
+                },
+        }
+        return cmd
+}
+
+func followMutationErr(err error) error {
+        switch e := err.(type) {
+        case mutate.NotFoundError:
+                return errNotFound(e.Kind, e.ID)
+        default:
+                return err
+        }
+}
@@ -0,0 +1,155 @@
+package cli
+
+import (
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+func newHooksCmd(app *App) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "hooks",
+		Short: "Hooks: run a command or POST a webhook when events are appended (meta/hooks.json)",
+		Long: strings.TrimSpace(`
+Hooks are configured in meta/hooks.json (committed) and fire after an event is appended on this
+machine. They only run once the file is trusted here (clarity hooks trust); any change to the
+file, including one that arrives with a pull, pauses them until it is trusted again.
+
+Failed deliveries are retried, then kept in a local dead-letter log (clarity hooks failures).
+Pass --no-hooks (or set CLARITY_NO_HOOKS=1) to append without running hooks.
+
+See: clarity docs hooks
+`),
+	}
+	cmd.AddCommand(newHooksListCmd(app))
+	cmd.AddCommand(newHooksTrustCmd(app))
+	cmd.AddCommand(newHooksFailuresCmd(app))
+	cmd.AddCommand(newHooksRetryCmd(app))
+	return cmd
+}
+
+func newHooksListCmd(app *App) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List configured hooks and whether they are trusted on this machine",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			_, s, err := loadDB(app)
+			if err != nil {
+				return writeErr(cmd, err)
+			}
+			f, err := s.LoadHooks()
+			if err != nil {
+				return writeErr(cmd, err)
+			}
+			trusted, err := s.HooksTrusted()
+			if err != nil {
+				return writeErr(cmd, err)
+			}
+			failures, err := s.ReadHookFailures()
+			if err != nil {
+				return writeErr(cmd, err)
+			}
+			hints := []string{"clarity hooks failures"}
+			if len(f.Hooks) > 0 && !trusted {
+				hints = []string{"review meta/hooks.json, then: clarity hooks trust"}
+			}
+			return writeOut(cmd, app, map[string]any{
+				"data":   f.Hooks,
+				"meta":   map[string]any{"trusted": trusted, "failures": len(failures)},
+				"_hints": hints,
+			})
+		},
+	}
+	return cmd
+}
+
+func newHooksTrustCmd(app *App) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "trust",
+		Short: "Allow the current meta/hooks.json to run on this machine",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			_, s, err := loadDB(app)
+			if err != nil {
+				return writeErr(cmd, err)
+			}
+			digest, err := s.TrustHooks()
+			if err != nil {
+				return writeErr(cmd, err)
+			}
+			f, err := s.LoadHooks()
+			if err != nil {
+				return writeErr(cmd, err)
+			}
+			return writeOut(cmd, app, map[string]any{
+				"data": map[string]any{"sha256": digest, "hooks": len(f.Hooks)},
+				"_hints": []string{
+					"clarity hooks list",
+				},
+			})
+		},
+	}
+	return cmd
+}
+
+func newHooksFailuresCmd(app *App) *cobra.Command {
+	var limit int
+
+	cmd := &cobra.Command{
+		Use:   "failures",
+		Short: "List dead-lettered hook deliveries (newest first)",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			_, s, err := loadDB(app)
+			if err != nil {
+				return writeErr(cmd, err)
+			}
+			failures, err := s.ReadHookFailures()
+			if err != nil {
+				return writeErr(cmd, err)
+			}
+			total := len(failures)
+			for i, j := 0, len(failures)-1; i < j; i, j = i+1, j-1 {
+				failures[i], failures[j] = failures[j], failures[i]
+			}
+			if limit > 0 && len(failures) > limit {
+				failures = failures[:limit]
+			}
+			return writeOut(cmd, app, map[string]any{
+				"data": failures,
+				"meta": map[string]any{"total": total},
+				"_hints": []string{
+					"clarity hooks retry",
+				},
+			})
+		},
+	}
+	cmd.Flags().IntVar(&limit, "limit", 50, "Max failures to return (0 = all)")
+	return cmd
+}
+
+func newHooksRetryCmd(app *App) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "retry",
+		Short: "Redeliver dead-lettered events; the ones that fail again stay in the log",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			_, s, err := loadDB(app)
+			if err != nil {
+				return writeErr(cmd, err)
+			}
+			delivered, remaining, err := s.RetryHookFailures()
+			if err != nil {
+				return writeErr(cmd, err)
+			}
+			return writeOut(cmd, app, map[string]any{
+				"data": map[string]any{"delivered": delivered, "remaining": len(remaining)},
+				"_hints": []string{
+					"clarity hooks failures",
+				},
+			})
+		},
+	}
+	return cmd
+}
@@ -21,6 +21,7 @@ func newIdentityCmd(app *App) *cobra.Command {
         cmd.AddCommand(newIdentityUseCmd(app))
         cmd.AddCommand(newIdentityListCmd(app))
         cmd.AddCommand(newIdentityWhoamiCmd(app))
+        cmd.AddCommand(newIdentityKeyCmd(app))
 
         return cmd
 }
@@ -71,9 +72,19 @@ func newIdentityCreateCmd(app *App) *cobra.Command {
                                 db.CurrentActorID = actor.ID
                                 app.ActorID = actor.ID
                         }
-                        if err := s.AppendEvent(actor.ID, "identity.create", actor.ID, map[string]any{"name": name, "kind": kind, "use": use, "ts": time.Now().UTC()}); err != nil {
+                        payload := map[string]any{"name": name, "kind": kind, "use": use, "ts": time.Now().UTC()}
+                        if parentUserID != nil {
+                                payload["userId"] = *parentUserID
+                        }
+                        if err := s.AppendEvent(actor.ID, "identity.create", actor.ID, payload); err != nil {
+                                return writeErr(cmd, err)
+                        }
+                        if err := addInitialKey(s, db, actor.ID); err != nil {
                                 return writeErr(cmd, err)
                         }
+                        if a, ok := db.FindActor(actor.ID); ok {
+                                actor = *a
+                        }
                         if err := s.Save(db); err != nil {
                                 return writeErr(cmd, err)
                         }
@@ -166,6 +166,12 @@ func ensureAgentIdentity(app *App, db *store.DB, s store.Store, opts ensureAgent
         }); err != nil {
                 return model.Actor{}, false, "", err
         }
+        if err := addInitialKey(s, db, a.ID); err != nil {
+                return model.Actor{}, false, "", err
+        }
+        if got, ok := db.FindActor(a.ID); ok {
+                a = *got
+        }
         if err := s.Save(db); err != nil {
                 return model.Actor{}, false, "", err
         }
@@ -0,0 +1,129 @@
+package cli
+
+import (
+        "errors"
+        "strings"
+
+        "clarity-cli/internal/model"
+        "clarity-cli/internal/store"
+
+        "github.com/spf13/cobra"
+)
+
+func newIdentityKeyCmd(app *App) *cobra.Command {
+        cmd := &cobra.Command{
+                Use:   "key",
+                Short: "Signing keys of identities (events are signed with them)",
+        }
+
+        cmd.AddCommand(newIdentityKeyAddCmd(app))
+        cmd.AddCommand(newIdentityKeyListCmd(app))
+        return cmd
+}
+
+func newIdentityKeyAddCmd(app *App) *cobra.Command {
+        cmd := &cobra.Command{
+                Use:   "add [actor-id]",
+                Short: "Add a signing key for an identity (default: the current actor)",
+                Long: strings.TrimSpace(`
+Generates an Ed25519 key, publishes its public key with an identity.key_add event and keeps the
+private key in .clarity/keys (local, not committed).
+
+The current actor issues the event: either the identity itself (signed with a key it already has, or
+with the new key when it has none) or, for an agent, its human.
+`),
+                Args: cobra.MaximumNArgs(1),
+                RunE: func(cmd *cobra.Command, args []string) error {
+                        db, s, err := loadDB(app)
+                        if err != nil {
+                                return writeErr(cmd, err)
+                        }
+                        by, err := currentActorID(app, db)
+                        if err != nil {
+                                return writeErr(cmd, err)
+                        }
+                        id := by
+                        if len(args) == 1 {
+                                id = strings.TrimSpace(args[0])
+                        }
+                        if _, ok := db.FindActor(id); !ok {
+                                return writeErr(cmd, errNotFound("actor", id))
+                        }
+                        key, err := s.AddActorKey(db, id, by)
+                        if err != nil {
+                                return writeErr(cmd, err)
+                        }
+                        if err := s.Save(db); err != nil {
+                                return writeErr(cmd, err)
+                        }
+                        return writeOut(cmd, app, map[string]any{
+                                "data":   map[string]any{"actorId": id, "key": key},
+                                "_hints": []string{"clarity identity key list " + id},
+                        })
+                },
+        }
+        return cmd
+}
+
+type identityKeyRow struct {
+        model.ActorKey
+        ActorID string `json:"actorId"`
+        // Local is true when the private key is on this machine.
+        Local bool `json:"local"`
+}
+
+func newIdentityKeyListCmd(app *App) *cobra.Command {
+        cmd := &cobra.Command{
+                Use:   "list [actor-id]",
+                Short: "List signing keys (default: all identities)",
+                Args:  cobra.MaximumNArgs(1),
+                RunE: func(cmd *cobra.Command, args []string) error {
+                        db, s, err := loadDB(app)
+                        if err != nil {
+                                return writeErr(cmd, err)
+                        }
+                        actors := db.Actors
+                        if len(args) == 1 {
+                                a, ok := db.FindActor(strings.TrimSpace(args[0]))
+                                if !ok {
+                                        return writeErr(cmd, errNotFound("actor", args[0]))
+                                }
+                                actors = []model.Actor{*a}
+                        }
+                        rows := []identityKeyRow{}
+                        for _, a := range actors {
+                                local, err := s.LoadLocalKey(a.ID)
+                                if err != nil {
+                                        return writeErr(cmd, err)
+                                }
+                                for _, k := range a.Keys {
+                                        rows = append(rows, identityKeyRow{ActorKey: k, ActorID: a.ID, Local: local != nil && local.KeyID == k.ID})
+                                }
+                        }
+                        return writeOut(cmd, app, map[string]any{"data": rows})
+                },
+        }
+        return cmd
+}
+
+// addInitialKey gives a newly created identity its signing key. An agent's key is added by its
+// human when the human's key is on this machine; when the human has keys elsewhere, the agent stays
+// unsigned until they run `clarity identity key add <agent-id>`. Workspaces without a Git-backed
+// log don't sign.
+func addInitialKey(s store.Store, db *store.DB, actorID string) error {
+        by := actorID
+        if human, ok := db.HumanUserIDForActor(actorID); ok && human != actorID {
+                k, err := s.LoadLocalKey(human)
+                if err != nil {
+                        return err
+                }
+                if k != nil {
+                        by = human
+                }
+        }
+        _, err := s.AddActorKey(db, actorID, by)
+        if errors.Is(err, store.ErrSigningUnsupported) || errors.Is(err, store.ErrNoLocalKey) {
+                return nil
+        }
+        return err
+}
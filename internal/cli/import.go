@@ -0,0 +1,320 @@
+package cli
+
+import (
+	"errors"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"clarity-cli/internal/importer"
+	"clarity-cli/internal/model"
+	"clarity-cli/internal/statusutil"
+	"clarity-cli/internal/store"
+
+	"github.com/spf13/cobra"
+)
+
+func newImportCmd(app *App) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "import",
+		Short: "Import external documents into an outline (as ordinary item events)",
+	}
+	cmd.AddCommand(newImportMarkdownCmd(app))
+	cmd.AddCommand(newImportOrgCmd(app))
+	return cmd
+}
+
+func newImportMarkdownCmd(app *App) *cobra.Command {
+	var outlineID string
+	var parentID string
+	var dryRun bool
+
+	cmd := &cobra.Command{
+		Use:   "markdown <file>",
+		Short: "Import headings and bullet lists from a Markdown file as items",
+		Long: strings.TrimSpace(`
+Headings and nested bullet lists become an item hierarchy; the text under a heading or bullet
+becomes the item's description. "- [ ]" / "- [x]" bullets become checkbox children, a leading
+status keyword (TODO, DONE, or any status id/label of the outline in capitals) sets the status,
+and "#tags" in titles become tags.
+
+Use "-" as the file to read from stdin.
+`),
+		Example: strings.TrimSpace(`
+clarity import markdown notes.md --outline <outline-id> --dry-run
+clarity import markdown notes.md --outline <outline-id> --parent <item-id>
+`),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			src, err := readImportSource(cmd, args[0])
+			if err != nil {
+				return writeErr(cmd, err)
+			}
+			return runImport(cmd, app, "markdown", outlineID, parentID, dryRun, func(keywords []string) importer.Document {
+				return importer.ParseMarkdown(src, importer.Options{Keywords: keywords})
+			})
+		},
+	}
+
+	cmd.Flags().StringVar(&outlineID, "outline", "", "Outline id to import into")
+	cmd.Flags().StringVar(&parentID, "parent", "", "Import under this item (optional; default: top level)")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Print the planned events without writing anything")
+	_ = cmd.MarkFlagRequired("outline")
+	return cmd
+}
+
+func newImportOrgCmd(app *App) *cobra.Command {
+	var outlineID string
+	var parentID string
+	var dryRun bool
+
+	cmd := &cobra.Command{
+		Use:   "org <file>",
+		Short: "Import headlines from an Org file as items",
+		Long: strings.TrimSpace(`
+Headlines become an item hierarchy and their body text the item's description. TODO keywords
+map to the outline's statuses (by id/label in capitals; keywords only declared in the file's
+"#+TODO:" line map to the first open or first end status), "[#A]" sets priority, ":tags:" become
+tags and SCHEDULED:/DEADLINE: timestamps (including repeaters like "+1w") set schedule/due.
+
+Files written by "clarity publish org" import back with the same structure.
+Use "-" as the file to read from stdin.
+`),
+		Example: strings.TrimSpace(`
+clarity import org todo.org --outline <outline-id> --dry-run
+clarity import org todo.org --outline <outline-id>
+`),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			src, err := readImportSource(cmd, args[0])
+			if err != nil {
+				return writeErr(cmd, err)
+			}
+			return runImport(cmd, app, "org", outlineID, parentID, dryRun, func(keywords []string) importer.Document {
+				return importer.ParseOrg(src, importer.Options{Keywords: keywords})
+			})
+		},
+	}
+
+	cmd.Flags().StringVar(&outlineID, "outline", "", "Outline id to import into")
+	cmd.Flags().StringVar(&parentID, "parent", "", "Import under this item (optional; default: top level)")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Print the planned events without writing anything")
+	_ = cmd.MarkFlagRequired("outline")
+	return cmd
+}
+
+func readImportSource(cmd *cobra.Command, path string) (string, error) {
+	if strings.TrimSpace(path) == "-" {
+		b, err := io.ReadAll(cmd.InOrStdin())
+		return string(b), err
+	}
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// runImport resolves the target, parses the document with the outline's status keywords and
+// either prints the planned item.create events (--dry-run) or appends them.
+func runImport(cmd *cobra.Command, app *App, source, outlineID, parentID string, dryRun bool, parse func(keywords []string) importer.Document) error {
+	db, s, err := loadDB(app)
+	if err != nil {
+		return writeErr(cmd, err)
+	}
+	actorID, err := currentActorID(app, db)
+	if err != nil {
+		return writeErr(cmd, err)
+	}
+	outlineID = strings.TrimSpace(outlineID)
+	outline, ok := db.FindOutline(outlineID)
+	if !ok || outline == nil {
+		return writeErr(cmd, errNotFound("outline", outlineID))
+	}
+	if err := checkCanContribute(db, actorID, outline.ProjectID, outline.ID); err != nil {
+		return writeErr(cmd, err)
+	}
+	var parent *model.Item
+	if strings.TrimSpace(parentID) != "" {
+		p, ok := db.FindItem(strings.TrimSpace(parentID))
+		if !ok || p == nil {
+			return writeErr(cmd, errNotFound("item", parentID))
+		}
+		if p.OutlineID != outline.ID {
+			return writeErr(cmd, errors.New("parent must be in the same outline"))
+		}
+		parent = p
+	}
+
+	statuses := importKeywordStatuses(*outline)
+	keywords := make([]string, 0, len(statuses))
+	for k := range statuses {
+		keywords = append(keywords, k)
+	}
+	doc := parse(keywords)
+	if doc.Count() == 0 {
+		return writeErr(cmd, errors.New("nothing to import: no headings or list items found"))
+	}
+	for k, done := range doc.DeclaredKeywords {
+		if _, ok := statuses[k]; ok {
+			continue
+		}
+		if done {
+			statuses[k] = statusutil.CheckboxCheckedStatusID(*outline)
+		} else {
+			statuses[k] = statusutil.CheckboxUncheckedStatusID(*outline)
+		}
+	}
+
+	items := planImportItems(db, s, *outline, parent, actorID, statuses, doc.Items, time.Now().UTC())
+	// Planned events carry no id/ts: those are assigned on append.
+	events := make([]map[string]any, 0, len(items))
+	for _, it := range items {
+		events = append(events, map[string]any{"type": "item.create", "entityId": it.ID, "actorId": actorID, "payload": it})
+	}
+
+	meta := map[string]any{"source": source, "count": len(items), "dryRun": dryRun}
+	if strings.TrimSpace(doc.Preamble) != "" {
+		meta["skipped"] = "text before the first heading or list item has no item to attach to"
+	}
+
+	if dryRun {
+		return writeOut(cmd, app, map[string]any{
+			"data": events,
+			"meta": meta,
+			"_hints": []string{
+				"re-run without --dry-run to append these events",
+			},
+		})
+	}
+
+	for _, it := range items {
+		if err := s.AppendEvent(actorID, "item.create", it.ID, it); err != nil {
+			return writeErr(cmd, err)
+		}
+	}
+	if err := s.Save(db); err != nil {
+		return writeErr(cmd, err)
+	}
+	ids := make([]string, 0, len(items))
+	for _, it := range items {
+		ids = append(ids, it.ID)
+	}
+	return writeOut(cmd, app, map[string]any{
+		"data": ids,
+		"meta": meta,
+		"_hints": []string{
+			"clarity items list --outline " + outline.ID,
+		},
+	})
+}
+
+// importKeywordStatuses maps title keywords to status ids: every status id/label of the outline in
+// capitals (spaces become "_", as in "clarity publish org"), plus TODO/DONE as the first open/closed
+// status when the outline doesn't define them itself.
+func importKeywordStatuses(outline model.Outline) map[string]string {
+	out := map[string]string{}
+	for _, def := range outline.StatusDefs {
+		id := strings.TrimSpace(def.ID)
+		if id == "" {
+			continue
+		}
+		for _, k := range []string{id, strings.TrimSpace(def.Label)} {
+			k = strings.ToUpper(strings.Join(strings.Fields(k), "_"))
+			if k == "" {
+				continue
+			}
+			if _, ok := out[k]; !ok {
+				out[k] = id
+			}
+		}
+	}
+	if _, ok := out["TODO"]; !ok {
+		if id := statusutil.CheckboxUncheckedStatusID(outline); id != "" {
+			out["TODO"] = id
+		}
+	}
+	if _, ok := out["DONE"]; !ok {
+		if id := statusutil.CheckboxCheckedStatusID(outline); id != "" {
+			out["DONE"] = id
+		}
+	}
+	return out
+}
+
+// planImportItems builds the items for the parsed tree (depth-first, parents before children) and
+// adds them to db so ids and sibling ranks stay unique. Nothing is written.
+func planImportItems(db *store.DB, s store.Store, outline model.Outline, parent *model.Item, actorID string, statuses map[string]string, nodes []*importer.Node, now time.Time) []model.Item {
+	var assign *string
+	if act, ok := db.FindActor(actorID); ok && act.Kind == model.ActorKindAgent {
+		tmp := actorID
+		assign = &tmp
+	}
+
+	var out []model.Item
+	var walk func(parentID *string, checkboxParent bool, ns []*importer.Node)
+	walk = func(parentID *string, checkboxParent bool, ns []*importer.Node) {
+		for _, n := range ns {
+			statusID := store.FirstStatusID(outline.StatusDefs)
+			switch {
+			case n.Keyword != "" && statuses[n.Keyword] != "":
+				statusID = statuses[n.Keyword]
+			case n.Checkbox && n.Checked:
+				statusID = statusutil.CheckboxCheckedStatusID(outline)
+			case n.Checkbox || checkboxParent:
+				statusID = statusutil.CheckboxUncheckedStatusID(outline)
+			}
+			childrenKind := ""
+			if allCheckboxes(n.Children) {
+				childrenKind = "checkbox"
+			}
+			it := model.Item{
+				ID:              s.NextID(db, "item"),
+				ProjectID:       outline.ProjectID,
+				OutlineID:       outline.ID,
+				ParentID:        parentID,
+				Rank:            nextSiblingRank(db, outline.ID, parentID),
+				Title:           n.Title,
+				Description:     n.Description,
+				StatusID:        statusID,
+				Priority:        n.Priority,
+				Due:             n.Due,
+				Schedule:        n.Schedule,
+				Tags:            n.Tags,
+				ChildrenKind:    childrenKind,
+				OwnerActorID:    actorID,
+				AssignedActorID: assign,
+				CreatedBy:       actorID,
+				CreatedAt:       now,
+				UpdatedAt:       now,
+			}
+			db.Items = append(db.Items, it)
+			out = append(out, it)
+			id := it.ID
+			walk(&id, childrenKind == "checkbox", n.Children)
+		}
+	}
+
+	var rootParent *string
+	checkboxRoot := false
+	if parent != nil {
+		id := parent.ID
+		rootParent = &id
+		checkboxRoot = strings.TrimSpace(parent.ChildrenKind) == "checkbox"
+	}
+	walk(rootParent, checkboxRoot, nodes)
+	return out
+}
+
+func allCheckboxes(ns []*importer.Node) bool {
+	if len(ns) == 0 {
+		return false
+	}
+	for _, n := range ns {
+		if !n.Checkbox {
+			return false
+		}
+	}
+	return true
+}
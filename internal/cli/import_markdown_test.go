@@ -0,0 +1,96 @@
+package cli
+
+import (
+        "encoding/json"
+        "os"
+        "path/filepath"
+        "testing"
+        "time"
+
+        "clarity-cli/internal/model"
+        "clarity-cli/internal/store"
+)
+
+func TestImportMarkdown_DryRunThenImport(t *testing.T) {
+        t.Parallel()
+
+        dir := t.TempDir()
+        now := time.Date(2025, 12, 20, 0, 0, 0, 0, time.UTC)
+        actorID := "act-testhuman"
+        projectID := "proj-test"
+        outlineID := "out-test"
+
+        db := &store.DB{
+                Version:        1,
+                CurrentActorID: actorID,
+                NextIDs:        map[string]int{},
+                Actors: []model.Actor{
+                        {ID: actorID, Kind: model.ActorKindHuman, Name: "Test Human"},
+                },
+                Projects: []model.Project{
+                        {ID: projectID, Name: "Test Project", CreatedBy: actorID, CreatedAt: now},
+                },
+                Outlines: []model.Outline{
+                        {ID: outlineID, ProjectID: projectID, Name: nil, StatusDefs: store.DefaultOutlineStatusDefs(), CreatedBy: actorID, CreatedAt: now},
+                },
+        }
+        if err := (store.Store{Dir: dir}).Save(db); err != nil {
+                t.Fatalf("seed store: %v", err)
+        }
+
+        src := filepath.Join(t.TempDir(), "plan.md")
+        if err := os.WriteFile(src, []byte("# DOING Launch #release\nShip it.\n\n- [ ] Write notes\n- [x] Book room\n"), 0o644); err != nil {
+                t.Fatalf("write markdown: %v", err)
+        }
+
+        out, errOut, err := runCLI(t, []string{"--dir", dir, "--actor", actorID, "import", "markdown", src, "--outline", outlineID, "--dry-run"})
+        if err != nil {
+                t.Fatalf("import --dry-run error: %v\nstderr:\n%s", err, string(errOut))
+        }
+        var planned struct {
+                Data []struct {
+                        Type    string     `json:"type"`
+                        Payload model.Item `json:"payload"`
+                } `json:"data"`
+        }
+        if err := json.Unmarshal(out, &planned); err != nil {
+                t.Fatalf("decode dry-run output: %v\n%s", err, string(out))
+        }
+        if len(planned.Data) != 3 || planned.Data[0].Type != "item.create" {
+                t.Fatalf("expected 3 planned item.create events; got %s", string(out))
+        }
+        if got, err := (store.Store{Dir: dir}).Load(); err != nil || len(got.Items) != 0 {
+                t.Fatalf("dry-run must not write items (err=%v)", err)
+        }
+
+        if _, errOut, err := runCLI(t, []string{"--dir", dir, "--actor", actorID, "import", "markdown", src, "--outline", outlineID}); err != nil {
+                t.Fatalf("import error: %v\nstderr:\n%s", err, string(errOut))
+        }
+        got, err := (store.Store{Dir: dir}).Load()
+        if err != nil {
+                t.Fatalf("load: %v", err)
+        }
+        if len(got.Items) != 3 {
+                t.Fatalf("expected 3 items; got %d", len(got.Items))
+        }
+        root := got.Items[0]
+        if root.Title != "Launch" || root.StatusID != "doing" || len(root.Tags) != 1 || root.Tags[0] != "release" || root.Description != "Ship it." || root.ChildrenKind != "checkbox" {
+                t.Fatalf("unexpected root item: %#v", root)
+        }
+        for _, it := range got.Items[1:] {
+                if it.ParentID == nil || *it.ParentID != root.ID {
+                        t.Fatalf("expected %q under root; got %#v", it.Title, it.ParentID)
+                }
+        }
+        if got.Items[1].StatusID != "todo" || got.Items[2].StatusID != "done" {
+                t.Fatalf("expected checkbox statuses todo/done; got %q/%q", got.Items[1].StatusID, got.Items[2].StatusID)
+        }
+
+        evs, err := store.ReadEventsForEntity(dir, root.ID, 0)
+        if err != nil {
+                t.Fatalf("read events: %v", err)
+        }
+        if len(evs) != 1 || evs[0].Type != "item.create" || evs[0].ActorID != actorID {
+                t.Fatalf("expected one attributed item.create event; got %#v", evs)
+        }
+}
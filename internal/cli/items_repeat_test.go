@@ -0,0 +1,78 @@
+package cli
+
+import (
+        "testing"
+        "time"
+
+        "clarity-cli/internal/model"
+        "clarity-cli/internal/store"
+)
+
+func TestItemsSetStatus_RepeatingScheduleRollsForward(t *testing.T) {
+        t.Parallel()
+
+        dir := t.TempDir()
+        now := time.Date(2025, 12, 20, 0, 0, 0, 0, time.UTC)
+        actorID := "act-testhuman"
+        projectID := "proj-test"
+        outlineID := "out-test"
+        itemID := "item-test"
+
+        db := &store.DB{
+                Version:        1,
+                CurrentActorID: actorID,
+                NextIDs:        map[string]int{},
+                Actors: []model.Actor{
+                        {ID: actorID, Kind: model.ActorKindHuman, Name: "Test Human"},
+                },
+                Projects: []model.Project{
+                        {ID: projectID, Name: "Test Project", CreatedBy: actorID, CreatedAt: now},
+                },
+                Outlines: []model.Outline{
+                        {ID: outlineID, ProjectID: projectID, Name: nil, StatusDefs: store.DefaultOutlineStatusDefs(), CreatedBy: actorID, CreatedAt: now},
+                },
+                Items: []model.Item{
+                        {ID: itemID, ProjectID: projectID, OutlineID: outlineID, Rank: "h", Title: "Weekly chore", StatusID: "todo", OwnerActorID: actorID, CreatedBy: actorID, CreatedAt: now, UpdatedAt: now},
+                },
+        }
+        if err := (store.Store{Dir: dir}).Save(db); err != nil {
+                t.Fatalf("seed store: %v", err)
+        }
+
+        if _, errOut, err := runCLI(t, []string{"--dir", dir, "--actor", actorID, "items", "set-schedule", itemID, "--at", "2026-01-05 09:00 +1w"}); err != nil {
+                t.Fatalf("items set-schedule error: %v\nstderr:\n%s", err, string(errOut))
+        }
+        // Changing only the repeater keeps the date.
+        if _, errOut, err := runCLI(t, []string{"--dir", dir, "--actor", actorID, "items", "set-schedule", itemID, "--repeat", "+2w"}); err != nil {
+                t.Fatalf("items set-schedule --repeat error: %v\nstderr:\n%s", err, string(errOut))
+        }
+        if _, _, err := runCLI(t, []string{"--dir", dir, "--actor", actorID, "items", "set-schedule", itemID, "--repeat", "2w"}); err == nil {
+                t.Fatalf("expected invalid repeater error")
+        }
+        if _, errOut, err := runCLI(t, []string{"--dir", dir, "--actor", actorID, "items", "set-status", itemID, "--status", "done"}); err != nil {
+                t.Fatalf("items set-status error: %v\nstderr:\n%s", err, string(errOut))
+        }
+
+        got, err := (store.Store{Dir: dir}).Load()
+        if err != nil {
+                t.Fatalf("load: %v", err)
+        }
+        it, ok := got.FindItem(itemID)
+        if !ok {
+                t.Fatalf("item missing")
+        }
+        if it.StatusID != "todo" {
+                t.Fatalf("expected item reopened as todo; got %q", it.StatusID)
+        }
+        if it.Schedule == nil || it.Schedule.Date != "2026-01-19" || it.Schedule.Time == nil || *it.Schedule.Time != "09:00" || it.Schedule.Repeat != "+2w" {
+                t.Fatalf("expected schedule rolled to 2026-01-19 09:00 +2w; got %#v", it.Schedule)
+        }
+
+        evs, err := store.ReadEventsForEntity(dir, itemID, 0)
+        if err != nil {
+                t.Fatalf("read events: %v", err)
+        }
+        if len(evs) < 2 || evs[len(evs)-2].Type != "item.set_status" || evs[len(evs)-1].Type != "item.repeat" {
+                t.Fatalf("expected item.set_status followed by item.repeat; got %#v", evs)
+        }
+}
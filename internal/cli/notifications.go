@@ -0,0 +1,123 @@
+package cli
+
+import (
+        "strings"
+
+        "clarity-cli/internal/notifications"
+        "clarity-cli/internal/store"
+
+        "github.com/spf13/cobra"
+)
+
+func newNotificationsCmd(app *App) *cobra.Command {
+        cmd := &cobra.Command{
+                Use:   "notifications",
+                Short: "Notifications for followed outlines and items (derived from the event log)",
+        }
+        cmd.AddCommand(newNotificationsListCmd(app))
+        cmd.AddCommand(newNotificationsMarkReadCmd(app))
+        return cmd
+}
+
+func newNotificationsListCmd(app *App) *cobra.Command {
+        var unread bool
+        var limit int
+
+        cmd := &cobra.Command{
+                Use:   "list",
+                Short: "List notifications (newest first)",
+                Long: strings.TrimSpace(`
+List item creations, status changes and comments on the outlines and items you follow
+(see ` + "`clarity follows`" + `). Your own events are not included.
+
+Notifications are derived from the recent event log; nothing extra is stored except a
+local "last seen" cursor per actor, which ` + "`clarity notifications mark-read`" + ` advances.
+`),
+                Args: cobra.NoArgs,
+                RunE: func(cmd *cobra.Command, args []string) error {
+                        db, s, err := loadDB(app)
+                        if err != nil {
+                                return writeErr(cmd, err)
+                        }
+                        actorID, err := currentActorID(app, db)
+                        if err != nil {
+                                return writeErr(cmd, err)
+                        }
+
+                        events, err := store.ReadEventsTail(s.Dir, notifications.DefaultWindow)
+                        if err != nil {
+                                return writeErr(cmd, err)
+                        }
+                        st, err := s.LoadNotificationsState()
+                        if err != nil {
+                                return writeErr(cmd, err)
+                        }
+                        var cursor *store.NotificationCursor
+                        if c, ok := st.LastSeen[actorID]; ok {
+                                cursor = &c
+                        }
+
+                        res := notifications.Feed(db, events, notifications.Options{
+                                ActorID:    actorID,
+                                Cursor:     cursor,
+                                UnreadOnly: unread,
+                                Limit:      limit,
+                        })
+                        meta := map[string]any{
+                                "total":    res.Total,
+                                "unread":   res.Unread,
+                                "returned": len(res.Entries),
+                                "limit":    limit,
+                        }
+                        if cursor != nil {
+                                meta["lastSeen"] = cursor
+                        }
+                        return writeOut(cmd, app, map[string]any{
+                                "data": res.Entries,
+                                "meta": meta,
+                                "_hints": []string{
+                                        "clarity <item-id>",
+                                        "clarity notifications mark-read",
+                                },
+                        })
+                },
+        }
+
+        cmd.Flags().BoolVar(&unread, "unread", false, "Only show unread notifications")
+        cmd.Flags().IntVar(&limit, "limit", 50, "Max results (0 = all)")
+        return cmd
+}
+
+func newNotificationsMarkReadCmd(app *App) *cobra.Command {
+        cmd := &cobra.Command{
+                Use:   "mark-read",
+                Short: "Mark all notifications as read (advances your last-seen cursor)",
+                Args:  cobra.NoArgs,
+                RunE: func(cmd *cobra.Command, args []string) error {
+                        db, s, err := loadDB(app)
+                        if err != nil {
+                                return writeErr(cmd, err)
+                        }
+                        actorID, err := currentActorID(app, db)
+                        if err != nil {
+                                return writeErr(cmd, err)
+                        }
+
+                        events, err := store.ReadEventsTail(s.Dir, 1)
+                        if err != nil {
+                                return writeErr(cmd, err)
+                        }
+                        st, err := s.LoadNotificationsState()
+                        if err != nil {
+                                return writeErr(cmd, err)
+                        }
+                        cursor := notifications.Latest(events)
+                        st.LastSeen[actorID] = cursor
+                        if err := s.SaveNotificationsState(st); err != nil {
+                                return writeErr(cmd, err)
+                        }
+                        return writeOut(cmd, app, map[string]any{"data": map[string]any{"actorId": actorID, "lastSeen": cursor}})
+                },
+        }
+        return cmd
+}
@@ -50,6 +50,9 @@ func newOutlinesCreateCmd(app *App) *cobra.Command {
                         if _, ok := db.FindProject(pid); !ok {
                                 return writeErr(cmd, errNotFound("project", pid))
                         }
+                        if err := checkCanMaintain(db, actorID, pid, ""); err != nil {
+                                return writeErr(cmd, err)
+                        }
                         var namePtr *string
                         n := strings.TrimSpace(name)
                         if n != "" {
@@ -154,6 +157,9 @@ func newOutlinesArchiveCmd(app *App) *cobra.Command {
                         if !ok {
                                 return writeErr(cmd, errNotFound("outline", oid))
                         }
+                        if err := checkCanMaintain(db, actorID, o.ProjectID, o.ID); err != nil {
+                                return writeErr(cmd, err)
+                        }
                         o.Archived = !unarchive
                         if err := s.AppendEvent(actorID, "outline.archive", o.ID, map[string]any{"archived": o.Archived}); err != nil {
                                 return writeErr(cmd, err)
@@ -68,6 +68,9 @@ func newOutlinesStatusAddCmd(app *App) *cobra.Command {
                         if !ok {
                                 return writeErr(cmd, errNotFound("outline", oid))
                         }
+                        if err := checkCanMaintain(db, actorID, o.ProjectID, o.ID); err != nil {
+                                return writeErr(cmd, err)
+                        }
                         label = strings.TrimSpace(label)
                         if label == "" {
                                 return writeErr(cmd, errors.New("missing --label"))
@@ -124,6 +127,9 @@ func newOutlinesStatusUpdateCmd(app *App) *cobra.Command {
                         if !ok {
                                 return writeErr(cmd, errNotFound("outline", oid))
                         }
+                        if err := checkCanMaintain(db, actorID, o.ProjectID, o.ID); err != nil {
+                                return writeErr(cmd, err)
+                        }
 
                         label = strings.TrimSpace(label)
                         if end && notEnd {
@@ -207,6 +213,9 @@ func newOutlinesStatusRemoveCmd(app *App) *cobra.Command {
                         if !ok {
                                 return writeErr(cmd, errNotFound("outline", oid))
                         }
+                        if err := checkCanMaintain(db, actorID, o.ProjectID, o.ID); err != nil {
+                                return writeErr(cmd, err)
+                        }
 
                         // Resolve key to id
                         sid := ""
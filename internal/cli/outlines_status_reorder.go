@@ -29,6 +29,9 @@ func newOutlinesStatusReorderCmd(app *App) *cobra.Command {
                         if !ok {
                                 return writeErr(cmd, errNotFound("outline", oid))
                         }
+                        if err := checkCanMaintain(db, actorID, o.ProjectID, o.ID); err != nil {
+                                return writeErr(cmd, err)
+                        }
                         if len(labels) == 0 {
                                 return writeErr(cmd, errors.New("missing --label (repeatable)"))
                         }
@@ -10,3 +10,21 @@ import (
 func canEditTask(db *store.DB, actorID string, t *model.Item) bool {
         return perm.CanEditItem(db, actorID, t)
 }
+
+// checkCanContribute errors unless actorID may add items, comments, worklog and attachments to the
+// outline (the contributor role, where the outline or its project has roles).
+func checkCanContribute(db *store.DB, actorID, projectID, outlineID string) error {
+        if perm.CanContribute(db, actorID, projectID, outlineID) {
+                return nil
+        }
+        return errorsRoleRequired(actorID, store.RoleContributor, projectID, outlineID)
+}
+
+// checkCanMaintain errors unless actorID may change the outline itself, or the project when
+// outlineID is "" (the maintainer role, where roles apply).
+func checkCanMaintain(db *store.DB, actorID, projectID, outlineID string) error {
+        if perm.CanMaintain(db, actorID, projectID, outlineID) {
+                return nil
+        }
+        return errorsRoleRequired(actorID, store.RoleMaintainer, projectID, outlineID)
+}
@@ -107,6 +107,9 @@ func newProjectsArchiveCmd(app *App) *cobra.Command {
                         if !ok {
                                 return writeErr(cmd, errNotFound("project", pid))
                         }
+                        if err := checkCanMaintain(db, actorID, p.ID, ""); err != nil {
+                                return writeErr(cmd, err)
+                        }
                         p.Archived = !unarchive
                         if db.CurrentProjectID == pid && p.Archived {
                                 db.CurrentProjectID = ""
@@ -93,6 +93,42 @@ func newPublishCmd(app *App) *cobra.Command {
                 },
         }
 
+        orgCmd := &cobra.Command{
+                Use:   "org <outline-id>",
+                Short: "Publish an outline as a single Org file (importable with `clarity import org`)",
+                Args:  cobra.ExactArgs(1),
+                RunE: func(cmd *cobra.Command, args []string) error {
+                        db, _, err := loadDB(app)
+                        if err != nil {
+                                return writeErr(cmd, err)
+                        }
+                        actorID, err := currentActorID(app, db)
+                        if err != nil {
+                                return writeErr(cmd, err)
+                        }
+                        toDir = strings.TrimSpace(toDir)
+                        if toDir == "" {
+                                return writeErr(cmd, errors.New("missing --to"))
+                        }
+                        res, err := publish.WriteOutlineOrg(db, args[0], toDir, publish.WriteOptions{
+                                IncludeArchived: includeArchived,
+                                Overwrite:       overwrite,
+                                ActorID:         actorID,
+                        })
+                        if err != nil {
+                                return writeErr(cmd, err)
+                        }
+                        return writeOut(cmd, app, map[string]any{
+                                "data": res,
+                                "_hints": []string{
+                                        "git status",
+                                        "git add -A",
+                                        "git commit -m \"Publish: outline " + args[0] + " (org)\"",
+                                },
+                        })
+                },
+        }
+
         cmd.PersistentFlags().StringVar(&toDir, "to", "", "Output directory")
         _ = cmd.MarkPersistentFlagRequired("to")
         cmd.PersistentFlags().BoolVar(&includeArchived, "include-archived", false, "Include archived items")
@@ -101,5 +137,6 @@ func newPublishCmd(app *App) *cobra.Command {
 
         cmd.AddCommand(itemCmd)
         cmd.AddCommand(outlineCmd)
+        cmd.AddCommand(orgCmd)
         return cmd
 }
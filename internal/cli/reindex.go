@@ -1,77 +1,43 @@
 package cli
 
 import (
-        "context"
-        "strings"
-
         "clarity-cli/internal/store"
 
         "github.com/spf13/cobra"
 )
 
 func newReindexCmd(app *App) *cobra.Command {
+        var full bool
+
         cmd := &cobra.Command{
                 Use:   "reindex",
                 Short: "Rebuild derived local SQLite state from JSONL events",
+                Long:  "Rebuild derived local SQLite state from JSONL events, starting from the latest snapshot\n(clarity workspace snapshot) when there is a usable one. Use --full to replay every event.",
                 RunE: func(cmd *cobra.Command, args []string) error {
                         dir, err := resolveDir(app)
                         if err != nil {
                                 return writeErr(cmd, err)
                         }
 
+                        // Reindex carries local UI/session meta (current actor/project) forward: it is not
+                        // part of the canonical event stream.
                         s := store.Store{Dir: dir}
-
-                        // Preserve local UI/session meta across reindex.
-                        // This meta is not part of the canonical event stream, so it must be carried forward
-                        // from the existing derived state.
-                        prevActorID := ""
-                        prevProjectID := ""
-                        if existing, err := s.LoadSQLite(context.Background()); err == nil && existing != nil {
-                                prevActorID = strings.TrimSpace(existing.CurrentActorID)
-                                prevProjectID = strings.TrimSpace(existing.CurrentProjectID)
+                        reindex := s.Reindex
+                        if full {
+                                reindex = s.ReindexFull
                         }
-
-                        res, err := store.ReplayEventsV1(dir)
+                        res, err := reindex()
                         if err != nil {
                                 return writeErr(cmd, err)
                         }
 
-                        if strings.TrimSpace(prevActorID) != "" {
-                                if _, ok := res.DB.FindActor(prevActorID); ok {
-                                        res.DB.CurrentActorID = prevActorID
-                                }
-                        }
-                        if strings.TrimSpace(res.DB.CurrentActorID) == "" {
-                                // First-time bootstrap convenience: if there's exactly one human actor (common for
-                                // personal workspaces and migrations), pick it.
-                                pick := ""
-                                for _, a := range res.DB.Actors {
-                                        if a.Kind == "human" && strings.TrimSpace(a.ID) != "" {
-                                                pick = strings.TrimSpace(a.ID)
-                                                break
-                                        }
-                                }
-                                if pick == "" && len(res.DB.Actors) > 0 {
-                                        pick = strings.TrimSpace(res.DB.Actors[0].ID)
-                                }
-                                res.DB.CurrentActorID = pick
-                        }
-
-                        if strings.TrimSpace(prevProjectID) != "" {
-                                if _, ok := res.DB.FindProject(prevProjectID); ok {
-                                        res.DB.CurrentProjectID = prevProjectID
-                                }
-                        }
-                        if err := s.Save(res.DB); err != nil {
-                                return writeErr(cmd, err)
-                        }
-
                         return writeOut(cmd, app, map[string]any{
                                 "data": map[string]any{
                                         "dir":          dir,
                                         "applied":      res.AppliedCount,
                                         "skipped":      res.SkippedCount,
                                         "skippedTypes": res.SkippedTypes,
+                                        "snapshot":     res.Snapshot,
                                 },
                                 "meta": map[string]any{
                                         "actors":   len(res.DB.Actors),
@@ -90,5 +56,6 @@ func newReindexCmd(app *App) *cobra.Command {
                 },
         }
 
+        cmd.Flags().BoolVar(&full, "full", false, "Replay every event, ignoring snapshots")
         return cmd
 }
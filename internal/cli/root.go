@@ -20,6 +20,7 @@ type App struct {
 	ActorID    string
 	PrettyJSON bool
 	Format     string
+	NoHooks    bool
 
 	// Track explicit flag usage so we can distinguish env defaults (CLARITY_DIR, CLARITY_WORKSPACE)
 	// from user intent on the command line.
@@ -27,11 +28,16 @@ type App struct {
 	workspaceFlagSet bool
 
 	appendCountStart uint64
+
+	// warm keeps the loaded workspace across commands run by `clarity serve --api` (nil otherwise).
+	warm *warmDB
 }
 
 func NewRootCmd() *cobra.Command {
-	app := &App{}
+	return newRootCmd(&App{})
+}
 
+func newRootCmd(app *App) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:          "clarity",
 		Short:        "Clarity (local-first) CLI + TUI",
@@ -82,7 +88,8 @@ func NewRootCmd() *cobra.Command {
 			return nil
 		}
 		// Avoid attempting sync after long-running commands.
-		if strings.HasPrefix(strings.TrimSpace(cmd.CommandPath()), "clarity webtui") {
+		if strings.HasPrefix(strings.TrimSpace(cmd.CommandPath()), "clarity webtui") ||
+			strings.HasPrefix(strings.TrimSpace(cmd.CommandPath()), "clarity serve") {
 			return nil
 		}
 		return autoSyncWorkspaceBestEffort(cmd, app)
@@ -93,32 +100,46 @@ func NewRootCmd() *cobra.Command {
 	cmd.PersistentFlags().StringVar(&app.ActorID, "actor", envOr("CLARITY_ACTOR", ""), "Actor id (overrides currentActorId in db.json)")
 	cmd.PersistentFlags().BoolVar(&app.PrettyJSON, "pretty", false, "Pretty-print JSON output")
 	cmd.PersistentFlags().StringVar(&app.Format, "format", envOr("CLARITY_FORMAT", "json"), "Output format (json|edn)")
+	cmd.PersistentFlags().BoolVar(&app.NoHooks, "no-hooks", false, "Don't run meta/hooks.json hooks for events this command appends (or set CLARITY_NO_HOOKS=1)")
 
 	cmd.AddCommand(newInitCmd(app))
 	cmd.AddCommand(newDocsCmd(app))
 	cmd.AddCommand(newDoctorCmd(app))
 	cmd.AddCommand(newReindexCmd(app))
 	cmd.AddCommand(newStatusCmd(app))
+	cmd.AddCommand(newStateCmd(app))
+	cmd.AddCommand(newUndoCmd(app))
+	cmd.AddCommand(newRedoCmd(app))
 	cmd.AddCommand(newWorkspaceCmd(app))
 	cmd.AddCommand(newIdentityCmd(app))
 	cmd.AddCommand(newProjectsCmd(app))
 	cmd.AddCommand(newOutlinesCmd(app))
 	cmd.AddCommand(newItemsCmd(app))
+	cmd.AddCommand(newAgendaCmd(app))
+	cmd.AddCommand(newSearchCmd(app))
+	cmd.AddCommand(newViewsCmd(app))
 	cmd.AddCommand(newDepsCmd(app))
 	cmd.AddCommand(newCommentsCmd(app))
+	cmd.AddCommand(newFollowsCmd(app))
+	cmd.AddCommand(newNotificationsCmd(app))
+	cmd.AddCommand(newConflictsCmd(app))
 	cmd.AddCommand(newEventsCmd(app))
+	cmd.AddCommand(newHooksCmd(app))
 	cmd.AddCommand(newPublishCmd(app))
+	cmd.AddCommand(newImportCmd(app))
 	cmd.AddCommand(newSyncCmd(app))
 	cmd.AddCommand(newWorklogCmd(app))
 	cmd.AddCommand(newAgentCmd(app))
 	cmd.AddCommand(newCaptureCmd(app))
 	cmd.AddCommand(newAttachmentsCmd(app))
 	cmd.AddCommand(newWebTUICmd(app))
+	cmd.AddCommand(newServeCmd(app))
 
 	return cmd
 }
 
 func runTUI(app *App) error {
+	noHooksForProcess(app)
 	st, _, err := loadDB(app)
 	if err != nil {
 		return err
@@ -164,7 +185,11 @@ func loadDB(app *App) (*store.DB, store.Store, error) {
 		app.Dir = dir
 	}
 
-	s := store.Store{Dir: dir}
+	s := store.Store{Dir: dir, NoHooks: app.NoHooks}
+	if app.warm != nil {
+		db, err := app.warm.load(s)
+		return db, s, err
+	}
 	db, err := s.Load()
 	if err != nil {
 		return nil, s, err
@@ -172,6 +197,13 @@ func loadDB(app *App) (*store.DB, store.Store, error) {
 	return db, s, nil
 }
 
+// noHooksForProcess makes --no-hooks reach the stores the TUI and webtui open themselves.
+func noHooksForProcess(app *App) {
+	if app.NoHooks {
+		_ = os.Setenv("CLARITY_NO_HOOKS", "1")
+	}
+}
+
 func currentActorID(app *App, db *store.DB) (string, error) {
 	if app.ActorID != "" {
 		return app.ActorID, nil
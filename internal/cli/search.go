@@ -0,0 +1,120 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"clarity-cli/internal/search"
+	"clarity-cli/internal/store"
+
+	"github.com/spf13/cobra"
+)
+
+type searchMatchOut struct {
+	search.Match
+	// Highlighted is Snippet with each highlight wrapped in "**" (Markdown bold).
+	Highlighted string `json:"highlighted"`
+}
+
+type searchHitOut struct {
+	search.Hit
+	Matches []searchMatchOut `json:"matches"`
+}
+
+func newSearchCmd(app *App) *cobra.Command {
+	var limit int
+
+	cmd := &cobra.Command{
+		Use:   "search <query>",
+		Short: "Full-text search across items, descriptions, comments and worklog",
+		Long: strings.TrimSpace(`
+Search all projects for items matching every term.
+
+Searched text: titles, descriptions, tags, comment bodies, attachment titles,
+and worklog entries visible to you (your own human user and your agents).
+A term matches at the start of a word, case-insensitively; quote phrases.
+
+Qualifiers (combine freely with terms):
+  status:<id|label>     tag:<tag>            assignee:me|none|<actor-id|name>
+  project:<id|name>     outline:<id|name>    is:open|done|archived|priority|on-hold|blocked
+  due<DATE  due<=DATE  due>DATE  due>=DATE  due:DATE  due:none   (same for schedule)
+DATE is YYYY-MM-DD or today.
+
+Archived items are excluded unless is:archived is given.
+`),
+		Example: strings.TrimSpace(`
+clarity search deploy
+clarity search '"release notes" status:doing'
+clarity search 'tag:infra assignee:me due<2026-11-01'
+`),
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			db, s, err := loadDB(app)
+			if err != nil {
+				return writeErr(cmd, err)
+			}
+			actorID, err := currentActorID(app, db)
+			if err != nil {
+				return writeErr(cmd, err)
+			}
+
+			q, err := search.Parse(strings.Join(args, " "))
+			if err != nil {
+				return writeErr(cmd, err)
+			}
+			if q.Empty() {
+				return writeErr(cmd, errors.New("empty query"))
+			}
+
+			index := "scan"
+			opts := search.Options{ActorID: actorID, Today: time.Now(), Limit: limit}
+			if candidates := searchCandidates(s, q.Terms); candidates != nil {
+				opts.Candidates = candidates
+				index = "fts5"
+			}
+			res := search.Run(db, q, opts)
+
+			hits := make([]searchHitOut, 0, len(res.Hits))
+			for _, h := range res.Hits {
+				out := searchHitOut{Hit: h, Matches: make([]searchMatchOut, 0, len(h.Matches))}
+				for _, m := range h.Matches {
+					out.Matches = append(out.Matches, searchMatchOut{Match: m, Highlighted: search.Mark(m.Snippet, m.Highlights, "**", "**")})
+				}
+				hits = append(hits, out)
+			}
+
+			return writeOut(cmd, app, map[string]any{
+				"data": hits,
+				"meta": map[string]any{
+					"query":    res.Query,
+					"total":    res.Total,
+					"returned": len(hits),
+					"limit":    limit,
+					"index":    index,
+				},
+				"_hints": []string{
+					"clarity <item-id>",
+					"clarity comments list <item-id>",
+				},
+			})
+		},
+	}
+
+	cmd.Flags().IntVar(&limit, "limit", 50, "Max results (0 = all)")
+	return cmd
+}
+
+// searchCandidates narrows the scan using the SQLite FTS5 index. It returns nil (full scan)
+// when there are no terms, the index isn't built yet, or the index can't be queried.
+func searchCandidates(s store.Store, terms []string) map[string]bool {
+	if len(terms) == 0 {
+		return nil
+	}
+	ids, err := s.SearchCandidates(context.Background(), terms)
+	if err != nil {
+		return nil
+	}
+	return ids
+}
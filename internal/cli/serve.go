@@ -0,0 +1,96 @@
+package cli
+
+import (
+        "crypto/rand"
+        "encoding/hex"
+        "errors"
+        "fmt"
+        "net/http"
+        "strings"
+        "time"
+
+        "github.com/spf13/cobra"
+)
+
+func newServeCmd(app *App) *cobra.Command {
+        var api bool
+        var addr string
+        var token string
+
+        cmd := &cobra.Command{
+                Use:   "serve",
+                Short: "Serve the workspace over a local HTTP JSON API (for agents and integrations)",
+                Long: strings.TrimSpace(`
+Serve REST endpoints for projects, outlines, items, deps, comments, worklog and events, plus a
+Server-Sent Events stream of appended events. Responses use the same {data, meta, _hints} envelope
+and permission checks as the CLI; the workspace stays loaded between requests.
+
+Every request needs "Authorization: Bearer <token>". The token comes from --token or
+CLARITY_API_TOKEN; without either a random one is generated and printed on startup.
+Requests act as the actor in the X-Clarity-Actor header (default: --actor / current actor).
+
+See: clarity docs api
+`),
+                Example: strings.TrimSpace(`
+clarity serve --api
+clarity serve --api --addr 127.0.0.1:4000 --token "$CLARITY_API_TOKEN"
+curl -H "Authorization: Bearer $TOKEN" http://127.0.0.1:3335/v1/items/ready
+`),
+                Args: cobra.NoArgs,
+                RunE: func(cmd *cobra.Command, args []string) error {
+                        if !api {
+                                return writeErr(cmd, errors.New("nothing to serve: pass --api"))
+                        }
+                        db, s, err := loadDB(app)
+                        if err != nil {
+                                return writeErr(cmd, err)
+                        }
+                        actorID := strings.TrimSpace(app.ActorID)
+                        if actorID == "" {
+                                actorID = strings.TrimSpace(db.CurrentActorID)
+                        }
+
+                        generated := false
+                        token = strings.TrimSpace(token)
+                        if token == "" {
+                                b := make([]byte, 24)
+                                if _, err := rand.Read(b); err != nil {
+                                        return writeErr(cmd, err)
+                                }
+                                token = hex.EncodeToString(b)
+                                generated = true
+                        }
+                        addr = strings.TrimSpace(addr)
+                        if addr == "" {
+                                return writeErr(cmd, errors.New("serve: missing --addr"))
+                        }
+
+                        srv := newAPIServer(s.Dir, token, actorID)
+                        data := map[string]any{
+                                "addr":      addr,
+                                "workspace": strings.TrimSpace(app.Workspace),
+                                "dir":       s.Dir,
+                                "actor":     actorID,
+                                "startedAt": time.Now().UTC().Format(time.RFC3339Nano),
+                        }
+                        if generated {
+                                data["token"] = token
+                        }
+                        _ = writeOut(cmd, app, map[string]any{
+                                "data": data,
+                                "_hints": []string{
+                                        "curl -H 'Authorization: Bearer <token>' http://" + addr + "/v1/items/ready",
+                                        "curl -N -H 'Authorization: Bearer <token>' http://" + addr + "/v1/events/stream",
+                                },
+                        })
+
+                        fmt.Fprintf(cmd.ErrOrStderr(), "Clarity API listening on http://%s (workspace=%s)\n", addr, strings.TrimSpace(app.Workspace))
+                        return http.ListenAndServe(addr, srv.Handler())
+                },
+        }
+
+        cmd.Flags().BoolVar(&api, "api", false, "Serve the HTTP JSON API")
+        cmd.Flags().StringVar(&addr, "addr", "127.0.0.1:3335", "Bind address (host:port; loopback by default)")
+        cmd.Flags().StringVar(&token, "token", envOr("CLARITY_API_TOKEN", ""), "Bearer token clients must send (default: $CLARITY_API_TOKEN, else generated)")
+        return cmd
+}
@@ -0,0 +1,140 @@
+package cli
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"clarity-cli/internal/store"
+
+	"github.com/spf13/cobra"
+)
+
+func newStateCmd(app *App) *cobra.Command {
+	var asOf string
+	var entityID string
+
+	cmd := &cobra.Command{
+		Use:   "state",
+		Short: "Show workspace state (projects, outlines, items), optionally as of a past event or date",
+		Long: strings.TrimSpace(`
+Replays the event log up to a point and returns the projects, outlines and items as they were
+then. --as-of takes an event id (the state right after that event), a date (YYYY-MM-DD: the end
+of that day, local time), a local "YYYY-MM-DD HH:MM" or an RFC3339 timestamp.
+
+Nothing is written: the historical state is computed on the fly.
+`),
+		Example: strings.TrimSpace(`
+clarity state --as-of 2026-09-01
+clarity state --as-of <event-id> --entity <outline-id>
+clarity items show <item-id> --as-of 2026-09-01
+`),
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			db, s, err := loadDB(app)
+			if err != nil {
+				return writeErr(cmd, err)
+			}
+			var asOfMeta map[string]any
+			if strings.TrimSpace(asOf) != "" {
+				if db, asOfMeta, err = loadDBAsOf(db, s, asOf); err != nil {
+					return writeErr(cmd, err)
+				}
+			}
+			meta := map[string]any{}
+			if asOfMeta != nil {
+				meta["asOf"] = asOfMeta
+			}
+
+			if id := strings.TrimSpace(entityID); id != "" {
+				var data any
+				if p, ok := db.FindProject(id); ok {
+					data, meta["kind"] = p, "project"
+				} else if o, ok := db.FindOutline(id); ok {
+					data, meta["kind"] = o, "outline"
+				} else if it, ok := db.FindItem(id); ok {
+					data, meta["kind"] = it, "item"
+				} else {
+					return writeErr(cmd, errNotFound("entity", id))
+				}
+				return writeOut(cmd, app, map[string]any{"data": data, "meta": meta})
+			}
+
+			meta["counts"] = map[string]any{
+				"projects": len(db.Projects),
+				"outlines": len(db.Outlines),
+				"items":    len(db.Items),
+			}
+			return writeOut(cmd, app, map[string]any{
+				"data": map[string]any{
+					"projects": db.Projects,
+					"outlines": db.Outlines,
+					"items":    db.Items,
+				},
+				"meta": meta,
+				"_hints": []string{
+					"clarity state --as-of <event-id|date> --entity <id>",
+					"clarity items show <item-id> --as-of <event-id|date>",
+				},
+			})
+		},
+	}
+
+	cmd.Flags().StringVar(&asOf, "as-of", "", "Event id, date (YYYY-MM-DD = end of that day), 'YYYY-MM-DD HH:MM' or RFC3339 (default: now)")
+	cmd.Flags().StringVar(&entityID, "entity", "", "Only this project, outline or item")
+	return cmd
+}
+
+// parseAsOf parses an --as-of value: a date or timestamp, otherwise an event id.
+func parseAsOf(s string) (store.AsOf, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return store.AsOf{}, errors.New("empty --as-of")
+	}
+	if reDateOnly.MatchString(s) {
+		day, err := time.ParseInLocation("2006-01-02", s, time.Local)
+		if err != nil {
+			return store.AsOf{}, err
+		}
+		return store.AsOf{Time: day.AddDate(0, 0, 1).Add(-time.Millisecond)}, nil
+	}
+	if reDateTime.MatchString(s) {
+		for _, layout := range []string{"2006-01-02 15:04", "2006-01-02T15:04", "2006-01-02 15:04:05", "2006-01-02T15:04:05"} {
+			if ts, err := time.ParseInLocation(layout, s, time.Local); err == nil {
+				return store.AsOf{Time: ts}, nil
+			}
+		}
+	}
+	if ts, err := time.Parse(time.RFC3339Nano, s); err == nil {
+		return store.AsOf{Time: ts}, nil
+	}
+	return store.AsOf{EventID: s}, nil
+}
+
+// loadDBAsOf replays the workspace up to asOf. The returned DB is read-only (never Save it).
+func loadDBAsOf(cur *store.DB, s store.Store, asOf string) (*store.DB, map[string]any, error) {
+	at, err := parseAsOf(asOf)
+	if err != nil {
+		return nil, nil, err
+	}
+	res, err := s.ReplayAsOf(at)
+	if errors.Is(err, store.ErrEventNotFound) {
+		return nil, nil, errNotFound("event", at.EventID)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	if cur != nil {
+		res.DB.CurrentActorID = cur.CurrentActorID
+	}
+	meta := map[string]any{"at": at.String(), "eventsApplied": res.Applied}
+	if res.Last != nil {
+		meta["lastEvent"] = map[string]any{
+			"id":       res.Last.EventID,
+			"type":     res.Last.Type,
+			"actorId":  res.Last.ActorID,
+			"issuedAt": res.Last.IssuedAt,
+		}
+	}
+	return res.DB, meta, nil
+}
@@ -0,0 +1,84 @@
+package cli
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	"clarity-cli/internal/store"
+)
+
+func TestAsOf_ItemsShowAndState(t *testing.T) {
+	dir := t.TempDir()
+	if _, err := store.EnsureGitBackedV1Layout(dir); err != nil {
+		t.Fatalf("layout: %v", err)
+	}
+	mustWriteFile(t, filepath.Join(dir, "events", "events.rep-a.jsonl"), []byte(conflictsBaseEvents))
+
+	type envelope struct {
+		Data json.RawMessage `json:"data"`
+		Meta map[string]any  `json:"meta"`
+	}
+	cli := func(args ...string) (envelope, error) {
+		t.Helper()
+		out, _, err := runCLI(t, append([]string{"--dir", dir, "--actor", "act-1"}, args...))
+		var env envelope
+		if err == nil {
+			if err := json.Unmarshal(out, &env); err != nil {
+				t.Fatalf("decode %v: %v\n%s", args, err, out)
+			}
+		}
+		return env, err
+	}
+	description := func(args ...string) string {
+		t.Helper()
+		env, err := cli(args...)
+		if err != nil {
+			t.Fatalf("%v: %v", args, err)
+		}
+		var data struct {
+			Item struct {
+				Description string `json:"description"`
+			} `json:"item"`
+		}
+		if err := json.Unmarshal(env.Data, &data); err != nil {
+			t.Fatalf("decode item: %v", err)
+		}
+		return data.Item.Description
+	}
+
+	if got := description("items", "show", "item-1"); got != "from A" {
+		t.Fatalf("current description = %q", got)
+	}
+	if got := description("items", "show", "item-1", "--as-of", "evt-4"); got != "" {
+		t.Fatalf("description as of evt-4 = %q", got)
+	}
+	if got := description("items", "show", "item-1", "--as-of", "2025-12-31T00:00:03.500Z"); got != "" {
+		t.Fatalf("description as of 00:00:03.5 = %q", got)
+	}
+	if got := description("items", "show", "item-1", "--as-of", "2025-12-31T00:00:04Z"); got != "from A" {
+		t.Fatalf("description as of 00:00:04 = %q", got)
+	}
+	if _, err := cli("items", "show", "item-1", "--as-of", "evt-3"); err == nil {
+		t.Fatalf("expected not found before the item was created")
+	}
+	if _, err := cli("items", "show", "item-1", "--as-of", "evt-nope"); err == nil {
+		t.Fatalf("expected error for an unknown event")
+	}
+
+	env, err := cli("state", "--as-of", "evt-3", "--entity", "out-1")
+	if err != nil {
+		t.Fatalf("state --entity: %v", err)
+	}
+	if env.Meta["kind"] != "outline" {
+		t.Fatalf("unexpected meta: %+v", env.Meta)
+	}
+	env, err = cli("state", "--as-of", "evt-2")
+	if err != nil {
+		t.Fatalf("state: %v", err)
+	}
+	counts, _ := env.Meta["counts"].(map[string]any)
+	if counts["projects"] != float64(1) || counts["outlines"] != float64(0) || counts["items"] != float64(0) {
+		t.Fatalf("unexpected counts as of evt-2: %+v", env.Meta)
+	}
+}
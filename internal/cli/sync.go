@@ -115,7 +115,7 @@ func newSyncSetupCmd(app *App) *cobra.Command {
                         if strings.TrimSpace(final.Upstream) == "" {
                                 hints = append(hints, "git push -u origin HEAD")
                         }
-                        hints = append(hints, "clarity sync status", "clarity reindex", "clarity doctor --fail")
+                        hints = append(hints, "clarity sync status", "clarity doctor --fail")
 
                         return writeOut(cmd, app, map[string]any{
                                 "data": map[string]any{
@@ -249,7 +249,7 @@ func newSyncPullCmd(app *App) *cobra.Command {
                                         "after":  after,
                                 },
                                 "_hints": []string{
-                                        "clarity reindex",
+                                        "clarity status",
                                         "clarity doctor --fail",
                                 },
                         })
@@ -376,7 +376,7 @@ func newSyncResolveCmd(app *App) *cobra.Command {
                         if st.InProgressKind == "rebase" {
                                 hints = append(hints, "git rebase --abort")
                         }
-                        hints = append(hints, "clarity reindex", "clarity doctor --fail")
+                        hints = append(hints, "clarity doctor --fail")
 
                         return writeOut(cmd, app, map[string]any{
                                 "data": map[string]any{
@@ -8,6 +8,8 @@ import (
 
 	"clarity-cli/internal/model"
 	"clarity-cli/internal/mutate"
+	"clarity-cli/internal/readiness"
+	"clarity-cli/internal/search"
 	"clarity-cli/internal/statusutil"
 	"clarity-cli/internal/store"
 
@@ -201,6 +203,10 @@ func newItemsCopyCmd(app *App) *cobra.Command {
 				assigned = &tmp
 			}
 
+			if err := checkCanContribute(db, actorID, destProjectID, destOutlineID); err != nil {
+				return writeErr(cmd, err)
+			}
+
 			now := time.Now().UTC()
 			it := model.Item{
 				ID:                 s.NextID(db, "item"),
@@ -353,6 +359,9 @@ func newItemsCreateCmd(app *App) *cobra.Command {
 			if !ok || outline == nil {
 				return writeErr(cmd, errNotFound("outline", oid))
 			}
+			if err := checkCanContribute(db, actorID, pid, oid); err != nil {
+				return writeErr(cmd, err)
+			}
 
 			desc := description
 			if ff := strings.TrimSpace(filedFrom); ff != "" {
@@ -431,12 +440,18 @@ func newItemsListCmd(app *App) *cobra.Command {
 	var mine bool
 	var status string
 	var includeArchived bool
+	var query string
 
 	cmd := &cobra.Command{
 		Use:   "list",
 		Short: "List items",
+		Example: strings.TrimSpace(`
+clarity items list --outline <outline-id>
+clarity items list --query 'is:ready tag:infra owner:me'
+clarity items list --query 'due>=today due<+7d sort:due'
+`),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			db, _, err := loadDB(app)
+			db, s, err := loadDB(app)
 			if err != nil {
 				return writeErr(cmd, err)
 			}
@@ -461,11 +476,38 @@ func newItemsListCmd(app *App) *cobra.Command {
 				filterStatus = true
 			}
 
+			// --query narrows the list using the search/view query language; an explicit sort: wins
+			// over the default outline order.
+			var queryOrder map[string]int
+			var querySort bool
+			if strings.TrimSpace(query) != "" {
+				q, err := search.Parse(query)
+				if err != nil {
+					return writeErr(cmd, err)
+				}
+				// Like `search`, queries are run as the current actor (assignee:me, worklog).
+				queryActorID, err := currentActorID(app, db)
+				if err != nil {
+					return writeErr(cmd, err)
+				}
+				matched, _ := runItemQuery(db, s, queryActorID, q, 0)
+				queryOrder = make(map[string]int, len(matched))
+				for i, it := range matched {
+					queryOrder[it.ID] = i
+				}
+				querySort = q.Sort != search.SortRelevance
+			}
+
 			out := make([]model.Item, 0)
 			for _, t := range db.Items {
 				if !includeArchived && t.Archived {
 					continue
 				}
+				if queryOrder != nil {
+					if _, ok := queryOrder[t.ID]; !ok {
+						continue
+					}
+				}
 				if projectID != "" && t.ProjectID != projectID {
 					continue
 				}
@@ -512,6 +554,9 @@ func newItemsListCmd(app *App) *cobra.Command {
 				}
 				return out[i].CreatedAt.Before(out[j].CreatedAt)
 			})
+			if querySort {
+				sort.SliceStable(out, func(i, j int) bool { return queryOrder[out[i].ID] < queryOrder[out[j].ID] })
+			}
 
 			return writeOut(cmd, app, map[string]any{"data": out})
 		},
@@ -522,22 +567,30 @@ func newItemsListCmd(app *App) *cobra.Command {
 	cmd.Flags().BoolVar(&mine, "mine", false, "Only items assigned to current actor")
 	cmd.Flags().StringVar(&status, "status", "", "Filter by status id (e.g. todo|doing|done)")
 	cmd.Flags().BoolVar(&includeArchived, "include-archived", false, "Include archived items")
+	cmd.Flags().StringVar(&query, "query", "", "Filter with the query language (e.g. 'is:ready tag:infra due<+7d'; see: clarity docs views)")
 
 	return cmd
 }
 
 func newItemsShowCmd(app *App) *cobra.Command {
+	var asOf string
 	cmd := &cobra.Command{
 		Use:   "show <item-id>",
 		Short: "Show an item",
 		Aliases: []string{
 			"get",
 		},
+		Example: strings.TrimSpace(`
+clarity items show item-abc
+clarity items show item-abc --as-of 2026-09-01
+clarity items show item-abc --as-of <event-id>
+`),
 		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return showItem(app, cmd, args[0])
+			return showItem(app, cmd, args[0], asOf)
 		},
 	}
+	cmd.Flags().StringVar(&asOf, "as-of", "", "Show the item as it was after this event id, or at this date/time (YYYY-MM-DD = end of that day)")
 	return cmd
 }
 
@@ -572,11 +625,17 @@ func newItemsEventsCmd(app *App) *cobra.Command {
 	return cmd
 }
 
-func showItem(app *App, cmd *cobra.Command, id string) error {
-	db, _, err := loadDB(app)
+func showItem(app *App, cmd *cobra.Command, id string, asOf string) error {
+	db, s, err := loadDB(app)
 	if err != nil {
 		return writeErr(cmd, err)
 	}
+	var asOfMeta map[string]any
+	if strings.TrimSpace(asOf) != "" {
+		if db, asOfMeta, err = loadDBAsOf(db, s, asOf); err != nil {
+			return writeErr(cmd, err)
+		}
+	}
 	t, ok := db.FindItem(id)
 	if !ok {
 		return writeErr(cmd, errNotFound("item", id))
@@ -749,6 +808,30 @@ func showItem(app *App, cmd *cobra.Command, id string) error {
 			hints = append(pickup, hints...)
 		}
 	}
+	if asOfMeta != nil {
+		hints = []string{
+			"clarity items show " + id,
+			"clarity items events " + id,
+		}
+	}
+
+	meta := map[string]any{
+		"comments": map[string]any{
+			"count": commentsCount,
+		},
+		"worklog": map[string]any{
+			"count": worklogCount,
+		},
+		"deps": map[string]any{
+			"blocks": map[string]any{
+				"out": depsOut,
+				"in":  depsIn,
+			},
+		},
+	}
+	if asOfMeta != nil {
+		meta["asOf"] = asOfMeta
+	}
 
 	return writeOut(cmd, app, map[string]any{
 		"data": map[string]any{
@@ -770,20 +853,7 @@ func showItem(app *App, cmd *cobra.Command, id string) error {
 				"related": depsRelated,
 			},
 		},
-		"meta": map[string]any{
-			"comments": map[string]any{
-				"count": commentsCount,
-			},
-			"worklog": map[string]any{
-				"count": worklogCount,
-			},
-			"deps": map[string]any{
-				"blocks": map[string]any{
-					"out": depsOut,
-					"in":  depsIn,
-				},
-			},
-		},
+		"meta":   meta,
 		"_hints": hints,
 	})
 }
@@ -919,9 +989,18 @@ func newItemsSetStatusCmd(app *App) *cobra.Command {
 			if err := s.AppendEvent(actorID, "item.set_status", t.ID, res.EventPayload); err != nil {
 				return writeErr(cmd, err)
 			}
+			if res.RepeatPayload != nil {
+				// Completing a repeating item reopens it with the next schedule/due.
+				if err := s.AppendEvent(actorID, "item.repeat", t.ID, res.RepeatPayload); err != nil {
+					return writeErr(cmd, err)
+				}
+			}
 			if err := s.Save(db); err != nil {
 				return writeErr(cmd, err)
 			}
+			if res.RepeatPayload != nil {
+				return writeOut(cmd, app, map[string]any{"data": t, "meta": map[string]any{"repeat": res.RepeatPayload}})
+			}
 			return writeOut(cmd, app, map[string]any{"data": t})
 		},
 	}
@@ -938,7 +1017,8 @@ func newItemsReadyCmd(app *App) *cobra.Command {
 		Use:   "ready",
 		Short: "List ready items (good for picking the next task)",
 		Long: strings.TrimSpace(`
-List items that are not archived, not in an end-state, and have no blocking dependencies.
+List items that are not archived, not in an end-state, and have no open blocking dependencies.
+A blocker stops blocking once it reaches an end-state or is archived (see: clarity deps blockers <item-id>).
 By default, items that are on-hold are excluded.
 
 This is the recommended way to find the next thing to work on.
@@ -973,12 +1053,8 @@ clarity <item-id>
 				}
 			}
 
-			blocked := map[string]bool{}
-			for _, d := range db.Deps {
-				if d.Type == model.DependencyBlocks {
-					blocked[d.FromItemID] = true
-				}
-			}
+			// Blockers that reached an end-state (or were archived) no longer block.
+			blocked := readiness.OpenBlockerCounts(db)
 
 			mine := make([]model.Item, 0)
 			out := make([]model.Item, 0)
@@ -989,7 +1065,7 @@ clarity <item-id>
 				if !includeOnHold && t.OnHold {
 					continue
 				}
-				if blocked[t.ID] {
+				if blocked[t.ID] > 0 {
 					continue
 				}
 				assignedTo := ""
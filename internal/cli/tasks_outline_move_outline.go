@@ -46,6 +46,10 @@ func newItemsMoveOutlineCmd(app *App) *cobra.Command {
                         if o.ProjectID != t.ProjectID {
                                 return writeErr(cmd, errors.New("target outline must belong to the same project"))
                         }
+                        // Moving in adds to the target outline (replay requires it too).
+                        if err := checkCanContribute(db, actorID, o.ProjectID, o.ID); err != nil {
+                                return writeErr(cmd, err)
+                        }
 
                         // Block move if status would be invalid in target outline unless user provides --set-status.
                         statusToUse := t.StatusID
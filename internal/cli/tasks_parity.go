@@ -249,10 +249,16 @@ func newItemsSetItemKindCmd(app *App) *cobra.Command {
 
 func newItemsSetDueCmd(app *App) *cobra.Command {
 	var at string
+	var repeatSpec string
 	var clear bool
 	cmd := &cobra.Command{
 		Use:   "set-due <item-id>",
 		Short: "Set/clear due date (owner-only); accepts RFC3339 or local date/time",
+		Example: strings.TrimSpace(`
+clarity items set-due <item-id> --at 2026-01-09
+clarity items set-due <item-id> --at "2026-01-09 +1m"
+clarity items set-due <item-id> --repeat none
+`),
 		Aliases: []string{
 			"due",
 		},
@@ -279,10 +285,7 @@ func newItemsSetDueCmd(app *App) *cobra.Command {
 			if clear {
 				t.Due = nil
 			} else {
-				if strings.TrimSpace(at) == "" {
-					return writeErr(cmd, errors.New("missing --at (or pass --clear)"))
-				}
-				dt, err := parseDateTime(at)
+				dt, err := applyDateTimeFlags(t.Due, at, repeatSpec, cmd.Flags().Changed("repeat"))
 				if err != nil {
 					return writeErr(cmd, err)
 				}
@@ -299,17 +302,24 @@ func newItemsSetDueCmd(app *App) *cobra.Command {
 			return writeOut(cmd, app, map[string]any{"data": t})
 		},
 	}
-	cmd.Flags().StringVar(&at, "at", "", "Due datetime (RFC3339 or YYYY-MM-DD[ HH:MM])")
+	cmd.Flags().StringVar(&at, "at", "", "Due datetime (RFC3339 or YYYY-MM-DD[ HH:MM], optionally followed by a repeater like +1w)")
+	cmd.Flags().StringVar(&repeatSpec, "repeat", "", "Repeater: +Nd|w|m|y (shift once), ++N... (catch up past today), .+N... (from completion), or none")
 	cmd.Flags().BoolVar(&clear, "clear", false, "Clear due date")
 	return cmd
 }
 
 func newItemsSetScheduleCmd(app *App) *cobra.Command {
 	var at string
+	var repeatSpec string
 	var clear bool
 	cmd := &cobra.Command{
 		Use:   "set-schedule <item-id>",
 		Short: "Set/clear schedule date (owner-only); accepts RFC3339 or local date/time",
+		Example: strings.TrimSpace(`
+clarity items set-schedule <item-id> --at "2026-01-05 09:00"
+clarity items set-schedule <item-id> --at 2026-01-05 --repeat ++1w
+clarity items set-schedule <item-id> --repeat .+2w
+`),
 		Aliases: []string{
 			"schedule",
 		},
@@ -336,10 +346,7 @@ func newItemsSetScheduleCmd(app *App) *cobra.Command {
 			if clear {
 				t.Schedule = nil
 			} else {
-				if strings.TrimSpace(at) == "" {
-					return writeErr(cmd, errors.New("missing --at (or pass --clear)"))
-				}
-				dt, err := parseDateTime(at)
+				dt, err := applyDateTimeFlags(t.Schedule, at, repeatSpec, cmd.Flags().Changed("repeat"))
 				if err != nil {
 					return writeErr(cmd, err)
 				}
@@ -356,7 +363,8 @@ func newItemsSetScheduleCmd(app *App) *cobra.Command {
 			return writeOut(cmd, app, map[string]any{"data": t})
 		},
 	}
-	cmd.Flags().StringVar(&at, "at", "", "Schedule datetime (RFC3339 or YYYY-MM-DD[ HH:MM])")
+	cmd.Flags().StringVar(&at, "at", "", "Schedule datetime (RFC3339 or YYYY-MM-DD[ HH:MM], optionally followed by a repeater like +1w)")
+	cmd.Flags().StringVar(&repeatSpec, "repeat", "", "Repeater: +Nd|w|m|y (shift once), ++N... (catch up past today), .+N... (from completion), or none")
 	cmd.Flags().BoolVar(&clear, "clear", false, "Clear schedule date")
 	return cmd
 }
@@ -0,0 +1,128 @@
+package cli
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"clarity-cli/internal/store"
+
+	"github.com/spf13/cobra"
+)
+
+func newUndoCmd(app *App) *cobra.Command {
+	return newUndoRedoCmd(app, false)
+}
+
+func newRedoCmd(app *App) *cobra.Command {
+	return newUndoRedoCmd(app, true)
+}
+
+func newUndoRedoCmd(app *App, redo bool) *cobra.Command {
+	var n int
+
+	use, short, long := "undo", "Undo your last item edits by appending compensating events", strings.TrimSpace(`
+Undoes the current actor's most recent item edits (title, description, status, priority,
+on-hold, due/schedule, tags, moves, archive) by appending events that restore the previous
+values, computed by replaying the log up to the edit. Nothing is removed from history.
+
+Refuses when someone else has changed the item since: undoing would overwrite their change.
+Undone edits can be redone with clarity redo until you make a new edit.
+`)
+	if redo {
+		use, short, long = "redo", "Redo edits you undid with clarity undo", strings.TrimSpace(`
+Reapplies the current actor's most recent undone edits, in reverse order of undoing. Making a
+new edit clears what can be redone.
+`)
+	}
+
+	cmd := &cobra.Command{
+		Use:   use,
+		Short: short,
+		Long:  long,
+		Example: strings.TrimSpace(`
+clarity undo
+clarity undo --n 3
+clarity redo
+`),
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if n < 1 {
+				return writeErr(cmd, errors.New("--n must be at least 1"))
+			}
+			db, s, err := loadDB(app)
+			if err != nil {
+				return writeErr(cmd, err)
+			}
+			actorID, err := currentActorID(app, db)
+			if err != nil {
+				return writeErr(cmd, err)
+			}
+
+			steps := []map[string]any{}
+			var stepErr error
+			for len(steps) < n {
+				var p store.UndoPlan
+				if redo {
+					p, stepErr = s.PlanRedo(actorID, time.Time{})
+				} else {
+					p, stepErr = s.PlanUndo(actorID, time.Time{})
+				}
+				if stepErr != nil {
+					break
+				}
+				it, ok := db.FindItem(p.Of.EntityID)
+				if !ok {
+					stepErr = errNotFound("item", p.Of.EntityID)
+					break
+				}
+				if !canEditTask(db, actorID, it) {
+					stepErr = errorsOwnerOnly(actorID, it.OwnerActorID, it.ID)
+					break
+				}
+				types := make([]string, 0, len(p.Events))
+				for _, pe := range p.Events {
+					if err := s.AppendEvent(actorID, pe.Type, pe.EntityID, pe.Payload); err != nil {
+						return writeErr(cmd, err)
+					}
+					types = append(types, pe.Type)
+				}
+				if err := p.Apply(db); err != nil {
+					return writeErr(cmd, err)
+				}
+				steps = append(steps, map[string]any{
+					"eventId":  p.Of.EventID,
+					"type":     p.Of.Type,
+					"entityId": p.Of.EntityID,
+					"appended": types,
+				})
+			}
+			if len(steps) > 0 {
+				if err := s.Save(db); err != nil {
+					return writeErr(cmd, err)
+				}
+			}
+			// Running out of history after at least one step is not an error.
+			if stepErr != nil && (len(steps) == 0 || !(errors.Is(stepErr, store.ErrNothingToUndo) || errors.Is(stepErr, store.ErrNothingToRedo))) {
+				return writeErr(cmd, stepErr)
+			}
+
+			hints := []string{"clarity redo", "clarity items events <item-id>"}
+			if redo {
+				hints = []string{"clarity undo", "clarity items events <item-id>"}
+			}
+			key := "undone"
+			if redo {
+				key = "redone"
+			}
+			return writeOut(cmd, app, map[string]any{
+				"data":   map[string]any{key: steps},
+				"meta":   map[string]any{"actorId": actorID, "requested": n, "count": len(steps)},
+				"_hints": hints,
+			})
+		},
+	}
+
+	cmd.Flags().IntVar(&n, "n", 1, "How many edits to "+use)
+	return cmd
+}
@@ -0,0 +1,84 @@
+package cli
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"clarity-cli/internal/store"
+)
+
+func TestUndoRedo_CompensatesOwnEdits(t *testing.T) {
+	dir := t.TempDir()
+	if _, err := store.EnsureGitBackedV1Layout(dir); err != nil {
+		t.Fatalf("layout: %v", err)
+	}
+	mustWriteFile(t, filepath.Join(dir, "events", "events.rep-a.jsonl"), []byte(conflictsBaseEvents))
+
+	cli := func(args ...string) []byte {
+		t.Helper()
+		out, errOut, err := runCLI(t, append([]string{"--dir", dir, "--actor", "act-1"}, args...))
+		if err != nil {
+			t.Fatalf("%v: %v\nstderr:\n%s", args, err, errOut)
+		}
+		return out
+	}
+	item := func() (string, bool) {
+		t.Helper()
+		var env struct {
+			Data struct {
+				Item struct {
+					Title    string `json:"title"`
+					Priority bool   `json:"priority"`
+				} `json:"item"`
+			} `json:"data"`
+		}
+		if err := json.Unmarshal(cli("items", "show", "item-1"), &env); err != nil {
+			t.Fatalf("decode item: %v", err)
+		}
+		return env.Data.Item.Title, env.Data.Item.Priority
+	}
+
+	cli("items", "set-title", "item-1", "--title", "New")
+	cli("items", "set-priority", "item-1", "--on")
+
+	var env struct {
+		Data struct {
+			Undone []map[string]any `json:"undone"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(cli("undo", "--n", "2"), &env); err != nil {
+		t.Fatalf("decode undo: %v", err)
+	}
+	if len(env.Data.Undone) != 2 || env.Data.Undone[0]["type"] != "item.set_priority" || env.Data.Undone[1]["type"] != "item.set_title" {
+		t.Fatalf("unexpected undo output: %+v", env.Data.Undone)
+	}
+	if title, prio := item(); title != "T" || prio {
+		t.Fatalf("after undo: title=%q priority=%v", title, prio)
+	}
+
+	cli("redo")
+	if title, prio := item(); title != "New" || prio {
+		t.Fatalf("after redo: title=%q priority=%v", title, prio)
+	}
+
+	// Someone else edits the item: undoing now would overwrite their change.
+	f, err := os.OpenFile(filepath.Join(dir, "events", "events.rep-b.jsonl"), os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		t.Fatalf("open shard: %v", err)
+	}
+	if _, err := f.WriteString(`{"eventId":"evt-b1","workspaceId":"ws-1","replicaId":"rep-b","entityKind":"item","entityId":"item-1","entitySeq":9,"type":"item.set_description","issuedAt":"2099-01-01T00:00:00Z","actorId":"act-2","payload":{"description":"from B"}}` + "\n"); err != nil {
+		t.Fatalf("append shard: %v", err)
+	}
+	_ = f.Close()
+
+	_, errOut, err := runCLI(t, []string{"--dir", dir, "--actor", "act-1", "undo"})
+	if err == nil || !strings.Contains(string(errOut), "changed since by act-2") {
+		t.Fatalf("expected undo to refuse, got err=%v stderr=%s", err, errOut)
+	}
+	if title, _ := item(); title != "New" {
+		t.Fatalf("refused undo changed the title to %q", title)
+	}
+}
@@ -0,0 +1,210 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"clarity-cli/internal/model"
+	"clarity-cli/internal/search"
+	"clarity-cli/internal/store"
+
+	"github.com/spf13/cobra"
+)
+
+func newViewsCmd(app *App) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "views",
+		Short: "Saved views: named queries shared via meta/views.json",
+		Long: strings.TrimSpace(`
+Saved views are named queries stored in meta/views.json (committed, so they are shared with
+everyone using the workspace). The TUI lists them under the agenda menu (a).
+
+Query language: the qualifiers of ` + "`clarity search`" + ` (see: clarity docs views).
+`),
+	}
+	cmd.AddCommand(newViewsListCmd(app))
+	cmd.AddCommand(newViewsSaveCmd(app))
+	cmd.AddCommand(newViewsRemoveCmd(app))
+	cmd.AddCommand(newViewsRunCmd(app))
+	return cmd
+}
+
+func newViewsListCmd(app *App) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List saved views",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			_, s, err := loadDB(app)
+			if err != nil {
+				return writeErr(cmd, err)
+			}
+			f, err := s.LoadViews()
+			if err != nil {
+				return writeErr(cmd, err)
+			}
+			return writeOut(cmd, app, map[string]any{
+				"data": f.Views,
+				"_hints": []string{
+					"clarity views run <name>",
+					"clarity views save <name> --query \"...\"",
+				},
+			})
+		},
+	}
+	return cmd
+}
+
+func newViewsSaveCmd(app *App) *cobra.Command {
+	var query string
+	var description string
+	var key string
+
+	cmd := &cobra.Command{
+		Use:   "save <name>",
+		Short: "Create or replace a saved view",
+		Example: strings.TrimSpace(`
+clarity views save this-week --query 'is:open due<=+7d sort:due' --key w
+clarity views save my-ready --query 'is:ready assignee:me sort:priority' --description "Ready for me"
+clarity views save discussed --query 'commented>=-3d is:open'
+`),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name := strings.TrimSpace(args[0])
+			if err := store.ValidateViewName(name); err != nil {
+				return writeErr(cmd, err)
+			}
+			q, err := search.Parse(query)
+			if err != nil {
+				return writeErr(cmd, err)
+			}
+			if q.Empty() {
+				return writeErr(cmd, errors.New("empty query"))
+			}
+
+			_, s, err := loadDB(app)
+			if err != nil {
+				return writeErr(cmd, err)
+			}
+			f, err := s.LoadViews()
+			if err != nil {
+				return writeErr(cmd, err)
+			}
+			v := store.SavedView{
+				Name:        name,
+				Query:       strings.TrimSpace(query),
+				Description: strings.TrimSpace(description),
+				Key:         strings.TrimSpace(key),
+			}
+			f.Upsert(v)
+			if err := s.SaveViews(f); err != nil {
+				return writeErr(cmd, err)
+			}
+			return writeOut(cmd, app, map[string]any{
+				"data":   v,
+				"_hints": []string{"clarity views run " + v.Name},
+			})
+		},
+	}
+
+	cmd.Flags().StringVar(&query, "query", "", "Query (same language as `clarity search`)")
+	cmd.Flags().StringVar(&description, "description", "", "Description (optional)")
+	cmd.Flags().StringVar(&key, "key", "", "Single-character shortcut in the TUI agenda menu (optional)")
+	_ = cmd.MarkFlagRequired("query")
+	return cmd
+}
+
+func newViewsRemoveCmd(app *App) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "remove <name>",
+		Short: "Remove a saved view",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			_, s, err := loadDB(app)
+			if err != nil {
+				return writeErr(cmd, err)
+			}
+			f, err := s.LoadViews()
+			if err != nil {
+				return writeErr(cmd, err)
+			}
+			if !f.Remove(args[0]) {
+				return writeErr(cmd, errNotFound("view", args[0]))
+			}
+			if err := s.SaveViews(f); err != nil {
+				return writeErr(cmd, err)
+			}
+			return writeOut(cmd, app, map[string]any{"data": map[string]any{"name": strings.TrimSpace(args[0]), "removed": true}})
+		},
+	}
+	return cmd
+}
+
+func newViewsRunCmd(app *App) *cobra.Command {
+	var limit int
+
+	cmd := &cobra.Command{
+		Use:   "run <name>",
+		Short: "Run a saved view and list the matching items",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			db, s, err := loadDB(app)
+			if err != nil {
+				return writeErr(cmd, err)
+			}
+			f, err := s.LoadViews()
+			if err != nil {
+				return writeErr(cmd, err)
+			}
+			v, ok := f.Find(args[0])
+			if !ok {
+				return writeErr(cmd, errNotFound("view", args[0]))
+			}
+			q, err := search.Parse(v.Query)
+			if err != nil {
+				return writeErr(cmd, fmt.Errorf("view %q: %w", v.Name, err))
+			}
+			actorID, err := currentActorID(app, db)
+			if err != nil {
+				return writeErr(cmd, err)
+			}
+
+			items, total := runItemQuery(db, s, actorID, q, limit)
+			return writeOut(cmd, app, map[string]any{
+				"data": items,
+				"meta": map[string]any{
+					"view":     v.Name,
+					"query":    v.Query,
+					"total":    total,
+					"returned": len(items),
+					"limit":    limit,
+				},
+				"_hints": []string{
+					"clarity <item-id>",
+					"clarity views list",
+				},
+			})
+		},
+	}
+
+	cmd.Flags().IntVar(&limit, "limit", 0, "Max results (0 = all)")
+	return cmd
+}
+
+// runItemQuery evaluates q (search/view query language) and returns the matching items in result order.
+func runItemQuery(db *store.DB, s store.Store, actorID string, q search.Query, limit int) ([]model.Item, int) {
+	opts := search.Options{ActorID: actorID, Today: time.Now(), Limit: limit}
+	if candidates := searchCandidates(s, q.Terms); candidates != nil {
+		opts.Candidates = candidates
+	}
+	res := search.Run(db, q, opts)
+	items := make([]model.Item, 0, len(res.Hits))
+	for _, h := range res.Hits {
+		if it, ok := db.FindItem(h.ItemID); ok && it != nil {
+			items = append(items, *it)
+		}
+	}
+	return items, res.Total
+}
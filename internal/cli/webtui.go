@@ -34,6 +34,7 @@ clarity webtui --addr 127.0.0.1:3334
 clarity --workspace "Flakstad Software" webtui --addr :3334
 `),
                 RunE: func(cmd *cobra.Command, args []string) error {
+                        noHooksForProcess(app)
                         dir, err := resolveDir(app)
                         if err != nil {
                                 return writeErr(cmd, err)
@@ -43,9 +43,13 @@ func newWorklogAddCmd(app *App) *cobra.Command {
                         }
 
                         itemID := args[0]
-                        if _, ok := db.FindItem(itemID); !ok {
+                        it, ok := db.FindItem(itemID)
+                        if !ok {
                                 return writeErr(cmd, errNotFound("item", itemID))
                         }
+                        if err := checkCanContribute(db, actorID, it.ProjectID, it.OutlineID); err != nil {
+                                return writeErr(cmd, err)
+                        }
                         body = strings.TrimSpace(body)
                         if body == "" {
                                 return writeErr(cmd, errors.New("missing --body"))
@@ -31,6 +31,7 @@ func newWorkspaceCmd(app *App) *cobra.Command {
         cmd.AddCommand(newWorkspaceListCmd(app))
         cmd.AddCommand(newWorkspaceRenameCmd(app))
         cmd.AddCommand(newWorkspaceExportCmd(app))
+        cmd.AddCommand(newWorkspaceSnapshotCmd(app))
         cmd.AddCommand(newWorkspaceImportCmd(app))
         cmd.AddCommand(newWorkspaceMigrateCmd(app))
 
@@ -712,6 +713,47 @@ func newWorkspaceExportCmd(app *App) *cobra.Command {
         return cmd
 }
 
+func newWorkspaceSnapshotCmd(app *App) *cobra.Command {
+        cmd := &cobra.Command{
+                Use:   "snapshot",
+                Short: "Write a snapshot of the materialized state into the workspace (snapshots/)",
+                Long: strings.TrimSpace(`
+Replays the event log and writes the resulting state, with the event watermark it covers and a
+sha256 digest signed with the current actor's key, to snapshots/snapshot-<time>-<replica>.json.
+Commit it like the events.
+
+Rebuilds (clarity reindex, or a Load that can't catch up incrementally) start from the latest
+snapshot and apply only the events after it. The event log itself is kept as is, and
+clarity doctor checks that the snapshot equals a replay of the events it covers.
+`),
+                Args: cobra.NoArgs,
+                RunE: func(cmd *cobra.Command, args []string) error {
+                        db, s, err := loadDB(app)
+                        if err != nil {
+                                return writeErr(cmd, err)
+                        }
+                        // The creator signs the snapshot: rebuilds only start from signed ones.
+                        actorID, err := currentActorID(app, db)
+                        if err != nil {
+                                return writeErr(cmd, err)
+                        }
+
+                        info, err := s.WriteSnapshot(actorID)
+                        if err != nil {
+                                return writeErr(cmd, err)
+                        }
+                        return writeOut(cmd, app, map[string]any{
+                                "data": info,
+                                "_hints": []string{
+                                        "clarity doctor --fail",
+                                        "clarity reindex",
+                                },
+                        })
+                },
+        }
+        return cmd
+}
+
 func newWorkspaceImportCmd(app *App) *cobra.Command {
         var from string
         var nameOpt string
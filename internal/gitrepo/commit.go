@@ -160,6 +160,7 @@ func stageWorkspaceCanonical(ctx context.Context, workspaceDir string, repoRoot
         addIfExists("events")
         addIfExists(filepath.Join("meta", "workspace.json"))
         addIfExists("resources")
+        addIfExists("snapshots")
         // Workspace-scoped ignore rules (important for keeping derived state out of Git status).
         addIfExists(".gitignore")
 
@@ -172,6 +172,8 @@ func describeEvent(ev stagedEvent) string {
                         return "status " + strings.TrimSpace(p.StatusID)
                 }
                 return "status"
+        case "item.repeat":
+                return "repeat item"
         case "item.set_description":
                 return "edit description"
         case "item.set_parent":
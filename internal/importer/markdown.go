@@ -0,0 +1,308 @@
+// Package importer parses external documents (Markdown, Org) into an item tree that the CLI
+// turns into ordinary item.create events.
+//
+// Parsing is pure: it knows nothing about workspaces, ids or status defs. Callers pass the
+// keywords they want recognized (e.g. "TODO", "DONE") and map them to statuses themselves.
+package importer
+
+import (
+	"regexp"
+	"strings"
+
+	"clarity-cli/internal/model"
+)
+
+// Node is one imported item.
+type Node struct {
+	Title string `json:"title"`
+	// Keyword is the leading status keyword stripped from the title (e.g. "TODO"), if any.
+	Keyword string   `json:"keyword,omitempty"`
+	Tags    []string `json:"tags,omitempty"`
+	// Description is the body text under the heading / bullet (markdown, trimmed).
+	Description string `json:"description,omitempty"`
+	// Checkbox is set for "- [ ]" / "- [x]" bullets; Checked for the latter.
+	Checkbox bool `json:"checkbox,omitempty"`
+	Checked  bool `json:"checked,omitempty"`
+	// Priority, Schedule and Due are only set by formats that carry them (Org).
+	Priority bool            `json:"priority,omitempty"`
+	Schedule *model.DateTime `json:"schedule,omitempty"`
+	Due      *model.DateTime `json:"due,omitempty"`
+	Line     int             `json:"line"`
+	Children []*Node         `json:"children,omitempty"`
+
+	body          []string
+	contentIndent int
+}
+
+// Document is the parsed tree.
+type Document struct {
+	Items []*Node `json:"items"`
+	// Preamble is text before the first heading or bullet; it has no item to attach to.
+	Preamble string `json:"preamble,omitempty"`
+	// DeclaredKeywords are keywords declared by the file itself (Org "#+TODO:" lines), mapped to
+	// whether they are done states.
+	DeclaredKeywords map[string]bool `json:"declaredKeywords,omitempty"`
+}
+
+// Options controls parsing.
+type Options struct {
+	// Keywords are recognized as a leading status keyword on titles (exact, case-sensitive match).
+	Keywords []string
+}
+
+var (
+	mdHeadingRe  = regexp.MustCompile(`^ {0,3}(#{1,6})[ \t]+(.*?)(?:[ \t]+#+)?[ \t]*$`)
+	mdBulletRe   = regexp.MustCompile(`^( *)([-*+]|[0-9]+[.)])[ \t]+(.*\S)[ \t]*$`)
+	mdCheckboxRe = regexp.MustCompile(`^\[([ xX])\][ \t]+(.*)$`)
+	mdFenceRe    = regexp.MustCompile("^ *(```|~~~)")
+	tagRe        = regexp.MustCompile(`(^|\s)#([\p{L}\p{N}_][\p{L}\p{N}_/-]*)`)
+)
+
+type headingEntry struct {
+	level int
+	node  *Node
+}
+
+type bulletEntry struct {
+	indent int
+	node   *Node
+}
+
+// ParseMarkdown parses headings and (nested) bullet lists into an item tree.
+//
+// Rules:
+//   - heading levels nest relative to each other (a "###" under a "#" is a child of it)
+//   - bullets nest by indentation under the nearest preceding heading
+//   - other text (including fenced code) becomes the description of the nearest item above it
+//   - "- [ ]" / "- [x]" bullets become checkbox items
+//   - a leading keyword from opts.Keywords and "#tag" words are stripped from titles
+func ParseMarkdown(src string, opts Options) Document {
+	keywords := map[string]bool{}
+	for _, k := range opts.Keywords {
+		if k = strings.TrimSpace(k); k != "" {
+			keywords[k] = true
+		}
+	}
+
+	var doc Document
+	var preamble []string
+	var headings []headingEntry
+	var bullets []bulletEntry
+	var cur *Node
+	fence := ""
+	prevBlank := true
+
+	addChild := func(parent *Node, n *Node) {
+		if parent == nil {
+			doc.Items = append(doc.Items, n)
+			return
+		}
+		parent.Children = append(parent.Children, n)
+	}
+	addBody := func(line string) {
+		if cur == nil {
+			preamble = append(preamble, line)
+			return
+		}
+		cur.body = append(cur.body, dedent(line, cur.contentIndent))
+	}
+
+	lines := strings.Split(strings.ReplaceAll(src, "\r\n", "\n"), "\n")
+	for i, raw := range lines {
+		line := strings.TrimRight(expandTabs(raw), " ")
+
+		if fence != "" {
+			addBody(line)
+			if strings.HasPrefix(strings.TrimSpace(line), fence) {
+				fence = ""
+			}
+			continue
+		}
+		if m := mdFenceRe.FindStringSubmatch(line); m != nil {
+			fence = m[1]
+			addBody(line)
+			prevBlank = false
+			continue
+		}
+
+		if strings.TrimSpace(line) == "" {
+			if cur != nil || len(preamble) > 0 {
+				addBody("")
+			}
+			prevBlank = true
+			continue
+		}
+
+		if m := mdHeadingRe.FindStringSubmatch(line); m != nil && strings.TrimSpace(m[2]) != "" {
+			level := len(m[1])
+			for len(headings) > 0 && headings[len(headings)-1].level >= level {
+				headings = headings[:len(headings)-1]
+			}
+			n := newNode(m[2], i+1, keywords)
+			var parent *Node
+			if len(headings) > 0 {
+				parent = headings[len(headings)-1].node
+			}
+			addChild(parent, n)
+			headings = append(headings, headingEntry{level: level, node: n})
+			bullets = nil
+			cur = n
+			prevBlank = false
+			continue
+		}
+
+		if m := mdBulletRe.FindStringSubmatch(line); m != nil {
+			indent := len(m[1])
+			for len(bullets) > 0 && bullets[len(bullets)-1].indent >= indent {
+				bullets = bullets[:len(bullets)-1]
+			}
+			text := m[3]
+			checkbox, checked := false, false
+			if cm := mdCheckboxRe.FindStringSubmatch(text); cm != nil {
+				checkbox = true
+				checked = cm[1] != " "
+				text = cm[2]
+			}
+			n := newNode(text, i+1, keywords)
+			n.Checkbox = checkbox
+			n.Checked = checked
+			n.contentIndent = indent + len(m[2]) + 1
+			var parent *Node
+			if len(bullets) > 0 {
+				parent = bullets[len(bullets)-1].node
+			} else if len(headings) > 0 {
+				parent = headings[len(headings)-1].node
+			}
+			addChild(parent, n)
+			bullets = append(bullets, bulletEntry{indent: indent, node: n})
+			cur = n
+			prevBlank = false
+			continue
+		}
+
+		// Plain text: indented lines (or lazy continuations) belong to the list item above;
+		// anything else ends the list and belongs to the enclosing heading.
+		if len(bullets) > 0 {
+			indent := len(line) - len(strings.TrimLeft(line, " "))
+			owner := -1
+			for j := len(bullets) - 1; j >= 0; j-- {
+				if indent > bullets[j].indent {
+					owner = j
+					break
+				}
+			}
+			switch {
+			case owner >= 0:
+				bullets = bullets[:owner+1]
+				cur = bullets[owner].node
+			case !prevBlank:
+				// Lazy continuation of the current list item.
+			default:
+				bullets = nil
+				cur = nil
+				if len(headings) > 0 {
+					cur = headings[len(headings)-1].node
+				}
+			}
+		}
+		addBody(line)
+		prevBlank = false
+	}
+
+	var finish func(ns []*Node)
+	finish = func(ns []*Node) {
+		for _, n := range ns {
+			n.Description = joinBody(n.body)
+			n.body = nil
+			finish(n.Children)
+		}
+	}
+	finish(doc.Items)
+	doc.Preamble = joinBody(preamble)
+	return doc
+}
+
+// Count returns the number of nodes in the tree.
+func (d Document) Count() int {
+	var count func(ns []*Node) int
+	count = func(ns []*Node) int {
+		n := len(ns)
+		for _, c := range ns {
+			n += count(c.Children)
+		}
+		return n
+	}
+	return count(d.Items)
+}
+
+func newNode(text string, line int, keywords map[string]bool) *Node {
+	n := &Node{Line: line}
+	text = strings.TrimSpace(text)
+	if first, rest, _ := strings.Cut(text, " "); keywords[first] && strings.TrimSpace(rest) != "" {
+		n.Keyword = first
+		text = strings.TrimSpace(rest)
+	}
+	n.Title, n.Tags = extractTags(text)
+	if n.Title == "" {
+		// A title made only of tags keeps them as text.
+		n.Title, n.Tags = text, nil
+	}
+	return n
+}
+
+// extractTags strips "#tag" words from s. Purely numeric words ("#123") are left alone since
+// they are usually issue references.
+func extractTags(s string) (string, []string) {
+	var tags []string
+	seen := map[string]bool{}
+	out := tagRe.ReplaceAllStringFunc(s, func(m string) string {
+		sub := tagRe.FindStringSubmatch(m)
+		tag := sub[2]
+		if strings.Trim(tag, "0123456789") == "" {
+			return m
+		}
+		if !seen[tag] {
+			seen[tag] = true
+			tags = append(tags, tag)
+		}
+		return sub[1]
+	})
+	return strings.Join(strings.Fields(out), " "), tags
+}
+
+func expandTabs(s string) string {
+	if !strings.Contains(s, "\t") {
+		return s
+	}
+	var b strings.Builder
+	col := 0
+	for _, r := range s {
+		if r == '\t' {
+			n := 4 - col%4
+			b.WriteString(strings.Repeat(" ", n))
+			col += n
+			continue
+		}
+		b.WriteRune(r)
+		col++
+	}
+	return b.String()
+}
+
+func dedent(line string, n int) string {
+	for i := 0; i < n && strings.HasPrefix(line, " "); i++ {
+		line = line[1:]
+	}
+	return line
+}
+
+// joinBody drops leading/trailing blank lines but keeps the indentation of the text itself.
+func joinBody(lines []string) string {
+	for len(lines) > 0 && strings.TrimSpace(lines[0]) == "" {
+		lines = lines[1:]
+	}
+	for len(lines) > 0 && strings.TrimSpace(lines[len(lines)-1]) == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,87 @@
+package importer
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseMarkdown_HeadingsBulletsAndBodies(t *testing.T) {
+	src := strings.TrimSpace(`
+Intro text with no item.
+
+# TODO Launch plan #release
+Why we are doing this.
+
+## Prep
+- [ ] Write notes #docs
+- [x] Book room
+  Second floor.
+- Draft agenda
+  - DONE Collect topics
+    1. Nested numbered
+
+### Deep heading
+` + "```" + `
+# not a heading
+- not a bullet
+` + "```" + `
+
+# Retro
+Closing paragraph.
+`)
+
+	doc := ParseMarkdown(src, Options{Keywords: []string{"TODO", "DONE"}})
+	if doc.Preamble != "Intro text with no item." {
+		t.Fatalf("preamble = %q", doc.Preamble)
+	}
+	if got := doc.Count(); got != 9 {
+		t.Fatalf("count = %d, want 9", got)
+	}
+	if len(doc.Items) != 2 {
+		t.Fatalf("top-level = %d, want 2", len(doc.Items))
+	}
+
+	launch := doc.Items[0]
+	if launch.Title != "Launch plan" || launch.Keyword != "TODO" || strings.Join(launch.Tags, ",") != "release" {
+		t.Fatalf("launch = %+v", launch)
+	}
+	if launch.Description != "Why we are doing this." {
+		t.Fatalf("launch description = %q", launch.Description)
+	}
+
+	prep := launch.Children[0]
+	if prep.Title != "Prep" || len(prep.Children) != 4 {
+		t.Fatalf("prep = %+v", prep)
+	}
+	notes, room, agenda, deep := prep.Children[0], prep.Children[1], prep.Children[2], prep.Children[3]
+	if !notes.Checkbox || notes.Checked || notes.Title != "Write notes" || strings.Join(notes.Tags, ",") != "docs" {
+		t.Fatalf("notes = %+v", notes)
+	}
+	if !room.Checkbox || !room.Checked || room.Description != "Second floor." {
+		t.Fatalf("room = %+v", room)
+	}
+	if agenda.Checkbox || len(agenda.Children) != 1 {
+		t.Fatalf("agenda = %+v", agenda)
+	}
+	topics := agenda.Children[0]
+	if topics.Keyword != "DONE" || topics.Title != "Collect topics" || len(topics.Children) != 1 || topics.Children[0].Title != "Nested numbered" {
+		t.Fatalf("topics = %+v", topics)
+	}
+	if deep.Title != "Deep heading" || !strings.Contains(deep.Description, "# not a heading") || len(deep.Children) != 0 {
+		t.Fatalf("deep = %+v", deep)
+	}
+
+	if retro := doc.Items[1]; retro.Title != "Retro" || retro.Description != "Closing paragraph." {
+		t.Fatalf("retro = %+v", retro)
+	}
+}
+
+func TestParseMarkdown_KeywordsAndTagsEdgeCases(t *testing.T) {
+	doc := ParseMarkdown("- Todo lowercase stays\n- Fix #123 crash\n- #only\n- TODO", Options{Keywords: []string{"TODO"}})
+	want := []string{"Todo lowercase stays", "Fix #123 crash", "#only", "TODO"}
+	for i, n := range doc.Items {
+		if n.Title != want[i] || n.Keyword != "" || len(n.Tags) != 0 {
+			t.Fatalf("item %d = %+v, want title %q", i, n, want[i])
+		}
+	}
+}
@@ -0,0 +1,247 @@
+package importer
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"clarity-cli/internal/model"
+	"clarity-cli/internal/repeat"
+)
+
+var (
+	orgHeadlineRe = regexp.MustCompile(`^(\*+)[ \t]+(.*\S)[ \t]*$`)
+	orgPriorityRe = regexp.MustCompile(`^\[#([A-Za-z0-9])\][ \t]*`)
+	orgTagsRe     = regexp.MustCompile(`[ \t]+:([^\s:]+(?::[^\s:]+)*):$`)
+	orgPlanningRe = regexp.MustCompile(`(SCHEDULED|DEADLINE|CLOSED):[ \t]*([<\[][^>\]]*[>\]])`)
+	orgDrawerRe   = regexp.MustCompile(`^[ \t]*:([A-Za-z_]+):[ \t]*$`)
+	orgTodoLineRe = regexp.MustCompile(`(?i)^#\+(?:SEQ_|TYP_)?TODO:(.*)$`)
+	orgTimeRe     = regexp.MustCompile(`^([0-9]{1,2}):([0-9]{2})`)
+	orgRepeatRe   = regexp.MustCompile(`^(\.\+|\+\+|\+)[0-9]+[a-z]$`)
+	orgEscapedRe  = regexp.MustCompile(`^,+(?:\*|#\+|:[A-Za-z_]+:$|(?:SCHEDULED|DEADLINE|CLOSED):)`)
+)
+
+// ParseOrg parses Org headlines into an item tree.
+//
+// Rules:
+//   - headline depth ("*", "**", ...) is the hierarchy
+//   - a leading TODO keyword (from opts.Keywords or the file's "#+TODO:" lines) is stripped
+//   - "[#A]" sets Priority (other priorities are dropped), trailing ":tag1:tag2:" become tags
+//   - SCHEDULED:/DEADLINE: timestamps (with an optional repeater) set Schedule/Due
+//   - property/logbook drawers are skipped; the remaining text is the description
+//   - a "," escaping a body line that starts with "*", "#+", a drawer or a planning keyword is
+//     removed (see RenderOutlineOrg)
+func ParseOrg(src string, opts Options) Document {
+	keywords := map[string]bool{}
+	for _, k := range opts.Keywords {
+		if k = strings.TrimSpace(k); k != "" {
+			keywords[k] = true
+		}
+	}
+
+	var doc Document
+	var preamble []string
+	var stack []headingEntry
+	var cur *Node
+	inDrawer := false
+	sawBody := false
+
+	lines := strings.Split(strings.ReplaceAll(src, "\r\n", "\n"), "\n")
+	for i, raw := range lines {
+		line := strings.TrimRight(expandTabs(raw), " ")
+
+		if m := orgHeadlineRe.FindStringSubmatch(line); m != nil {
+			level := len(m[1])
+			for len(stack) > 0 && stack[len(stack)-1].level >= level {
+				stack = stack[:len(stack)-1]
+			}
+			n := newOrgNode(m[2], i+1, keywords, doc.DeclaredKeywords)
+			if len(stack) > 0 {
+				parent := stack[len(stack)-1].node
+				parent.Children = append(parent.Children, n)
+			} else {
+				doc.Items = append(doc.Items, n)
+			}
+			stack = append(stack, headingEntry{level: level, node: n})
+			cur = n
+			inDrawer = false
+			sawBody = false
+			continue
+		}
+
+		if cur == nil {
+			// File-level settings ("#+TITLE:", "#+TODO:", ...) are not content.
+			if m := orgTodoLineRe.FindStringSubmatch(line); m != nil {
+				if doc.DeclaredKeywords == nil {
+					doc.DeclaredKeywords = map[string]bool{}
+				}
+				for k, done := range parseOrgTodoLine(m[1]) {
+					doc.DeclaredKeywords[k] = done
+				}
+				continue
+			}
+			if strings.HasPrefix(line, "#+") {
+				continue
+			}
+			preamble = append(preamble, unescapeOrgLine(line))
+			continue
+		}
+
+		if inDrawer {
+			if strings.EqualFold(strings.TrimSpace(line), ":END:") {
+				inDrawer = false
+			}
+			continue
+		}
+		if !sawBody {
+			if orgDrawerRe.MatchString(line) && !strings.EqualFold(strings.TrimSpace(line), ":END:") {
+				inDrawer = true
+				continue
+			}
+			if ms := orgPlanningRe.FindAllStringSubmatch(line, -1); ms != nil && strings.TrimSpace(orgPlanningRe.ReplaceAllString(line, "")) == "" {
+				for _, pm := range ms {
+					dt, err := parseOrgTimestamp(pm[2])
+					if err != nil {
+						continue
+					}
+					switch pm[1] {
+					case "SCHEDULED":
+						cur.Schedule = dt
+					case "DEADLINE":
+						cur.Due = dt
+					}
+				}
+				continue
+			}
+		}
+		if strings.TrimSpace(line) != "" {
+			sawBody = true
+		}
+		cur.body = append(cur.body, unescapeOrgLine(line))
+	}
+
+	var finish func(ns []*Node)
+	finish = func(ns []*Node) {
+		for _, n := range ns {
+			n.Description = joinBody(dedentCommon(n.body))
+			n.body = nil
+			finish(n.Children)
+		}
+	}
+	finish(doc.Items)
+	doc.Preamble = joinBody(preamble)
+	return doc
+}
+
+func newOrgNode(text string, line int, keywords map[string]bool, declared map[string]bool) *Node {
+	n := &Node{Line: line}
+	text = strings.TrimSpace(text)
+	if m := orgTagsRe.FindStringSubmatch(text); m != nil {
+		n.Tags = strings.Split(m[1], ":")
+		text = strings.TrimSpace(text[:len(text)-len(m[0])])
+	}
+	if first, rest, _ := strings.Cut(text, " "); keywords[first] || hasKey(declared, first) {
+		n.Keyword = first
+		text = strings.TrimSpace(rest)
+	}
+	if m := orgPriorityRe.FindStringSubmatch(text); m != nil {
+		n.Priority = strings.EqualFold(m[1], "A")
+		text = strings.TrimSpace(text[len(m[0]):])
+	}
+	n.Title = text
+	if n.Title == "" {
+		n.Title = "(untitled)"
+	}
+	return n
+}
+
+func hasKey(m map[string]bool, k string) bool {
+	_, ok := m[k]
+	return ok
+}
+
+// parseOrgTodoLine parses the value of a "#+TODO:" line ("TODO WAIT(w@) | DONE(d!) CANCELED").
+// Keywords after "|" are done states; without "|" the last keyword is the done state.
+func parseOrgTodoLine(v string) map[string]bool {
+	out := map[string]bool{}
+	var words []string
+	done := false
+	sawBar := false
+	for _, f := range strings.Fields(v) {
+		if f == "|" {
+			done = true
+			sawBar = true
+			continue
+		}
+		if i := strings.IndexByte(f, '('); i > 0 {
+			f = f[:i]
+		}
+		out[f] = done
+		words = append(words, f)
+	}
+	if !sawBar && len(words) > 0 {
+		out[words[len(words)-1]] = true
+	}
+	return out
+}
+
+// parseOrgTimestamp parses "<2026-01-05 Mon 09:00 +1w>" (active or inactive).
+func parseOrgTimestamp(ts string) (*model.DateTime, error) {
+	inner := strings.TrimSpace(ts[1 : len(ts)-1])
+	fields := strings.Fields(inner)
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("empty timestamp")
+	}
+	if _, err := time.Parse("2006-01-02", fields[0]); err != nil {
+		return nil, fmt.Errorf("invalid timestamp date %q", fields[0])
+	}
+	dt := &model.DateTime{Date: fields[0]}
+	for _, f := range fields[1:] {
+		if m := orgTimeRe.FindStringSubmatch(f); m != nil {
+			t := m[1] + ":" + m[2]
+			if len(m[1]) == 1 {
+				t = "0" + t
+			}
+			dt.Time = &t
+			continue
+		}
+		if orgRepeatRe.MatchString(f) {
+			// Units Clarity can't represent (e.g. hours) are dropped rather than failing the import.
+			if r, err := repeat.Normalize(f); err == nil {
+				dt.Repeat = r
+			}
+		}
+	}
+	return dt, nil
+}
+
+func unescapeOrgLine(line string) string {
+	trimmed := strings.TrimLeft(line, " ")
+	if orgEscapedRe.MatchString(trimmed) {
+		return line[:len(line)-len(trimmed)] + trimmed[1:]
+	}
+	return line
+}
+
+// dedentCommon removes the indentation shared by all non-blank lines (org-adapt-indentation).
+func dedentCommon(lines []string) []string {
+	min := -1
+	for _, l := range lines {
+		if strings.TrimSpace(l) == "" {
+			continue
+		}
+		n := len(l) - len(strings.TrimLeft(l, " "))
+		if min < 0 || n < min {
+			min = n
+		}
+	}
+	if min <= 0 {
+		return lines
+	}
+	out := make([]string, len(lines))
+	for i, l := range lines {
+		out[i] = dedent(l, min)
+	}
+	return out
+}
@@ -0,0 +1,64 @@
+package importer
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseOrg_HeadlinesPlanningAndDrawers(t *testing.T) {
+	src := strings.TrimSpace(`
+#+TITLE: Chores
+#+TODO: TODO WAIT(w@) | DONE(d!) CANCELED
+Loose intro.
+
+* TODO [#A] Water plants                                     :home:weekly:
+  SCHEDULED: <2026-01-05 Mon 9:00 +1w> DEADLINE: <2026-01-09 Fri>
+  :PROPERTIES:
+  :ID: abc
+  :END:
+  Use the green can.
+  ,* not a headline
+** WAIT Buy soil
+*** CANCELED [#C] Old idea
+* Notes
+Body with SCHEDULED: <2026-02-01> mid-text stays.
+`)
+
+	doc := ParseOrg(src, Options{Keywords: []string{"TODO", "DONE"}})
+	if doc.Preamble != "Loose intro." {
+		t.Fatalf("preamble = %q", doc.Preamble)
+	}
+	if !doc.DeclaredKeywords["CANCELED"] || doc.DeclaredKeywords["WAIT"] {
+		t.Fatalf("declared keywords = %#v", doc.DeclaredKeywords)
+	}
+	if got := doc.Count(); got != 4 {
+		t.Fatalf("count = %d, want 4", got)
+	}
+
+	water := doc.Items[0]
+	if water.Keyword != "TODO" || !water.Priority || water.Title != "Water plants" || strings.Join(water.Tags, ",") != "home,weekly" {
+		t.Fatalf("water = %+v", water)
+	}
+	if water.Schedule == nil || water.Schedule.Date != "2026-01-05" || water.Schedule.Time == nil || *water.Schedule.Time != "09:00" || water.Schedule.Repeat != "+1w" {
+		t.Fatalf("schedule = %#v", water.Schedule)
+	}
+	if water.Due == nil || water.Due.Date != "2026-01-09" || water.Due.Time != nil {
+		t.Fatalf("due = %#v", water.Due)
+	}
+	if water.Description != "Use the green can.\n* not a headline" {
+		t.Fatalf("description = %q", water.Description)
+	}
+
+	soil := water.Children[0]
+	if soil.Keyword != "WAIT" || soil.Title != "Buy soil" {
+		t.Fatalf("soil = %+v", soil)
+	}
+	if old := soil.Children[0]; old.Keyword != "CANCELED" || old.Priority || old.Title != "Old idea" {
+		t.Fatalf("old = %+v", old)
+	}
+
+	notes := doc.Items[1]
+	if notes.Keyword != "" || notes.Schedule != nil || !strings.Contains(notes.Description, "SCHEDULED: <2026-02-01>") {
+		t.Fatalf("notes = %+v", notes)
+	}
+}
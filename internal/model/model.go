@@ -14,6 +14,14 @@ type Actor struct {
 	Kind   ActorKind `json:"kind"`
 	Name   string    `json:"name"`
 	UserID *string   `json:"userId,omitempty"`
+	// Keys are the actor's Ed25519 public keys (identity.key_add), oldest first.
+	Keys []ActorKey `json:"keys,omitempty"`
+}
+
+type ActorKey struct {
+	ID        string `json:"id"`
+	PublicKey string `json:"publicKey"` // base64
+	AddedBy   string `json:"addedBy"`
 }
 
 type Project struct {
@@ -95,6 +103,10 @@ type Item struct {
 type DateTime struct {
 	Date string  `json:"date"`           // YYYY-MM-DD
 	Time *string `json:"time,omitempty"` // HH:MM
+
+	// Repeat is an optional Org-style repeater ("+1w", "++1d", ".+2w"; see internal/repeat).
+	// Completing the item rolls the date forward instead of leaving it done.
+	Repeat string `json:"repeat,omitempty"`
 }
 
 type DependencyType string
@@ -125,9 +137,25 @@ type Comment struct {
 	Body             string    `json:"body"`
 	CreatedAt        time.Time `json:"createdAt"`
 
+	// EditedAt is set by comment.edit / comment.redact (the body shown is always the latest).
+	EditedAt *time.Time `json:"editedAt,omitempty"`
+	// Redacted is set by comment.redact; Body is replaced with RedactedCommentBody.
+	Redacted bool `json:"redacted,omitempty"`
+	// Revisions holds prior bodies, oldest first (cleared of content by redaction).
+	Revisions []CommentRevision `json:"revisions,omitempty"`
+
 	LegacyTaskID string `json:"taskId,omitempty"`
 }
 
+// RedactedCommentBody replaces the body (and prior revisions) of a redacted comment.
+const RedactedCommentBody = "[redacted]"
+
+type CommentRevision struct {
+	Body string `json:"body"`
+	// At is when this body was written (comment creation or a previous edit).
+	At time.Time `json:"at"`
+}
+
 type WorklogEntry struct {
 	ID        string    `json:"id"`
 	ItemID    string    `json:"itemId"`
@@ -158,11 +186,29 @@ type Attachment struct {
 	// Relative path from workspace root to the stored file (git-trackable).
 	Path string `json:"path"`
 
+	// Extracted text (text-like files, archive listings), capped; indexed by search.
+	Text string `json:"text,omitempty"`
+
 	CreatedBy string    `json:"createdBy"`
 	CreatedAt time.Time `json:"createdAt"`
 	UpdatedAt time.Time `json:"updatedAt"`
 }
 
+const (
+	FollowTargetOutline = "outline"
+	FollowTargetItem    = "item"
+)
+
+// Follow records that an actor follows an outline (all of its items) or a single item.
+// Follows drive the derived notifications feed.
+type Follow struct {
+	ID         string    `json:"id"`
+	ActorID    string    `json:"actorId"`
+	TargetKind string    `json:"targetKind"` // "outline"|"item"
+	TargetID   string    `json:"targetId"`
+	CreatedAt  time.Time `json:"createdAt"`
+}
+
 type Event struct {
 	ID       string    `json:"id"`
 	TS       time.Time `json:"ts"`
@@ -170,4 +216,7 @@ type Event struct {
 	Type     string    `json:"type"`
 	EntityID string    `json:"entityId"`
 	Payload  any       `json:"payload"`
+	// Signature is the outcome of checking the event's signature: "verified", "unsigned" (its
+	// actor had no key yet), "missing" or "invalid". Empty when not checked (SQLite event log).
+	Signature string `json:"signature,omitempty"`
 }
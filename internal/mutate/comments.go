@@ -0,0 +1,91 @@
+package mutate
+
+import (
+        "errors"
+        "strings"
+        "time"
+
+        "clarity-cli/internal/model"
+        "clarity-cli/internal/perm"
+        "clarity-cli/internal/store"
+)
+
+type CommentResult struct {
+        Comment      *model.Comment
+        Changed      bool
+        EventPayload map[string]any
+}
+
+// EditComment replaces a comment's body (author-only). The previous body is kept as a revision.
+// Callers are responsible for saving db and appending the comment.edit event.
+func EditComment(db *store.DB, actorID, commentID, body string) (CommentResult, error) {
+        c, err := authoredComment(db, actorID, commentID)
+        if err != nil || c == nil {
+                return CommentResult{}, err
+        }
+        if c.Redacted {
+                return CommentResult{}, errors.New("comment is redacted")
+        }
+        body = strings.TrimSpace(body)
+        if body == "" {
+                return CommentResult{}, errors.New("comment body is empty")
+        }
+        now := time.Now().UTC()
+        if !store.ApplyCommentEdit(c, body, now) {
+                return CommentResult{Comment: c, Changed: false}, nil
+        }
+        return CommentResult{
+                Comment: c,
+                Changed: true,
+                EventPayload: map[string]any{
+                        "id":       c.ID,
+                        "itemId":   c.ItemID,
+                        "body":     c.Body,
+                        "editedAt": now,
+                },
+        }, nil
+}
+
+// RedactComment replaces a comment's body and prior revisions with model.RedactedCommentBody
+// (author-only). Callers are responsible for saving db and appending the comment.redact event.
+func RedactComment(db *store.DB, actorID, commentID string) (CommentResult, error) {
+        c, err := authoredComment(db, actorID, commentID)
+        if err != nil || c == nil {
+                return CommentResult{}, err
+        }
+        now := time.Now().UTC()
+        if !store.ApplyCommentRedact(c, now) {
+                return CommentResult{Comment: c, Changed: false}, nil
+        }
+        return CommentResult{
+                Comment: c,
+                Changed: true,
+                EventPayload: map[string]any{
+                        "id":         c.ID,
+                        "itemId":     c.ItemID,
+                        "redactedAt": now,
+                },
+        }, nil
+}
+
+func authoredComment(db *store.DB, actorID, commentID string) (*model.Comment, error) {
+        commentID = strings.TrimSpace(commentID)
+        actorID = strings.TrimSpace(actorID)
+        if db == nil || commentID == "" || actorID == "" {
+                return nil, nil
+        }
+        for i := range db.Comments {
+                c := &db.Comments[i]
+                if strings.TrimSpace(c.ID) != commentID {
+                        continue
+                }
+                if strings.TrimSpace(c.AuthorID) != actorID {
+                        return nil, AuthorOnlyError{ActorID: actorID, AuthorID: c.AuthorID, CommentID: commentID}
+                }
+                if it, ok := db.FindItem(c.ItemID); ok && !perm.CanContribute(db, actorID, it.ProjectID, it.OutlineID) {
+                        return nil, RoleRequiredError{ActorID: actorID, Role: store.RoleContributor, ProjectID: it.ProjectID, OutlineID: it.OutlineID}
+                }
+                return c, nil
+        }
+        return nil, NotFoundError{Kind: "comment", ID: commentID}
+}
@@ -0,0 +1,81 @@
+package mutate
+
+import (
+        "testing"
+        "time"
+
+        "clarity-cli/internal/model"
+        "clarity-cli/internal/store"
+)
+
+func TestEditComment(t *testing.T) {
+        db := &store.DB{
+                Comments: []model.Comment{
+                        {ID: "cmt-1", ItemID: "item-1", AuthorID: "act-author", Body: "first", CreatedAt: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)},
+                },
+        }
+
+        if _, err := EditComment(db, "act-other", "cmt-1", "nope"); err == nil {
+                t.Fatalf("expected author-only error")
+        }
+        if _, err := EditComment(db, "act-author", "cmt-missing", "x"); err == nil {
+                t.Fatalf("expected not found error")
+        }
+        if _, err := EditComment(db, "act-author", "cmt-1", "  "); err == nil {
+                t.Fatalf("expected empty body error")
+        }
+
+        res, err := EditComment(db, "act-author", "cmt-1", "second")
+        if err != nil {
+                t.Fatalf("EditComment error: %v", err)
+        }
+        if !res.Changed || res.Comment.Body != "second" || res.Comment.EditedAt == nil {
+                t.Fatalf("unexpected result: %+v", res.Comment)
+        }
+        if len(res.Comment.Revisions) != 1 || res.Comment.Revisions[0].Body != "first" {
+                t.Fatalf("expected prior body kept as a revision, got %+v", res.Comment.Revisions)
+        }
+        if res.EventPayload["body"] != "second" {
+                t.Fatalf("unexpected payload: %+v", res.EventPayload)
+        }
+
+        // No-op
+        res2, err := EditComment(db, "act-author", "cmt-1", "second")
+        if err != nil {
+                t.Fatalf("EditComment no-op error: %v", err)
+        }
+        if res2.Changed {
+                t.Fatalf("expected changed=false")
+        }
+}
+
+func TestRedactComment(t *testing.T) {
+        db := &store.DB{
+                Comments: []model.Comment{
+                        {ID: "cmt-1", ItemID: "item-1", AuthorID: "act-author", Body: "first", CreatedAt: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)},
+                },
+        }
+        if _, err := EditComment(db, "act-author", "cmt-1", "secret=123"); err != nil {
+                t.Fatalf("EditComment error: %v", err)
+        }
+
+        if _, err := RedactComment(db, "act-other", "cmt-1"); err == nil {
+                t.Fatalf("expected author-only error")
+        }
+        res, err := RedactComment(db, "act-author", "cmt-1")
+        if err != nil {
+                t.Fatalf("RedactComment error: %v", err)
+        }
+        c := res.Comment
+        if !res.Changed || !c.Redacted || c.Body != model.RedactedCommentBody {
+                t.Fatalf("unexpected redacted comment: %+v", c)
+        }
+        for _, r := range c.Revisions {
+                if r.Body != model.RedactedCommentBody {
+                        t.Fatalf("expected revisions to be redacted, got %+v", c.Revisions)
+                }
+        }
+        if _, err := EditComment(db, "act-author", "cmt-1", "again"); err == nil {
+                t.Fatalf("expected editing a redacted comment to fail")
+        }
+}
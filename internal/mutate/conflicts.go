@@ -0,0 +1,75 @@
+package mutate
+
+import (
+        "fmt"
+        "strings"
+        "time"
+
+        "clarity-cli/internal/perm"
+        "clarity-cli/internal/store"
+)
+
+type ResolveConflictResult struct {
+        Conflict     store.Conflict
+        Side         store.ConflictSide
+        EventType    string
+        EventPayload map[string]any
+}
+
+// ResolveConflict applies the value of the side written by takeEventID to db. It enforces
+// permissions via internal/perm. Callers are responsible for saving db and appending the event:
+// its parents (the entity's heads) descend from every side, which resolves the conflict.
+func ResolveConflict(db *store.DB, actorID string, c store.Conflict, takeEventID string, now time.Time) (ResolveConflictResult, error) {
+        actorID = strings.TrimSpace(actorID)
+        takeEventID = strings.TrimSpace(takeEventID)
+        if db == nil || actorID == "" {
+                return ResolveConflictResult{}, nil
+        }
+
+        var side *store.ConflictSide
+        resolves := make([]string, 0, len(c.Sides))
+        for i := range c.Sides {
+                resolves = append(resolves, c.Sides[i].EventID)
+                if c.Sides[i].EventID == takeEventID {
+                        side = &c.Sides[i]
+                }
+        }
+        if side == nil {
+                return ResolveConflictResult{}, fmt.Errorf("event %s is not a side of the conflict on %s", takeEventID, c.EntityID)
+        }
+        typ := store.ConflictEventType(c.EntityKind, c.Field)
+        if typ == "" {
+                return ResolveConflictResult{}, fmt.Errorf("cannot resolve %s conflicts on %s", c.Field, c.EntityKind)
+        }
+
+        switch c.EntityKind {
+        case "item":
+                it, ok := db.FindItem(c.EntityID)
+                if !ok {
+                        return ResolveConflictResult{}, NotFoundError{Kind: "item", ID: c.EntityID}
+                }
+                if !perm.CanEditItem(db, actorID, it) {
+                        return ResolveConflictResult{}, OwnerOnlyError{ActorID: actorID, OwnerActorID: it.OwnerActorID, ItemID: it.ID}
+                }
+                it.Description = side.Value
+                it.UpdatedAt = now.UTC()
+        case "outline":
+                o, ok := db.FindOutline(c.EntityID)
+                if !ok {
+                        return ResolveConflictResult{}, NotFoundError{Kind: "outline", ID: c.EntityID}
+                }
+                if !perm.CanMaintain(db, actorID, o.ProjectID, o.ID) {
+                        return ResolveConflictResult{}, RoleRequiredError{ActorID: actorID, Role: store.RoleMaintainer, ProjectID: o.ProjectID, OutlineID: o.ID}
+                }
+                o.Description = strings.TrimSpace(side.Value)
+        }
+        return ResolveConflictResult{
+                Conflict:  c,
+                Side:      *side,
+                EventType: typ,
+                EventPayload: map[string]any{
+                        "description": side.Value,
+                        "resolves":    resolves,
+                },
+        }, nil
+}
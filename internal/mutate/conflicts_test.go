@@ -0,0 +1,71 @@
+package mutate
+
+import (
+        "testing"
+        "time"
+
+        "clarity-cli/internal/model"
+        "clarity-cli/internal/store"
+)
+
+func TestResolveConflict(t *testing.T) {
+        db := &store.DB{
+                Actors: []model.Actor{
+                        {ID: "act-owner", Kind: model.ActorKindHuman, Name: "Owner", UserID: strPtr("act-owner")},
+                        {ID: "act-other", Kind: model.ActorKindHuman, Name: "Other", UserID: strPtr("act-other")},
+                },
+                Items: []model.Item{
+                        {ID: "item-1", OwnerActorID: "act-owner", Description: "from B"},
+                },
+        }
+        c := store.Conflict{
+                EntityKind: "item",
+                EntityID:   "item-1",
+                Field:      "description",
+                Current:    "from B",
+                Sides: []store.ConflictSide{
+                        {EventID: "evt-a", Value: "from A"},
+                        {EventID: "evt-b", Value: "from B"},
+                },
+        }
+
+        if _, err := ResolveConflict(db, "act-other", c, "evt-a", time.Now()); err == nil {
+                t.Fatalf("expected owner-only error")
+        }
+        if _, err := ResolveConflict(db, "act-owner", c, "evt-missing", time.Now()); err == nil {
+                t.Fatalf("expected error for unknown side")
+        }
+
+        res, err := ResolveConflict(db, "act-owner", c, "evt-a", time.Now())
+        if err != nil {
+                t.Fatalf("ResolveConflict error: %v", err)
+        }
+        if res.EventType != "item.set_description" || res.EventPayload["description"] != "from A" {
+                t.Fatalf("unexpected result: %+v", res)
+        }
+        if got := res.EventPayload["resolves"].([]string); len(got) != 2 {
+                t.Fatalf("expected both sides in resolves, got %v", got)
+        }
+        if it, _ := db.FindItem("item-1"); it.Description != "from A" {
+                t.Fatalf("description = %q", it.Description)
+        }
+
+        // Outline descriptions need the maintainer role where roles apply.
+        db.Outlines = []model.Outline{{ID: "out-1", ProjectID: "proj", Description: "from B"}}
+        db.SetAccessRules(store.NewAccessRules(store.UsersFile{Roles: []store.RoleGrant{
+                {ActorID: "act-owner", Role: store.RoleMaintainer, ProjectID: "proj"},
+                {ActorID: "act-other", Role: store.RoleContributor, ProjectID: "proj"},
+        }}))
+        oc := store.Conflict{EntityKind: "outline", EntityID: "out-1", Field: "description", Current: "from B", Sides: c.Sides}
+        if _, err := ResolveConflict(db, "act-other", oc, "evt-a", time.Now()); err == nil {
+                t.Fatalf("expected role-required error")
+        } else if e, ok := err.(RoleRequiredError); !ok || e.Role != store.RoleMaintainer || e.OutlineID != "out-1" {
+                t.Fatalf("expected RoleRequiredError for out-1, got %v", err)
+        }
+        if _, err := ResolveConflict(db, "act-owner", oc, "evt-a", time.Now()); err != nil {
+                t.Fatalf("ResolveConflict (maintainer): %v", err)
+        }
+        if o, _ := db.FindOutline("out-1"); o.Description != "from A" {
+                t.Fatalf("outline description = %q", o.Description)
+        }
+}
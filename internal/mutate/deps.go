@@ -0,0 +1,103 @@
+package mutate
+
+import (
+        "fmt"
+        "strings"
+
+        "clarity-cli/internal/model"
+        "clarity-cli/internal/perm"
+        "clarity-cli/internal/store"
+)
+
+type DepResult struct {
+        Dep          model.Dependency
+        Changed      bool
+        EventPayload map[string]any
+}
+
+// RemoveDependency deletes a dependency edge. Permissions follow the dependent (from) item,
+// matching `deps add`. Callers are responsible for saving db and appending the dep.remove event.
+func RemoveDependency(db *store.DB, actorID, depID string) (DepResult, error) {
+        depID = strings.TrimSpace(depID)
+        actorID = strings.TrimSpace(actorID)
+        if db == nil || depID == "" || actorID == "" {
+                return DepResult{}, nil
+        }
+
+        idx, err := editableDepIndex(db, actorID, depID)
+        if err != nil {
+                return DepResult{}, err
+        }
+        d := db.Deps[idx]
+        db.Deps = append(db.Deps[:idx], db.Deps[idx+1:]...)
+        return DepResult{
+                Dep:     d,
+                Changed: true,
+                EventPayload: map[string]any{
+                        "id":         d.ID,
+                        "fromItemId": d.FromItemID,
+                        "toItemId":   d.ToItemID,
+                        "type":       string(d.Type),
+                },
+        }, nil
+}
+
+// SetDependencyType changes a dependency edge's type (blocks|related).
+// Callers are responsible for saving db and appending the dep.update event.
+func SetDependencyType(db *store.DB, actorID, depID string, typ model.DependencyType) (DepResult, error) {
+        depID = strings.TrimSpace(depID)
+        actorID = strings.TrimSpace(actorID)
+        if db == nil || depID == "" || actorID == "" {
+                return DepResult{}, nil
+        }
+        switch typ {
+        case model.DependencyBlocks, model.DependencyRelated:
+        default:
+                return DepResult{}, fmt.Errorf("invalid dependency type: %q (expected blocks|related)", string(typ))
+        }
+
+        idx, err := editableDepIndex(db, actorID, depID)
+        if err != nil {
+                return DepResult{}, err
+        }
+        d := &db.Deps[idx]
+        if d.Type == typ {
+                return DepResult{Dep: *d, Changed: false}, nil
+        }
+        prev := d.Type
+        d.Type = typ
+        return DepResult{
+                Dep:     *d,
+                Changed: true,
+                EventPayload: map[string]any{
+                        "id":         d.ID,
+                        "fromItemId": d.FromItemID,
+                        "toItemId":   d.ToItemID,
+                        "type":       string(d.Type),
+                        "from":       string(prev),
+                },
+        }, nil
+}
+
+func editableDepIndex(db *store.DB, actorID, depID string) (int, error) {
+        idx := -1
+        for i := range db.Deps {
+                if strings.TrimSpace(db.Deps[i].ID) == depID {
+                        idx = i
+                        break
+                }
+        }
+        if idx < 0 {
+                return -1, NotFoundError{Kind: "dep", ID: depID}
+        }
+        fromID := strings.TrimSpace(db.Deps[idx].FromItemID)
+        it, ok := db.FindItem(fromID)
+        if !ok {
+                // Dangling edge: there is no owner to check against, so anyone may clean it up.
+                return idx, nil
+        }
+        if !perm.CanEditItem(db, actorID, it) {
+                return -1, OwnerOnlyError{ActorID: actorID, OwnerActorID: it.OwnerActorID, ItemID: fromID}
+        }
+        return idx, nil
+}
@@ -0,0 +1,90 @@
+package mutate
+
+import (
+        "testing"
+
+        "clarity-cli/internal/model"
+        "clarity-cli/internal/store"
+)
+
+func TestRemoveDependency(t *testing.T) {
+        db := &store.DB{
+                Actors: []model.Actor{
+                        {ID: "act-owner", Kind: model.ActorKindHuman, Name: "Owner", UserID: strPtr("act-owner")},
+                        {ID: "act-other", Kind: model.ActorKindHuman, Name: "Other", UserID: strPtr("act-other")},
+                },
+                Items: []model.Item{
+                        {ID: "item-a", OwnerActorID: "act-owner"},
+                        {ID: "item-b", OwnerActorID: "act-other"},
+                },
+                Deps: []model.Dependency{
+                        {ID: "dep-1", FromItemID: "item-a", ToItemID: "item-b", Type: model.DependencyBlocks},
+                        {ID: "dep-2", FromItemID: "item-b", ToItemID: "item-a", Type: model.DependencyRelated},
+                },
+        }
+
+        if _, err := RemoveDependency(db, "act-other", "dep-1"); err == nil {
+                t.Fatalf("expected owner-only error")
+        }
+        if _, err := RemoveDependency(db, "act-owner", "dep-missing"); err == nil {
+                t.Fatalf("expected not found error")
+        }
+
+        res, err := RemoveDependency(db, "act-owner", "dep-1")
+        if err != nil {
+                t.Fatalf("RemoveDependency error: %v", err)
+        }
+        if !res.Changed || res.Dep.ID != "dep-1" {
+                t.Fatalf("unexpected result: %+v", res)
+        }
+        if res.EventPayload["fromItemId"] != "item-a" || res.EventPayload["toItemId"] != "item-b" {
+                t.Fatalf("unexpected payload: %+v", res.EventPayload)
+        }
+        if len(db.Deps) != 1 || db.Deps[0].ID != "dep-2" {
+                t.Fatalf("expected only dep-2 to remain, got %+v", db.Deps)
+        }
+}
+
+func TestSetDependencyType(t *testing.T) {
+        db := &store.DB{
+                Actors: []model.Actor{
+                        {ID: "act-owner", Kind: model.ActorKindHuman, Name: "Owner", UserID: strPtr("act-owner")},
+                        {ID: "act-other", Kind: model.ActorKindHuman, Name: "Other", UserID: strPtr("act-other")},
+                },
+                Items: []model.Item{
+                        {ID: "item-a", OwnerActorID: "act-owner"},
+                        {ID: "item-b", OwnerActorID: "act-other"},
+                },
+                Deps: []model.Dependency{
+                        {ID: "dep-1", FromItemID: "item-a", ToItemID: "item-b", Type: model.DependencyBlocks},
+                        {ID: "dep-2", FromItemID: "item-b", ToItemID: "item-a", Type: model.DependencyRelated},
+                },
+        }
+
+        if _, err := SetDependencyType(db, "act-owner", "dep-1", model.DependencyType("nope")); err == nil {
+                t.Fatalf("expected invalid type error")
+        }
+        if _, err := SetDependencyType(db, "act-owner", "dep-2", model.DependencyBlocks); err == nil {
+                t.Fatalf("expected owner-only error")
+        }
+
+        res, err := SetDependencyType(db, "act-owner", "dep-1", model.DependencyRelated)
+        if err != nil {
+                t.Fatalf("SetDependencyType error: %v", err)
+        }
+        if !res.Changed || db.Deps[0].Type != model.DependencyRelated {
+                t.Fatalf("expected type=related, got %+v", db.Deps[0])
+        }
+        if res.EventPayload["from"] != "blocks" || res.EventPayload["type"] != "related" {
+                t.Fatalf("unexpected payload: %+v", res.EventPayload)
+        }
+
+        // No-op
+        res2, err := SetDependencyType(db, "act-owner", "dep-1", model.DependencyRelated)
+        if err != nil {
+                t.Fatalf("SetDependencyType no-op error: %v", err)
+        }
+        if res2.Changed {
+                t.Fatalf("expected changed=false")
+        }
+}
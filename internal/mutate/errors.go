@@ -21,3 +21,26 @@ func (e OwnerOnlyError) Error() string {
         // Keep this generic; CLI/TUI can wrap with more specific phrasing.
         return "owner-only"
 }
+
+type AuthorOnlyError struct {
+        ActorID   string
+        AuthorID  string
+        CommentID string
+}
+
+func (e AuthorOnlyError) Error() string {
+        return "author-only"
+}
+
+// RoleRequiredError is returned when meta/users.json gives the actor less than Role on the item's
+// outline (OutlineID) or project.
+type RoleRequiredError struct {
+        ActorID   string
+        Role      string
+        ProjectID string
+        OutlineID string
+}
+
+func (e RoleRequiredError) Error() string {
+        return "role-required"
+}
@@ -0,0 +1,103 @@
+package mutate
+
+import (
+        "strings"
+        "time"
+
+        "clarity-cli/internal/model"
+        "clarity-cli/internal/store"
+)
+
+type FollowResult struct {
+        Follow       model.Follow
+        Changed      bool
+        EventPayload map[string]any
+}
+
+// ResolveFollowTarget maps an outline or item id to its follow target kind.
+func ResolveFollowTarget(db *store.DB, targetID string) (string, error) {
+        targetID = strings.TrimSpace(targetID)
+        if _, ok := db.FindOutline(targetID); ok {
+                return model.FollowTargetOutline, nil
+        }
+        if _, ok := db.FindItem(targetID); ok {
+                return model.FollowTargetItem, nil
+        }
+        return "", NotFoundError{Kind: "outline or item", ID: targetID}
+}
+
+// Follow makes actorID follow an outline (all of its items) or a single item.
+// Following an already-followed target is a no-op. newID is only used when a follow is created.
+// Callers are responsible for saving db and appending the follow.add event.
+func Follow(db *store.DB, actorID, targetID, newID string, now time.Time) (FollowResult, error) {
+        actorID = strings.TrimSpace(actorID)
+        targetID = strings.TrimSpace(targetID)
+        if db == nil || actorID == "" || targetID == "" {
+                return FollowResult{}, nil
+        }
+        kind, err := ResolveFollowTarget(db, targetID)
+        if err != nil {
+                return FollowResult{}, err
+        }
+        if f := db.FindFollow(actorID, kind, targetID); f != nil {
+                return FollowResult{Follow: *f}, nil
+        }
+
+        f := model.Follow{
+                ID:         strings.TrimSpace(newID),
+                ActorID:    actorID,
+                TargetKind: kind,
+                TargetID:   targetID,
+                CreatedAt:  now.UTC(),
+        }
+        db.Follows = append(db.Follows, f)
+        return FollowResult{
+                Follow:  f,
+                Changed: true,
+                EventPayload: map[string]any{
+                        "id":         f.ID,
+                        "actorId":    f.ActorID,
+                        "targetKind": f.TargetKind,
+                        "targetId":   f.TargetID,
+                        "createdAt":  f.CreatedAt,
+                },
+        }, nil
+}
+
+// Unfollow removes actorID's follow of targetID. Unfollowing something that isn't followed is a no-op.
+// Callers are responsible for saving db and appending the follow.remove event.
+func Unfollow(db *store.DB, actorID, targetID string) (FollowResult, error) {
+        actorID = strings.TrimSpace(actorID)
+        targetID = strings.TrimSpace(targetID)
+        if db == nil || actorID == "" || targetID == "" {
+                return FollowResult{}, nil
+        }
+
+        // The target may have been deleted since it was followed, so look at existing follows first.
+        idx := -1
+        for i, f := range db.Follows {
+                if f.ActorID == actorID && f.TargetID == targetID {
+                        idx = i
+                        break
+                }
+        }
+        if idx < 0 {
+                if _, err := ResolveFollowTarget(db, targetID); err != nil {
+                        return FollowResult{}, err
+                }
+                return FollowResult{}, nil
+        }
+
+        f := db.Follows[idx]
+        db.Follows = append(db.Follows[:idx], db.Follows[idx+1:]...)
+        return FollowResult{
+                Follow:  f,
+                Changed: true,
+                EventPayload: map[string]any{
+                        "id":         f.ID,
+                        "actorId":    f.ActorID,
+                        "targetKind": f.TargetKind,
+                        "targetId":   f.TargetID,
+                },
+        }, nil
+}
@@ -0,0 +1,49 @@
+package mutate
+
+import (
+        "errors"
+        "testing"
+        "time"
+
+        "clarity-cli/internal/model"
+        "clarity-cli/internal/store"
+)
+
+func TestFollowAndUnfollow(t *testing.T) {
+        now := time.Now().UTC()
+        db := &store.DB{
+                Actors:   []model.Actor{{ID: "act-a", Kind: model.ActorKindHuman, Name: "a"}},
+                Outlines: []model.Outline{{ID: "out-a", ProjectID: "proj-a", CreatedAt: now}},
+                Items:    []model.Item{{ID: "item-a", ProjectID: "proj-a", OutlineID: "out-a", Title: "A", CreatedAt: now, UpdatedAt: now}},
+        }
+
+        res, err := Follow(db, "act-a", "out-a", "fol-1", now)
+        if err != nil {
+                t.Fatalf("follow: %v", err)
+        }
+        if !res.Changed || res.Follow.TargetKind != model.FollowTargetOutline || res.EventPayload["targetId"] != "out-a" {
+                t.Fatalf("unexpected follow result: %+v", res)
+        }
+        res, err = Follow(db, "act-a", "out-a", "fol-2", now)
+        if err != nil || res.Changed || res.Follow.ID != "fol-1" {
+                t.Fatalf("expected re-follow to be a no-op, got %+v err=%v", res, err)
+        }
+        if res, err := Follow(db, "act-a", "item-a", "fol-3", now); err != nil || res.Follow.TargetKind != model.FollowTargetItem {
+                t.Fatalf("expected item follow, got %+v err=%v", res, err)
+        }
+        if _, err := Follow(db, "act-a", "item-missing", "fol-4", now); !errors.As(err, &NotFoundError{}) {
+                t.Fatalf("expected not found, got %v", err)
+        }
+
+        res, err = Unfollow(db, "act-a", "out-a")
+        if err != nil || !res.Changed || res.EventPayload["id"] != "fol-1" {
+                t.Fatalf("unexpected unfollow result: %+v err=%v", res, err)
+        }
+        if len(db.Follows) != 1 {
+                t.Fatalf("expected 1 remaining follow, got %d", len(db.Follows))
+        }
+        res, err = Unfollow(db, "act-a", "out-a")
+        if err != nil || res.Changed {
+                t.Fatalf("expected second unfollow to be a no-op, got %+v err=%v", res, err)
+        }
+}
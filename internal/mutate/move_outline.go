@@ -52,6 +52,10 @@ func MoveItemToOutline(db *store.DB, actorID, itemID, toOutlineID, statusOverrid
 			return false, nil, errors.New("permission denied")
 		}
 	}
+	// Moving in adds to the target outline (replay requires it too).
+	if !perm.CanContribute(db, actorID, o.ProjectID, o.ID) {
+		return false, nil, RoleRequiredError{ActorID: actorID, Role: store.RoleContributor, ProjectID: o.ProjectID, OutlineID: o.ID}
+	}
 
 	changed := false
 	for _, id := range ids {
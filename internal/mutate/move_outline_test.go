@@ -0,0 +1,44 @@
+package mutate
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"clarity-cli/internal/model"
+	"clarity-cli/internal/store"
+)
+
+func TestMoveItemToOutline_RequiresContributorOnTarget(t *testing.T) {
+	now := time.Now().UTC()
+	db := &store.DB{
+		Actors: []model.Actor{{ID: "act-owner", Kind: model.ActorKindHuman, Name: "Owner"}},
+		Outlines: []model.Outline{
+			{ID: "out-a", ProjectID: "proj", StatusDefs: store.DefaultOutlineStatusDefs()},
+			{ID: "out-b", ProjectID: "proj", StatusDefs: store.DefaultOutlineStatusDefs()},
+		},
+		Items: []model.Item{{ID: "item-1", ProjectID: "proj", OutlineID: "out-a", StatusID: "todo", OwnerActorID: "act-owner", CreatedBy: "act-owner", CreatedAt: now, UpdatedAt: now}},
+	}
+	db.SetAccessRules(store.NewAccessRules(store.UsersFile{Roles: []store.RoleGrant{
+		{ActorID: "act-owner", Role: store.RoleContributor, OutlineID: "out-a"},
+		{ActorID: "act-owner", Role: store.RoleViewer, OutlineID: "out-b"},
+	}}))
+
+	// The owner may edit the item, but only view the target outline.
+	_, _, err := MoveItemToOutline(db, "act-owner", "item-1", "out-b", "", false, now)
+	var roleErr RoleRequiredError
+	if !errors.As(err, &roleErr) || roleErr.OutlineID != "out-b" || roleErr.Role != store.RoleContributor {
+		t.Fatalf("expected RoleRequiredError for out-b, got %v", err)
+	}
+	if db.Items[0].OutlineID != "out-a" {
+		t.Fatalf("expected the item to stay in out-a, got %s", db.Items[0].OutlineID)
+	}
+
+	db.SetAccessRules(store.NewAccessRules(store.UsersFile{Roles: []store.RoleGrant{
+		{ActorID: "act-owner", Role: store.RoleContributor, ProjectID: "proj"},
+	}}))
+	changed, payload, err := MoveItemToOutline(db, "act-owner", "item-1", "out-b", "", false, now)
+	if err != nil || !changed || payload["to"] != "out-b" {
+		t.Fatalf("expected the move to succeed, got changed=%v payload=%v err=%v", changed, payload, err)
+	}
+}
@@ -0,0 +1,67 @@
+package mutate
+
+import (
+        "strings"
+        "time"
+
+        "clarity-cli/internal/model"
+        "clarity-cli/internal/repeat"
+        "clarity-cli/internal/statusutil"
+)
+
+// rollForward reopens a completed item whose schedule/due carries a repeater: each repeating date
+// moves to its next occurrence and the status goes back to reopenStatus (the status before
+// completion, or the outline's first non-end status).
+// It returns the item.repeat event payload, or nil when the item doesn't repeat.
+func rollForward(outline model.Outline, it *model.Item, completedStatus, prevStatus string, now time.Time) map[string]any {
+        if it == nil || !statusutil.IsEndState(outline, completedStatus) {
+                return nil
+        }
+        nextDue, dueChanged := nextOccurrence(it.Due, now)
+        nextSchedule, scheduleChanged := nextOccurrence(it.Schedule, now)
+        if !dueChanged && !scheduleChanged {
+                return nil
+        }
+
+        reopen := strings.TrimSpace(prevStatus)
+        if reopen == "" || statusutil.IsEndState(outline, reopen) {
+                reopen = statusutil.CheckboxUncheckedStatusID(outline)
+        }
+        if reopen == "" && len(outline.StatusDefs) == 0 {
+                reopen = "todo"
+        }
+
+        payload := map[string]any{
+                "from": completedStatus,
+                "to":   reopen,
+        }
+        if dueChanged {
+                payload["previousDue"] = it.Due
+                payload["due"] = nextDue
+                it.Due = nextDue
+        }
+        if scheduleChanged {
+                payload["previousSchedule"] = it.Schedule
+                payload["schedule"] = nextSchedule
+                it.Schedule = nextSchedule
+        }
+        it.StatusID = reopen
+        return payload
+}
+
+func nextOccurrence(dt *model.DateTime, now time.Time) (*model.DateTime, bool) {
+        if dt == nil || strings.TrimSpace(dt.Repeat) == "" {
+                return nil, false
+        }
+        date, err := repeat.NextDate(dt.Date, dt.Repeat, now)
+        if err != nil {
+                return nil, false
+        }
+        next := *dt
+        next.Date = date
+        if dt.Time != nil {
+                tm := *dt.Time
+                next.Time = &tm
+        }
+        return &next, true
+}
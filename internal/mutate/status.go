@@ -3,6 +3,7 @@ package mutate
 import (
         "errors"
         "strings"
+        "time"
 
         "clarity-cli/internal/model"
         "clarity-cli/internal/perm"
@@ -17,9 +18,14 @@ type SetStatusResult struct {
         Item         *model.Item
         Changed      bool
         EventPayload map[string]any
+
+        // RepeatPayload is set when completing the item rolled a repeating schedule/due forward
+        // (the item is reopened); callers append it as an item.repeat event after item.set_status.
+        RepeatPayload map[string]any
 }
 
 // SetItemStatus sets item.StatusID, validating against the item's outline status defs (empty is allowed).
+// Completing an item with a repeater reopens it with the next dates (see RepeatPayload).
 // Callers are responsible for saving db and appending the item.set_status event.
 func SetItemStatus(db *store.DB, actorID, itemID, statusID string, note *string) (SetStatusResult, error) {
         itemID = strings.TrimSpace(itemID)
@@ -43,11 +49,13 @@ func SetItemStatus(db *store.DB, actorID, itemID, statusID string, note *string)
         }
 
         // Validate against outline status defs when present (empty allowed).
+        var outline model.Outline
         if statusID != "" {
                 o, ok := db.FindOutline(strings.TrimSpace(it.OutlineID))
                 if !ok || o == nil {
                         return SetStatusResult{}, errors.New("outline not found")
                 }
+                outline = *o
                 if !statusutil.ValidateStatusID(*o, statusID) {
                         return SetStatusResult{}, ErrInvalidStatus
                 }
@@ -66,8 +74,9 @@ func SetItemStatus(db *store.DB, actorID, itemID, statusID string, note *string)
                 payload["note"] = *note
         }
         return SetStatusResult{
-                Item:         it,
-                Changed:      true,
-                EventPayload: payload,
+                Item:          it,
+                Changed:       true,
+                EventPayload:  payload,
+                RepeatPayload: rollForward(outline, it, statusID, prev, time.Now()),
         }, nil
 }
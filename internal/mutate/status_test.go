@@ -108,3 +108,68 @@ func TestSetItemStatus_RequiresNote_WhenStatusDefRequiresNote(t *testing.T) {
                 t.Fatalf("expected payload.note to be present (empty allowed); got %#v", res.EventPayload)
         }
 }
+
+func TestSetItemStatus_RollsRepeatingItemForward(t *testing.T) {
+        tm := "09:00"
+        db := &store.DB{
+                Actors: []model.Actor{
+                        {ID: "act-human", Kind: model.ActorKindHuman, Name: "Human", UserID: strPtr("act-human")},
+                },
+                Outlines: []model.Outline{
+                        {
+                                ID:        "out-1",
+                                ProjectID: "proj-1",
+                                StatusDefs: []model.OutlineStatusDef{
+                                        {ID: "todo"},
+                                        {ID: "doing"},
+                                        {ID: "done", IsEndState: true},
+                                },
+                        },
+                },
+                Items: []model.Item{
+                        {
+                                ID:           "item-1",
+                                ProjectID:    "proj-1",
+                                OutlineID:    "out-1",
+                                StatusID:     "doing",
+                                OwnerActorID: "act-human",
+                                Schedule:     &model.DateTime{Date: "2026-01-05", Time: &tm, Repeat: "+1w"},
+                                Due:          &model.DateTime{Date: "2026-01-07"},
+                        },
+                },
+        }
+
+        res, err := SetItemStatus(db, "act-human", "item-1", "done", nil)
+        if err != nil {
+                t.Fatalf("SetItemStatus error: %v", err)
+        }
+        if !res.Changed || res.EventPayload["to"] != "done" {
+                t.Fatalf("expected the completion to be recorded; got %#v", res.EventPayload)
+        }
+        if res.RepeatPayload == nil {
+                t.Fatalf("expected a repeat payload")
+        }
+        if res.Item.StatusID != "doing" {
+                t.Fatalf("expected item reopened to its previous status; got %q", res.Item.StatusID)
+        }
+        if s := res.Item.Schedule; s == nil || s.Date != "2026-01-12" || s.Time == nil || *s.Time != "09:00" || s.Repeat != "+1w" {
+                t.Fatalf("expected schedule rolled forward one week; got %#v", s)
+        }
+        if d := res.Item.Due; d == nil || d.Date != "2026-01-07" {
+                t.Fatalf("expected non-repeating due to stay; got %#v", d)
+        }
+        if _, ok := res.RepeatPayload["due"]; ok {
+                t.Fatalf("did not expect due in repeat payload: %#v", res.RepeatPayload)
+        }
+
+        // Non-repeating items complete normally.
+        db.Items[0].Schedule.Repeat = ""
+        db.Items[0].StatusID = "todo"
+        res, err = SetItemStatus(db, "act-human", "item-1", "done", nil)
+        if err != nil {
+                t.Fatalf("SetItemStatus error: %v", err)
+        }
+        if res.RepeatPayload != nil || res.Item.StatusID != "done" {
+                t.Fatalf("expected plain completion; got status=%q repeat=%#v", res.Item.StatusID, res.RepeatPayload)
+        }
+}
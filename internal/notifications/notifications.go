@@ -0,0 +1,274 @@
+package notifications
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"clarity-cli/internal/model"
+	"clarity-cli/internal/store"
+)
+
+// DefaultWindow bounds how many recent events the feed is derived from.
+const DefaultWindow = 2000
+
+// Notify lists the event types that show up in the feed.
+var Notify = map[string]bool{
+	"item.create":     true,
+	"item.set_status": true,
+	"comment.add":     true,
+}
+
+// Entry is one notification: an event on a followed outline/item, rendered for display.
+type Entry struct {
+	EventID   string    `json:"eventId"`
+	TS        time.Time `json:"ts"`
+	Type      string    `json:"type"`
+	ActorID   string    `json:"actorId"`
+	OutlineID string    `json:"outlineId"`
+	ItemID    string    `json:"itemId"`
+	Title     string    `json:"title"`
+	Summary   string    `json:"summary"`
+	// Via is the kind of follow that matched ("item" wins over "outline").
+	Via    string `json:"via"`
+	Unread bool   `json:"unread"`
+}
+
+type Options struct {
+	// ActorID is whose follows (and read cursor) to use. Events caused by this actor are skipped.
+	ActorID string
+	// Cursor is the actor's last-seen position; nil means everything is unread.
+	Cursor *store.NotificationCursor
+	// UnreadOnly drops read entries.
+	UnreadOnly bool
+	// Limit caps the returned entries (0 = all).
+	Limit int
+}
+
+type Result struct {
+	// Entries are newest-first.
+	Entries []Entry `json:"entries"`
+	Total   int     `json:"total"`
+	Unread  int     `json:"unread"`
+}
+
+// Feed derives the notifications for opts.ActorID from events (oldest-first, as returned by
+// store.ReadEventsTail) joined against the actor's follows.
+func Feed(db *store.DB, events []model.Event, opts Options) Result {
+	res := Result{Entries: []Entry{}}
+	actorID := strings.TrimSpace(opts.ActorID)
+	if db == nil || actorID == "" {
+		return res
+	}
+	followsOutline := map[string]bool{}
+	followsItem := map[string]bool{}
+	for _, f := range db.FollowsForActor(actorID) {
+		switch f.TargetKind {
+		case model.FollowTargetOutline:
+			followsOutline[f.TargetID] = true
+		case model.FollowTargetItem:
+			followsItem[f.TargetID] = true
+		}
+	}
+	if len(followsOutline) == 0 && len(followsItem) == 0 {
+		return res
+	}
+
+	// Events after the cursor event are unread. If the cursor event fell out of the window,
+	// fall back to comparing timestamps.
+	cursorIdx := -1
+	if opts.Cursor != nil && opts.Cursor.EventID != "" {
+		for i := range events {
+			if events[i].ID == opts.Cursor.EventID {
+				cursorIdx = i
+				break
+			}
+		}
+	}
+
+	all := make([]Entry, 0)
+	for i, ev := range events {
+		if !Notify[ev.Type] || strings.TrimSpace(ev.ActorID) == actorID {
+			continue
+		}
+		itemID, outlineID, title := eventTarget(db, ev)
+		if itemID == "" {
+			continue
+		}
+		via := ""
+		switch {
+		case followsItem[itemID]:
+			via = model.FollowTargetItem
+		case outlineID != "" && followsOutline[outlineID]:
+			via = model.FollowTargetOutline
+		default:
+			continue
+		}
+		unread := true
+		switch {
+		case cursorIdx >= 0:
+			unread = i > cursorIdx
+		case opts.Cursor != nil && !opts.Cursor.TS.IsZero():
+			unread = ev.TS.After(opts.Cursor.TS)
+		}
+		all = append(all, Entry{
+			EventID:   ev.ID,
+			TS:        ev.TS,
+			Type:      ev.Type,
+			ActorID:   ev.ActorID,
+			OutlineID: outlineID,
+			ItemID:    itemID,
+			Title:     title,
+			Summary:   Summary(db, ev),
+			Via:       via,
+			Unread:    unread,
+		})
+	}
+
+	for i := len(all) - 1; i >= 0; i-- {
+		e := all[i]
+		if e.Unread {
+			res.Unread++
+		}
+		if opts.UnreadOnly && !e.Unread {
+			continue
+		}
+		res.Total++
+		if opts.Limit > 0 && len(res.Entries) >= opts.Limit {
+			continue
+		}
+		res.Entries = append(res.Entries, e)
+	}
+	return res
+}
+
+// Latest returns a cursor at the newest event in events (for "mark all read").
+func Latest(events []model.Event) store.NotificationCursor {
+	if len(events) == 0 {
+		return store.NotificationCursor{TS: time.Now().UTC()}
+	}
+	ev := events[len(events)-1]
+	return store.NotificationCursor{EventID: ev.ID, TS: ev.TS}
+}
+
+// eventTarget maps an event to its item, the item's outline and a display title.
+// The current state wins; the event payload is a fallback for items that no longer exist.
+func eventTarget(db *store.DB, ev model.Event) (itemID, outlineID, title string) {
+	var p struct {
+		ID        string `json:"id"`
+		ItemID    string `json:"itemId"`
+		OutlineID string `json:"outlineId"`
+		Title     string `json:"title"`
+	}
+	_ = decodePayload(ev.Payload, &p)
+
+	switch {
+	case strings.HasPrefix(ev.Type, "item."):
+		itemID = strings.TrimSpace(ev.EntityID)
+		if itemID == "" {
+			itemID = strings.TrimSpace(p.ID)
+		}
+	case strings.HasPrefix(ev.Type, "comment."):
+		itemID = strings.TrimSpace(p.ItemID)
+	}
+	if itemID == "" {
+		return "", "", ""
+	}
+	if it, ok := db.FindItem(itemID); ok && it != nil {
+		return itemID, it.OutlineID, it.Title
+	}
+	return itemID, strings.TrimSpace(p.OutlineID), strings.TrimSpace(p.Title)
+}
+
+// Summary renders a short human-readable description of a notification event.
+func Summary(db *store.DB, ev model.Event) string {
+	who := actorName(db, ev.ActorID)
+	switch ev.Type {
+	case "item.create":
+		return who + " created the item"
+	case "item.set_status":
+		var p struct {
+			To     string `json:"to"`
+			Status string `json:"status"`
+		}
+		_ = decodePayload(ev.Payload, &p)
+		to := strings.TrimSpace(p.To)
+		if to == "" {
+			to = strings.TrimSpace(p.Status)
+		}
+		if to == "" {
+			return who + " cleared the status"
+		}
+		if it, ok := db.FindItem(strings.TrimSpace(ev.EntityID)); ok && it != nil {
+			if def, ok := db.StatusDef(it.OutlineID, to); ok && def != nil && strings.TrimSpace(def.Label) != "" {
+				to = def.Label
+			}
+		}
+		return fmt.Sprintf("%s set status to %s", who, to)
+	case "comment.add":
+		// The body comes from derived state, not the payload: it may have been redacted since.
+		var p struct {
+			ID string `json:"id"`
+		}
+		_ = decodePayload(ev.Payload, &p)
+		id := strings.TrimSpace(p.ID)
+		if id == "" {
+			id = strings.TrimSpace(ev.EntityID)
+		}
+		c := findComment(db, id)
+		if c == nil {
+			return who + " commented"
+		}
+		if c.Redacted {
+			return who + " commented (redacted)"
+		}
+		body := strings.Join(strings.Fields(c.Body), " ")
+		if r := []rune(body); len(r) > 80 {
+			body = string(r[:79]) + "…"
+		}
+		return fmt.Sprintf("%s commented: %s", who, body)
+	}
+	return who + " " + ev.Type
+}
+
+func findComment(db *store.DB, id string) *model.Comment {
+	for i := range db.Comments {
+		if strings.TrimSpace(db.Comments[i].ID) == id {
+			return &db.Comments[i]
+		}
+	}
+	return nil
+}
+
+func actorName(db *store.DB, actorID string) string {
+	if a, ok := db.FindActor(strings.TrimSpace(actorID)); ok && a != nil && strings.TrimSpace(a.Name) != "" {
+		return strings.TrimSpace(a.Name)
+	}
+	if strings.TrimSpace(actorID) == "" {
+		return "someone"
+	}
+	return actorID
+}
+
+// decodePayload accepts both raw JSON payloads and already-decoded maps.
+func decodePayload(payload any, out any) error {
+	var b []byte
+	switch p := payload.(type) {
+	case nil:
+		return nil
+	case json.RawMessage:
+		b = p
+	case []byte:
+		b = p
+	case string:
+		b = []byte(p)
+	default:
+		var err error
+		b, err = json.Marshal(p)
+		if err != nil {
+			return err
+		}
+	}
+	return json.Unmarshal(b, out)
+}
@@ -0,0 +1,113 @@
+package notifications
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"clarity-cli/internal/model"
+	"clarity-cli/internal/store"
+)
+
+func ev(id, actorID, typ, entityID string, payload map[string]any, ts time.Time) model.Event {
+	b, _ := json.Marshal(payload)
+	return model.Event{ID: id, TS: ts, ActorID: actorID, Type: typ, EntityID: entityID, Payload: json.RawMessage(b)}
+}
+
+func entryIDs(res Result) []string {
+	out := make([]string, 0, len(res.Entries))
+	for _, e := range res.Entries {
+		out = append(out, e.EventID)
+	}
+	return out
+}
+
+func TestFeed(t *testing.T) {
+	db := &store.DB{
+		Actors: []model.Actor{
+			{ID: "act-me", Kind: model.ActorKindHuman, Name: "Me"},
+			{ID: "act-bob", Kind: model.ActorKindHuman, Name: "Bob"},
+		},
+		Outlines: []model.Outline{
+			{ID: "out-a", ProjectID: "proj-a", StatusDefs: store.DefaultOutlineStatusDefs()},
+			{ID: "out-b", ProjectID: "proj-a", StatusDefs: store.DefaultOutlineStatusDefs()},
+		},
+		Items: []model.Item{
+			{ID: "item-a", ProjectID: "proj-a", OutlineID: "out-a", Title: "Followed outline item"},
+			{ID: "item-b", ProjectID: "proj-a", OutlineID: "out-b", Title: "Followed item"},
+			{ID: "item-c", ProjectID: "proj-a", OutlineID: "out-b", Title: "Not followed"},
+		},
+		Follows: []model.Follow{
+			{ID: "fol-1", ActorID: "act-me", TargetKind: model.FollowTargetOutline, TargetID: "out-a"},
+			{ID: "fol-2", ActorID: "act-me", TargetKind: model.FollowTargetItem, TargetID: "item-b"},
+			{ID: "fol-3", ActorID: "act-bob", TargetKind: model.FollowTargetItem, TargetID: "item-c"},
+		},
+		Comments: []model.Comment{
+			{ID: "cmt-1", ItemID: "item-a", AuthorID: "act-me", Body: "my own comment"},
+			{ID: "cmt-2", ItemID: "item-c", AuthorID: "act-bob", Body: "unfollowed"},
+			{ID: "cmt-3", ItemID: "item-b", AuthorID: "act-bob", Body: "looks\ngood"},
+		},
+	}
+	t0 := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	events := []model.Event{
+		ev("evt-1", "act-bob", "item.create", "item-a", map[string]any{"id": "item-a", "outlineId": "out-a", "title": "Followed outline item"}, t0),
+		ev("evt-2", "act-bob", "item.set_status", "item-b", map[string]any{"from": "todo", "to": "doing"}, t0.Add(time.Minute)),
+		ev("evt-3", "act-me", "comment.add", "cmt-1", map[string]any{"itemId": "item-a", "body": "my own comment"}, t0.Add(2*time.Minute)),
+		ev("evt-4", "act-bob", "comment.add", "cmt-2", map[string]any{"itemId": "item-c", "body": "unfollowed"}, t0.Add(3*time.Minute)),
+		ev("evt-5", "act-bob", "item.set_title", "item-a", map[string]any{"title": "x"}, t0.Add(4*time.Minute)),
+		ev("evt-6", "act-bob", "comment.add", "cmt-3", map[string]any{"itemId": "item-b", "body": "looks\ngood"}, t0.Add(5*time.Minute)),
+	}
+
+	t.Run("joins events against follows", func(t *testing.T) {
+		res := Feed(db, events, Options{ActorID: "act-me"})
+		ids := entryIDs(res)
+		if len(ids) != 3 || ids[0] != "evt-6" || ids[1] != "evt-2" || ids[2] != "evt-1" {
+			t.Fatalf("expected newest-first followed events from others, got %v", ids)
+		}
+		if res.Total != 3 || res.Unread != 3 {
+			t.Fatalf("expected total=3 unread=3 without a cursor, got %d/%d", res.Total, res.Unread)
+		}
+		if got := res.Entries[0].Summary; got != "Bob commented: looks good" {
+			t.Fatalf("unexpected comment summary: %q", got)
+		}
+		if got := res.Entries[1].Summary; got != "Bob set status to DOING" {
+			t.Fatalf("unexpected status summary: %q", got)
+		}
+		if res.Entries[1].Via != model.FollowTargetItem || res.Entries[2].Via != model.FollowTargetOutline {
+			t.Fatalf("unexpected via: %q %q", res.Entries[1].Via, res.Entries[2].Via)
+		}
+
+		if res := Feed(db, events, Options{ActorID: "act-nobody"}); len(res.Entries) != 0 {
+			t.Fatalf("expected no entries without follows, got %v", entryIDs(res))
+		}
+	})
+
+	t.Run("cursor and unread only", func(t *testing.T) {
+		cur := store.NotificationCursor{EventID: "evt-2", TS: events[1].TS}
+		res := Feed(db, events, Options{ActorID: "act-me", Cursor: &cur, UnreadOnly: true})
+		if ids := entryIDs(res); len(ids) != 1 || ids[0] != "evt-6" || res.Unread != 1 {
+			t.Fatalf("expected only evt-6 unread, got %v (unread=%d)", ids, res.Unread)
+		}
+
+		// The cursor event is outside the window: fall back to its timestamp.
+		cur = store.NotificationCursor{EventID: "evt-gone", TS: events[0].TS}
+		res = Feed(db, events, Options{ActorID: "act-me", Cursor: &cur, Limit: 1})
+		if res.Unread != 2 || res.Total != 3 || len(res.Entries) != 1 {
+			t.Fatalf("expected unread=2 total=3 returned=1, got %d/%d/%d", res.Unread, res.Total, len(res.Entries))
+		}
+
+		latest := Latest(events)
+		res = Feed(db, events, Options{ActorID: "act-me", Cursor: &latest})
+		if res.Unread != 0 {
+			t.Fatalf("expected nothing unread after mark-read, got %d", res.Unread)
+		}
+	})
+
+	// Last: it edits db.
+	t.Run("redacted comments hide their body", func(t *testing.T) {
+		store.ApplyCommentRedact(&db.Comments[2], time.Now().UTC())
+		if got := Feed(db, events, Options{ActorID: "act-me"}).Entries[0].Summary; got != "Bob commented (redacted)" {
+			t.Fatalf("unexpected redacted comment summary: %q", got)
+		}
+	})
+}
@@ -26,6 +26,9 @@ func assignGraceDuration() time.Duration {
 // - Assignment acts as a human-level "edit lock":
 //   - If an item is assigned to a different human user (including their agents), you can't edit it.
 //
+// - Roles (meta/users.json) come first where a project/outline has them:
+//   - A maintainer can edit any item in it; without at least the contributor role you can't edit.
+//
 // - Otherwise:
 //   - The owner can edit.
 //   - The assignee can edit.
@@ -46,6 +49,16 @@ func CanEditItem(db *store.DB, actorID string, it *model.Item) bool {
                 return false
         }
 
+        if role, restricted := db.RoleOf(actorID, it.ProjectID, it.OutlineID); restricted {
+                switch role {
+                case store.RoleMaintainer:
+                        return true
+                case store.RoleContributor:
+                default:
+                        return false
+                }
+        }
+
         // Assignment is a human-level lock: if assigned to some other human, deny edits.
         if it.AssignedActorID != nil && strings.TrimSpace(*it.AssignedActorID) != "" {
                 if assignedHuman, ok := db.HumanUserIDForActor(strings.TrimSpace(*it.AssignedActorID)); ok {
@@ -91,3 +104,21 @@ func CanEditItem(db *store.DB, actorID string, it *model.Item) bool {
         }
         return time.Now().UTC().Before(it.OwnerDelegatedAt.Add(assignGraceDuration()))
 }
+
+// CanContribute reports whether actorID may add to an outline (create items, comment, log work,
+// attach files): it needs the contributor role where the outline or its project has roles.
+func CanContribute(db *store.DB, actorID, projectID, outlineID string) bool {
+        if db == nil || strings.TrimSpace(actorID) == "" {
+                return false
+        }
+        return db.HasRole(actorID, projectID, outlineID, store.RoleContributor)
+}
+
+// CanMaintain reports whether actorID may change an outline itself (rename, description, statuses,
+// archive), or a project when outlineID is "": it needs the maintainer role where roles apply.
+func CanMaintain(db *store.DB, actorID, projectID, outlineID string) bool {
+        if db == nil || strings.TrimSpace(actorID) == "" {
+                return false
+        }
+        return db.HasRole(actorID, projectID, outlineID, store.RoleMaintainer)
+}
@@ -151,3 +151,58 @@ func TestCanEditItem_HumanCanEditItemsAssignedToTheirAgentsEvenIfNotOwner(t *tes
                 t.Fatalf("expected human to be able to edit item assigned to their agent even if not owner")
         }
 }
+
+func TestCanEditItem_RolesOverrideOwnership(t *testing.T) {
+        now := time.Now().UTC()
+
+        h1 := "act-human-1"
+        h2 := "act-human-2"
+        h3 := "act-human-3"
+        a3 := "act-agent-3"
+
+        db := &store.DB{
+                Actors: []model.Actor{
+                        {ID: h1, Kind: model.ActorKindHuman, Name: "h1"},
+                        {ID: h2, Kind: model.ActorKindHuman, Name: "h2"},
+                        {ID: h3, Kind: model.ActorKindHuman, Name: "h3"},
+                        {ID: a3, Kind: model.ActorKindAgent, Name: "a3", UserID: &h3},
+                },
+                Items: []model.Item{{
+                        ID:              "item-a",
+                        ProjectID:       "proj",
+                        OutlineID:       "out",
+                        Title:           "A",
+                        OwnerActorID:    h1,
+                        AssignedActorID: &h1,
+                        CreatedBy:       h1,
+                        CreatedAt:       now,
+                        UpdatedAt:       now,
+                }},
+        }
+        db.SetAccessRules(store.NewAccessRules(store.UsersFile{Roles: []store.RoleGrant{
+                {ActorID: h1, Role: store.RoleViewer, OutlineID: "out"},
+                {ActorID: h2, Role: store.RoleMaintainer, ProjectID: "proj"},
+                {ActorID: h3, Role: store.RoleContributor, ProjectID: "proj"},
+        }}))
+        it := &db.Items[0]
+
+        // The outline grant (viewer) wins over ownership.
+        if CanEditItem(db, h1, it) || CanContribute(db, h1, "proj", "out") {
+                t.Fatalf("expected a viewer to be unable to edit their own item")
+        }
+        // A maintainer can edit items owned by and assigned to someone else.
+        if !CanEditItem(db, h2, it) || !CanMaintain(db, h2, "proj", "out") {
+                t.Fatalf("expected the project maintainer to edit any item")
+        }
+        // A contributor (and their agent) can add to the outline, but ownership rules still apply.
+        if !CanContribute(db, a3, "proj", "out") || CanMaintain(db, a3, "proj", "out") {
+                t.Fatalf("expected the agent to inherit its human's contributor role")
+        }
+        if CanEditItem(db, h3, it) {
+                t.Fatalf("expected a contributor to be unable to edit someone else's item")
+        }
+        // Projects without grants are open.
+        if !CanContribute(db, h1, "other", "") || !CanMaintain(db, h1, "other", "") {
+                t.Fatalf("expected unrestricted projects to allow everyone")
+        }
+}
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"clarity-cli/internal/model"
+	"clarity-cli/internal/readiness"
 	"clarity-cli/internal/store"
 )
 
@@ -100,6 +101,10 @@ func RenderItemMarkdown(db *store.DB, itemID string, opt RenderOptions) (string,
 			writeLn("- Tags: " + strings.Join(tags, ", "))
 		}
 	}
+	blockers := readiness.Blockers(db, item.ID)
+	if len(blockers) > 0 {
+		writeLn(fmt.Sprintf("- Blocked by: %d open item(s)", len(blockers)))
+	}
 	writeLn("- Created: " + item.CreatedAt.UTC().Format(time.RFC3339))
 	writeLn("- Updated: " + item.UpdatedAt.UTC().Format(time.RFC3339))
 
@@ -111,6 +116,24 @@ func RenderItemMarkdown(db *store.DB, itemID string, opt RenderOptions) (string,
 		writeLn(desc)
 	}
 
+	if len(blockers) > 0 {
+		writeLn("")
+		writeLn("## Blocked by")
+		writeLn("")
+		for _, b := range blockers {
+			prefix := strings.Repeat("  ", b.Depth-1)
+			if b.Reason == readiness.ReasonMissing {
+				writeLn(prefix + "- " + b.ItemID + " (missing)")
+				continue
+			}
+			line := prefix + "- [" + strings.TrimSpace(b.Title) + "](" + b.ItemID + ".md)"
+			if strings.TrimSpace(b.StatusID) != "" {
+				line += " (" + strings.TrimSpace(b.StatusID) + ")"
+			}
+			writeLn(line)
+		}
+	}
+
 	comments := commentsForItem(db, item.ID)
 	if len(comments) > 0 {
 		writeLn("")
@@ -122,6 +145,11 @@ func RenderItemMarkdown(db *store.DB, itemID string, opt RenderOptions) (string,
 			if strings.TrimSpace(c.AuthorID) != "" {
 				writeLn("- Author: " + strings.TrimSpace(c.AuthorID))
 			}
+			if c.Redacted {
+				writeLn("- Redacted: true")
+			} else if c.EditedAt != nil {
+				writeLn("- Edited: " + c.EditedAt.UTC().Format(time.RFC3339))
+			}
 			writeLn("")
 			body := strings.TrimSpace(c.Body)
 			if body == "" {
@@ -166,10 +194,13 @@ func formatDateTime(dt *model.DateTime) string {
 	if date == "" {
 		return ""
 	}
-	if dt.Time == nil || strings.TrimSpace(*dt.Time) == "" {
-		return date
+	if dt.Time != nil && strings.TrimSpace(*dt.Time) != "" {
+		date += " " + strings.TrimSpace(*dt.Time)
+	}
+	if r := strings.TrimSpace(dt.Repeat); r != "" {
+		date += " " + r
 	}
-	return date + " " + strings.TrimSpace(*dt.Time)
+	return date
 }
 
 func RenderOutlineIndexMarkdown(db *store.DB, outlineID string, items []*model.Item, opt RenderOptions) (string, error) {
@@ -205,14 +236,15 @@ func RenderOutlineIndexMarkdown(db *store.DB, outlineID string, items []*model.I
 	writeLn("")
 
 	tree := buildOutlineTree(items, opt.IncludeArchived)
+	blocked := readiness.OpenBlockerCounts(db)
 	for _, root := range tree.Roots {
-		renderOutlineItemLine(&buf, tree, root, 0)
+		renderOutlineItemLine(&buf, tree, blocked, root, 0)
 	}
 
 	return buf.String(), nil
 }
 
-func renderOutlineItemLine(buf *bytes.Buffer, tree outlineTree, it *model.Item, depth int) {
+func renderOutlineItemLine(buf *bytes.Buffer, tree outlineTree, blocked map[string]int, it *model.Item, depth int) {
 	if buf == nil || it == nil {
 		return
 	}
@@ -221,9 +253,12 @@ func renderOutlineItemLine(buf *bytes.Buffer, tree outlineTree, it *model.Item,
 	if status != "" {
 		status = " (" + status + ")"
 	}
+	if n := blocked[it.ID]; n > 0 {
+		status += fmt.Sprintf(" — blocked by %d", n)
+	}
 	fmt.Fprintf(buf, "%s- [%s](items/%s.md)%s\n", prefix, strings.TrimSpace(it.Title), it.ID, status)
 	for _, ch := range tree.Children[it.ID] {
-		renderOutlineItemLine(buf, tree, ch, depth+1)
+		renderOutlineItemLine(buf, tree, blocked, ch, depth+1)
 	}
 }
 
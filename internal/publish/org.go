@@ -0,0 +1,166 @@
+package publish
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"clarity-cli/internal/model"
+	"clarity-cli/internal/store"
+)
+
+// orgEscapeRe matches body lines (after indentation) that Org would read as a headline, a setting,
+// a drawer or a planning line, plus lines already starting with "," followed by one of those, so
+// the escape itself round-trips. Such lines get a leading ",", as in Org's own comma escaping.
+var orgEscapeRe = regexp.MustCompile(`^,*(?:\*|#\+|:[A-Za-z_]+:$|(?:SCHEDULED|DEADLINE|CLOSED):)`)
+
+// orgTagsColumn mirrors Org's default org-tags-column (-77): tags end at column 77.
+const orgTagsColumn = 77
+
+// RenderOutlineOrg renders an outline as a single Org file: items become headlines (ordered by
+// rank), statuses become TODO keywords (declared in a "#+TODO:" line from the outline's status
+// defs), priority becomes "[#A]", tags ":a:b:", schedule/due SCHEDULED:/DEADLINE: and the
+// description the body. The output only depends on the outline's state, so re-publishing an
+// unchanged outline produces an identical file.
+func RenderOutlineOrg(db *store.DB, outlineID string, items []*model.Item, opt RenderOptions) (string, error) {
+	if db == nil {
+		return "", fmt.Errorf("missing db")
+	}
+	outline, ok := db.FindOutline(strings.TrimSpace(outlineID))
+	if !ok || outline == nil {
+		return "", fmt.Errorf("outline not found: %s", outlineID)
+	}
+
+	var buf bytes.Buffer
+	writeLn := func(s string) {
+		buf.WriteString(s)
+		buf.WriteString("\n")
+	}
+
+	title := outline.ID
+	if outline.Name != nil && strings.TrimSpace(*outline.Name) != "" {
+		title = strings.TrimSpace(*outline.Name)
+	}
+	writeLn("#+TITLE: " + title)
+	var open, done []string
+	for _, def := range outline.StatusDefs {
+		k := orgKeyword(def.ID)
+		if k == "" {
+			continue
+		}
+		if def.IsEndState {
+			done = append(done, k)
+		} else {
+			open = append(open, k)
+		}
+	}
+	if len(open)+len(done) > 0 {
+		writeLn("#+TODO: " + strings.TrimSpace(strings.Join(open, " ")+" | "+strings.Join(done, " ")))
+	}
+	writeLn("")
+	if desc := strings.TrimSpace(outline.Description); desc != "" {
+		writeOrgBody(writeLn, desc)
+		writeLn("")
+	}
+
+	tree := buildOutlineTree(items, opt.IncludeArchived)
+	for _, root := range tree.Roots {
+		renderOrgHeadline(writeLn, tree, root, 1)
+	}
+	return buf.String(), nil
+}
+
+func renderOrgHeadline(writeLn func(string), tree outlineTree, it *model.Item, depth int) {
+	if it == nil {
+		return
+	}
+	head := strings.Repeat("*", depth)
+	if k := orgKeyword(it.StatusID); k != "" {
+		head += " " + k
+	}
+	if it.Priority {
+		head += " [#A]"
+	}
+	head += " " + strings.TrimSpace(it.Title)
+
+	seen := map[string]bool{}
+	tags := make([]string, 0, len(it.Tags))
+	for _, t := range it.Tags {
+		if t = orgTag(t); t != "" && !seen[t] {
+			seen[t] = true
+			tags = append(tags, t)
+		}
+	}
+	sort.Strings(tags)
+	if len(tags) > 0 {
+		tagStr := ":" + strings.Join(tags, ":") + ":"
+		pad := orgTagsColumn - len([]rune(head)) - len([]rune(tagStr))
+		if pad < 1 {
+			pad = 1
+		}
+		head += strings.Repeat(" ", pad) + tagStr
+	}
+	writeLn(head)
+
+	var planning []string
+	if ts := orgTimestamp(it.Schedule); ts != "" {
+		planning = append(planning, "SCHEDULED: "+ts)
+	}
+	if ts := orgTimestamp(it.Due); ts != "" {
+		planning = append(planning, "DEADLINE: "+ts)
+	}
+	if len(planning) > 0 {
+		writeLn(strings.Join(planning, " "))
+	}
+	if desc := strings.TrimSpace(it.Description); desc != "" {
+		writeOrgBody(writeLn, desc)
+	}
+	for _, ch := range tree.Children[it.ID] {
+		renderOrgHeadline(writeLn, tree, ch, depth+1)
+	}
+}
+
+// writeOrgBody writes body text, escaping lines Org would read as headlines, settings, drawers or
+// planning lines.
+func writeOrgBody(writeLn func(string), body string) {
+	for _, line := range strings.Split(body, "\n") {
+		line = strings.TrimRight(line, " \t")
+		rest := strings.TrimLeft(line, " \t")
+		if orgEscapeRe.MatchString(rest) {
+			line = line[:len(line)-len(rest)] + "," + rest
+		}
+		writeLn(line)
+	}
+}
+
+func orgKeyword(statusID string) string {
+	return strings.ToUpper(strings.Join(strings.Fields(statusID), "_"))
+}
+
+// orgTag makes a tag usable in a headline: Org tags can't contain whitespace or ":", so runs of
+// those become "_".
+func orgTag(tag string) string {
+	return strings.Join(strings.FieldsFunc(tag, func(r rune) bool { return unicode.IsSpace(r) || r == ':' }), "_")
+}
+
+func orgTimestamp(dt *model.DateTime) string {
+	if dt == nil || strings.TrimSpace(dt.Date) == "" {
+		return ""
+	}
+	date := strings.TrimSpace(dt.Date)
+	s := date
+	if d, err := time.Parse("2006-01-02", date); err == nil {
+		s += " " + d.Format("Mon")
+	}
+	if dt.Time != nil && strings.TrimSpace(*dt.Time) != "" {
+		s += " " + strings.TrimSpace(*dt.Time)
+	}
+	if r := strings.TrimSpace(dt.Repeat); r != "" {
+		s += " " + r
+	}
+	return "<" + s + ">"
+}
@@ -0,0 +1,131 @@
+package publish
+
+import (
+        "strings"
+        "testing"
+        "time"
+
+        "clarity-cli/internal/importer"
+        "clarity-cli/internal/model"
+        "clarity-cli/internal/store"
+)
+
+func TestRenderOutlineOrg_RoundTripsThroughImporter(t *testing.T) {
+        t.Parallel()
+
+        now := time.Date(2025, 12, 20, 0, 0, 0, 0, time.UTC)
+        actorID := "act-human"
+        outlineID := "out-test"
+        name := "Chores"
+        nine := "09:00"
+        parentID := "item-a"
+
+        db := &store.DB{
+                Version: 1,
+                Outlines: []model.Outline{
+                        {ID: outlineID, ProjectID: "proj-test", Name: &name, StatusDefs: store.DefaultOutlineStatusDefs(), CreatedBy: actorID, CreatedAt: now},
+                },
+                Items: []model.Item{
+                        {ID: "item-b", OutlineID: outlineID, Rank: "t", Title: "Second", StatusID: "done", CreatedAt: now, UpdatedAt: now},
+                        {
+                                ID:          parentID,
+                                OutlineID:   outlineID,
+                                Rank:        "h",
+                                Title:       "Water plants",
+                                Description: "Use the green can.\n* markdown bullet",
+                                StatusID:    "todo",
+                                Priority:    true,
+                                Tags:        []string{"weekly", "home"},
+                                Schedule:    &model.DateTime{Date: "2026-01-05", Time: &nine, Repeat: "+1w"},
+                                Due:         &model.DateTime{Date: "2026-01-09"},
+                                CreatedAt:   now,
+                                UpdatedAt:   now,
+                        },
+                        {ID: "item-c", OutlineID: outlineID, ParentID: &parentID, Rank: "h", Title: "Buy soil", StatusID: "doing", CreatedAt: now, UpdatedAt: now},
+                        {ID: "item-d", OutlineID: outlineID, Rank: "z", Title: "Gone", StatusID: "todo", Archived: true, CreatedAt: now, UpdatedAt: now},
+                },
+        }
+        items := make([]*model.Item, 0, len(db.Items))
+        for i := range db.Items {
+                items = append(items, &db.Items[i])
+        }
+
+        org, err := RenderOutlineOrg(db, outlineID, items, RenderOptions{})
+        if err != nil {
+                t.Fatalf("RenderOutlineOrg: %v", err)
+        }
+        for _, want := range []string{
+                "#+TITLE: Chores\n#+TODO: TODO DOING | DONE\n",
+                "* TODO [#A] Water plants",
+                ":home:weekly:\nSCHEDULED: <2026-01-05 Mon 09:00 +1w> DEADLINE: <2026-01-09 Fri>\nUse the green can.\n,* markdown bullet\n** DOING Buy soil\n* DONE Second\n",
+        } {
+                if !strings.Contains(org, want) {
+                        t.Fatalf("expected org output to contain %q; got:\n%s", want, org)
+                }
+        }
+        if strings.Contains(org, "Gone") {
+                t.Fatalf("archived items must be excluded by default:\n%s", org)
+        }
+        if again, _ := RenderOutlineOrg(db, outlineID, items, RenderOptions{}); again != org {
+                t.Fatalf("expected stable output")
+        }
+
+        doc := importer.ParseOrg(org, importer.Options{Keywords: []string{"TODO", "DOING", "DONE"}})
+        if len(doc.Items) != 2 {
+                t.Fatalf("expected 2 top-level headlines; got %d", len(doc.Items))
+        }
+        water := doc.Items[0]
+        if water.Title != "Water plants" || water.Keyword != "TODO" || !water.Priority || strings.Join(water.Tags, ",") != "home,weekly" {
+                t.Fatalf("unexpected headline: %+v", water)
+        }
+        if water.Description != "Use the green can.\n* markdown bullet" {
+                t.Fatalf("description did not round-trip: %q", water.Description)
+        }
+        if water.Schedule == nil || *water.Schedule.Time != "09:00" || water.Schedule.Repeat != "+1w" || water.Due == nil || water.Due.Date != "2026-01-09" {
+                t.Fatalf("dates did not round-trip: %#v %#v", water.Schedule, water.Due)
+        }
+        if len(water.Children) != 1 || water.Children[0].Keyword != "DOING" || doc.Items[1].Keyword != "DONE" {
+                t.Fatalf("unexpected tree: %+v", doc.Items)
+        }
+}
+
+func TestRenderOutlineOrg_EscapesOrgSyntaxInBodiesAndTags(t *testing.T) {
+        t.Parallel()
+
+        now := time.Date(2025, 12, 20, 0, 0, 0, 0, time.UTC)
+        outlineID := "out-test"
+        desc := "SCHEDULED: <2026-01-05 Mon>\n:PROPERTIES:\n  :END:\n,* already escaped\n#+not a setting"
+        db := &store.DB{
+                Version: 1,
+                Outlines: []model.Outline{
+                        {ID: outlineID, ProjectID: "proj-test", StatusDefs: store.DefaultOutlineStatusDefs(), CreatedBy: "act-human", CreatedAt: now},
+                },
+                Items: []model.Item{
+                        {ID: "item-a", OutlineID: outlineID, Rank: "h", Title: "Notes", Description: desc, StatusID: "todo", Tags: []string{"two words", "a:b", "a_b"}, CreatedAt: now, UpdatedAt: now},
+                },
+        }
+
+        org, err := RenderOutlineOrg(db, outlineID, []*model.Item{&db.Items[0]}, RenderOptions{})
+        if err != nil {
+                t.Fatalf("RenderOutlineOrg: %v", err)
+        }
+        want := ":a_b:two_words:\n,SCHEDULED: <2026-01-05 Mon>\n,:PROPERTIES:\n  ,:END:\n,,* already escaped\n,#+not a setting\n"
+        if !strings.Contains(org, want) {
+                t.Fatalf("expected org output to contain %q; got:\n%s", want, org)
+        }
+
+        doc := importer.ParseOrg(org, importer.Options{Keywords: []string{"TODO", "DOING", "DONE"}})
+        if len(doc.Items) != 1 {
+                t.Fatalf("expected 1 headline; got %d", len(doc.Items))
+        }
+        notes := doc.Items[0]
+        if notes.Title != "Notes" || strings.Join(notes.Tags, ",") != "a_b,two_words" {
+                t.Fatalf("unexpected headline: %+v", notes)
+        }
+        if notes.Schedule != nil {
+                t.Fatalf("body line was read as planning: %#v", notes.Schedule)
+        }
+        if notes.Description != desc {
+                t.Fatalf("description did not round-trip: %q", notes.Description)
+        }
+}
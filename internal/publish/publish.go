@@ -126,6 +126,48 @@ func WriteOutline(db *store.DB, outlineID string, toDir string, opt WriteOptions
         return WriteResult{Written: written}, nil
 }
 
+// WriteOutlineOrg writes the outline as a single Org file: outlines/<outline-id>.org.
+func WriteOutlineOrg(db *store.DB, outlineID string, toDir string, opt WriteOptions) (WriteResult, error) {
+        if db == nil {
+                return WriteResult{}, errors.New("missing db")
+        }
+        outlineID = strings.TrimSpace(outlineID)
+        if outlineID == "" {
+                return WriteResult{}, errors.New("missing outlineID")
+        }
+        toDir = strings.TrimSpace(toDir)
+        if toDir == "" {
+                return WriteResult{}, errors.New("missing --to")
+        }
+        toDir = filepath.Clean(toDir)
+
+        all := make([]*model.Item, 0)
+        for i := range db.Items {
+                it := &db.Items[i]
+                if strings.TrimSpace(it.OutlineID) != outlineID {
+                        continue
+                }
+                all = append(all, it)
+        }
+
+        org, err := RenderOutlineOrg(db, outlineID, all, RenderOptions{
+                IncludeArchived: opt.IncludeArchived,
+                ActorID:         opt.ActorID,
+        })
+        if err != nil {
+                return WriteResult{}, err
+        }
+        outDir := filepath.Join(toDir, "outlines")
+        if err := os.MkdirAll(outDir, 0o755); err != nil {
+                return WriteResult{}, err
+        }
+        outPath := filepath.Join(outDir, outlineID+".org")
+        if err := writeFile(outPath, []byte(org), opt.Overwrite); err != nil {
+                return WriteResult{}, err
+        }
+        return WriteResult{Written: []string{outPath}}, nil
+}
+
 func writeFile(path string, b []byte, overwrite bool) error {
         if !overwrite {
                 if _, err := os.Stat(path); err == nil {
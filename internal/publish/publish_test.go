@@ -112,3 +112,86 @@ func TestWriteOutline_WritesIndexAndItems(t *testing.T) {
                 t.Fatalf("stat item-a.md: %v", err)
         }
 }
+
+func TestRenderItemMarkdown_ListsOnlyOpenBlockers(t *testing.T) {
+        t.Parallel()
+
+        now := time.Date(2025, 12, 20, 0, 0, 0, 0, time.UTC)
+        actorID := "act-human"
+        item := func(id, title, status string) model.Item {
+                return model.Item{ID: id, ProjectID: "proj-test", OutlineID: "out-test", Title: title, StatusID: status, OwnerActorID: actorID, CreatedBy: actorID, CreatedAt: now, UpdatedAt: now}
+        }
+        db := &store.DB{
+                Outlines: []model.Outline{
+                        {ID: "out-test", ProjectID: "proj-test", StatusDefs: store.DefaultOutlineStatusDefs(), CreatedBy: actorID, CreatedAt: now},
+                },
+                Items: []model.Item{
+                        item("item-a", "Blocked", "todo"),
+                        item("item-b", "Open blocker", "doing"),
+                        item("item-c", "Finished blocker", "done"),
+                },
+                Deps: []model.Dependency{
+                        {ID: "dep-1", FromItemID: "item-a", ToItemID: "item-b", Type: model.DependencyBlocks},
+                        {ID: "dep-2", FromItemID: "item-a", ToItemID: "item-c", Type: model.DependencyBlocks},
+                },
+        }
+
+        md, err := RenderItemMarkdown(db, "item-a", RenderOptions{ActorID: actorID})
+        if err != nil {
+                t.Fatalf("RenderItemMarkdown: %v", err)
+        }
+        if !strings.Contains(md, "- Blocked by: 1 open item(s)") || !strings.Contains(md, "## Blocked by") {
+                t.Fatalf("expected blocked-by meta and section, got:\n%s", md)
+        }
+        if !strings.Contains(md, "[Open blocker](item-b.md) (doing)") {
+                t.Fatalf("expected open blocker to be listed, got:\n%s", md)
+        }
+        if strings.Contains(md, "Finished blocker") {
+                t.Fatalf("expected finished blocker to be omitted, got:\n%s", md)
+        }
+
+        db.Items[1].StatusID = "done"
+        md, err = RenderItemMarkdown(db, "item-a", RenderOptions{ActorID: actorID})
+        if err != nil {
+                t.Fatalf("RenderItemMarkdown: %v", err)
+        }
+        if strings.Contains(md, "Blocked by") {
+                t.Fatalf("expected no blocked-by output once blockers are done, got:\n%s", md)
+        }
+}
+
+func TestRenderItemMarkdown_RedactedCommentHidesBody(t *testing.T) {
+        t.Parallel()
+
+        now := time.Date(2025, 12, 20, 0, 0, 0, 0, time.UTC)
+        actorID := "act-human"
+        db := &store.DB{
+                Outlines: []model.Outline{
+                        {ID: "out-test", ProjectID: "proj-test", StatusDefs: store.DefaultOutlineStatusDefs(), CreatedBy: actorID, CreatedAt: now},
+                },
+                Items: []model.Item{
+                        {ID: "item-a", ProjectID: "proj-test", OutlineID: "out-test", Title: "A", StatusID: "todo", OwnerActorID: actorID, CreatedBy: actorID, CreatedAt: now, UpdatedAt: now},
+                },
+                Comments: []model.Comment{
+                        {ID: "cmt-1", ItemID: "item-a", AuthorID: actorID, Body: "token=hunter2", CreatedAt: now},
+                        {ID: "cmt-2", ItemID: "item-a", AuthorID: actorID, Body: "typo", CreatedAt: now.Add(time.Minute)},
+                },
+        }
+        store.ApplyCommentEdit(&db.Comments[0], "token=hunter3", now.Add(time.Hour))
+        store.ApplyCommentRedact(&db.Comments[0], now.Add(2*time.Hour))
+        store.ApplyCommentEdit(&db.Comments[1], "fixed typo", now.Add(time.Hour))
+
+        md, err := RenderItemMarkdown(db, "item-a", RenderOptions{ActorID: actorID})
+        if err != nil {
+                t.Fatalf("RenderItemMarkdown: %v", err)
+        }
+        if strings.Contains(md, "hunter") {
+                t.Fatalf("expected redacted body to be hidden, got:\n%s", md)
+        }
+        if !strings.Contains(md, "- Redacted: true") || !strings.Contains(md, model.RedactedCommentBody) {
+                t.Fatalf("expected redaction marker, got:\n%s", md)
+        }
+        if !strings.Contains(md, "- Edited: ") || !strings.Contains(md, "fixed typo") {
+                t.Fatalf("expected edited marker and latest body, got:\n%s", md)
+        }
+}
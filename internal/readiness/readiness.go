@@ -0,0 +1,189 @@
+// Package readiness computes whether items are blocked by open "blocks" dependencies.
+//
+// It is shared by the CLI (items ready, deps tree, deps blockers, completion checks),
+// the TUI (blocked badge) and publish output so they all agree on what "blocked" means.
+package readiness
+
+import (
+	"strings"
+
+	"clarity-cli/internal/model"
+	"clarity-cli/internal/statusutil"
+	"clarity-cli/internal/store"
+)
+
+// Reason explains why a blocker does (or no longer does) block an item.
+type Reason string
+
+const (
+	// ReasonOpen: the blocker is not archived and not in an end-state.
+	ReasonOpen Reason = "open"
+	// ReasonMissing: the blocker item does not exist (treated as blocking until cleaned up).
+	ReasonMissing Reason = "missing"
+	// ReasonEndState: the blocker reached an end-state status (resolved).
+	ReasonEndState Reason = "end-state"
+	// ReasonArchived: the blocker is archived (resolved).
+	ReasonArchived Reason = "archived"
+)
+
+type Blocker struct {
+	ItemID   string `json:"itemId"`
+	Title    string `json:"title,omitempty"`
+	StatusID string `json:"status,omitempty"`
+	DepID    string `json:"depId"`
+	// BlocksItemID is the item this blocker directly blocks (the queried item for depth 1).
+	BlocksItemID string `json:"blocksItemId"`
+	// Depth is 1 for direct blockers, 2 for blockers of blockers, and so on.
+	Depth  int    `json:"depth"`
+	Reason Reason `json:"reason"`
+}
+
+// IsResolved reports whether an item no longer blocks anything: it is archived or its
+// status is an end-state of its outline.
+func IsResolved(db *store.DB, it *model.Item) bool {
+	if it == nil {
+		return false
+	}
+	return resolvedReason(db, it) != ""
+}
+
+func resolvedReason(db *store.DB, it *model.Item) Reason {
+	if it.Archived {
+		return ReasonArchived
+	}
+	o := model.Outline{}
+	if db != nil {
+		if found, ok := db.FindOutline(strings.TrimSpace(it.OutlineID)); ok && found != nil {
+			o = *found
+		}
+	}
+	if statusutil.IsEndState(o, it.StatusID) {
+		return ReasonEndState
+	}
+	return ""
+}
+
+// IsBlocked reports whether itemID has at least one open (or missing) blocker.
+func IsBlocked(db *store.DB, itemID string) bool {
+	itemID = strings.TrimSpace(itemID)
+	if db == nil || itemID == "" {
+		return false
+	}
+	for _, d := range db.Deps {
+		if d.Type != model.DependencyBlocks || strings.TrimSpace(d.FromItemID) != itemID {
+			continue
+		}
+		toID := strings.TrimSpace(d.ToItemID)
+		if toID == "" || toID == itemID {
+			continue
+		}
+		b, ok := db.FindItem(toID)
+		if !ok || b == nil || !IsResolved(db, b) {
+			return true
+		}
+	}
+	return false
+}
+
+// Blockers returns the open blockers of itemID, transitively: an open blocker's own open
+// blockers are included (with a higher Depth). Resolved blockers stop the walk, since a
+// finished or archived item no longer blocks anything. The result is in depth-first
+// order (each blocker is followed by its own blockers) and lists every item once.
+func Blockers(db *store.DB, itemID string) []Blocker {
+	itemID = strings.TrimSpace(itemID)
+	out := []Blocker{}
+	if db == nil || itemID == "" {
+		return out
+	}
+	graph := blocksEdges(db)
+	seen := map[string]bool{itemID: true}
+	var walk func(id string, depth int)
+	walk = func(id string, depth int) {
+		for _, d := range graph[id] {
+			toID := strings.TrimSpace(d.ToItemID)
+			if seen[toID] {
+				continue
+			}
+			b := Blocker{ItemID: toID, DepID: d.ID, BlocksItemID: id, Depth: depth}
+			it, ok := db.FindItem(toID)
+			if !ok || it == nil {
+				b.Reason = ReasonMissing
+				seen[toID] = true
+				out = append(out, b)
+				continue
+			}
+			if resolvedReason(db, it) != "" {
+				continue
+			}
+			b.Title = it.Title
+			b.StatusID = it.StatusID
+			b.Reason = ReasonOpen
+			seen[toID] = true
+			out = append(out, b)
+			walk(toID, depth+1)
+		}
+	}
+	walk(itemID, 1)
+	return out
+}
+
+// ResolvedBlockers returns the direct blockers of itemID that no longer block it
+// (archived or in an end-state).
+func ResolvedBlockers(db *store.DB, itemID string) []Blocker {
+	itemID = strings.TrimSpace(itemID)
+	out := []Blocker{}
+	if db == nil || itemID == "" {
+		return out
+	}
+	for _, d := range blocksEdges(db)[itemID] {
+		it, ok := db.FindItem(strings.TrimSpace(d.ToItemID))
+		if !ok || it == nil {
+			continue
+		}
+		reason := resolvedReason(db, it)
+		if reason == "" {
+			continue
+		}
+		out = append(out, Blocker{
+			ItemID:       it.ID,
+			Title:        it.Title,
+			StatusID:     it.StatusID,
+			DepID:        d.ID,
+			BlocksItemID: itemID,
+			Depth:        1,
+			Reason:       reason,
+		})
+	}
+	return out
+}
+
+// OpenBlockerCounts returns, for every blocked item, the number of open blockers
+// (transitive, as in Blockers). Items that are not blocked are omitted.
+func OpenBlockerCounts(db *store.DB) map[string]int {
+	out := map[string]int{}
+	if db == nil {
+		return out
+	}
+	for id := range blocksEdges(db) {
+		if n := len(Blockers(db, id)); n > 0 {
+			out[id] = n
+		}
+	}
+	return out
+}
+
+// blocksEdges indexes "blocks" deps by their dependent (from) item, in db order.
+func blocksEdges(db *store.DB) map[string][]model.Dependency {
+	graph := map[string][]model.Dependency{}
+	for _, d := range db.Deps {
+		if d.Type != model.DependencyBlocks {
+			continue
+		}
+		fromID := strings.TrimSpace(d.FromItemID)
+		if fromID == "" || strings.TrimSpace(d.ToItemID) == "" {
+			continue
+		}
+		graph[fromID] = append(graph[fromID], d)
+	}
+	return graph
+}
@@ -0,0 +1,90 @@
+package readiness
+
+import (
+	"testing"
+
+	"clarity-cli/internal/model"
+	"clarity-cli/internal/store"
+)
+
+func blocks(id, from, to string) model.Dependency {
+	return model.Dependency{ID: id, FromItemID: from, ToItemID: to, Type: model.DependencyBlocks}
+}
+
+func TestBlockers_ResolvedBlockersDoNotBlock(t *testing.T) {
+	db := &store.DB{
+		Outlines: []model.Outline{{ID: "out-a", ProjectID: "proj-a", StatusDefs: store.DefaultOutlineStatusDefs()}},
+		Items: []model.Item{
+			{ID: "item-a", Title: "A", OutlineID: "out-a", StatusID: "todo"},
+			{ID: "item-done", Title: "Done", OutlineID: "out-a", StatusID: "done"},
+			{ID: "item-archived", Title: "Archived", OutlineID: "out-a", StatusID: "todo", Archived: true},
+		},
+		Deps: []model.Dependency{
+			blocks("dep-1", "item-a", "item-done"),
+			blocks("dep-2", "item-a", "item-archived"),
+			{ID: "dep-3", FromItemID: "item-a", ToItemID: "item-done", Type: model.DependencyRelated},
+		},
+	}
+
+	if IsBlocked(db, "item-a") {
+		t.Fatalf("expected item-a to be ready once its blockers are done/archived")
+	}
+	if got := Blockers(db, "item-a"); len(got) != 0 {
+		t.Fatalf("expected no open blockers, got %+v", got)
+	}
+	resolved := ResolvedBlockers(db, "item-a")
+	if len(resolved) != 2 || resolved[0].Reason != ReasonEndState || resolved[1].Reason != ReasonArchived {
+		t.Fatalf("unexpected resolved blockers: %+v", resolved)
+	}
+}
+
+func TestBlockers_Transitive(t *testing.T) {
+	db := &store.DB{
+		Outlines: []model.Outline{{ID: "out-a", ProjectID: "proj-a", StatusDefs: store.DefaultOutlineStatusDefs()}},
+		Items: []model.Item{
+			{ID: "item-a", Title: "A", OutlineID: "out-a", StatusID: "todo"},
+			{ID: "item-b", Title: "B", OutlineID: "out-a", StatusID: "todo"},
+			{ID: "item-c", Title: "C", OutlineID: "out-a", StatusID: "todo"},
+			{ID: "item-d", Title: "D", OutlineID: "out-a", StatusID: "done"},
+			{ID: "item-e", Title: "E", OutlineID: "out-a", StatusID: "todo"},
+		},
+		Deps: []model.Dependency{
+			blocks("dep-1", "item-a", "item-b"),
+			blocks("dep-2", "item-b", "item-c"),
+			blocks("dep-3", "item-c", "item-a"), // cycle back to the root
+			blocks("dep-4", "item-a", "item-d"),
+			blocks("dep-5", "item-d", "item-e"), // behind a resolved blocker: ignored
+			blocks("dep-6", "item-b", "item-missing"),
+		},
+	}
+
+	got := Blockers(db, "item-a")
+	want := []struct {
+		id     string
+		depth  int
+		reason Reason
+	}{
+		{"item-b", 1, ReasonOpen},
+		{"item-c", 2, ReasonOpen},
+		{"item-missing", 2, ReasonMissing},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("expected %d blockers, got %+v", len(want), got)
+	}
+	for i, w := range want {
+		if got[i].ItemID != w.id || got[i].Depth != w.depth || got[i].Reason != w.reason {
+			t.Fatalf("blocker %d: expected %+v, got %+v", i, w, got[i])
+		}
+	}
+	if got[1].BlocksItemID != "item-b" {
+		t.Fatalf("expected item-c to block item-b, got %+v", got[1])
+	}
+	if !IsBlocked(db, "item-a") {
+		t.Fatalf("expected item-a to be blocked")
+	}
+
+	counts := OpenBlockerCounts(db)
+	if counts["item-a"] != 3 || counts["item-d"] != 1 {
+		t.Fatalf("unexpected counts: %+v", counts)
+	}
+}
@@ -0,0 +1,133 @@
+// Package repeat implements Org-style repeaters on schedule/due dates.
+//
+// A repeater is written like Org's timestamp cookies:
+//   - "+1w"  shift the date by the interval once (a missed week stays missed)
+//   - "++1w" shift by the interval until the date is in the future (keeps the weekday)
+//   - ".+1w" restart from today (e.g. "1 week after I last did it")
+//
+// Units: d (days), w (weeks), m (months), y (years).
+package repeat
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const dateLayout = "2006-01-02"
+
+type Kind string
+
+const (
+	// KindCumulate ("+") shifts the date by the interval once.
+	KindCumulate Kind = "+"
+	// KindCatchUp ("++") shifts the date by the interval until it is after today.
+	KindCatchUp Kind = "++"
+	// KindRestart (".+") sets the date to today plus the interval.
+	KindRestart Kind = ".+"
+)
+
+type Spec struct {
+	Kind Kind
+	N    int
+	// Unit is one of 'd', 'w', 'm', 'y'.
+	Unit byte
+}
+
+var specRe = regexp.MustCompile(`^(\+\+|\.\+|\+)([0-9]+)([dwmy])$`)
+
+// Parse parses a repeater such as "+1w", "++1d" or ".+2w".
+func Parse(s string) (Spec, error) {
+	m := specRe.FindStringSubmatch(strings.ToLower(strings.TrimSpace(s)))
+	if m == nil {
+		return Spec{}, fmt.Errorf("invalid repeater %q (expected +Nd, ++Nw, .+Nm, ... with unit d|w|m|y)", s)
+	}
+	n, err := strconv.Atoi(m[2])
+	if err != nil || n <= 0 || n > 1000 {
+		return Spec{}, fmt.Errorf("invalid repeater %q (interval must be 1-1000)", s)
+	}
+	return Spec{Kind: Kind(m[1]), N: n, Unit: m[3][0]}, nil
+}
+
+// Normalize validates s and returns its canonical form ("" stays "").
+func Normalize(s string) (string, error) {
+	if strings.TrimSpace(s) == "" {
+		return "", nil
+	}
+	sp, err := Parse(s)
+	if err != nil {
+		return "", err
+	}
+	return sp.String(), nil
+}
+
+func (s Spec) String() string {
+	return fmt.Sprintf("%s%d%c", s.Kind, s.N, s.Unit)
+}
+
+func (s Spec) step(t time.Time, times int) time.Time {
+	n := s.N * times
+	switch s.Unit {
+	case 'w':
+		return t.AddDate(0, 0, 7*n)
+	case 'm':
+		return t.AddDate(0, n, 0)
+	case 'y':
+		return t.AddDate(n, 0, 0)
+	default:
+		return t.AddDate(0, 0, n)
+	}
+}
+
+// Next returns the date after completing an occurrence on date (only calendar dates are used).
+func (s Spec) Next(date, today time.Time) time.Time {
+	date = dateOnly(date)
+	today = dateOnly(today)
+	switch s.Kind {
+	case KindRestart:
+		return s.step(today, 1)
+	case KindCatchUp:
+		next := s.step(date, 1)
+		for i := 2; !next.After(today); i++ {
+			next = s.step(date, i)
+		}
+		return next
+	default:
+		return s.step(date, 1)
+	}
+}
+
+// Occurrences returns the dates of the occurrences after date that fall in [from, to].
+func (s Spec) Occurrences(date, from, to time.Time) []time.Time {
+	date, from, to = dateOnly(date), dateOnly(from), dateOnly(to)
+	var out []time.Time
+	for i := 1; ; i++ {
+		d := s.step(date, i)
+		if d.After(to) {
+			return out
+		}
+		if !d.Before(from) {
+			out = append(out, d)
+		}
+	}
+}
+
+// NextDate applies repeater spec to a YYYY-MM-DD date.
+func NextDate(date, spec string, today time.Time) (string, error) {
+	sp, err := Parse(spec)
+	if err != nil {
+		return "", err
+	}
+	d, err := time.Parse(dateLayout, strings.TrimSpace(date))
+	if err != nil {
+		return "", fmt.Errorf("invalid date %q (expected YYYY-MM-DD)", date)
+	}
+	return sp.Next(d, today).Format(dateLayout), nil
+}
+
+func dateOnly(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,73 @@
+package repeat
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParse(t *testing.T) {
+	cases := map[string]string{
+		"+1w":  "+1w",
+		"++1d": "++1d",
+		".+2w": ".+2w",
+		" +3M": "+3m",
+		"+1y":  "+1y",
+	}
+	for in, want := range cases {
+		sp, err := Parse(in)
+		if err != nil {
+			t.Fatalf("Parse(%q): %v", in, err)
+		}
+		if got := sp.String(); got != want {
+			t.Fatalf("Parse(%q) = %q, want %q", in, got, want)
+		}
+	}
+	for _, bad := range []string{"", "1w", "+w", "+0d", "+1h", "+-1d", "..+1d"} {
+		if _, err := Parse(bad); err == nil {
+			t.Fatalf("Parse(%q): expected error", bad)
+		}
+	}
+}
+
+func TestNextDate(t *testing.T) {
+	today := time.Date(2026, 3, 18, 15, 0, 0, 0, time.UTC) // Wednesday
+	cases := []struct {
+		date, spec, want string
+	}{
+		// "+" shifts once, even if the result is still in the past.
+		{"2026-03-02", "+1w", "2026-03-09"},
+		// "++" keeps the weekday but lands after today.
+		{"2026-03-02", "++1w", "2026-03-23"},
+		{"2026-03-18", "++1d", "2026-03-19"},
+		// ".+" restarts from today.
+		{"2026-03-02", ".+2w", "2026-04-01"},
+		{"2026-01-31", "+1m", "2026-03-03"},
+		{"2026-03-18", "+1y", "2027-03-18"},
+	}
+	for _, c := range cases {
+		got, err := NextDate(c.date, c.spec, today)
+		if err != nil {
+			t.Fatalf("NextDate(%q, %q): %v", c.date, c.spec, err)
+		}
+		if got != c.want {
+			t.Fatalf("NextDate(%q, %q) = %q, want %q", c.date, c.spec, got, c.want)
+		}
+	}
+}
+
+func TestOccurrences(t *testing.T) {
+	sp, _ := Parse("+2d")
+	date := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	from := time.Date(2026, 3, 4, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2026, 3, 8, 0, 0, 0, 0, time.UTC)
+	got := sp.Occurrences(date, from, to)
+	want := []string{"2026-03-05", "2026-03-07"}
+	if len(got) != len(want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i].Format(dateLayout) != want[i] {
+			t.Fatalf("got %v, want %v", got, want)
+		}
+	}
+}
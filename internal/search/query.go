@@ -0,0 +1,300 @@
+package search
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+const dateLayout = "2006-01-02"
+
+// DateOp compares an item date against a qualifier date.
+type DateOp string
+
+const (
+	DateEq   DateOp = "="
+	DateLt   DateOp = "<"
+	DateLte  DateOp = "<="
+	DateGt   DateOp = ">"
+	DateGte  DateOp = ">="
+	DateNone DateOp = "none" // the item has no such date
+)
+
+// DateCond is a parsed `due<2026-11-01` / `schedule:today` / `due:none` / `commented>=-7d` qualifier.
+type DateCond struct {
+	Op DateOp `json:"op"`
+	// Date is YYYY-MM-DD, or relative to today (resolved at match time): today, or an offset
+	// in days/weeks like +7d, -2w.
+	Date string `json:"date,omitempty"`
+}
+
+// SortKey orders query results (see the sort: qualifier).
+type SortKey string
+
+const (
+	SortRelevance SortKey = ""         // score, then most recently updated (default)
+	SortDue       SortKey = "due"      // earliest due first; undated last
+	SortSchedule  SortKey = "schedule" // earliest scheduled first; unscheduled last
+	SortUpdated   SortKey = "updated"  // most recently updated first
+	SortCreated   SortKey = "created"  // newest first
+	SortTitle     SortKey = "title"    // alphabetical
+	SortPriority  SortKey = "priority" // priority items first, then most recently updated
+)
+
+var sortValues = map[string]SortKey{
+	"due":      SortDue,
+	"schedule": SortSchedule,
+	"sched":    SortSchedule,
+	"updated":  SortUpdated,
+	"created":  SortCreated,
+	"title":    SortTitle,
+	"priority": SortPriority,
+}
+
+// Query is a parsed search query: free-text terms plus field qualifiers.
+//
+// Semantics:
+//   - every term must match somewhere in the item (title, description, comments, visible worklog,
+//     tags, attachment titles); a term matches at the start of a word, case-insensitively
+//   - repeated status:/assignee:/owner:/project:/outline:/parent: qualifiers are OR'ed,
+//     repeated tag:/is: and date conditions are AND'ed (so due>=X due<Y is a range)
+//   - archived items (and items in archived projects/outlines) are excluded unless is:archived
+type Query struct {
+	Raw       string     `json:"raw"`
+	Terms     []string   `json:"terms,omitempty"`
+	Statuses  []string   `json:"status,omitempty"`
+	Tags      []string   `json:"tag,omitempty"`
+	Assignee  []string   `json:"assignee,omitempty"`
+	Owner     []string   `json:"owner,omitempty"`
+	Projects  []string   `json:"project,omitempty"`
+	Outlines  []string   `json:"outline,omitempty"`
+	Parents   []string   `json:"parent,omitempty"`
+	Is        []string   `json:"is,omitempty"`
+	Due       []DateCond `json:"due,omitempty"`
+	Schedule  []DateCond `json:"schedule,omitempty"`
+	Commented []DateCond `json:"commented,omitempty"`
+	Sort      SortKey    `json:"sort,omitempty"`
+}
+
+// Empty reports whether the query has neither terms nor qualifiers (sort: alone doesn't count).
+func (q Query) Empty() bool {
+	return len(q.Terms) == 0 && len(q.Statuses) == 0 && len(q.Tags) == 0 && len(q.Assignee) == 0 &&
+		len(q.Owner) == 0 && len(q.Projects) == 0 && len(q.Outlines) == 0 && len(q.Parents) == 0 &&
+		len(q.Is) == 0 && len(q.Due) == 0 && len(q.Schedule) == 0 && len(q.Commented) == 0
+}
+
+var isValues = map[string]bool{
+	"open":     true,
+	"done":     true,
+	"archived": true,
+	"priority": true,
+	"on-hold":  true,
+	"blocked":  true,
+	"ready":    true,
+}
+
+// Parse parses a query string such as:
+//
+//	deploy "release notes" status:doing tag:infra assignee:me due<2026-11-01
+//
+// Quoted phrases are single terms. Unknown qualifiers (`foo:bar`) are treated as plain text so
+// searching for things like URLs keeps working.
+func Parse(raw string) (Query, error) {
+	q := Query{Raw: strings.TrimSpace(raw)}
+	for _, tok := range tokenize(raw) {
+		if tok.quoted {
+			if t := strings.Join(strings.Fields(tok.text), " "); t != "" {
+				q.Terms = append(q.Terms, t)
+			}
+			continue
+		}
+		handled, err := q.applyQualifier(tok.text)
+		if err != nil {
+			return Query{}, err
+		}
+		if !handled {
+			q.Terms = append(q.Terms, tok.text)
+		}
+	}
+	return q, nil
+}
+
+func (q *Query) applyQualifier(tok string) (bool, error) {
+	for _, field := range []string{"due", "schedule", "sched", "commented"} {
+		if !strings.HasPrefix(strings.ToLower(tok), field) {
+			continue
+		}
+		rest := tok[len(field):]
+		cond, ok, err := parseDateCond(rest)
+		if !ok {
+			continue
+		}
+		if err != nil {
+			return false, fmt.Errorf("invalid %s qualifier %q: %w", field, tok, err)
+		}
+		switch field {
+		case "due":
+			q.Due = append(q.Due, cond)
+		case "commented":
+			q.Commented = append(q.Commented, cond)
+		default:
+			q.Schedule = append(q.Schedule, cond)
+		}
+		return true, nil
+	}
+
+	key, val, ok := strings.Cut(tok, ":")
+	if !ok {
+		return false, nil
+	}
+	val = strings.TrimSpace(val)
+	switch strings.ToLower(key) {
+	case "status":
+		q.Statuses = append(q.Statuses, val)
+	case "tag":
+		q.Tags = append(q.Tags, strings.TrimPrefix(val, "#"))
+	case "assignee":
+		q.Assignee = append(q.Assignee, val)
+	case "owner":
+		q.Owner = append(q.Owner, val)
+	case "project":
+		q.Projects = append(q.Projects, val)
+	case "outline":
+		q.Outlines = append(q.Outlines, val)
+	case "parent":
+		q.Parents = append(q.Parents, val)
+	case "is":
+		v := strings.ToLower(val)
+		switch v {
+		case "onhold":
+			v = "on-hold"
+		case "end", "end-state":
+			v = "done"
+		}
+		if !isValues[v] {
+			return false, fmt.Errorf("invalid qualifier %q (expected is:open|done|end|archived|priority|on-hold|blocked|ready)", tok)
+		}
+		q.Is = append(q.Is, v)
+	case "sort":
+		k, ok := sortValues[strings.ToLower(val)]
+		if !ok {
+			return false, fmt.Errorf("invalid qualifier %q (expected sort:due|schedule|updated|created|title|priority)", tok)
+		}
+		q.Sort = k
+	default:
+		return false, nil
+	}
+	if val == "" {
+		return false, fmt.Errorf("empty qualifier value: %q", tok)
+	}
+	return true, nil
+}
+
+// parseDateCond parses the part after the field name: ":2026-11-01", "<today", ">=2026-01-01", ":none".
+// ok=false means the token isn't a date qualifier at all (e.g. "duet" or "schedules").
+func parseDateCond(rest string) (DateCond, bool, error) {
+	var op DateOp
+	switch {
+	case strings.HasPrefix(rest, "<="):
+		op, rest = DateLte, rest[2:]
+	case strings.HasPrefix(rest, ">="):
+		op, rest = DateGte, rest[2:]
+	case strings.HasPrefix(rest, "<"):
+		op, rest = DateLt, rest[1:]
+	case strings.HasPrefix(rest, ">"):
+		op, rest = DateGt, rest[1:]
+	case strings.HasPrefix(rest, ":"):
+		op, rest = DateEq, rest[1:]
+	default:
+		return DateCond{}, false, nil
+	}
+	rest = strings.ToLower(strings.TrimSpace(rest))
+	switch rest {
+	case "":
+		return DateCond{}, true, fmt.Errorf("missing date")
+	case "none":
+		if op != DateEq {
+			return DateCond{}, true, fmt.Errorf("none only works with ':'")
+		}
+		return DateCond{Op: DateNone}, true, nil
+	case "today":
+		return DateCond{Op: op, Date: "today"}, true, nil
+	}
+	if _, ok := relativeDays(rest); ok {
+		return DateCond{Op: op, Date: rest}, true, nil
+	}
+	if _, err := time.Parse(dateLayout, rest); err != nil {
+		return DateCond{}, true, fmt.Errorf("expected YYYY-MM-DD, today, an offset like +7d/-2w, or none")
+	}
+	return DateCond{Op: op, Date: rest}, true, nil
+}
+
+// relativeDays parses day offsets like "+7d", "-3d", "+2w".
+func relativeDays(s string) (int, bool) {
+	if len(s) < 3 || (s[0] != '+' && s[0] != '-') {
+		return 0, false
+	}
+	unit := 1
+	switch s[len(s)-1] {
+	case 'd':
+	case 'w':
+		unit = 7
+	default:
+		return 0, false
+	}
+	n, err := strconv.Atoi(s[1 : len(s)-1])
+	if err != nil || n < 0 {
+		return 0, false
+	}
+	if s[0] == '-' {
+		n = -n
+	}
+	return n * unit, true
+}
+
+// ResolveDate turns a DateCond date into YYYY-MM-DD relative to today.
+func ResolveDate(date string, today time.Time) string {
+	if date == "today" {
+		return today.Format(dateLayout)
+	}
+	if n, ok := relativeDays(date); ok {
+		return today.AddDate(0, 0, n).Format(dateLayout)
+	}
+	return date
+}
+
+type token struct {
+	text   string
+	quoted bool
+}
+
+func tokenize(s string) []token {
+	var out []token
+	var b strings.Builder
+	inQuote := false
+	flush := func(quoted bool) {
+		if b.Len() > 0 || quoted {
+			out = append(out, token{text: b.String(), quoted: quoted})
+		}
+		b.Reset()
+	}
+	for _, r := range s {
+		switch {
+		case r == '"':
+			if inQuote {
+				flush(true)
+			} else {
+				flush(false)
+			}
+			inQuote = !inQuote
+		case unicode.IsSpace(r) && !inQuote:
+			flush(false)
+		default:
+			b.WriteRune(r)
+		}
+	}
+	flush(inQuote)
+	return out
+}
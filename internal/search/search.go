@@ -0,0 +1,547 @@
+package search
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"clarity-cli/internal/model"
+	"clarity-cli/internal/readiness"
+	"clarity-cli/internal/statusutil"
+	"clarity-cli/internal/store"
+)
+
+// Field names where a term matched.
+type Field string
+
+const (
+	FieldTitle       Field = "title"
+	FieldTags        Field = "tags"
+	FieldDescription Field = "description"
+	FieldAttachment  Field = "attachment"
+	FieldComment     Field = "comment"
+	FieldWorklog     Field = "worklog"
+)
+
+// fieldWeight ranks matches: a hit in the title beats one buried in a comment.
+var fieldWeight = map[Field]int{
+	FieldTitle:       10,
+	FieldTags:        6,
+	FieldDescription: 4,
+	FieldAttachment:  3,
+	FieldComment:     2,
+	FieldWorklog:     2,
+}
+
+// DefaultMaxMatches caps the snippets returned per item.
+const DefaultMaxMatches = 3
+
+type Options struct {
+	// ActorID is the searching actor: it resolves assignee:me and limits worklog to the actor's human user.
+	ActorID string
+	// Today resolves "today" in date qualifiers (default: time.Now()).
+	Today time.Time
+	// Limit caps the number of hits (0 = all).
+	Limit int
+	// MaxMatches caps snippets per hit (0 = DefaultMaxMatches).
+	MaxMatches int
+	// Candidates optionally restricts the scan to these item ids (e.g. from the SQLite FTS index).
+	// nil means scan every item.
+	Candidates map[string]bool
+}
+
+// Span is a highlighted byte range [Start, End) within a snippet.
+type Span struct {
+	Start int `json:"start"`
+	End   int `json:"end"`
+}
+
+type Match struct {
+	Field      Field  `json:"field"`
+	ID         string `json:"id,omitempty"` // comment/worklog/attachment id
+	Snippet    string `json:"snippet"`
+	Highlights []Span `json:"highlights,omitempty"`
+}
+
+type Hit struct {
+	ItemID    string  `json:"itemId"`
+	Title     string  `json:"title"`
+	ProjectID string  `json:"projectId"`
+	OutlineID string  `json:"outlineId"`
+	StatusID  string  `json:"status,omitempty"`
+	Score     int     `json:"score"`
+	Matches   []Match `json:"matches"`
+}
+
+type Result struct {
+	Query Query `json:"query"`
+	Hits  []Hit `json:"hits"`
+	// Total is the number of matching items before Limit was applied.
+	Total int `json:"total"`
+}
+
+// doc is one searchable text belonging to an item.
+type doc struct {
+	field Field
+	id    string
+	text  string
+}
+
+// Run evaluates q over all items in db.
+func Run(db *store.DB, q Query, opts Options) Result {
+	res := Result{Query: q, Hits: []Hit{}}
+	if db == nil {
+		return res
+	}
+	today := opts.Today
+	if today.IsZero() {
+		today = time.Now()
+	}
+	maxMatches := opts.MaxMatches
+	if maxMatches <= 0 {
+		maxMatches = DefaultMaxMatches
+	}
+	humanID, _ := db.HumanUserIDForActor(strings.TrimSpace(opts.ActorID))
+
+	items := map[string]model.Item{}
+	for _, it := range db.Items {
+		if opts.Candidates != nil && !opts.Candidates[it.ID] {
+			continue
+		}
+		if !matchesFilters(db, it, q, opts.ActorID, today) {
+			continue
+		}
+		hit := Hit{
+			ItemID:    it.ID,
+			Title:     it.Title,
+			ProjectID: it.ProjectID,
+			OutlineID: it.OutlineID,
+			StatusID:  it.StatusID,
+			Matches:   []Match{},
+		}
+		if len(q.Terms) > 0 {
+			docs := itemDocs(db, it, humanID)
+			matched := make([]bool, len(q.Terms))
+			for _, d := range docs {
+				hitTerm := false
+				for ti, term := range q.Terms {
+					if len(findTerm(flatten(d.text), term)) == 0 {
+						continue
+					}
+					hitTerm = true
+					if !matched[ti] {
+						matched[ti] = true
+						hit.Score += fieldWeight[d.field]
+					}
+				}
+				if hitTerm && len(hit.Matches) < maxMatches {
+					snip, spans := Snippet(d.text, q.Terms)
+					hit.Matches = append(hit.Matches, Match{Field: d.field, ID: d.id, Snippet: snip, Highlights: spans})
+				}
+			}
+			if !allTrue(matched) {
+				continue
+			}
+		}
+		items[it.ID] = it
+		res.Hits = append(res.Hits, hit)
+	}
+
+	sort.SliceStable(res.Hits, func(i, j int) bool {
+		a, b := res.Hits[i], res.Hits[j]
+		ia, ib := items[a.ItemID], items[b.ItemID]
+		if c := compareBySort(q.Sort, ia, ib); c != 0 {
+			return c < 0
+		}
+		if a.Score != b.Score {
+			return a.Score > b.Score
+		}
+		if !ia.UpdatedAt.Equal(ib.UpdatedAt) {
+			return ia.UpdatedAt.After(ib.UpdatedAt)
+		}
+		return a.ItemID < b.ItemID
+	})
+	res.Total = len(res.Hits)
+	if opts.Limit > 0 && len(res.Hits) > opts.Limit {
+		res.Hits = res.Hits[:opts.Limit]
+	}
+	return res
+}
+
+// itemDocs returns the item's searchable texts, in field-weight order.
+// Worklog is private: only entries written by the searching actor's human user (or their agents) are included.
+func itemDocs(db *store.DB, it model.Item, humanID string) []doc {
+	docs := []doc{{field: FieldTitle, text: it.Title}}
+	if len(it.Tags) > 0 {
+		docs = append(docs, doc{field: FieldTags, text: strings.Join(it.Tags, " ")})
+	}
+	if strings.TrimSpace(it.Description) != "" {
+		docs = append(docs, doc{field: FieldDescription, text: it.Description})
+	}
+	comments := db.CommentsForItem(it.ID)
+	attachments := append([]model.Attachment{}, db.AttachmentsForItem(it.ID)...)
+	for _, c := range comments {
+		attachments = append(attachments, db.AttachmentsForComment(c.ID)...)
+	}
+	for _, a := range attachments {
+		docs = append(docs, doc{field: FieldAttachment, id: a.ID, text: attachmentText(a)})
+	}
+	for _, c := range comments {
+		docs = append(docs, doc{field: FieldComment, id: c.ID, text: c.Body})
+	}
+	if humanID != "" {
+		for _, w := range db.WorklogForItem(it.ID) {
+			if authorHuman, ok := db.HumanUserIDForActor(w.AuthorID); ok && authorHuman == humanID {
+				docs = append(docs, doc{field: FieldWorklog, id: w.ID, text: w.Body})
+			}
+		}
+	}
+	return docs
+}
+
+// flatten collapses whitespace so phrases match across line breaks.
+func flatten(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+func attachmentText(a model.Attachment) string {
+	parts := make([]string, 0, 3)
+	if t := strings.TrimSpace(a.Title); t != "" {
+		parts = append(parts, t)
+	}
+	if n := strings.TrimSpace(a.OriginalName); n != "" && n != strings.TrimSpace(a.Title) {
+		parts = append(parts, n)
+	}
+	if t := strings.TrimSpace(a.Text); t != "" {
+		parts = append(parts, t)
+	}
+	return strings.Join(parts, " ")
+}
+
+// compareBySort orders two items by an explicit sort: qualifier (0 = tie, fall back to relevance).
+func compareBySort(k SortKey, a, b model.Item) int {
+	switch k {
+	case SortDue:
+		return compareDates(a.Due, b.Due)
+	case SortSchedule:
+		return compareDates(a.Schedule, b.Schedule)
+	case SortUpdated:
+		return -a.UpdatedAt.Compare(b.UpdatedAt)
+	case SortCreated:
+		return -a.CreatedAt.Compare(b.CreatedAt)
+	case SortTitle:
+		return strings.Compare(strings.ToLower(a.Title), strings.ToLower(b.Title))
+	case SortPriority:
+		if a.Priority != b.Priority {
+			if a.Priority {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
+// compareDates sorts earlier dates (then times) first; items without a date go last.
+func compareDates(a, b *model.DateTime) int {
+	ad, bd := "", ""
+	if a != nil {
+		ad = strings.TrimSpace(a.Date)
+	}
+	if b != nil {
+		bd = strings.TrimSpace(b.Date)
+	}
+	switch {
+	case ad == bd && ad == "":
+		return 0
+	case ad == "":
+		return 1
+	case bd == "":
+		return -1
+	case ad != bd:
+		return strings.Compare(ad, bd)
+	}
+	at, bt := "", ""
+	if a.Time != nil {
+		at = *a.Time
+	}
+	if b.Time != nil {
+		bt = *b.Time
+	}
+	return strings.Compare(at, bt)
+}
+
+func matchesFilters(db *store.DB, it model.Item, q Query, actorID string, today time.Time) bool {
+	outline, _ := db.FindOutline(it.OutlineID)
+	archived := it.Archived
+	if p, ok := db.FindProject(it.ProjectID); ok && p.Archived {
+		archived = true
+	}
+	if outline != nil && outline.Archived {
+		archived = true
+	}
+	if archived && !contains(q.Is, "archived") {
+		return false
+	}
+
+	if len(q.Statuses) > 0 && !anyOf(q.Statuses, func(s string) bool { return statusMatches(outline, it.StatusID, s) }) {
+		return false
+	}
+	for _, tag := range q.Tags {
+		if !hasTag(it.Tags, tag) {
+			return false
+		}
+	}
+	if len(q.Assignee) > 0 && !anyOf(q.Assignee, func(s string) bool { return assigneeMatches(db, it, s, actorID) }) {
+		return false
+	}
+	if len(q.Owner) > 0 && !anyOf(q.Owner, func(s string) bool { return ownerMatches(db, it, s, actorID) }) {
+		return false
+	}
+	if len(q.Parents) > 0 && !anyOf(q.Parents, func(s string) bool { return parentMatches(it, s) }) {
+		return false
+	}
+	if len(q.Projects) > 0 && !anyOf(q.Projects, func(s string) bool { return projectMatches(db, it, s) }) {
+		return false
+	}
+	if len(q.Outlines) > 0 && !anyOf(q.Outlines, func(s string) bool { return outlineMatches(outline, s) }) {
+		return false
+	}
+	for _, v := range q.Is {
+		if !isMatches(db, it, outline, v) {
+			return false
+		}
+	}
+	for _, c := range q.Due {
+		if !dateMatches(it.Due, c, today) {
+			return false
+		}
+	}
+	for _, c := range q.Schedule {
+		if !dateMatches(it.Schedule, c, today) {
+			return false
+		}
+	}
+	if len(q.Commented) > 0 && !commentedMatches(db, it.ID, q.Commented, today) {
+		return false
+	}
+	return true
+}
+
+func statusMatches(outline *model.Outline, statusID, want string) bool {
+	want = strings.TrimSpace(want)
+	if strings.EqualFold(want, "none") {
+		return strings.TrimSpace(statusID) == ""
+	}
+	if strings.EqualFold(statusID, want) {
+		return true
+	}
+	if outline != nil {
+		for _, def := range outline.StatusDefs {
+			if def.ID == statusID && strings.EqualFold(def.Label, want) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func hasTag(tags []string, want string) bool {
+	for _, t := range tags {
+		if strings.EqualFold(strings.TrimSpace(t), want) {
+			return true
+		}
+	}
+	return false
+}
+
+func assigneeMatches(db *store.DB, it model.Item, want, actorID string) bool {
+	assigned := ""
+	if it.AssignedActorID != nil {
+		assigned = strings.TrimSpace(*it.AssignedActorID)
+	}
+	switch strings.ToLower(want) {
+	case "none":
+		return assigned == ""
+	case "me":
+		// "me" covers the actor's human user and all of their agents.
+		if assigned == "" {
+			return false
+		}
+		me, ok := db.HumanUserIDForActor(actorID)
+		if !ok {
+			return assigned == actorID
+		}
+		h, ok := db.HumanUserIDForActor(assigned)
+		return ok && h == me
+	}
+	if assigned == want {
+		return true
+	}
+	if a, ok := db.FindActor(assigned); ok && strings.EqualFold(a.Name, want) {
+		return true
+	}
+	return false
+}
+
+func ownerMatches(db *store.DB, it model.Item, want, actorID string) bool {
+	owner := strings.TrimSpace(it.OwnerActorID)
+	if owner == "" {
+		return false
+	}
+	if strings.EqualFold(want, "me") {
+		me, ok := db.HumanUserIDForActor(actorID)
+		if !ok {
+			return owner == actorID
+		}
+		h, ok := db.HumanUserIDForActor(owner)
+		return ok && h == me
+	}
+	if owner == want {
+		return true
+	}
+	a, ok := db.FindActor(owner)
+	return ok && strings.EqualFold(a.Name, want)
+}
+
+// parentMatches matches direct children of an item id; parent:none matches top-level items.
+func parentMatches(it model.Item, want string) bool {
+	parent := ""
+	if it.ParentID != nil {
+		parent = strings.TrimSpace(*it.ParentID)
+	}
+	if strings.EqualFold(want, "none") {
+		return parent == ""
+	}
+	return parent == want
+}
+
+// commentedMatches reports whether some comment on the item satisfies every condition
+// (commented:none matches items without comments).
+func commentedMatches(db *store.DB, itemID string, conds []DateCond, today time.Time) bool {
+	comments := db.CommentsForItem(itemID)
+	for _, c := range conds {
+		if c.Op == DateNone {
+			return len(comments) == 0
+		}
+	}
+	for _, cm := range comments {
+		dt := &model.DateTime{Date: cm.CreatedAt.In(today.Location()).Format(dateLayout)}
+		ok := true
+		for _, c := range conds {
+			if !dateMatches(dt, c, today) {
+				ok = false
+				break
+			}
+		}
+		if ok {
+			return true
+		}
+	}
+	return false
+}
+
+func projectMatches(db *store.DB, it model.Item, want string) bool {
+	if it.ProjectID == want {
+		return true
+	}
+	p, ok := db.FindProject(it.ProjectID)
+	return ok && strings.EqualFold(p.Name, want)
+}
+
+func outlineMatches(o *model.Outline, want string) bool {
+	if o == nil {
+		return false
+	}
+	if o.ID == want {
+		return true
+	}
+	return o.Name != nil && strings.EqualFold(strings.TrimSpace(*o.Name), want)
+}
+
+func isMatches(db *store.DB, it model.Item, outline *model.Outline, v string) bool {
+	endState := false
+	if outline != nil {
+		endState = statusutil.IsEndState(*outline, it.StatusID)
+	} else {
+		endState = statusutil.IsEndState(model.Outline{}, it.StatusID)
+	}
+	switch v {
+	case "open":
+		return !endState && !it.Archived
+	case "done":
+		return endState
+	case "archived":
+		// Archived items are let through by matchesFilters; this narrows to them.
+		if it.Archived || (outline != nil && outline.Archived) {
+			return true
+		}
+		p, ok := db.FindProject(it.ProjectID)
+		return ok && p.Archived
+	case "priority":
+		return it.Priority
+	case "on-hold":
+		return it.OnHold
+	case "blocked":
+		return readiness.IsBlocked(db, it.ID)
+	case "ready":
+		// Same rule as `clarity items ready` (minus its assignment filter).
+		return !endState && !it.Archived && !it.OnHold && !readiness.IsBlocked(db, it.ID)
+	}
+	return false
+}
+
+func dateMatches(dt *model.DateTime, c DateCond, today time.Time) bool {
+	date := ""
+	if dt != nil {
+		date = strings.TrimSpace(dt.Date)
+	}
+	if c.Op == DateNone {
+		return date == ""
+	}
+	if date == "" {
+		return false
+	}
+	want := ResolveDate(c.Date, today)
+	// YYYY-MM-DD compares correctly as a string.
+	switch c.Op {
+	case DateEq:
+		return date == want
+	case DateLt:
+		return date < want
+	case DateLte:
+		return date <= want
+	case DateGt:
+		return date > want
+	case DateGte:
+		return date >= want
+	}
+	return false
+}
+
+func anyOf(xs []string, f func(string) bool) bool {
+	for _, x := range xs {
+		if f(x) {
+			return true
+		}
+	}
+	return false
+}
+
+func contains(xs []string, v string) bool {
+	for _, x := range xs {
+		if x == v {
+			return true
+		}
+	}
+	return false
+}
+
+func allTrue(xs []bool) bool {
+	for _, x := range xs {
+		if !x {
+			return false
+		}
+	}
+	return true
+}
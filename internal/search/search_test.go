@@ -0,0 +1,217 @@
+package search
+
+import (
+	"testing"
+	"time"
+
+	"clarity-cli/internal/model"
+	"clarity-cli/internal/store"
+)
+
+func mustParse(t *testing.T, s string) Query {
+	t.Helper()
+	q, err := Parse(s)
+	if err != nil {
+		t.Fatalf("parse %q: %v", s, err)
+	}
+	return q
+}
+
+func hitIDs(res Result) []string {
+	out := make([]string, 0, len(res.Hits))
+	for _, h := range res.Hits {
+		out = append(out, h.ItemID)
+	}
+	return out
+}
+
+func TestParse_TermsPhrasesAndQualifiers(t *testing.T) {
+	q := mustParse(t, `deploy "release  notes" status:doing tag:#infra assignee:me due<2026-11-01 sched:none is:open http://x`)
+	if len(q.Terms) != 3 || q.Terms[0] != "deploy" || q.Terms[1] != "release notes" || q.Terms[2] != "http://x" {
+		t.Fatalf("unexpected terms: %#v", q.Terms)
+	}
+	if len(q.Statuses) != 1 || q.Tags[0] != "infra" || q.Assignee[0] != "me" || q.Is[0] != "open" {
+		t.Fatalf("unexpected qualifiers: %#v", q)
+	}
+	if len(q.Due) != 1 || q.Due[0] != (DateCond{Op: DateLt, Date: "2026-11-01"}) {
+		t.Fatalf("unexpected due: %#v", q.Due)
+	}
+	if len(q.Schedule) != 1 || q.Schedule[0].Op != DateNone {
+		t.Fatalf("unexpected schedule: %#v", q.Schedule)
+	}
+
+	for _, bad := range []string{"due<tomorrow", "is:weird", "tag:", "due>none"} {
+		if _, err := Parse(bad); err == nil {
+			t.Fatalf("expected parse error for %q", bad)
+		}
+	}
+}
+
+func TestSnippet_HighlightsAndMark(t *testing.T) {
+	snip, spans := Snippet("Needs a redeploy check before\ndeploying", []string{"deploy"})
+	if snip != "Needs a redeploy check before deploying" {
+		t.Fatalf("unexpected snippet: %q", snip)
+	}
+	if got := Mark(snip, spans, "**", "**"); got != "Needs a redeploy check before **deploy**ing" {
+		t.Fatalf("unexpected marked snippet: %q", got)
+	}
+
+	long := ""
+	for i := 0; i < 40; i++ {
+		long += "filler "
+	}
+	snip, spans = Snippet(long+"needle "+long, []string{"needle"})
+	if len(spans) != 1 || snip[spans[0].Start:spans[0].End] != "needle" {
+		t.Fatalf("expected needle highlighted in %q (%v)", snip, spans)
+	}
+	if snip[:len("…")] != "…" || snip[len(snip)-len("…"):] != "…" {
+		t.Fatalf("expected ellipses on both sides: %q", snip)
+	}
+}
+
+func TestRun(t *testing.T) {
+	now := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	humanA, humanB := "act-a", "act-b"
+	agentA := "act-agent"
+	assigned := agentA
+	name := "Ops"
+	parent := "item-1"
+	today := time.Date(2026, 10, 17, 0, 0, 0, 0, time.UTC)
+	db := &store.DB{
+		Actors: []model.Actor{
+			{ID: humanA, Kind: model.ActorKindHuman, Name: "Alice"},
+			{ID: humanB, Kind: model.ActorKindHuman, Name: "Bob"},
+			{ID: agentA, Kind: model.ActorKindAgent, Name: "alice-agent", UserID: &humanA},
+		},
+		Projects: []model.Project{{ID: "proj-a", Name: "Platform"}, {ID: "proj-old", Name: "Old", Archived: true}},
+		Outlines: []model.Outline{
+			{ID: "out-a", ProjectID: "proj-a", Name: &name, StatusDefs: store.DefaultOutlineStatusDefs()},
+			{ID: "out-old", ProjectID: "proj-old", StatusDefs: store.DefaultOutlineStatusDefs()},
+		},
+		Items: []model.Item{
+			{ID: "item-1", ProjectID: "proj-a", OutlineID: "out-a", Title: "Deploy pipeline", StatusID: "doing", Tags: []string{"infra"},
+				AssignedActorID: &assigned, OwnerActorID: humanA, Due: &model.DateTime{Date: "2026-10-20"}, CreatedAt: now, UpdatedAt: now},
+			{ID: "item-2", ProjectID: "proj-a", OutlineID: "out-a", Title: "Write docs", StatusID: "todo",
+				Description: "Explain the\nrelease notes process", ParentID: &parent, OwnerActorID: humanB, Due: &model.DateTime{Date: "2026-12-01"}, CreatedAt: today, UpdatedAt: now},
+			{ID: "item-3", ProjectID: "proj-a", OutlineID: "out-a", Title: "Archived deploy", Archived: true, UpdatedAt: now},
+			{ID: "item-4", ProjectID: "proj-old", OutlineID: "out-old", Title: "Old deploy", UpdatedAt: now},
+		},
+		Comments: []model.Comment{{ID: "c-1", ItemID: "item-2", AuthorID: humanB, Body: "Needs a redeploy check before deploying", CreatedAt: today.AddDate(0, 0, -2)}},
+		Worklog: []model.WorklogEntry{
+			{ID: "w-1", ItemID: "item-2", AuthorID: agentA, Body: "benchmarked the deploy", CreatedAt: now},
+			{ID: "w-2", ItemID: "item-1", AuthorID: humanB, Body: "secret kubernetes notes", CreatedAt: now},
+		},
+		Attachments: []model.Attachment{
+			{ID: "att-1", EntityKind: "comment", EntityID: "c-1", Title: "Runbook", OriginalName: "runbook.pdf"},
+			{ID: "att-2", EntityKind: "item", EntityID: "item-1", OriginalName: "rollback.md", Text: "# Rollback\n\nDrain the canary first."},
+		},
+	}
+
+	t.Run("matches across fields and ranks title first", func(t *testing.T) {
+		res := Run(db, mustParse(t, "deploy"), Options{ActorID: "act-a"})
+		ids := hitIDs(res)
+		if len(ids) != 2 || ids[0] != "item-1" || ids[1] != "item-2" {
+			t.Fatalf("expected title hit first and archived items excluded, got %v", ids)
+		}
+		// item-2 matches via the comment ("deploying", but not "redeploy") and the actor's agent's worklog.
+		var fields []Field
+		for _, m := range res.Hits[1].Matches {
+			fields = append(fields, m.Field)
+		}
+		if len(fields) != 2 || fields[0] != FieldComment || fields[1] != FieldWorklog {
+			t.Fatalf("unexpected match fields: %v", fields)
+		}
+
+		res = Run(db, mustParse(t, "deploy is:archived"), Options{ActorID: "act-a"})
+		if ids := hitIDs(res); len(ids) != 2 || ids[0] != "item-3" || ids[1] != "item-4" {
+			t.Fatalf("expected archived items (incl. archived project), got %v", ids)
+		}
+	})
+
+	t.Run("worklog is private to the actor's human", func(t *testing.T) {
+		if res := Run(db, mustParse(t, "kubernetes"), Options{ActorID: "act-a"}); len(res.Hits) != 0 {
+			t.Fatalf("expected other user's worklog to be hidden, got %v", hitIDs(res))
+		}
+		if res := Run(db, mustParse(t, "kubernetes"), Options{ActorID: "act-b"}); len(res.Hits) != 1 {
+			t.Fatalf("expected author's own worklog to match, got %v", hitIDs(res))
+		}
+	})
+
+	t.Run("qualifiers filter", func(t *testing.T) {
+		cases := map[string][]string{
+			"status:DOING":                   {"item-1"},
+			"tag:infra assignee:me":          {"item-1"},
+			"assignee:none":                  {"item-2"},
+			"due<2026-11-01":                 {"item-1"},
+			"due>today project:platform":     {"item-1", "item-2"},
+			"outline:ops runbook":            {"item-2"},
+			`"release notes"`:                {"item-2"},
+			"canary":                         {"item-1"},
+			"status:doing status:todo write": {"item-2"},
+		}
+		for q, want := range cases {
+			res := Run(db, mustParse(t, q), Options{ActorID: "act-a", Today: today})
+			got := hitIDs(res)
+			if len(got) != len(want) {
+				t.Fatalf("%q: expected %v, got %v", q, want, got)
+			}
+			for i := range want {
+				if got[i] != want[i] {
+					t.Fatalf("%q: expected %v, got %v", q, want, got)
+				}
+			}
+		}
+	})
+
+	t.Run("candidates and limit", func(t *testing.T) {
+		res := Run(db, mustParse(t, "deploy"), Options{ActorID: "act-a", Candidates: map[string]bool{"item-2": true}})
+		if ids := hitIDs(res); len(ids) != 1 || ids[0] != "item-2" {
+			t.Fatalf("expected candidates to narrow the scan, got %v", ids)
+		}
+		res = Run(db, mustParse(t, "deploy"), Options{ActorID: "act-a", Limit: 1})
+		if len(res.Hits) != 1 || res.Total != 2 {
+			t.Fatalf("expected limit=1 total=2, got %d/%d", len(res.Hits), res.Total)
+		}
+	})
+
+	t.Run("view qualifiers", func(t *testing.T) {
+		cases := map[string][]string{
+			"owner:me":                         {"item-1"},
+			"owner:bob":                        {"item-2"},
+			"parent:item-1":                    {"item-2"},
+			"parent:none project:platform":     {"item-1"},
+			"commented>=-3d":                   {"item-2"},
+			"commented>=-1d":                   {},
+			"commented:none project:platform":  {"item-1"},
+			"due>=today due<+2w":               {"item-1"},
+			"is:end project:platform":          {},
+			"is:ready project:platform":        {"item-1", "item-2"},
+			"project:platform sort:due":        {"item-1", "item-2"},
+			"project:platform sort:created":    {"item-2", "item-1"},
+			"project:platform sort:title":      {"item-1", "item-2"},
+			"tag:infra sort:priority is:ready": {"item-1"},
+		}
+		for q, want := range cases {
+			res := Run(db, mustParse(t, q), Options{ActorID: "act-a", Today: today})
+			got := hitIDs(res)
+			if len(got) != len(want) {
+				t.Fatalf("%q: expected %v, got %v", q, want, got)
+			}
+			for i := range want {
+				if got[i] != want[i] {
+					t.Fatalf("%q: expected %v, got %v", q, want, got)
+				}
+			}
+		}
+
+		if _, err := Parse("sort:random"); err == nil {
+			t.Fatalf("expected error for unknown sort key")
+		}
+		if q := mustParse(t, "sort:due"); !q.Empty() || q.Sort != SortDue {
+			t.Fatalf("expected sort-only query to be empty with sort=due, got %#v", q)
+		}
+		if got := ResolveDate("-2w", today); got != "2026-10-03" {
+			t.Fatalf("unexpected relative date: %s", got)
+		}
+	})
+}
@@ -0,0 +1,141 @@
+package search
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	snippetBefore = 30 // runes of context before the first match
+	snippetWidth  = 120
+)
+
+// findTerm returns the byte spans in text where term matches at the start of a word
+// (case-insensitive). This mirrors FTS5 prefix queries ("term"*), so the SQLite index
+// and the in-memory scan agree on what matches.
+func findTerm(text, term string) []Span {
+	term = strings.TrimSpace(term)
+	if term == "" || text == "" {
+		return nil
+	}
+	var out []Span
+	prev := rune(-1)
+	for i, r := range text {
+		if prev == -1 || !isWordRune(prev) || !isWordRune(r) {
+			if n, ok := hasPrefixFold(text[i:], term); ok {
+				out = append(out, Span{Start: i, End: i + n})
+			}
+		}
+		prev = r
+	}
+	return out
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// hasPrefixFold reports whether s starts with prefix under Unicode case folding,
+// returning the number of bytes of s consumed.
+func hasPrefixFold(s, prefix string) (int, bool) {
+	n := 0
+	for _, pr := range prefix {
+		if n >= len(s) {
+			return 0, false
+		}
+		sr, size := utf8.DecodeRuneInString(s[n:])
+		if sr != pr && !strings.EqualFold(string(sr), string(pr)) {
+			return 0, false
+		}
+		n += size
+	}
+	return n, true
+}
+
+// Snippet returns a single-line excerpt of text around the first term match, with the byte
+// spans of every term match inside the excerpt.
+func Snippet(text string, terms []string) (string, []Span) {
+	flat := strings.Join(strings.Fields(text), " ")
+
+	first := -1
+	for _, t := range terms {
+		if spans := findTerm(flat, t); len(spans) > 0 && (first == -1 || spans[0].Start < first) {
+			first = spans[0].Start
+		}
+	}
+	if first == -1 {
+		first = 0
+	}
+
+	runes := []rune(flat)
+	firstRune := utf8.RuneCountInString(flat[:first])
+	start := firstRune - snippetBefore
+	if start < 0 {
+		start = 0
+	}
+	end := start + snippetWidth
+	if end > len(runes) {
+		end = len(runes)
+		if end-snippetWidth < start {
+			start = end - snippetWidth
+			if start < 0 {
+				start = 0
+			}
+		}
+	}
+	snip := string(runes[start:end])
+	if start > 0 {
+		snip = "…" + snip
+	}
+	if end < len(runes) {
+		snip += "…"
+	}
+
+	var spans []Span
+	for _, t := range terms {
+		spans = append(spans, findTerm(snip, t)...)
+	}
+	return snip, mergeSpans(spans)
+}
+
+func mergeSpans(spans []Span) []Span {
+	if len(spans) == 0 {
+		return nil
+	}
+	sort.Slice(spans, func(i, j int) bool { return spans[i].Start < spans[j].Start })
+	out := []Span{spans[0]}
+	for _, s := range spans[1:] {
+		last := &out[len(out)-1]
+		if s.Start <= last.End {
+			if s.End > last.End {
+				last.End = s.End
+			}
+			continue
+		}
+		out = append(out, s)
+	}
+	return out
+}
+
+// Mark wraps each highlighted span of snippet in open/close markers (e.g. "**" for Markdown).
+func Mark(snippet string, spans []Span, open, close string) string {
+	if len(spans) == 0 {
+		return snippet
+	}
+	var b strings.Builder
+	pos := 0
+	for _, s := range spans {
+		if s.Start < pos || s.End > len(snippet) {
+			continue
+		}
+		b.WriteString(snippet[pos:s.Start])
+		b.WriteString(open)
+		b.WriteString(snippet[s.Start:s.End])
+		b.WriteString(close)
+		pos = s.End
+	}
+	b.WriteString(snippet[pos:])
+	return b.String()
+}
@@ -0,0 +1,327 @@
+package store
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"clarity-cli/internal/model"
+)
+
+// Project- and outline-level roles.
+//
+// meta/users.json (committed) can grant actors a role on a project or an outline (RoleGrant). A
+// scope with at least one grant is restricted:
+//   - viewer (or no grant): can't write in it
+//   - contributor: the ownership rules of perm.CanEditItem apply as usual, and they can create
+//     items, comment, log work and attach files
+//   - maintainer: can edit any item, and the outline/project itself (rename, statuses, archive)
+//
+// An outline grant takes precedence over a project grant; an agent without its own grant has its
+// human's role. Scopes without grants are open to every actor, as before roles existed.
+//
+// Mutations check roles up front (perm, cli, tui), and replay applies the same rules to every
+// event, by event time: an event whose actor lacked the role is skipped and reported by doctor
+// (event_unauthorized), so a replica that ignores the rules can't change shared state.
+//
+// Grant bounds (since/until) are compared with the event's issuedAt, which its writer sets: they
+// are not a security boundary. An actor whose grant ended can still write an event dated inside
+// it; only removing the grant (which also rejects their earlier events) shuts them out.
+
+const (
+	RoleViewer      = "viewer"
+	RoleContributor = "contributor"
+	RoleMaintainer  = "maintainer"
+)
+
+func roleRank(role string) int {
+	switch role {
+	case RoleViewer:
+		return 1
+	case RoleContributor:
+		return 2
+	case RoleMaintainer:
+		return 3
+	default:
+		return 0
+	}
+}
+
+// AccessRules are the role grants of meta/users.json. A nil *AccessRules (no grants) allows
+// everything.
+type AccessRules struct {
+	grants []RoleGrant
+	digest string
+}
+
+// NewAccessRules returns the rules declared in f, or nil when it grants no roles.
+func NewAccessRules(f UsersFile) *AccessRules {
+	if len(f.Roles) == 0 {
+		return nil
+	}
+	b, _ := json.Marshal(f.Roles)
+	sum := sha256.Sum256(b)
+	return &AccessRules{grants: f.Roles, digest: "sha256:" + hex.EncodeToString(sum[:])}
+}
+
+// Digest identifies the rules, so state replayed under other rules can be told apart ("" for none).
+func (r *AccessRules) Digest() string {
+	if r == nil {
+		return ""
+	}
+	return r.digest
+}
+
+func (s Store) loadAccessRules() (*AccessRules, error) {
+	f, _, err := LoadUsers(s.workspaceRoot())
+	if err != nil {
+		return nil, err
+	}
+	return NewAccessRules(f), nil
+}
+
+func (g RoleGrant) startedBy(at time.Time) bool {
+	return g.Since == nil || !at.Before(*g.Since)
+}
+
+func (g RoleGrant) activeAt(at time.Time) bool {
+	return g.startedBy(at) && (g.Until == nil || at.Before(*g.Until))
+}
+
+// roleAt returns actorID's role on the outline (or, without an outline grant, its project) at
+// time at, and whether the scope is restricted at all.
+func (r *AccessRules) roleAt(db *DB, actorID, projectID, outlineID string, at time.Time) (string, bool) {
+	if r == nil {
+		return "", false
+	}
+	actorID = strings.TrimSpace(actorID)
+	human := ""
+	if db != nil {
+		if h, ok := db.HumanUserIDForActor(actorID); ok && h != actorID {
+			human = h
+		}
+	}
+
+	restricted := false
+	lookup := func(inScope func(g RoleGrant) bool) string {
+		for _, g := range r.grants {
+			if inScope(g) && g.startedBy(at) {
+				restricted = true
+				break
+			}
+		}
+		// The actor's own grant wins over its human's.
+		for _, who := range []string{actorID, human} {
+			if who == "" {
+				continue
+			}
+			role := ""
+			for _, g := range r.grants {
+				if g.ActorID == who && inScope(g) && g.activeAt(at) && roleRank(g.Role) > roleRank(role) {
+					role = g.Role
+				}
+			}
+			if role != "" {
+				return role
+			}
+		}
+		return ""
+	}
+
+	if outlineID = strings.TrimSpace(outlineID); outlineID != "" {
+		if role := lookup(func(g RoleGrant) bool { return g.OutlineID == outlineID }); role != "" {
+			return role, true
+		}
+	}
+	if projectID = strings.TrimSpace(projectID); projectID != "" {
+		if role := lookup(func(g RoleGrant) bool { return g.ProjectID == projectID }); role != "" {
+			return role, true
+		}
+	}
+	return "", restricted
+}
+
+// allows reports whether actorID has at least role min on the scope at time at.
+func (r *AccessRules) allows(db *DB, actorID, projectID, outlineID, min string, at time.Time) bool {
+	role, restricted := r.roleAt(db, actorID, projectID, outlineID, at)
+	return !restricted || roleRank(role) >= roleRank(min)
+}
+
+// SetAccessRules sets the rules role checks on db use (Load sets them from meta/users.json).
+func (db *DB) SetAccessRules(r *AccessRules) {
+	db.access = r
+}
+
+// RoleOf returns actorID's current role on the outline (or the project, when outlineID is "").
+// restricted is false when the scope has no grants; role is "" when the actor has none.
+func (db *DB) RoleOf(actorID, projectID, outlineID string) (role string, restricted bool) {
+	return db.access.roleAt(db, actorID, projectID, outlineID, time.Now().UTC())
+}
+
+// HasRole reports whether actorID may currently act with role min on the outline (or the
+// project, when outlineID is ""). Unrestricted scopes allow everyone.
+func (db *DB) HasRole(actorID, projectID, outlineID, min string) bool {
+	return db.access.allows(db, actorID, projectID, outlineID, min, time.Now().UTC())
+}
+
+// RejectedEvent is an event replay skipped because its actor lacked the role for it.
+type RejectedEvent struct {
+	Line   EventV1Line
+	Reason string
+}
+
+// accessScope is a project/outline an event writes to, and the role it needs there.
+type accessScope struct {
+	projectID string
+	outlineID string
+	role      string
+}
+
+// checkEvent returns why ev is not allowed on db (the state just before it), or "" if it is. Grant
+// bounds are checked at ev.IssuedAt, as claimed by the writer (see above).
+func (r *AccessRules) checkEvent(db *DB, ev EventV1) string {
+	if r == nil {
+		return ""
+	}
+	at := ev.IssuedAt.UTC()
+	for _, sc := range eventAccessScopes(db, ev) {
+		if r.allows(db, ev.ActorID, sc.projectID, sc.outlineID, sc.role, at) {
+			continue
+		}
+		role, _ := r.roleAt(db, ev.ActorID, sc.projectID, sc.outlineID, at)
+		if role == "" {
+			role = "no role"
+		}
+		where := "project " + sc.projectID
+		if sc.outlineID != "" {
+			where = "outline " + sc.outlineID
+		}
+		return fmt.Sprintf("%s needs %s on %s; actor %s has %s", strings.TrimSpace(ev.Type), sc.role, where, strings.TrimSpace(ev.ActorID), role)
+	}
+	return ""
+}
+
+// eventAccessScopes returns where ev writes, looked up in db before ev is applied. Events about
+// entities that don't exist (yet) need nothing: replay doesn't apply them either.
+func eventAccessScopes(db *DB, ev EventV1) []accessScope {
+	item := func(id string) []accessScope {
+		if it, ok := db.FindItem(strings.TrimSpace(id)); ok && it != nil {
+			return []accessScope{{projectID: it.ProjectID, outlineID: it.OutlineID, role: RoleContributor}}
+		}
+		return nil
+	}
+	comment := func(id string) []accessScope {
+		if c, ok := findCommentByID(db, strings.TrimSpace(id)); ok && c != nil {
+			return item(c.ItemID)
+		}
+		return nil
+	}
+	outline := func(id, role string) []accessScope {
+		if o, ok := db.FindOutline(strings.TrimSpace(id)); ok && o != nil {
+			return []accessScope{{projectID: o.ProjectID, outlineID: o.ID, role: role}}
+		}
+		return nil
+	}
+	attachment := func(a model.Attachment) []accessScope {
+		if a.EntityKind == "comment" {
+			return comment(a.EntityID)
+		}
+		return item(a.EntityID)
+	}
+	var p struct {
+		ID         string `json:"id"`
+		ItemID     string `json:"itemId"`
+		FromItemID string `json:"fromItemId"`
+		ProjectID  string `json:"projectId"`
+		OutlineID  string `json:"outlineId"`
+		To         string `json:"to"`
+		EntityKind string `json:"entityKind"`
+		EntityID   string `json:"entityId"`
+	}
+	_ = json.Unmarshal(ev.Payload, &p)
+	idOr := func(id string) string {
+		if id = strings.TrimSpace(id); id != "" {
+			return id
+		}
+		return strings.TrimSpace(ev.EntityID)
+	}
+
+	typ := strings.TrimSpace(ev.Type)
+	switch {
+	case typ == "item.create":
+		return []accessScope{{projectID: p.ProjectID, outlineID: p.OutlineID, role: RoleContributor}}
+	case typ == "item.move_outline":
+		return append(item(ev.EntityID), outline(p.To, RoleContributor)...)
+	case strings.HasPrefix(typ, "item."):
+		return item(ev.EntityID)
+	case typ == "comment.add", typ == "worklog.add":
+		return item(p.ItemID)
+	case strings.HasPrefix(typ, "comment."):
+		return comment(idOr(p.ID))
+	case typ == "attachment.remove":
+		if a, ok := db.FindAttachment(attachmentRemovedID(ev)); ok && a != nil {
+			return attachment(*a)
+		}
+		return nil
+	case strings.HasPrefix(typ, "attachment."):
+		// Writing an attachment that exists needs the role where it is, too (replay doesn't move
+		// attachments, but an add with an existing id would otherwise overwrite it from anywhere).
+		scopes := attachment(model.Attachment{EntityKind: p.EntityKind, EntityID: p.EntityID})
+		if a, ok := db.FindAttachment(idOr(p.ID)); ok && a != nil {
+			scopes = append(scopes, attachment(*a)...)
+		}
+		return scopes
+	case typ == "dep.add":
+		return item(p.FromItemID)
+	case strings.HasPrefix(typ, "dep."):
+		if d, ok := findDepByID(db, idOr(p.ID)); ok && d != nil {
+			return item(d.FromItemID)
+		}
+		return nil
+	case typ == "outline.create":
+		return []accessScope{{projectID: p.ProjectID, role: RoleMaintainer}}
+	case strings.HasPrefix(typ, "outline."):
+		return outline(ev.EntityID, RoleMaintainer)
+	case strings.HasPrefix(typ, "project.") && typ != "project.create":
+		return []accessScope{{projectID: strings.TrimSpace(ev.EntityID), role: RoleMaintainer}}
+	default:
+		return nil
+	}
+}
+
+// accessIssues reports events replay rejects under the current roles (see checkEvent).
+func accessIssues(st Store, lines []EventV1Line) []DoctorIssue {
+	rules, err := st.loadAccessRules()
+	if err != nil {
+		return []DoctorIssue{{Level: DoctorIssueLevelError, Code: "users_invalid", Message: err.Error(), Path: UsersPath(st.workspaceRoot())}}
+	}
+	if rules == nil {
+		return nil
+	}
+	ordered := append([]EventV1Line(nil), lines...)
+	sortEventV1Lines(ordered)
+	res, err := replayEventV1Lines(ordered, rules, nil)
+	if err != nil {
+		return []DoctorIssue{{Level: DoctorIssueLevelError, Code: "access_check_failed", Message: err.Error()}}
+	}
+	var issues []DoctorIssue
+	for _, rej := range res.Rejected {
+		ev := rej.Line.Event
+		issues = append(issues, DoctorIssue{
+			Level:      DoctorIssueLevelError,
+			Code:       "event_unauthorized",
+			Message:    rej.Reason + "; replay skips it",
+			Path:       rej.Line.Path,
+			Line:       rej.Line.Line,
+			EventID:    strings.TrimSpace(ev.EventID),
+			ReplicaID:  strings.TrimSpace(ev.ReplicaID),
+			EntityKind: strings.TrimSpace(string(ev.EntityKind)),
+			EntityID:   strings.TrimSpace(ev.EntityID),
+			Type:       strings.TrimSpace(ev.Type),
+		})
+	}
+	return issues
+}
@@ -0,0 +1,164 @@
+package store
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func writeUsersFile(t *testing.T, dir, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Join(dir, "meta"), 0o755); err != nil {
+		t.Fatalf("mkdir meta: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "meta", "users.json"), []byte(content), 0o644); err != nil {
+		t.Fatalf("write users.json: %v", err)
+	}
+}
+
+func TestAccess_ReplayRejectsEventsWithoutRole(t *testing.T) {
+	s := newWatermarkWorkspace(t)
+	writeShard(t, s.Dir, "rep-b", ""+
+		`{"eventId":"evt-b1","workspaceId":"ws-1","replicaId":"rep-b","entityKind":"actor","entityId":"act-2","entitySeq":0,"type":"identity.create","issuedAt":"2025-12-31T00:00:06Z","actorId":"act-2","payload":{"name":"B","kind":"human"}}`+"\n"+
+		`{"eventId":"evt-b2","workspaceId":"ws-1","replicaId":"rep-b","entityKind":"actor","entityId":"act-3","entitySeq":0,"type":"identity.create","issuedAt":"2025-12-31T00:00:07Z","actorId":"act-2","payload":{"name":"B's agent","kind":"agent","userId":"act-2"}}`+"\n")
+
+	// act-1 maintains proj-1; act-2 (and so their agent) may only view out-1.
+	writeUsersFile(t, s.Dir, `{"users":[],"roles":[
+  {"actorId":"act-1","role":"maintainer","projectId":"proj-1"},
+  {"actorId":"act-2","role":"viewer","outlineId":"out-1"}
+]}`)
+	if _, res := catchUpOnce(t, s); res.Mode != "full" || !strings.Contains(res.Reason, "roles") {
+		t.Fatalf("expected changed roles to force a full replay, got %+v", res)
+	}
+
+	appendShard(t, s.Dir, "rep-b", strings.Replace(setTitleLine("evt-b3", "evt-5", "rep-b", "2025-12-31T00:00:08Z", "From agent"), `"actorId":"act-1"`, `"actorId":"act-3"`, 1))
+	db, res := catchUpOnce(t, s)
+	if res.Mode != "incremental" || res.Applied != 0 || itemTitle(t, db) != "Base" {
+		t.Fatalf("expected the agent's edit to be rejected, got %+v title=%q", res, itemTitle(t, db))
+	}
+	full, err := ReplayEventsV1(s.Dir)
+	if err != nil {
+		t.Fatalf("ReplayEventsV1: %v", err)
+	}
+	if len(full.Rejected) != 1 || full.Rejected[0].Line.Event.EventID != "evt-b3" || itemTitle(t, full.DB) != "Base" {
+		t.Fatalf("expected full replay to reject evt-b3, got %+v", full.Rejected)
+	}
+
+	found := false
+	for _, it := range DoctorEventsV1(s.Dir).Issues {
+		if it.Code == "event_unauthorized" && it.EventID == "evt-b3" && it.Level == DoctorIssueLevelError {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatalf("expected doctor to report evt-b3 as event_unauthorized")
+	}
+
+	// Mutations see the same roles.
+	full.DB.SetAccessRules(mustAccessRules(t, s))
+	if full.DB.HasRole("act-3", "proj-1", "out-1", RoleContributor) || !full.DB.HasRole("act-1", "proj-1", "out-1", RoleMaintainer) {
+		t.Fatalf("unexpected roles on out-1")
+	}
+
+	// Granting act-2 contributor (for events from before the change too) lets the edit through.
+	writeUsersFile(t, s.Dir, `{"users":[],"roles":[
+  {"actorId":"act-1","role":"maintainer","projectId":"proj-1"},
+  {"actorId":"act-2","role":"contributor","outlineId":"out-1"}
+]}`)
+	db, res = catchUpOnce(t, s)
+	if res.Mode != "full" || itemTitle(t, db) != "From agent" {
+		t.Fatalf("expected a full replay applying the edit, got %+v title=%q", res, itemTitle(t, db))
+	}
+}
+
+func TestAccess_AttachmentWritesNeedTheRoleWhereTheAttachmentIs(t *testing.T) {
+	s := newWatermarkWorkspace(t)
+	line := func(id, actorID, typ, entityKind, entityID, payload string) string {
+		return `{"eventId":"` + id + `","workspaceId":"ws-1","replicaId":"rep-b","entityKind":"` + entityKind + `","entityId":"` + entityID + `","entitySeq":0,"type":"` + typ + `","issuedAt":"2025-12-31T00:01:` + id[len(id)-2:] + `Z","actorId":"` + actorID + `","payload":` + payload + `}` + "\n"
+	}
+	attachment := func(entityID, title string) string {
+		return `{"id":"att-1","entityKind":"item","entityId":"` + entityID + `","title":"` + title + `","originalName":"a.txt","sizeBytes":1,"path":"attachments/att-1/a.txt"}`
+	}
+	writeShard(t, s.Dir, "rep-b", ""+
+		line("evt-b01", "act-2", "identity.create", "actor", "act-2", `{"name":"B","kind":"human"}`)+
+		line("evt-b02", "act-2", "project.create", "project", "proj-2", `{"id":"proj-2","name":"Q","createdBy":"act-2"}`)+
+		line("evt-b03", "act-2", "outline.create", "outline", "out-2", `{"id":"out-2","projectId":"proj-2","createdBy":"act-2"}`)+
+		line("evt-b04", "act-2", "item.create", "item", "item-2", `{"id":"item-2","projectId":"proj-2","outlineId":"out-2","title":"Open","status":"todo","ownerActorId":"act-2","createdBy":"act-2"}`)+
+		line("evt-b05", "act-1", "attachment.add", "attachment", "att-1", attachment("item-1", "Secret"))+
+		// act-2 may contribute to out-2 only: re-homing, renaming or re-adding att-1 from there is refused.
+		line("evt-b06", "act-2", "attachment.update", "attachment", "att-1", attachment("item-2", "Stolen"))+
+		line("evt-b07", "act-2", "attachment.add", "attachment", "att-1", attachment("item-2", "Stolen"))+
+		line("evt-b08", "act-2", "attachment.update", "attachment", "att-1", attachment("item-1", "Renamed"))+
+		// act-1 may write both, but replay doesn't move attachments either.
+		line("evt-b09", "act-1", "attachment.replace", "attachment", "att-1", attachment("item-2", "Moved")))
+	writeUsersFile(t, s.Dir, `{"users":[],"roles":[
+  {"actorId":"act-1","role":"maintainer","projectId":"proj-1"},
+  {"actorId":"act-1","role":"contributor","projectId":"proj-2"},
+  {"actorId":"act-2","role":"maintainer","projectId":"proj-2"}
+]}`)
+
+	res, err := ReplayEventsV1(s.Dir)
+	if err != nil {
+		t.Fatalf("ReplayEventsV1: %v", err)
+	}
+	var rejected []string
+	for _, r := range res.Rejected {
+		rejected = append(rejected, r.Line.Event.EventID)
+	}
+	if strings.Join(rejected, ",") != "evt-b06,evt-b07,evt-b08" {
+		t.Fatalf("expected act-2's attachment writes to be rejected, got %v", rejected)
+	}
+	a, ok := res.DB.FindAttachment("att-1")
+	if !ok || a.EntityID != "item-1" || a.Title != "Secret" {
+		t.Fatalf("expected att-1 to stay on item-1 untouched, got %+v", a)
+	}
+}
+
+func TestAccess_GrantsAreBoundedByTime(t *testing.T) {
+	since := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	until := since.Add(24 * time.Hour)
+	r := NewAccessRules(UsersFile{Roles: []RoleGrant{
+		{ActorID: "act-1", Role: RoleContributor, ProjectID: "proj-1", Since: &since, Until: &until},
+	}})
+	db := &DB{}
+
+	// Before the first grant the project is open; after a grant ends it stays restricted.
+	if role, restricted := r.roleAt(db, "act-2", "proj-1", "", since.Add(-time.Hour)); restricted || role != "" {
+		t.Fatalf("expected proj-1 to be unrestricted before since, got %q %v", role, restricted)
+	}
+	if !r.allows(db, "act-1", "proj-1", "out-1", RoleContributor, since.Add(time.Hour)) {
+		t.Fatalf("expected act-1 to contribute while the grant is active")
+	}
+	if r.allows(db, "act-1", "proj-1", "out-1", RoleContributor, until) {
+		t.Fatalf("expected the grant to end at until")
+	}
+	if r.allows(db, "act-2", "proj-1", "", RoleViewer, since) {
+		t.Fatalf("expected actors without a grant to be denied once proj-1 is restricted")
+	}
+}
+
+func TestLoadUsers_ValidatesRoles(t *testing.T) {
+	for _, raw := range []string{
+		`{"users":[],"roles":[{"actorId":"act-1","role":"owner","projectId":"proj-1"}]}`,
+		`{"users":[],"roles":[{"actorId":"act-1","role":"viewer"}]}`,
+		`{"users":[],"roles":[{"actorId":"act-1","role":"viewer","projectId":"proj-1","outlineId":"out-1"}]}`,
+		`{"users":[],"roles":[{"role":"viewer","projectId":"proj-1"}]}`,
+	} {
+		dir := t.TempDir()
+		writeUsersFile(t, dir, raw)
+		if _, _, err := LoadUsers(dir); err == nil {
+			t.Fatalf("expected %s to be rejected", raw)
+		}
+	}
+}
+
+func mustAccessRules(t *testing.T, s Store) *AccessRules {
+	t.Helper()
+	r, err := s.loadAccessRules()
+	if err != nil {
+		t.Fatalf("loadAccessRules: %v", err)
+	}
+	return r
+}
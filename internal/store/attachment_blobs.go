@@ -0,0 +1,423 @@
+package store
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"clarity-cli/internal/model"
+)
+
+// Content-addressed attachment storage.
+//
+// A file is stored once, at resources/attachments/sha256/<first 2 hex>/<sha256><ext> (or under the
+// same key in an external blob store, see attachment_stores.go), however many attachments (or
+// versions of one) use it. The extension only helps OS handlers open the file; a blob is found by
+// its hash alone. Blobs are never deleted when an attachment is removed or replaced: the events
+// still reference them. `clarity attachments gc` deletes what nothing references (in the repo).
+
+type attachmentBlob struct {
+	Path         string // model.Attachment.Path: relative to the workspace root, or "<backend>:<key>"
+	Sha256Hex    string
+	SizeBytes    int64
+	OriginalName string
+}
+
+// storeAttachmentBlob copies srcPath into blob storage (unless identical content is already
+// there).
+func (s Store) storeAttachmentBlob(srcPath string, maxBytes int64) (attachmentBlob, error) {
+	srcPath = filepath.Clean(strings.TrimSpace(srcPath))
+	if srcPath == "" || srcPath == "." {
+		return attachmentBlob{}, errors.New("missing source path")
+	}
+	st, err := os.Stat(srcPath)
+	if err != nil {
+		return attachmentBlob{}, err
+	}
+	if st.IsDir() {
+		return attachmentBlob{}, errors.New("attachments: source path is a directory")
+	}
+	if maxBytes <= 0 {
+		maxBytes = DefaultAttachmentMaxBytes
+	}
+	if st.Size() > maxBytes {
+		return attachmentBlob{}, fmt.Errorf("attachments: file too large (%d bytes > %d bytes)", st.Size(), maxBytes)
+	}
+
+	orig := filepath.Base(srcPath)
+	if strings.TrimSpace(orig) == "" || orig == "." || orig == string(filepath.Separator) {
+		orig = "attachment"
+	}
+
+	cfg, err := s.LoadBlobStore()
+	if err != nil {
+		return attachmentBlob{}, err
+	}
+	external := cfg.external(st.Size())
+	root := filepath.Join(s.workspaceRoot(), attachmentBlobsRel)
+	tmpDir := root
+	if external {
+		tmpDir = filepath.Join(s.localDir(), "blobs")
+	}
+	if err := os.MkdirAll(tmpDir, 0o755); err != nil {
+		return attachmentBlob{}, err
+	}
+	in, err := os.Open(srcPath)
+	if err != nil {
+		return attachmentBlob{}, err
+	}
+	defer in.Close()
+	tmp, err := os.CreateTemp(tmpDir, ".blob-*.tmp")
+	if err != nil {
+		return attachmentBlob{}, err
+	}
+	tmpPath := tmp.Name()
+	defer func() { _ = os.Remove(tmpPath) }()
+
+	h := sha256.New()
+	n, err := io.Copy(io.MultiWriter(tmp, h), io.LimitReader(in, maxBytes+1))
+	if cerr := tmp.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return attachmentBlob{}, err
+	}
+	if n > maxBytes {
+		return attachmentBlob{}, fmt.Errorf("attachments: file too large (%d bytes > %d bytes)", n, maxBytes)
+	}
+	sum := hex.EncodeToString(h.Sum(nil))
+
+	blob := attachmentBlob{Sha256Hex: sum, SizeBytes: n, OriginalName: orig}
+	if external {
+		key := "sha256/" + sum[:2] + "/" + sum + blobExt(orig)
+		if err := s.putExternalBlob(context.Background(), cfg, tmpPath, key, blob); err != nil {
+			return attachmentBlob{}, err
+		}
+		blob.Path = cfg.Backend + ":" + key
+		return blob, nil
+	}
+	if existing, ok := s.findAttachmentBlob(sum); ok {
+		blob.Path = existing
+		return blob, nil
+	}
+	dir := filepath.Join(root, sum[:2])
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return attachmentBlob{}, err
+	}
+	name := sum + blobExt(orig)
+	if err := os.Chmod(tmpPath, 0o644); err != nil {
+		return attachmentBlob{}, err
+	}
+	if err := os.Rename(tmpPath, filepath.Join(dir, name)); err != nil {
+		return attachmentBlob{}, err
+	}
+	blob.Path = filepath.ToSlash(filepath.Join(attachmentBlobsRel, sum[:2], name))
+	return blob, nil
+}
+
+// findAttachmentBlob returns the stored blob with this hash, whatever its extension.
+func (s Store) findAttachmentBlob(sum string) (string, bool) {
+	dir := filepath.Join(s.workspaceRoot(), attachmentBlobsRel, sum[:2])
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return "", false
+	}
+	for _, e := range entries {
+		if name := e.Name(); !e.IsDir() && (name == sum || strings.HasPrefix(name, sum+".")) {
+			return filepath.ToSlash(filepath.Join(attachmentBlobsRel, sum[:2], name)), true
+		}
+	}
+	return "", false
+}
+
+// blobExt keeps a short, plain extension (".png", ".pdf"); anything else is dropped.
+func blobExt(name string) string {
+	ext := strings.ToLower(filepath.Ext(name))
+	if len(ext) < 2 || len(ext) > 10 {
+		return ""
+	}
+	for _, r := range ext[1:] {
+		if !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9') {
+			return ""
+		}
+	}
+	return ext
+}
+
+// attachmentRefs is what the event log says about attachment files: the attachments that exist
+// now, and every path any attachment (or earlier version of one) has pointed at.
+type attachmentRefs struct {
+	live  map[string]model.Attachment
+	paths map[string]bool
+}
+
+func attachmentRefsOf(lines []EventV1Line) attachmentRefs {
+	refs := attachmentRefs{live: map[string]model.Attachment{}, paths: map[string]bool{}}
+	for _, l := range lines {
+		ev := l.Event
+		switch ev.Type {
+		case "attachment.add", "attachment.update", "attachment.replace":
+			var a model.Attachment
+			if err := json.Unmarshal(ev.Payload, &a); err != nil {
+				continue
+			}
+			if strings.TrimSpace(a.ID) == "" {
+				a.ID = strings.TrimSpace(ev.EntityID)
+			}
+			if p := cleanAttachmentRel(a.Path); p != "" {
+				refs.paths[p] = true
+			}
+			refs.live[strings.TrimSpace(a.ID)] = a
+		case "attachment.remove":
+			delete(refs.live, attachmentRemovedID(ev))
+		}
+	}
+	return refs
+}
+
+func attachmentRemovedID(ev EventV1) string {
+	var p struct {
+		ID string `json:"id"`
+	}
+	_ = json.Unmarshal(ev.Payload, &p)
+	if id := strings.TrimSpace(p.ID); id != "" {
+		return id
+	}
+	return strings.TrimSpace(ev.EntityID)
+}
+
+func cleanAttachmentRel(p string) string {
+	p = strings.TrimSpace(p)
+	if p == "" {
+		return ""
+	}
+	return filepath.ToSlash(filepath.Clean(filepath.FromSlash(p)))
+}
+
+// attachmentFiles lists the files under resources/attachments/ (slash paths relative to the
+// workspace root) with their sizes.
+func (s Store) attachmentFiles() (map[string]int64, error) {
+	root := s.workspaceRoot()
+	out := map[string]int64{}
+	err := filepath.WalkDir(s.attachmentsDir(), func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(root, p)
+		if err != nil {
+			return err
+		}
+		out[filepath.ToSlash(rel)] = info.Size()
+		return nil
+	})
+	return out, err
+}
+
+// AttachmentGCResult lists the files `clarity attachments gc` deleted (or would delete).
+type AttachmentGCResult struct {
+	Deleted []string `json:"deleted"`
+	Bytes   int64    `json:"bytes"`
+	Kept    int      `json:"kept"`
+	DryRun  bool     `json:"dryRun"`
+}
+
+// GCAttachments deletes attachment files that no event references. With removed, it also deletes
+// files only referenced by removed attachments or replaced versions; files of current attachments
+// are always kept.
+func (s Store) GCAttachments(removed, dryRun bool) (AttachmentGCResult, error) {
+	lines, err := s.replayOrderedLines()
+	if err != nil {
+		return AttachmentGCResult{}, err
+	}
+	refs := attachmentRefsOf(lines)
+	keep := map[string]bool{}
+	for _, a := range refs.live {
+		if p := cleanAttachmentRel(a.Path); p != "" {
+			keep[p] = true
+		}
+	}
+	if !removed {
+		for p := range refs.paths {
+			keep[p] = true
+		}
+	}
+
+	files, err := s.attachmentFiles()
+	if err != nil {
+		return AttachmentGCResult{}, err
+	}
+	res := AttachmentGCResult{Deleted: []string{}, DryRun: dryRun}
+	for p, size := range files {
+		if keep[p] {
+			res.Kept++
+			continue
+		}
+		res.Deleted = append(res.Deleted, p)
+		res.Bytes += size
+	}
+	sort.Strings(res.Deleted)
+	if dryRun {
+		return res, nil
+	}
+	root := s.workspaceRoot()
+	for _, p := range res.Deleted {
+		abs := filepath.Join(root, filepath.FromSlash(p))
+		if err := os.Remove(abs); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return res, err
+		}
+		// Drop directories left empty (per-attachment dirs from the old layout, hash prefixes).
+		for dir := filepath.Dir(abs); dir != s.attachmentsDir() && strings.HasPrefix(dir, s.attachmentsDir()); dir = filepath.Dir(dir) {
+			if os.Remove(dir) != nil {
+				break
+			}
+		}
+	}
+	return res, nil
+}
+
+// attachmentIssues reports current attachments whose file is missing or has the wrong size, and
+// files under resources/attachments/ that no event references.
+func attachmentIssues(st Store, lines []EventV1Line) []DoctorIssue {
+	ordered := append([]EventV1Line(nil), lines...)
+	sortEventV1Lines(ordered)
+	refs := attachmentRefsOf(ordered)
+	files, err := st.attachmentFiles()
+	if err != nil {
+		return []DoctorIssue{{Level: DoctorIssueLevelError, Code: "attachments_read_failed", Message: err.Error(), Path: st.attachmentsDir()}}
+	}
+
+	var issues []DoctorIssue
+	ids := make([]string, 0, len(refs.live))
+	for id := range refs.live {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	external := map[string][]model.Attachment{}
+	for _, id := range ids {
+		a := refs.live[id]
+		if name, _, err := splitAttachmentPath(a.Path); err != nil {
+			issues = append(issues, DoctorIssue{
+				Level:      DoctorIssueLevelError,
+				Code:       "attachment_path_invalid",
+				Message:    err.Error(),
+				EntityKind: "attachment",
+				EntityID:   id,
+			})
+			continue
+		} else if name != "" {
+			external[name] = append(external[name], a)
+			continue
+		}
+		p := cleanAttachmentRel(a.Path)
+		size, ok := files[p]
+		switch {
+		case !ok:
+			issues = append(issues, DoctorIssue{
+				Level:      DoctorIssueLevelError,
+				Code:       "attachment_blob_missing",
+				Message:    fmt.Sprintf("attachment file %s is missing (not committed or pulled yet?)", p),
+				Path:       filepath.Join(st.workspaceRoot(), filepath.FromSlash(p)),
+				EntityKind: "attachment",
+				EntityID:   id,
+			})
+		case a.SizeBytes > 0 && size != a.SizeBytes:
+			issues = append(issues, DoctorIssue{
+				Level:      DoctorIssueLevelError,
+				Code:       "attachment_blob_size_mismatch",
+				Message:    fmt.Sprintf("attachment file %s is %d bytes, expected %d", p, size, a.SizeBytes),
+				Path:       filepath.Join(st.workspaceRoot(), filepath.FromSlash(p)),
+				EntityKind: "attachment",
+				EntityID:   id,
+			})
+		}
+	}
+
+	issues = append(issues, externalAttachmentIssues(st, external)...)
+
+	var orphans []string
+	for p := range files {
+		if !refs.paths[p] {
+			orphans = append(orphans, p)
+		}
+	}
+	sort.Strings(orphans)
+	for _, p := range orphans {
+		issues = append(issues, DoctorIssue{
+			Level:   DoctorIssueLevelWarn,
+			Code:    "attachment_blob_orphaned",
+			Message: fmt.Sprintf("%s is not referenced by any attachment event (clarity attachments gc deletes it)", p),
+			Path:    filepath.Join(st.workspaceRoot(), filepath.FromSlash(p)),
+		})
+	}
+	return issues
+}
+
+// externalAttachmentIssues checks that attachments stored outside the repo exist in their blob
+// store. An unreachable store is reported once, as a warning (e.g. offline).
+func externalAttachmentIssues(st Store, byBackend map[string][]model.Attachment) []DoctorIssue {
+	if len(byBackend) == 0 {
+		return nil
+	}
+	cfg, err := st.LoadBlobStore()
+	if err != nil {
+		return []DoctorIssue{{Level: DoctorIssueLevelError, Code: "attachment_store_invalid", Message: err.Error(), Path: st.blobStorePath()}}
+	}
+	names := make([]string, 0, len(byBackend))
+	for name := range byBackend {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var issues []DoctorIssue
+	for _, name := range names {
+		b, err := cfg.backend(name)
+		if err != nil {
+			issues = append(issues, DoctorIssue{Level: DoctorIssueLevelError, Code: "attachment_store_unconfigured", Message: err.Error(), Path: st.blobStorePath()})
+			continue
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		for _, a := range byBackend[name] {
+			_, key, _ := splitAttachmentPath(a.Path)
+			ok, err := b.Exists(ctx, key)
+			if err != nil {
+				issues = append(issues, DoctorIssue{
+					Level:   DoctorIssueLevelWarn,
+					Code:    "attachment_store_unreachable",
+					Message: fmt.Sprintf("could not check %d attachment(s) in the %s store: %v", len(byBackend[name]), name, err),
+				})
+				break
+			}
+			if !ok {
+				issues = append(issues, DoctorIssue{
+					Level:      DoctorIssueLevelError,
+					Code:       "attachment_blob_missing",
+					Message:    fmt.Sprintf("attachment file %s is missing from the %s store", key, name),
+					EntityKind: "attachment",
+					EntityID:   a.ID,
+				})
+			}
+		}
+		cancel()
+	}
+	return issues
+}
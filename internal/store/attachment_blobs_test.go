@@ -0,0 +1,212 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"clarity-cli/internal/model"
+)
+
+func doctorCodes(dir string) map[string]int {
+	codes := map[string]int{}
+	for _, it := range DoctorEventsV1(dir).Issues {
+		codes[it.Code]++
+	}
+	return codes
+}
+
+func TestAttachments_ContentAddressedRemoveReplaceAndGC(t *testing.T) {
+	s := newWatermarkWorkspace(t)
+	src := t.TempDir()
+	shot := filepath.Join(src, "Shot.PNG")
+	if err := os.WriteFile(shot, []byte("same bytes"), 0o644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	v2 := filepath.Join(src, "shot-v2.png")
+	if err := os.WriteFile(v2, []byte("other bytes"), 0o644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	db, err := s.Load()
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	a1, err := s.AddAttachment(db, "act-1", "item", "item-1", shot, "one", "", 0)
+	if err != nil {
+		t.Fatalf("AddAttachment: %v", err)
+	}
+	a2, err := s.AddAttachment(db, "act-1", "item", "item-1", shot, "two", "", 0)
+	if err != nil {
+		t.Fatalf("AddAttachment: %v", err)
+	}
+	if a1.Path != a2.Path || !strings.HasPrefix(a1.Path, "resources/attachments/sha256/"+a1.Sha256Hex[:2]+"/"+a1.Sha256Hex) || !strings.HasSuffix(a1.Path, ".png") {
+		t.Fatalf("expected one content-addressed blob, got %q and %q", a1.Path, a2.Path)
+	}
+	for _, a := range []model.Attachment{a1, a2} {
+		if err := s.AppendEvent("act-1", "attachment.add", a.ID, a); err != nil {
+			t.Fatalf("AppendEvent: %v", err)
+		}
+	}
+	if err := s.Save(db); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	r1, err := s.ReplaceAttachment(db, "act-1", a1.ID, v2, 0)
+	if err != nil {
+		t.Fatalf("ReplaceAttachment: %v", err)
+	}
+	if r1.Path == a1.Path || r1.Title != "one" {
+		t.Fatalf("unexpected replacement: %+v", r1)
+	}
+	if err := s.AppendEvent("act-1", "attachment.replace", r1.ID, r1); err != nil {
+		t.Fatalf("AppendEvent: %v", err)
+	}
+	if _, err := s.RemoveAttachment(db, a2.ID); err != nil {
+		t.Fatalf("RemoveAttachment: %v", err)
+	}
+	if err := s.AppendEvent("act-1", "attachment.remove", a2.ID, map[string]any{"id": a2.ID}); err != nil {
+		t.Fatalf("AppendEvent: %v", err)
+	}
+	if err := s.Save(db); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	res, err := s.ReindexFull()
+	if err != nil {
+		t.Fatalf("ReindexFull: %v", err)
+	}
+	if len(res.DB.Attachments) != 1 || res.DB.Attachments[0].ID != a1.ID || res.DB.Attachments[0].Path != r1.Path {
+		t.Fatalf("unexpected replayed attachments: %+v", res.DB.Attachments)
+	}
+
+	orphan := filepath.Join(s.workspaceRoot(), "resources", "attachments", "stray.bin")
+	if err := os.WriteFile(orphan, []byte("x"), 0o644); err != nil {
+		t.Fatalf("write orphan: %v", err)
+	}
+	if got := doctorCodes(s.Dir)["attachment_blob_orphaned"]; got != 1 {
+		t.Fatalf("expected 1 orphaned blob, got %d", got)
+	}
+
+	// The old version is still referenced by history: plain gc keeps it.
+	gc, err := s.GCAttachments(false, false)
+	if err != nil {
+		t.Fatalf("GCAttachments: %v", err)
+	}
+	if len(gc.Deleted) != 1 || gc.Deleted[0] != "resources/attachments/stray.bin" {
+		t.Fatalf("unexpected gc: %+v", gc)
+	}
+	gc, err = s.GCAttachments(true, false)
+	if err != nil {
+		t.Fatalf("GCAttachments --removed: %v", err)
+	}
+	if len(gc.Deleted) != 1 || gc.Deleted[0] != a1.Path {
+		t.Fatalf("unexpected gc --removed: %+v", gc)
+	}
+	if _, err := os.Stat(s.AttachmentAbsPath(r1)); err != nil {
+		t.Fatalf("current version deleted: %v", err)
+	}
+	if codes := doctorCodes(s.Dir); codes["attachment_blob_missing"]+codes["attachment_blob_orphaned"] != 0 {
+		t.Fatalf("unexpected attachment issues: %v", codes)
+	}
+
+	if err := os.Remove(s.AttachmentAbsPath(r1)); err != nil {
+		t.Fatalf("remove blob: %v", err)
+	}
+	if got := doctorCodes(s.Dir)["attachment_blob_missing"]; got != 1 {
+		t.Fatalf("expected 1 missing blob, got %d", got)
+	}
+}
+
+func TestAttachments_SharedDirStoreFetchesLazilyAndVerifies(t *testing.T) {
+	s := newWatermarkWorkspace(t)
+	shared := t.TempDir()
+	if err := s.SaveBlobStore(BlobStoreFile{Backend: BlobStoreDir, MinBytes: 4, Dir: &BlobStoreDirConfig{Path: shared}}); err != nil {
+		t.Fatalf("SaveBlobStore: %v", err)
+	}
+	src := t.TempDir()
+	big := filepath.Join(src, "big.pdf")
+	small := filepath.Join(src, "s.txt")
+	if err := os.WriteFile(big, []byte("large enough"), 0o644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	if err := os.WriteFile(small, []byte("x"), 0o644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	db, err := s.Load()
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	a, err := s.AddAttachment(db, "act-1", "item", "item-1", big, "", "", 0)
+	if err != nil {
+		t.Fatalf("AddAttachment: %v", err)
+	}
+	key := "sha256/" + a.Sha256Hex[:2] + "/" + a.Sha256Hex + ".pdf"
+	if a.Path != "dir:"+key {
+		t.Fatalf("expected an external path, got %q", a.Path)
+	}
+	if _, err := os.Stat(filepath.Join(shared, filepath.FromSlash(key))); err != nil {
+		t.Fatalf("expected blob in the shared dir: %v", err)
+	}
+	if sa, err := s.AddAttachment(db, "act-1", "item", "item-1", small, "", "", 0); err != nil || strings.Contains(sa.Path, ":") {
+		t.Fatalf("expected a small file to stay in the repo, got %q (%v)", sa.Path, err)
+	}
+	if err := s.AppendEvent("act-1", "attachment.add", a.ID, a); err != nil {
+		t.Fatalf("AppendEvent: %v", err)
+	}
+
+	// Another replica: nothing cached yet.
+	if err := os.RemoveAll(filepath.Join(s.localDir(), "blobs")); err != nil {
+		t.Fatalf("clear cache: %v", err)
+	}
+	p, err := s.AttachmentLocalPath(context.Background(), a)
+	if err != nil {
+		t.Fatalf("AttachmentLocalPath: %v", err)
+	}
+	if b, _ := os.ReadFile(p); string(b) != "large enough" {
+		t.Fatalf("unexpected fetched contents %q", b)
+	}
+	// A cached file that doesn't match is fetched again.
+	if err := os.WriteFile(p, []byte("stale cache!"), 0o644); err != nil {
+		t.Fatalf("tamper cache: %v", err)
+	}
+	if p, err = s.AttachmentLocalPath(context.Background(), a); err != nil {
+		t.Fatalf("AttachmentLocalPath: %v", err)
+	}
+	if b, _ := os.ReadFile(p); string(b) != "large enough" {
+		t.Fatalf("expected the cache to be refetched, got %q", b)
+	}
+
+	// Paths come from the log: none may leave the store, the cache or the workspace.
+	for _, bad := range []string{"dir:../../etc/passwd", "s3:a/../../b", "dir:/etc/passwd", "../outside.txt", "/etc/passwd"} {
+		evil := a
+		evil.Path = bad
+		if _, err := s.AttachmentLocalPath(context.Background(), evil); err == nil {
+			t.Fatalf("expected %q to be rejected", bad)
+		}
+		if got := s.AttachmentAbsPath(evil); got != "" {
+			t.Fatalf("expected no local path for %q, got %q", bad, got)
+		}
+	}
+
+	if err := os.RemoveAll(filepath.Join(s.localDir(), "blobs")); err != nil {
+		t.Fatalf("clear cache: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(shared, filepath.FromSlash(key)), []byte("tampered...."), 0o644); err != nil {
+		t.Fatalf("tamper: %v", err)
+	}
+	if _, err := s.AttachmentLocalPath(context.Background(), a); !errors.Is(err, ErrAttachmentChecksum) {
+		t.Fatalf("expected ErrAttachmentChecksum, got %v", err)
+	}
+
+	if err := os.Remove(filepath.Join(shared, filepath.FromSlash(key))); err != nil {
+		t.Fatalf("remove blob: %v", err)
+	}
+	if got := doctorCodes(s.Dir)["attachment_blob_missing"]; got != 1 {
+		t.Fatalf("expected 1 missing blob, got %d", got)
+	}
+}
@@ -0,0 +1,321 @@
+package store
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"clarity-cli/internal/blobstore"
+	"clarity-cli/internal/model"
+)
+
+// Attachment blob stores.
+//
+// meta/blobstore.json (committed) picks where new attachment files go: the workspace repo (the
+// default), a shared directory, or an S3-compatible bucket. Files smaller than minBytes stay in the
+// repo either way. An attachment stored outside the repo has a Path of "<backend>:<key>"
+// ("s3:sha256/ab/ab12….pdf"); its metadata is in the event log like any other attachment, and the
+// file is fetched into .clarity/blobs/ on first open/export and checked against Sha256Hex.
+//
+// Paths and credentials that differ per machine come from the environment: CLARITY_BLOBSTORE_DIR
+// overrides the shared directory, and S3 credentials are read from CLARITY_S3_ACCESS_KEY_ID /
+// CLARITY_S3_SECRET_ACCESS_KEY / CLARITY_S3_SESSION_TOKEN (or the AWS_* equivalents).
+
+const (
+	BlobStoreRepo = "repo"
+	BlobStoreDir  = "dir"
+	BlobStoreS3   = "s3"
+
+	blobStoreDirEnv = "CLARITY_BLOBSTORE_DIR"
+)
+
+var ErrAttachmentChecksum = errors.New("attachment checksum mismatch")
+
+// BlobStoreFile is meta/blobstore.json.
+type BlobStoreFile struct {
+	Version int    `json:"version"`
+	Backend string `json:"backend"`
+	// MinBytes keeps smaller files in the repo when Backend is external.
+	MinBytes int64 `json:"minBytes,omitempty"`
+
+	Dir *BlobStoreDirConfig `json:"dir,omitempty"`
+	S3  *BlobStoreS3Config  `json:"s3,omitempty"`
+}
+
+type BlobStoreDirConfig struct {
+	Path string `json:"path"`
+}
+
+type BlobStoreS3Config struct {
+	Endpoint string `json:"endpoint"`
+	Bucket   string `json:"bucket"`
+	Region   string `json:"region,omitempty"`
+	Prefix   string `json:"prefix,omitempty"`
+}
+
+func (s Store) blobStorePath() string {
+	return filepath.Join(s.workspaceRoot(), "meta", "blobstore.json")
+}
+
+// LoadBlobStore reads meta/blobstore.json. A missing file means everything stays in the repo.
+func (s Store) LoadBlobStore() (BlobStoreFile, error) {
+	f := BlobStoreFile{Version: 1, Backend: BlobStoreRepo}
+	b, err := os.ReadFile(s.blobStorePath())
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return f, nil
+		}
+		return BlobStoreFile{}, err
+	}
+	if err := json.Unmarshal(b, &f); err != nil {
+		return BlobStoreFile{}, fmt.Errorf("meta/blobstore.json: %w", err)
+	}
+	if f.Version == 0 {
+		f.Version = 1
+	}
+	if f.Version != 1 {
+		return BlobStoreFile{}, errors.New("meta/blobstore.json: unsupported version")
+	}
+	if err := f.validate(); err != nil {
+		return BlobStoreFile{}, fmt.Errorf("meta/blobstore.json: %w", err)
+	}
+	return f, nil
+}
+
+// SaveBlobStore validates and writes meta/blobstore.json.
+func (s Store) SaveBlobStore(f BlobStoreFile) error {
+	f.Version = 1
+	if err := f.validate(); err != nil {
+		return err
+	}
+	b, err := json.MarshalIndent(f, "", "  ")
+	if err != nil {
+		return err
+	}
+	dir := filepath.Dir(s.blobStorePath())
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	return atomicWriteFile(dir, ".blobstore-*.tmp", s.blobStorePath(), append(b, '\n'), 0o644)
+}
+
+func (f *BlobStoreFile) validate() error {
+	f.Backend = strings.ToLower(strings.TrimSpace(f.Backend))
+	if f.Backend == "" {
+		f.Backend = BlobStoreRepo
+	}
+	if f.MinBytes < 0 {
+		return errors.New("minBytes must not be negative")
+	}
+	switch f.Backend {
+	case BlobStoreRepo:
+	case BlobStoreDir:
+		if f.Dir == nil || strings.TrimSpace(f.Dir.Path) == "" {
+			return errors.New(`backend "dir" needs dir.path`)
+		}
+	case BlobStoreS3:
+		if f.S3 == nil || strings.TrimSpace(f.S3.Endpoint) == "" || strings.TrimSpace(f.S3.Bucket) == "" {
+			return errors.New(`backend "s3" needs s3.endpoint and s3.bucket`)
+		}
+	default:
+		return fmt.Errorf("unknown backend %q (expected repo|dir|s3)", f.Backend)
+	}
+	return nil
+}
+
+// external reports whether a file of size bytes goes to the external backend.
+func (f BlobStoreFile) external(size int64) bool {
+	return f.Backend != BlobStoreRepo && size >= f.MinBytes
+}
+
+// backend opens the named external backend from f (and the environment).
+func (f BlobStoreFile) backend(name string) (blobstore.Backend, error) {
+	switch name {
+	case BlobStoreDir:
+		root := strings.TrimSpace(os.Getenv(blobStoreDirEnv))
+		if root == "" && f.Dir != nil {
+			root = strings.TrimSpace(f.Dir.Path)
+		}
+		if root == "" {
+			return nil, fmt.Errorf("attachment store %q is not configured (meta/blobstore.json or %s)", name, blobStoreDirEnv)
+		}
+		return blobstore.Dir{Root: root}, nil
+	case BlobStoreS3:
+		if f.S3 == nil {
+			return nil, fmt.Errorf("attachment store %q is not configured (meta/blobstore.json)", name)
+		}
+		return &blobstore.S3{
+			Endpoint:        f.S3.Endpoint,
+			Bucket:          f.S3.Bucket,
+			Region:          f.S3.Region,
+			Prefix:          f.S3.Prefix,
+			AccessKeyID:     envFirst("CLARITY_S3_ACCESS_KEY_ID", "AWS_ACCESS_KEY_ID"),
+			SecretAccessKey: envFirst("CLARITY_S3_SECRET_ACCESS_KEY", "AWS_SECRET_ACCESS_KEY"),
+			SessionToken:    envFirst("CLARITY_S3_SESSION_TOKEN", "AWS_SESSION_TOKEN"),
+		}, nil
+	default:
+		return nil, fmt.Errorf("unknown attachment store %q", name)
+	}
+}
+
+// S3CredentialsFromEnv reports whether S3 credentials are set in the environment.
+func S3CredentialsFromEnv() bool {
+	return envFirst("CLARITY_S3_ACCESS_KEY_ID", "AWS_ACCESS_KEY_ID") != "" && envFirst("CLARITY_S3_SECRET_ACCESS_KEY", "AWS_SECRET_ACCESS_KEY") != ""
+}
+
+func envFirst(keys ...string) string {
+	for _, k := range keys {
+		if v := strings.TrimSpace(os.Getenv(k)); v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// splitAttachmentPath splits an external path ("s3:<key>") into backend and key; in-repo paths
+// have no backend. The path comes from the event log, so one that could leave the store, the local
+// cache or the workspace is an error.
+func splitAttachmentPath(p string) (backend, key string, err error) {
+	p = strings.TrimSpace(p)
+	for _, b := range []string{BlobStoreDir, BlobStoreS3} {
+		if strings.HasPrefix(p, b+":") {
+			key, err := blobstore.CleanKey(strings.TrimPrefix(p, b+":"))
+			if err != nil {
+				return b, "", fmt.Errorf("attachment path %q: %w", p, err)
+			}
+			return b, key, nil
+		}
+	}
+	if rel := cleanAttachmentRel(p); rel == ".." || strings.HasPrefix(rel, "../") || strings.HasPrefix(rel, "/") || filepath.IsAbs(p) {
+		return "", "", fmt.Errorf("attachment path %q is outside the workspace", p)
+	}
+	return "", p, nil
+}
+
+// AttachmentIsExternal reports whether a's file is in an external blob store.
+func AttachmentIsExternal(a model.Attachment) bool {
+	name, _, _ := splitAttachmentPath(a.Path)
+	return name != ""
+}
+
+func (s Store) blobCachePath(key string) string {
+	return filepath.Join(s.localDir(), "blobs", filepath.FromSlash(key))
+}
+
+// putExternalBlob uploads the file at tmpPath (unless the store already has it) and moves it into
+// the local cache.
+func (s Store) putExternalBlob(ctx context.Context, f BlobStoreFile, tmpPath, key string, blob attachmentBlob) error {
+	b, err := f.backend(f.Backend)
+	if err != nil {
+		return err
+	}
+	ok, err := b.Exists(ctx, key)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		in, err := os.Open(tmpPath)
+		if err != nil {
+			return err
+		}
+		err = b.Put(ctx, key, in, blob.SizeBytes, blob.Sha256Hex)
+		_ = in.Close()
+		if err != nil {
+			return err
+		}
+	}
+	cache := s.blobCachePath(key)
+	if err := os.MkdirAll(filepath.Dir(cache), 0o755); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, cache)
+}
+
+// AttachmentLocalPath returns a local file with the attachment's contents, fetching it from its
+// blob store first if needed, after checking it against the recorded Sha256Hex.
+func (s Store) AttachmentLocalPath(ctx context.Context, a model.Attachment) (string, error) {
+	name, key, err := splitAttachmentPath(a.Path)
+	if err != nil {
+		return "", err
+	}
+	if name == "" {
+		p := s.attachmentFilePath(a)
+		if err := verifyAttachmentFile(p, a.Sha256Hex); err != nil {
+			return "", err
+		}
+		return p, nil
+	}
+	cache := s.blobCachePath(key)
+	if _, err := os.Stat(cache); err == nil {
+		// Checked like a fetch: the cache is keyed by path, and another attachment may name the same
+		// key. A mismatching file is fetched again.
+		if err := verifyAttachmentFile(cache, a.Sha256Hex); err == nil {
+			return cache, nil
+		} else if !errors.Is(err, ErrAttachmentChecksum) {
+			return "", err
+		}
+	}
+
+	f, err := s.LoadBlobStore()
+	if err != nil {
+		return "", err
+	}
+	b, err := f.backend(name)
+	if err != nil {
+		return "", err
+	}
+	rc, err := b.Get(ctx, key)
+	if err != nil {
+		return "", fmt.Errorf("attachment %s: %w", a.ID, err)
+	}
+	defer rc.Close()
+	if err := os.MkdirAll(filepath.Dir(cache), 0o755); err != nil {
+		return "", err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(cache), ".fetch-*.tmp")
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+	h := sha256.New()
+	_, err = io.Copy(io.MultiWriter(tmp, h), rc)
+	if cerr := tmp.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return "", err
+	}
+	if got := hex.EncodeToString(h.Sum(nil)); a.Sha256Hex != "" && got != a.Sha256Hex {
+		return "", fmt.Errorf("%w: %s from %s is %s, expected %s", ErrAttachmentChecksum, a.ID, name, got, a.Sha256Hex)
+	}
+	if err := os.Rename(tmp.Name(), cache); err != nil {
+		return "", err
+	}
+	return cache, nil
+}
+
+func verifyAttachmentFile(p, want string) error {
+	f, err := os.Open(p)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	if want == "" {
+		return nil
+	}
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return err
+	}
+	if got := hex.EncodeToString(h.Sum(nil)); got != want {
+		return fmt.Errorf("%w: %s is %s, expected %s", ErrAttachmentChecksum, p, got, want)
+	}
+	return nil
+}
@@ -0,0 +1,198 @@
+package store
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode/utf8"
+
+	"clarity-cli/internal/model"
+)
+
+// Attachment text extraction.
+//
+// When a file is attached, the text of text-like files (plain text, Markdown, JSON, CSV) and the
+// file listing of archives is recorded on the attachment (model.Attachment.Text), capped at
+// maxAttachmentTextBytes. It travels in the event log with the other metadata, so search finds
+// attachments on every replica without fetching files from an external blob store.
+
+const maxAttachmentTextBytes = 32 << 10
+
+// maxArchiveEntries caps how many archive entries are listed.
+const maxArchiveEntries = 1000
+
+// Attachment preview kinds (see AttachmentPreviewKind).
+const (
+	AttachmentPreviewText     = "text"
+	AttachmentPreviewMarkdown = "markdown"
+	AttachmentPreviewJSON     = "json"
+	AttachmentPreviewCSV      = "csv"
+	AttachmentPreviewImage    = "image"
+	AttachmentPreviewArchive  = "archive"
+)
+
+// AttachmentPreviewKind classifies a file by name (and mime type) for previews and text
+// extraction. It returns "" for files that have neither.
+func AttachmentPreviewKind(name, mimeType string) string {
+	lower := strings.ToLower(strings.TrimSpace(name))
+	switch {
+	case strings.HasSuffix(lower, ".tar.gz"), strings.HasSuffix(lower, ".tgz"):
+		return AttachmentPreviewArchive
+	}
+	switch filepath.Ext(lower) {
+	case ".md", ".markdown":
+		return AttachmentPreviewMarkdown
+	case ".json":
+		return AttachmentPreviewJSON
+	case ".csv", ".tsv":
+		return AttachmentPreviewCSV
+	case ".txt", ".text", ".log":
+		return AttachmentPreviewText
+	case ".png", ".jpg", ".jpeg", ".gif":
+		return AttachmentPreviewImage
+	case ".zip", ".tar":
+		return AttachmentPreviewArchive
+	}
+	if strings.HasPrefix(strings.ToLower(strings.TrimSpace(mimeType)), "text/") {
+		return AttachmentPreviewText
+	}
+	return ""
+}
+
+// attachmentTextOf extracts the searchable text of the file at path ("" when there is none).
+func attachmentTextOf(path, name string) string {
+	switch AttachmentPreviewKind(name, guessMimeType(name)) {
+	case AttachmentPreviewText, AttachmentPreviewMarkdown, AttachmentPreviewJSON, AttachmentPreviewCSV:
+		s, _, err := ReadAttachmentText(path, maxAttachmentTextBytes)
+		if err != nil {
+			return ""
+		}
+		return s
+	case AttachmentPreviewArchive:
+		entries, _, err := ListAttachmentArchive(path, name, maxArchiveEntries)
+		if err != nil {
+			return ""
+		}
+		var b strings.Builder
+		for _, e := range entries {
+			if b.Len()+len(e.Name)+1 > maxAttachmentTextBytes {
+				break
+			}
+			b.WriteString(e.Name)
+			b.WriteByte('\n')
+		}
+		return strings.TrimSpace(b.String())
+	default:
+		return ""
+	}
+}
+
+// ReadAttachmentText reads up to maxBytes of a text file. truncated reports whether the file is
+// longer. Files that don't look like UTF-8 text are an error.
+func ReadAttachmentText(path string, maxBytes int) (text string, truncated bool, err error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", false, err
+	}
+	defer f.Close()
+	b, err := io.ReadAll(io.LimitReader(f, int64(maxBytes)+1))
+	if err != nil {
+		return "", false, err
+	}
+	if len(b) > maxBytes {
+		b = b[:maxBytes]
+		truncated = true
+		// Don't split a multi-byte rune at the cut.
+		for i := 0; i < utf8.UTFMax && len(b) > 0 && !utf8.Valid(b); i++ {
+			b = b[:len(b)-1]
+		}
+	}
+	if bytes.IndexByte(b, 0) >= 0 || !utf8.Valid(b) {
+		return "", false, errors.New("not a text file")
+	}
+	return string(b), truncated, nil
+}
+
+// ArchiveEntry is a file in a zip or tar archive.
+type ArchiveEntry struct {
+	Name string
+	Size int64
+	Dir  bool
+}
+
+// ListAttachmentArchive lists up to max entries of a zip, tar or tar.gz file (picked by name).
+// truncated reports whether there are more.
+func ListAttachmentArchive(path, name string, max int) (entries []ArchiveEntry, truncated bool, err error) {
+	lower := strings.ToLower(name)
+	if strings.HasSuffix(lower, ".zip") {
+		zr, err := zip.OpenReader(path)
+		if err != nil {
+			return nil, false, err
+		}
+		defer zr.Close()
+		for _, f := range zr.File {
+			if len(entries) == max {
+				return entries, true, nil
+			}
+			entries = append(entries, ArchiveEntry{Name: f.Name, Size: int64(f.UncompressedSize64), Dir: f.FileInfo().IsDir()})
+		}
+		return entries, false, nil
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, false, err
+	}
+	defer f.Close()
+	var r io.Reader = f
+	if strings.HasSuffix(lower, ".gz") || strings.HasSuffix(lower, ".tgz") {
+		gz, err := gzip.NewReader(f)
+		if err != nil {
+			return nil, false, err
+		}
+		defer gz.Close()
+		r = gz
+	}
+	tr := tar.NewReader(r)
+	for {
+		h, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return entries, false, nil
+		}
+		if err != nil {
+			return nil, false, err
+		}
+		if len(entries) == max {
+			return entries, true, nil
+		}
+		entries = append(entries, ArchiveEntry{Name: h.Name, Size: h.Size, Dir: h.Typeflag == tar.TypeDir})
+	}
+}
+
+// ExtractAttachmentText records the text of a's file on a (fetching it from its blob store if
+// needed). It reports whether the text changed.
+func (s Store) ExtractAttachmentText(ctx context.Context, a *model.Attachment) (bool, error) {
+	if a == nil {
+		return false, errors.New("nil attachment")
+	}
+	if AttachmentPreviewKind(a.OriginalName, a.MimeType) == "" {
+		return false, nil
+	}
+	p, err := s.AttachmentLocalPath(ctx, *a)
+	if err != nil {
+		return false, err
+	}
+	text := attachmentTextOf(p, a.OriginalName)
+	if text == a.Text {
+		return false, nil
+	}
+	a.Text = text
+	return true, nil
+}
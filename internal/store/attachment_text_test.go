@@ -0,0 +1,87 @@
+package store
+
+import (
+	"archive/zip"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"clarity-cli/internal/model"
+)
+
+func TestAttachments_TextIsExtractedAndIndexed(t *testing.T) {
+	s := newWatermarkWorkspace(t)
+	src := t.TempDir()
+	notes := filepath.Join(src, "rollback.md")
+	if err := os.WriteFile(notes, []byte("# Rollback\n\nDrain the canary first."), 0o644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	bin := filepath.Join(src, "dump.txt")
+	if err := os.WriteFile(bin, []byte("not\x00text"), 0o644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	archive := filepath.Join(src, "logs.zip")
+	f, err := os.Create(archive)
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	zw := zip.NewWriter(f)
+	for _, name := range []string{"logs/", "logs/kubelet.log"} {
+		if _, err := zw.Create(name); err != nil {
+			t.Fatalf("zip: %v", err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatalf("zip close: %v", err)
+	}
+	_ = f.Close()
+
+	db, err := s.Load()
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	want := map[string]string{
+		notes:   "# Rollback\n\nDrain the canary first.",
+		bin:     "",
+		archive: "logs/\nlogs/kubelet.log",
+	}
+	for path, text := range want {
+		a, err := s.AddAttachment(db, "act-1", "item", "item-1", path, "", "", 0)
+		if err != nil {
+			t.Fatalf("AddAttachment(%s): %v", path, err)
+		}
+		if a.Text != text {
+			t.Fatalf("%s: expected text %q, got %q", filepath.Base(path), text, a.Text)
+		}
+		if err := s.AppendEvent("act-1", "attachment.add", a.ID, a); err != nil {
+			t.Fatalf("AppendEvent: %v", err)
+		}
+	}
+	if err := s.Save(db); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	for _, term := range []string{"canary", "kubelet"} {
+		got, err := s.SearchCandidates(context.Background(), []string{term})
+		if err != nil {
+			t.Fatalf("SearchCandidates: %v", err)
+		}
+		if !got["item-1"] {
+			t.Fatalf("expected %q to find item-1 via attachment text, got %v", term, got)
+		}
+	}
+
+	// Backfill: an attachment recorded without text gets it from its file.
+	var a model.Attachment
+	for _, x := range db.Attachments {
+		if x.OriginalName == "rollback.md" {
+			a = x
+		}
+	}
+	a.Text = ""
+	changed, err := s.ExtractAttachmentText(context.Background(), &a)
+	if err != nil || !changed || a.Text == "" {
+		t.Fatalf("ExtractAttachmentText: changed=%v text=%q err=%v", changed, a.Text, err)
+	}
+}
@@ -1,13 +1,9 @@
 package store
 
 import (
-        "crypto/sha256"
-        "encoding/hex"
         "errors"
         "fmt"
-        "io"
         "mime"
-        "os"
         "path/filepath"
         "strings"
         "time"
@@ -21,11 +17,23 @@ func (s Store) attachmentsDir() string {
         return filepath.Join(s.workspaceRoot(), "resources", "attachments")
 }
 
+// attachmentBlobsRel is where file contents are stored, once per sha256 (see attachment_blobs.go).
+// Attachments added before content addressing keep their resources/attachments/<att-id>/ paths.
+var attachmentBlobsRel = filepath.Join("resources", "attachments", "sha256")
+
 func (s Store) attachmentFilePath(a model.Attachment) string {
         return filepath.Join(s.workspaceRoot(), filepath.FromSlash(strings.TrimSpace(a.Path)))
 }
 
+// AttachmentAbsPath is where the attachment's file is on disk: in the workspace, or in the local
+// cache for an external blob store (which may not be fetched yet; see AttachmentLocalPath). It is
+// "" for an invalid external key.
 func (s Store) AttachmentAbsPath(a model.Attachment) string {
+        if name, key, err := splitAttachmentPath(a.Path); err != nil {
+                return ""
+        } else if name != "" {
+                return s.blobCachePath(key)
+        }
         return s.attachmentFilePath(a)
 }
 
@@ -64,76 +72,24 @@ func (s Store) AddAttachment(db *DB, actorID string, entityKind string, entityID
         if entityID == "" {
                 return model.Attachment{}, errors.New("missing entity id")
         }
-        srcPath = filepath.Clean(strings.TrimSpace(srcPath))
-        if srcPath == "" {
-                return model.Attachment{}, errors.New("missing source path")
-        }
-        st, err := os.Stat(srcPath)
+        blob, err := s.storeAttachmentBlob(srcPath, maxBytes)
         if err != nil {
                 return model.Attachment{}, err
         }
-        if st.IsDir() {
-                return model.Attachment{}, errors.New("attachments: source path is a directory")
-        }
-        if maxBytes <= 0 {
-                maxBytes = DefaultAttachmentMaxBytes
-        }
-        if st.Size() > maxBytes {
-                return model.Attachment{}, fmt.Errorf("attachments: file too large (%d bytes > %d bytes)", st.Size(), maxBytes)
-        }
-
-        orig := filepath.Base(srcPath)
-        if strings.TrimSpace(orig) == "" || orig == "." || orig == string(filepath.Separator) {
-                orig = "attachment"
-        }
 
         now := time.Now().UTC()
-        id := s.NextID(db, "att")
-        destDir := filepath.Join(s.attachmentsDir(), id)
-        if err := os.MkdirAll(destDir, 0o755); err != nil {
-                return model.Attachment{}, err
-        }
-
-        // Keep a stable on-disk name for now; metadata stores original name separately.
-        destName := orig
-        destPath := filepath.Join(destDir, destName)
-
-        in, err := os.Open(srcPath)
-        if err != nil {
-                return model.Attachment{}, err
-        }
-        defer in.Close()
-
-        out, err := os.OpenFile(destPath, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o644)
-        if err != nil {
-                return model.Attachment{}, err
-        }
-        defer func() { _ = out.Close() }()
-
-        h := sha256.New()
-        w := io.MultiWriter(out, h)
-        n, err := io.Copy(w, io.LimitReader(in, maxBytes+1))
-        if err != nil {
-                return model.Attachment{}, err
-        }
-        if n > maxBytes {
-                return model.Attachment{}, fmt.Errorf("attachments: file too large (%d bytes > %d bytes)", n, maxBytes)
-        }
-
-        sum := hex.EncodeToString(h.Sum(nil))
-
-        rel := filepath.ToSlash(filepath.Join("resources", "attachments", id, destName))
         a := model.Attachment{
-                ID:           id,
+                ID:           s.NextID(db, "att"),
                 EntityKind:   kind,
                 EntityID:     entityID,
                 Title:        strings.TrimSpace(title),
                 Alt:          strings.TrimSpace(alt),
-                OriginalName: orig,
-                SizeBytes:    n,
-                MimeType:     guessMimeType(orig),
-                Sha256Hex:    sum,
-                Path:         rel,
+                OriginalName: blob.OriginalName,
+                SizeBytes:    blob.SizeBytes,
+                MimeType:     guessMimeType(blob.OriginalName),
+                Sha256Hex:    blob.Sha256Hex,
+                Path:         blob.Path,
+                Text:         attachmentTextOf(srcPath, blob.OriginalName),
                 CreatedBy:    actorID,
                 CreatedAt:    now,
                 UpdatedAt:    now,
@@ -145,6 +101,53 @@ func (s Store) AddAttachment(db *DB, actorID string, entityKind string, entityID
         return a, nil
 }
 
+// ReplaceAttachment points an attachment at a new file, keeping its id, entity, title and alt
+// text. The previous file stays in storage: history (and `--as-of`) still references it.
+func (s Store) ReplaceAttachment(db *DB, actorID string, attachmentID string, srcPath string, maxBytes int64) (model.Attachment, error) {
+        if db == nil {
+                return model.Attachment{}, errors.New("nil db")
+        }
+        if strings.TrimSpace(actorID) == "" {
+                return model.Attachment{}, errors.New("missing actor id")
+        }
+        a, ok := db.FindAttachment(attachmentID)
+        if !ok || a == nil {
+                return model.Attachment{}, fmt.Errorf("attachment not found: %s", strings.TrimSpace(attachmentID))
+        }
+        blob, err := s.storeAttachmentBlob(srcPath, maxBytes)
+        if err != nil {
+                return model.Attachment{}, err
+        }
+        a.OriginalName = blob.OriginalName
+        a.SizeBytes = blob.SizeBytes
+        a.MimeType = guessMimeType(blob.OriginalName)
+        a.Sha256Hex = blob.Sha256Hex
+        a.Path = blob.Path
+        a.Text = attachmentTextOf(srcPath, blob.OriginalName)
+        a.UpdatedAt = time.Now().UTC()
+        db.idxBuilt = false
+        return *a, nil
+}
+
+// RemoveAttachment drops an attachment from the derived state. Its file stays in storage until
+// `clarity attachments gc --removed`.
+func (s Store) RemoveAttachment(db *DB, attachmentID string) (model.Attachment, error) {
+        if db == nil {
+                return model.Attachment{}, errors.New("nil db")
+        }
+        attachmentID = strings.TrimSpace(attachmentID)
+        for i := range db.Attachments {
+                if strings.TrimSpace(db.Attachments[i].ID) != attachmentID {
+                        continue
+                }
+                a := db.Attachments[i]
+                db.Attachments = append(db.Attachments[:i], db.Attachments[i+1:]...)
+                db.idxBuilt = false
+                return a, nil
+        }
+        return model.Attachment{}, fmt.Errorf("attachment not found: %s", attachmentID)
+}
+
 func (s Store) UpdateAttachmentMetadata(db *DB, actorID string, attachmentID string, title string, alt string) (model.Attachment, error) {
         if db == nil {
                 return model.Attachment{}, errors.New("nil db")
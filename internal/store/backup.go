@@ -22,13 +22,7 @@ func (s Store) ReadEventsV1(ctx context.Context, limit int) ([]EventV1, error) {
         }
         defer db.Close()
 
-        q := `SELECT
-                event_id, workspace_id, replica_id,
-                entity_kind, entity_id, entity_seq,
-                type, parents_json,
-                issued_at_unixms, actor_id, payload_json,
-                local_status, server_status,
-                rejection_reason, published_at_unixms
+        q := `SELECT ` + eventV1Columns + `
         FROM events
         ORDER BY created_at_unixms ASC`
         var rows *sql.Rows
@@ -44,63 +38,11 @@ func (s Store) ReadEventsV1(ctx context.Context, limit int) ([]EventV1, error) {
 
         var out []EventV1
         for rows.Next() {
-                var (
-                        id, wsID, repID, kind, entityID, typ, parentsJSON, actorID, payloadJSON, localStatus, serverStatus string
-                        seq                                                                                                int64
-                        issuedAtMs                                                                                         int64
-                        rej                                                                                                sql.NullString
-                        publishedAtMs                                                                                      sql.NullInt64
-                )
-                if err := rows.Scan(
-                        &id, &wsID, &repID,
-                        &kind, &entityID, &seq,
-                        &typ, &parentsJSON,
-                        &issuedAtMs, &actorID, &payloadJSON,
-                        &localStatus, &serverStatus,
-                        &rej, &publishedAtMs,
-                ); err != nil {
+                ev, err := scanEventV1(rows)
+                if err != nil {
                         return nil, err
                 }
-
-                var parents []string
-                _ = json.Unmarshal([]byte(parentsJSON), &parents)
-
-                var rejPtr *string
-                if rej.Valid {
-                        v := strings.TrimSpace(rej.String)
-                        if v != "" {
-                                rejPtr = &v
-                        }
-                }
-
-                var pubPtr *int64
-                if publishedAtMs.Valid {
-                        v := publishedAtMs.Int64
-                        pubPtr = &v
-                }
-
-                payload := json.RawMessage(strings.TrimSpace(payloadJSON))
-                if len(payload) == 0 {
-                        payload = json.RawMessage("null")
-                }
-
-                out = append(out, EventV1{
-                        EventID:           id,
-                        WorkspaceID:       wsID,
-                        ReplicaID:         repID,
-                        EntityKind:        EntityKind(kind),
-                        EntityID:          entityID,
-                        EntitySeq:         seq,
-                        Type:              typ,
-                        Parents:           parents,
-                        IssuedAt:          time.UnixMilli(issuedAtMs).UTC(),
-                        ActorID:           actorID,
-                        Payload:           payload,
-                        LocalStatus:       localStatus,
-                        ServerStatus:      serverStatus,
-                        RejectionReason:   rejPtr,
-                        PublishedAtUnixMs: pubPtr,
-                })
+                out = append(out, ev)
         }
         if err := rows.Err(); err != nil {
                 return nil, err
@@ -111,6 +53,76 @@ func (s Store) ReadEventsV1(ctx context.Context, limit int) ([]EventV1, error) {
         return out, nil
 }
 
+const eventV1Columns = `
+                event_id, workspace_id, replica_id,
+                entity_kind, entity_id, entity_seq,
+                type, parents_json,
+                issued_at_unixms, actor_id, payload_json,
+                local_status, server_status,
+                rejection_reason, published_at_unixms`
+
+// scanEventV1 scans one row selected with eventV1Columns (plus any trailing columns in extra).
+func scanEventV1(rows *sql.Rows, extra ...any) (EventV1, error) {
+        var (
+                id, wsID, repID, kind, entityID, typ, parentsJSON, actorID, payloadJSON, localStatus, serverStatus string
+                seq                                                                                                int64
+                issuedAtMs                                                                                         int64
+                rej                                                                                                sql.NullString
+                publishedAtMs                                                                                      sql.NullInt64
+        )
+        dest := []any{
+                &id, &wsID, &repID,
+                &kind, &entityID, &seq,
+                &typ, &parentsJSON,
+                &issuedAtMs, &actorID, &payloadJSON,
+                &localStatus, &serverStatus,
+                &rej, &publishedAtMs,
+        }
+        if err := rows.Scan(append(dest, extra...)...); err != nil {
+                return EventV1{}, err
+        }
+
+        var parents []string
+        _ = json.Unmarshal([]byte(parentsJSON), &parents)
+
+        var rejPtr *string
+        if rej.Valid {
+                v := strings.TrimSpace(rej.String)
+                if v != "" {
+                        rejPtr = &v
+                }
+        }
+
+        var pubPtr *int64
+        if publishedAtMs.Valid {
+                v := publishedAtMs.Int64
+                pubPtr = &v
+        }
+
+        payload := json.RawMessage(strings.TrimSpace(payloadJSON))
+        if len(payload) == 0 {
+                payload = json.RawMessage("null")
+        }
+
+        return EventV1{
+                EventID:           id,
+                WorkspaceID:       wsID,
+                ReplicaID:         repID,
+                EntityKind:        EntityKind(kind),
+                EntityID:          entityID,
+                EntitySeq:         seq,
+                Type:              typ,
+                Parents:           parents,
+                IssuedAt:          time.UnixMilli(issuedAtMs).UTC(),
+                ActorID:           actorID,
+                Payload:           payload,
+                LocalStatus:       localStatus,
+                ServerStatus:      serverStatus,
+                RejectionReason:   rejPtr,
+                PublishedAtUnixMs: pubPtr,
+        }, nil
+}
+
 // ReplaceEventsV1 replaces the SQLite event log with the provided v1 events.
 //
 // This is intended for backup/restore workflows, not day-to-day mutations.
@@ -0,0 +1,45 @@
+package store
+
+import (
+	"strings"
+	"time"
+
+	"clarity-cli/internal/model"
+)
+
+// ApplyCommentEdit replaces a comment's body, keeping the previous body as a revision.
+// It is shared by the CLI/TUI mutation path and comment.edit replay so both derive the same state.
+func ApplyCommentEdit(c *model.Comment, body string, at time.Time) bool {
+	if c == nil || c.Redacted {
+		return false
+	}
+	body = strings.TrimSpace(body)
+	if body == c.Body {
+		return false
+	}
+	prevAt := c.CreatedAt
+	if c.EditedAt != nil {
+		prevAt = *c.EditedAt
+	}
+	c.Revisions = append(c.Revisions, model.CommentRevision{Body: c.Body, At: prevAt})
+	c.Body = body
+	at = at.UTC()
+	c.EditedAt = &at
+	return true
+}
+
+// ApplyCommentRedact replaces a comment's body and all prior revisions with
+// model.RedactedCommentBody. Revision timestamps are kept so history still shows when edits happened.
+func ApplyCommentRedact(c *model.Comment, at time.Time) bool {
+	if c == nil || c.Redacted {
+		return false
+	}
+	c.Body = model.RedactedCommentBody
+	for i := range c.Revisions {
+		c.Revisions[i].Body = model.RedactedCommentBody
+	}
+	c.Redacted = true
+	at = at.UTC()
+	c.EditedAt = &at
+	return true
+}
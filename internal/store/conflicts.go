@@ -0,0 +1,127 @@
+package store
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Conflict is a field that concurrent events set to different values (see replay_merge.go). The
+// state shows Current (the last write in replay order) until an edit that descends from every
+// side resolves it (e.g. `clarity conflicts resolve`).
+type Conflict struct {
+	EntityKind string         `json:"entityKind"`
+	EntityID   string         `json:"entityId"`
+	Field      string         `json:"field"`
+	Current    string         `json:"current"`
+	Sides      []ConflictSide `json:"sides"`
+}
+
+type ConflictSide struct {
+	EventID   string    `json:"eventId"`
+	ReplicaID string    `json:"replicaId"`
+	ActorID   string    `json:"actorId"`
+	IssuedAt  time.Time `json:"issuedAt"`
+	Value     string    `json:"value"`
+}
+
+// conflictFields maps event types whose concurrent writes are surfaced as conflicts to their field.
+var conflictFields = map[string]string{
+	"item.set_description":    "description",
+	"outline.set_description": "description",
+}
+
+// ConflictEventType returns the event type that writes field on an entity of kind.
+func ConflictEventType(kind, field string) string {
+	for typ, f := range conflictFields {
+		if f == field && strings.HasPrefix(typ, kind+".") {
+			return typ
+		}
+	}
+	return ""
+}
+
+// ListConflictsV1 returns the unresolved conflicts in the workspace's JSONL event logs, ordered by
+// entity id and field.
+func ListConflictsV1(dir string) ([]Conflict, error) {
+	lines, err := ReadEventsV1Lines(dir)
+	if err != nil {
+		return nil, err
+	}
+	sortEventV1Lines(lines)
+	g := newCausalGraph(lines)
+
+	type key struct{ entity, field string }
+	writes := map[key][]EventV1{}
+	var order []key
+	for _, l := range lines {
+		ev := l.Event
+		ent := strings.TrimSpace(ev.EntityID)
+		field, ok := conflictFields[strings.TrimSpace(ev.Type)]
+		if !ok || !g.forked[ent] {
+			continue
+		}
+		k := key{entity: ent, field: field}
+		if _, seen := writes[k]; !seen {
+			order = append(order, k)
+		}
+		writes[k] = append(writes[k], ev)
+	}
+
+	out := []Conflict{}
+	for _, k := range order {
+		ws := writes[k]
+		// The frontier: writes no other write descends from.
+		var frontier []EventV1
+		for i, a := range ws {
+			superseded := false
+			for j, b := range ws {
+				if i != j && g.isAncestor(a.EventID, b.EventID) {
+					superseded = true
+					break
+				}
+			}
+			if !superseded {
+				frontier = append(frontier, a)
+			}
+		}
+		if len(frontier) < 2 {
+			continue
+		}
+		c := Conflict{EntityKind: frontier[0].EntityKind.String(), EntityID: k.entity, Field: k.field}
+		distinct := map[string]bool{}
+		for _, ev := range frontier {
+			v := conflictValue(ev)
+			distinct[v] = true
+			c.Sides = append(c.Sides, ConflictSide{
+				EventID:   strings.TrimSpace(ev.EventID),
+				ReplicaID: strings.TrimSpace(ev.ReplicaID),
+				ActorID:   strings.TrimSpace(ev.ActorID),
+				IssuedAt:  ev.IssuedAt.UTC(),
+				Value:     v,
+			})
+		}
+		if len(distinct) < 2 {
+			// Both sides wrote the same value: nothing to decide.
+			continue
+		}
+		c.Current = c.Sides[len(c.Sides)-1].Value
+		out = append(out, c)
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		if out[i].EntityID != out[j].EntityID {
+			return out[i].EntityID < out[j].EntityID
+		}
+		return out[i].Field < out[j].Field
+	})
+	return out, nil
+}
+
+func conflictValue(ev EventV1) string {
+	var p struct {
+		Description string `json:"description"`
+	}
+	_ = json.Unmarshal(ev.Payload, &p)
+	return p.Description
+}
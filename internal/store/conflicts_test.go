@@ -0,0 +1,107 @@
+package store
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func itemEventLine(eventID, parentID, replicaID, issuedAt, typ, payload string) string {
+	parents := ""
+	if parentID != "" {
+		parents = `"parents":["` + parentID + `"],`
+	}
+	return `{"eventId":"` + eventID + `","workspaceId":"ws-1","replicaId":"` + replicaID + `","entityKind":"item","entityId":"item-1","entitySeq":0,"type":"` + typ + `",` + parents + `"issuedAt":"` + issuedAt + `","actorId":"act-1","payload":` + payload + `}` + "\n"
+}
+
+func newForkedWorkspace(t *testing.T, sideA, sideB string) string {
+	t.Helper()
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "events"), 0o755); err != nil {
+		t.Fatalf("mkdir events: %v", err)
+	}
+	writeShard(t, dir, "rep-a", watermarkBaseEvents+sideA)
+	writeShard(t, dir, "rep-b", sideB)
+	return dir
+}
+
+func TestReplayEventsV1_ConcurrentTagEditsUnion(t *testing.T) {
+	dir := newForkedWorkspace(t,
+		itemEventLine("evt-a1", "evt-5", "rep-a", "2025-12-31T00:00:06Z", "item.tags_set", `{"tags":["alpha"]}`),
+		// Issued later on top of the same head: replaces the tags, but must not drop "alpha".
+		itemEventLine("evt-b1", "evt-5", "rep-b", "2025-12-31T00:00:07Z", "item.tags_set", `{"tags":["beta"]}`),
+	)
+	res, err := ReplayEventsV1(dir)
+	if err != nil {
+		t.Fatalf("ReplayEventsV1: %v", err)
+	}
+	it, _ := res.DB.FindItem("item-1")
+	tags := slices.Clone(it.Tags)
+	slices.Sort(tags)
+	if !slices.Equal(tags, []string{"alpha", "beta"}) {
+		t.Fatalf("tags = %v, want [alpha beta]", tags)
+	}
+
+	// A remove that saw the tag still removes it.
+	appendShard(t, dir, "rep-a", `{"eventId":"evt-a2","workspaceId":"ws-1","replicaId":"rep-a","entityKind":"item","entityId":"item-1","entitySeq":0,"type":"item.tags_remove","parents":["evt-a1","evt-b1"],"issuedAt":"2025-12-31T00:00:08Z","actorId":"act-1","payload":{"tag":"alpha"}}`+"\n")
+	res, err = ReplayEventsV1(dir)
+	if err != nil {
+		t.Fatalf("ReplayEventsV1: %v", err)
+	}
+	it, _ = res.DB.FindItem("item-1")
+	if !slices.Equal(it.Tags, []string{"beta"}) {
+		t.Fatalf("tags after remove = %v, want [beta]", it.Tags)
+	}
+}
+
+func TestListConflictsV1_ConcurrentDescriptions(t *testing.T) {
+	dir := newForkedWorkspace(t,
+		itemEventLine("evt-a1", "evt-5", "rep-a", "2025-12-31T00:00:06Z", "item.set_description", `{"description":"from A"}`),
+		itemEventLine("evt-b1", "evt-5", "rep-b", "2025-12-31T00:00:07Z", "item.set_description", `{"description":"from B"}`)+
+			itemEventLine("evt-b2", "evt-b1", "rep-b", "2025-12-31T00:00:08Z", "item.set_title", `{"title":"B title"}`),
+	)
+
+	cs, err := ListConflictsV1(dir)
+	if err != nil {
+		t.Fatalf("ListConflictsV1: %v", err)
+	}
+	if len(cs) != 1 {
+		t.Fatalf("expected 1 conflict, got %+v", cs)
+	}
+	c := cs[0]
+	if c.EntityID != "item-1" || c.Field != "description" || len(c.Sides) != 2 || c.Current != "from B" {
+		t.Fatalf("unexpected conflict: %+v", c)
+	}
+	res, err := ReplayEventsV1(dir)
+	if err != nil {
+		t.Fatalf("ReplayEventsV1: %v", err)
+	}
+	if it, _ := res.DB.FindItem("item-1"); it.Description != c.Current || it.Title != "B title" {
+		t.Fatalf("state description=%q title=%q", it.Description, it.Title)
+	}
+
+	// Taking a side with an edit that descends from both resolves it.
+	appendShard(t, dir, "rep-a", `{"eventId":"evt-a2","workspaceId":"ws-1","replicaId":"rep-a","entityKind":"item","entityId":"item-1","entitySeq":0,"type":"item.set_description","parents":["evt-a1","evt-b2"],"issuedAt":"2025-12-31T00:00:09Z","actorId":"act-1","payload":{"description":"from A"}}`+"\n")
+	cs, err = ListConflictsV1(dir)
+	if err != nil {
+		t.Fatalf("ListConflictsV1: %v", err)
+	}
+	if len(cs) != 0 {
+		t.Fatalf("expected conflict to be resolved, got %+v", cs)
+	}
+}
+
+func TestListConflictsV1_SameValueIsNotAConflict(t *testing.T) {
+	dir := newForkedWorkspace(t,
+		itemEventLine("evt-a1", "evt-5", "rep-a", "2025-12-31T00:00:06Z", "item.set_description", `{"description":"same"}`),
+		itemEventLine("evt-b1", "evt-5", "rep-b", "2025-12-31T00:00:07Z", "item.set_description", `{"description":"same"}`),
+	)
+	cs, err := ListConflictsV1(dir)
+	if err != nil {
+		t.Fatalf("ListConflictsV1: %v", err)
+	}
+	if len(cs) != 0 {
+		t.Fatalf("expected no conflicts, got %+v", cs)
+	}
+}
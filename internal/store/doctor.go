@@ -11,6 +11,7 @@ import (
         "path/filepath"
         "sort"
         "strings"
+        "time"
 
         "clarity-cli/internal/gitrepo"
 )
@@ -525,6 +526,12 @@ func DoctorEventsV1(dir string) DoctorReport {
                 }
         }
 
+        issues = append(issues, clockSkewIssues(lines, time.Now().UTC())...)
+        issues = append(issues, attachmentIssues(st, lines)...)
+        issues = append(issues, snapshotIssues(st)...)
+        issues = append(issues, accessIssues(st, lines)...)
+        issues = append(issues, signatureIssues(st, lines)...)
+
         return DoctorReport{Issues: issuesOrEmpty(issues)}
 }
 
@@ -0,0 +1,132 @@
+package store
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// clockSkewThreshold is how far replica clocks may disagree before doctor reports it. Replay order
+// doesn't depend on wall clocks (see sortEventV1Lines), but issuedAt still shows up as
+// "updated at" times, in history and in agenda/notification ordering.
+const clockSkewThreshold = 2 * time.Minute
+
+// clockSkewIssues reports replicas whose clocks disagree:
+//   - an event issued "before" a parent written by another replica: the parent's replica was
+//     ahead of the child's by at least the difference (reported once per replica pair, max gap)
+//   - an event whose HLC is ahead of its own issuedAt: its replica had seen events stamped later
+//     than its own clock
+//   - events issued in the future (relative to now)
+func clockSkewIssues(lines []EventV1Line, now time.Time) []DoctorIssue {
+	byID := make(map[string]EventV1Line, len(lines))
+	for _, l := range lines {
+		if id := strings.TrimSpace(l.Event.EventID); id != "" {
+			if _, ok := byID[id]; !ok {
+				byID[id] = l
+			}
+		}
+	}
+
+	type pair struct{ ahead, behind string }
+	type worst struct {
+		gap time.Duration
+		at  EventV1Line
+	}
+	pairs := map[pair]worst{}
+	hlcAhead := map[string]worst{}
+	future := map[string]worst{}
+	note := func(m map[string]worst, k string, gap time.Duration, l EventV1Line) {
+		if gap > m[k].gap {
+			m[k] = worst{gap: gap, at: l}
+		}
+	}
+
+	for _, l := range lines {
+		ev := l.Event
+		rep := strings.TrimSpace(ev.ReplicaID)
+		if rep == "" || ev.IssuedAt.IsZero() {
+			continue
+		}
+		for _, pid := range ev.Parents {
+			p, ok := byID[strings.TrimSpace(pid)]
+			prep := strings.TrimSpace(p.Event.ReplicaID)
+			if !ok || prep == "" || prep == rep || p.Event.IssuedAt.IsZero() {
+				continue
+			}
+			if gap := p.Event.IssuedAt.Sub(ev.IssuedAt); gap > clockSkewThreshold {
+				if k := (pair{ahead: prep, behind: rep}); gap > pairs[k].gap {
+					pairs[k] = worst{gap: gap, at: l}
+				}
+			}
+		}
+		if !ev.HLC.IsZero() {
+			if gap := ev.HLC.Time().Sub(ev.IssuedAt); gap > clockSkewThreshold {
+				note(hlcAhead, rep, gap, l)
+			}
+		}
+		if gap := ev.IssuedAt.Sub(now); gap > clockSkewThreshold {
+			note(future, rep, gap, l)
+		}
+	}
+
+	var out []DoctorIssue
+	issue := func(code, msg string, w worst) DoctorIssue {
+		ev := w.at.Event
+		return DoctorIssue{
+			Level:      DoctorIssueLevelWarn,
+			Code:       code,
+			Message:    msg,
+			Path:       w.at.Path,
+			Line:       w.at.Line,
+			EventID:    strings.TrimSpace(ev.EventID),
+			ReplicaID:  strings.TrimSpace(ev.ReplicaID),
+			EntityKind: ev.EntityKind.String(),
+			EntityID:   strings.TrimSpace(ev.EntityID),
+			Type:       strings.TrimSpace(ev.Type),
+		}
+	}
+
+	pairKeys := make([]pair, 0, len(pairs))
+	for k := range pairs {
+		pairKeys = append(pairKeys, k)
+	}
+	sort.Slice(pairKeys, func(i, j int) bool {
+		if pairKeys[i].ahead != pairKeys[j].ahead {
+			return pairKeys[i].ahead < pairKeys[j].ahead
+		}
+		return pairKeys[i].behind < pairKeys[j].behind
+	})
+	for _, k := range pairKeys {
+		w := pairs[k]
+		out = append(out, issue("clock_skew",
+			fmt.Sprintf("replica %s's clock is at least %s behind replica %s (event issued before its parent)", k.behind, roundSkew(w.gap), k.ahead), w))
+	}
+	for _, rep := range sortedKeys(hlcAhead) {
+		w := hlcAhead[rep]
+		out = append(out, issue("clock_skew",
+			fmt.Sprintf("replica %s issued events %s before the latest time it had seen (another replica's clock is ahead)", rep, roundSkew(w.gap)), w))
+	}
+	for _, rep := range sortedKeys(future) {
+		w := future[rep]
+		out = append(out, issue("clock_in_future",
+			fmt.Sprintf("replica %s issued events %s in the future", rep, roundSkew(w.gap)), w))
+	}
+	return out
+}
+
+func roundSkew(d time.Duration) time.Duration {
+	if d >= time.Minute {
+		return d.Round(time.Second)
+	}
+	return d.Round(time.Millisecond)
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -3,6 +3,7 @@ package store
 import (
         "os"
         "path/filepath"
+        "strings"
         "testing"
 )
 
@@ -62,3 +63,34 @@ func TestDoctorEventsV1_ReportsParseErrorsWithFileLine(t *testing.T) {
                 t.Fatalf("expected malformed_json issue; got %#v", r.Issues)
         }
 }
+
+func TestDoctorEventsV1_ReportsClockSkewBetweenReplicas(t *testing.T) {
+        dir := t.TempDir()
+        if err := os.MkdirAll(filepath.Join(dir, "events"), 0o755); err != nil {
+                t.Fatalf("mkdir events: %v", err)
+        }
+        a := `{"eventId":"evt-1","workspaceId":"ws-1","replicaId":"rep-a","entityKind":"item","entityId":"item-1","entitySeq":1,"type":"item.create","issuedAt":"2025-12-31T11:00:00Z","hlc":"1767178800000:0","actorId":"act-1","payload":{}}` + "\n"
+        b := `{"eventId":"evt-2","workspaceId":"ws-1","replicaId":"rep-b","entityKind":"item","entityId":"item-1","entitySeq":2,"type":"item.set_title","parents":["evt-1"],"issuedAt":"2025-12-31T10:00:00Z","hlc":"1767178800000:1","actorId":"act-2","payload":{}}` + "\n"
+        for name, content := range map[string]string{"events.rep-a.jsonl": a, "events.rep-b.jsonl": b} {
+                if err := os.WriteFile(filepath.Join(dir, "events", name), []byte(content), 0o644); err != nil {
+                        t.Fatalf("write file: %v", err)
+                }
+        }
+
+        r := DoctorEventsV1(dir)
+        if r.HasErrors() {
+                t.Fatalf("clock skew should only warn; got %#v", r.Issues)
+        }
+        var skew []DoctorIssue
+        for _, it := range r.Issues {
+                if it.Code == "clock_skew" {
+                        skew = append(skew, it)
+                }
+        }
+        if len(skew) != 2 {
+                t.Fatalf("expected 2 clock_skew issues (parent + hlc); got %#v", r.Issues)
+        }
+        if skew[0].ReplicaID != "rep-b" || !strings.Contains(skew[0].Message, "1h0m0s behind replica rep-a") {
+                t.Fatalf("unexpected clock_skew issue: %#v", skew[0])
+        }
+}
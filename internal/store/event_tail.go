@@ -0,0 +1,216 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// EventTail follows the event log: each Poll returns the events appended since the previous one,
+// whichever process (or `git pull`) appended them.
+//
+// For the JSONL backend it keeps a byte offset per shard and only reads complete lines past it; a
+// new shard is read from the start. A shard that shrank was rewritten (e.g. a rebase) and is
+// followed from its new end. For the SQLite backend it keeps the last seen rowid.
+type EventTail struct {
+	s       Store
+	backend EventLogBackend
+	shards  map[string]int64
+	rowID   int64
+}
+
+// EventCursor is a resumable EventTail position (see EventTail.Cursor).
+type EventCursor struct {
+	Shards map[string]int64 `json:"shards,omitempty"`
+	RowID  int64            `json:"rowId,omitempty"`
+}
+
+// TailEvents returns a tail positioned at the current end of the log.
+func (s Store) TailEvents(ctx context.Context) (*EventTail, error) {
+	t := &EventTail{s: s, backend: s.eventLogBackend(), shards: map[string]int64{}}
+	if t.backend == EventLogBackendJSONL {
+		paths, err := s.shardPaths()
+		if err != nil {
+			return nil, err
+		}
+		for _, p := range paths {
+			st, err := os.Stat(p)
+			if err != nil {
+				if errors.Is(err, os.ErrNotExist) {
+					continue
+				}
+				return nil, err
+			}
+			t.shards[filepath.Base(p)] = st.Size()
+		}
+		return t, nil
+	}
+	db, err := s.openSQLite(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+	if err := db.QueryRowContext(ctx, `SELECT COALESCE(MAX(rowid), 0) FROM events`).Scan(&t.rowID); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// TailEventsFrom returns a tail positioned at cur; the zero cursor is the start of the log.
+func (s Store) TailEventsFrom(cur EventCursor) *EventTail {
+	t := &EventTail{s: s, backend: s.eventLogBackend(), shards: map[string]int64{}, rowID: cur.RowID}
+	for k, v := range cur.Shards {
+		t.shards[k] = v
+	}
+	return t
+}
+
+// TailEventsAfter returns the events that follow eventID in replay order (the order
+// `clarity events list` uses) and a tail positioned at the current end of the log.
+//
+// Events that arrive later but sort before eventID (concurrent edits from another replica) are
+// only seen by the tail if they are appended after this call; a saved Cursor doesn't have that gap.
+func (s Store) TailEventsAfter(ctx context.Context, eventID string) ([]EventV1, *EventTail, error) {
+	eventID = strings.TrimSpace(eventID)
+	t, err := s.TailEvents(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	var all []EventV1
+	if t.backend == EventLogBackendJSONL {
+		lines, err := s.readEventsV1LinesJSONL()
+		if err != nil {
+			return nil, nil, err
+		}
+		// Stop at what the tail already covers so nothing is returned twice.
+		kept := lines[:0]
+		for _, l := range lines {
+			if l.End <= t.shards[filepath.Base(l.Path)] {
+				kept = append(kept, l)
+			}
+		}
+		sortEventV1Lines(kept)
+		for _, l := range kept {
+			all = append(all, l.Event)
+		}
+	} else {
+		from := s.TailEventsFrom(EventCursor{})
+		if all, err = from.pollSQLite(ctx); err != nil {
+			return nil, nil, err
+		}
+		t = from
+	}
+	for i, ev := range all {
+		if strings.TrimSpace(ev.EventID) == eventID {
+			return append([]EventV1{}, all[i+1:]...), t, nil
+		}
+	}
+	return nil, nil, fmt.Errorf("%w: %s", ErrEventNotFound, eventID)
+}
+
+// Cursor returns the tail's current position.
+func (t *EventTail) Cursor() EventCursor {
+	cur := EventCursor{RowID: t.rowID}
+	if len(t.shards) > 0 {
+		cur.Shards = make(map[string]int64, len(t.shards))
+		for k, v := range t.shards {
+			cur.Shards[k] = v
+		}
+	}
+	return cur
+}
+
+// Poll returns the events appended since the last call, in replay order.
+func (t *EventTail) Poll(ctx context.Context) ([]EventV1, error) {
+	if t.backend != EventLogBackendJSONL {
+		return t.pollSQLite(ctx)
+	}
+	paths, err := t.s.shardPaths()
+	if err != nil {
+		return nil, err
+	}
+	var lines []EventV1Line
+	for _, p := range paths {
+		name := filepath.Base(p)
+		from := t.shards[name]
+		st, err := os.Stat(p)
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				continue
+			}
+			return nil, err
+		}
+		if st.Size() < from {
+			t.shards[name] = st.Size()
+			continue
+		}
+		if st.Size() == from {
+			continue
+		}
+		end, err := scanShardLines(p, from, 0, true, func(l EventV1Line) error {
+			lines = append(lines, l)
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		t.shards[name] = end
+	}
+	sortEventV1Lines(lines)
+	out := make([]EventV1, 0, len(lines))
+	for _, l := range lines {
+		out = append(out, l.Event)
+	}
+	return out, nil
+}
+
+func (t *EventTail) pollSQLite(ctx context.Context) ([]EventV1, error) {
+	db, err := t.s.openSQLite(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+	rows, err := db.QueryContext(ctx, `SELECT `+eventV1Columns+`, rowid FROM events WHERE rowid > ? ORDER BY rowid ASC`, t.rowID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	out := []EventV1{}
+	for rows.Next() {
+		var rowID int64
+		ev, err := scanEventV1(rows, &rowID)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, ev)
+		t.rowID = rowID
+	}
+	return out, rows.Err()
+}
+
+// ChangeStamp summarizes the size and mtime of the derived state and every event shard. It
+// changes whenever another process (or a `git pull`) writes to the workspace, so long-running
+// processes can keep a loaded DB until the stamp moves.
+func (s Store) ChangeStamp() (string, error) {
+	paths, err := s.shardPaths()
+	if err != nil {
+		return "", err
+	}
+	// SQLite writes land in the WAL until a checkpoint, so stat both.
+	paths = append(paths, s.sqlitePath(), s.sqlitePath()+"-wal")
+	var b strings.Builder
+	for _, p := range paths {
+		st, err := os.Stat(p)
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				continue
+			}
+			return "", err
+		}
+		fmt.Fprintf(&b, "%s:%d:%d;", filepath.Base(p), st.Size(), st.ModTime().UnixNano())
+	}
+	return b.String(), nil
+}
@@ -0,0 +1,74 @@
+package store
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestEventTail_FollowsAppendedAndPulledShards(t *testing.T) {
+	s := newWatermarkWorkspace(t)
+	ctx := context.Background()
+	tail, err := s.TailEvents(ctx)
+	if err != nil {
+		t.Fatalf("TailEvents: %v", err)
+	}
+	if evs, err := tail.Poll(ctx); err != nil || len(evs) != 0 {
+		t.Fatalf("expected nothing new, got %v (err=%v)", evs, err)
+	}
+
+	appendShard(t, s.Dir, "rep-a", setTitleLine("evt-a6", "evt-5", "rep-a", "2025-12-31T00:00:06Z", "A"))
+	writeShard(t, s.Dir, "rep-b", setTitleLine("evt-b1", "evt-a6", "rep-b", "2025-12-31T00:00:07Z", "B"))
+	evs, err := tail.Poll(ctx)
+	if err != nil {
+		t.Fatalf("Poll: %v", err)
+	}
+	if len(evs) != 2 || evs[0].EventID != "evt-a6" || evs[1].EventID != "evt-b1" {
+		t.Fatalf("unexpected events: %+v", evs)
+	}
+
+	// A partially written line waits for its newline.
+	appendShard(t, s.Dir, "rep-b", strings.TrimSuffix(setTitleLine("evt-b2", "evt-b1", "rep-b", "2025-12-31T00:00:08Z", "B2"), "\n"))
+	if evs, err := tail.Poll(ctx); err != nil || len(evs) != 0 {
+		t.Fatalf("expected partial line to wait, got %v (err=%v)", evs, err)
+	}
+	appendShard(t, s.Dir, "rep-b", "\n")
+	if evs, err := tail.Poll(ctx); err != nil || len(evs) != 1 || evs[0].EventID != "evt-b2" {
+		t.Fatalf("expected evt-b2, got %v (err=%v)", evs, err)
+	}
+}
+
+func TestEventTail_AfterEventAndCursorResume(t *testing.T) {
+	s := newWatermarkWorkspace(t)
+	ctx := context.Background()
+
+	evs, tail, err := s.TailEventsAfter(ctx, "evt-3")
+	if err != nil {
+		t.Fatalf("TailEventsAfter: %v", err)
+	}
+	if len(evs) != 2 || evs[0].EventID != "evt-4" || evs[1].EventID != "evt-5" {
+		t.Fatalf("unexpected backlog: %+v", evs)
+	}
+	if _, _, err := s.TailEventsAfter(ctx, "evt-nope"); err == nil {
+		t.Fatalf("expected error for unknown event")
+	}
+
+	if err := s.SaveWatchCursor("bot", tail.Cursor()); err != nil {
+		t.Fatalf("SaveWatchCursor: %v", err)
+	}
+	writeShard(t, s.Dir, "rep-b", setTitleLine("evt-b1", "evt-5", "rep-b", "2025-12-31T00:00:06Z", "B"))
+
+	cur, ok, err := s.LoadWatchCursor("bot")
+	if err != nil || !ok {
+		t.Fatalf("LoadWatchCursor: ok=%v err=%v", ok, err)
+	}
+	if evs, err := s.TailEventsFrom(cur).Poll(ctx); err != nil || len(evs) != 1 || evs[0].EventID != "evt-b1" {
+		t.Fatalf("expected evt-b1 after resume, got %v (err=%v)", evs, err)
+	}
+	if evs, err := s.TailEventsFrom(EventCursor{}).Poll(ctx); err != nil || len(evs) != 6 {
+		t.Fatalf("expected the whole log from the zero cursor, got %d events (err=%v)", len(evs), err)
+	}
+	if _, ok, _ := s.LoadWatchCursor("other"); ok {
+		t.Fatalf("unexpected cursor")
+	}
+}
@@ -7,6 +7,7 @@ import (
         "encoding/json"
         "errors"
         "fmt"
+        "io"
         "os"
         "path/filepath"
         "sort"
@@ -17,9 +18,12 @@ import (
 )
 
 type EventV1Line struct {
-        Path  string
-        Line  int
-        Event EventV1
+        Path string
+        Line int
+        // Offset/End are the byte range of the line in its shard (used for replay watermarks).
+        Offset int64
+        End    int64
+        Event  EventV1
 }
 
 func ReadEventsV1Lines(dir string) ([]EventV1Line, error) {
@@ -53,84 +57,96 @@ func (s Store) shardPath(replicaID string) string {
         return filepath.Join(s.eventsDir(), fmt.Sprintf("events.%s.jsonl", replicaID))
 }
 
-func (s Store) appendEventJSONL(ctx context.Context, actorID, typ, entityID string, payload any) error {
+// appendEventJSONL appends the event (after a merge marker if the entity has forked) and returns it.
+func (s Store) appendEventJSONL(ctx context.Context, actorID, typ, entityID string, payload any) (EventV1, error) {
         // Use the same contract validation as the SQLite backend.
         kind := inferEntityKindFromType(typ)
         if !kind.valid() {
-                return formatErrEventContract("invalid entity kind for type %q", typ)
+                return EventV1{}, formatErrEventContract("invalid entity kind for type %q", typ)
         }
         entityID = strings.TrimSpace(entityID)
         if entityID == "" {
-                return formatErrEventContract("missing entity id")
+                return EventV1{}, formatErrEventContract("missing entity id")
         }
         typ = strings.TrimSpace(typ)
         if typ == "" {
-                return formatErrEventContract("missing type")
+                return EventV1{}, formatErrEventContract("missing type")
         }
         actorID = strings.TrimSpace(actorID)
         if actorID == "" {
-                return formatErrEventContract("missing actor id")
+                return EventV1{}, formatErrEventContract("missing actor id")
         }
 
         // V1 Git-backed mode: workspaceId is committed (meta/workspace.json); replicaId is local-only
         // (.clarity/device.json, gitignored). This keeps the canonical log append-only and merge-friendly.
         wsMeta, _, err := s.loadOrInitWorkspaceMeta()
         if err != nil {
-                return err
+                return EventV1{}, err
         }
         device, _, err := s.loadOrInitDeviceFile()
         if err != nil {
-                return err
+                return EventV1{}, err
         }
         wsID := strings.TrimSpace(wsMeta.WorkspaceID)
         repID := strings.TrimSpace(device.ReplicaID)
 
         now := time.Now().UTC()
 
-        ids, parentIDs, maxSeq, err := s.scanEntityIDsParentsMaxSeqV1(kind, entityID)
+        cache, err := s.openHeadCache(ctx)
         if err != nil {
-                return err
+                return EventV1{}, err
+        }
+        defer cache.Close()
+        st, err := cache.lookup(ctx, kind, entityID)
+        if err != nil {
+                return EventV1{}, err
         }
-        heads := computeHeads(ids, parentIDs)
+
+        clock := st.LastClock.Next(now)
+        heads := st.Heads
         var parents []string
         seq := int64(1)
         switch {
-        case len(ids) == 0:
+        case st.Events == 0:
                 seq = 1
-        case maxSeq > 0:
-                seq = maxSeq + 1
+        case st.MaxSeq > 0:
+                seq = st.MaxSeq + 1
         default:
                 // Legacy compatibility: if existing JSONL events have entitySeq=0, allocate a
                 // stable-ish seq based on count to keep per-entity ordering usable.
-                seq = int64(len(ids) + 1)
+                seq = int64(st.Events + 1)
         }
 
+        var evs []EventV1
+
         // Auto-merge forks by appending a merge marker that references all current heads.
         // This keeps the log append-only and avoids blocking normal editing for transient concurrency.
         //
         // NOTE: Merge markers are currently treated as no-ops by reducers; they exist to linearize
         // the per-entity append contract. Higher-level “semantic merge” can be added later.
         if len(heads) > 1 {
-                mergeID, err := s.appendMergeMarkerJSONL(kind, wsID, repID, actorID, entityID, now, seq, heads)
+                merge, err := newMergeMarkerV1(kind, wsID, repID, actorID, entityID, now, clock, seq, heads)
                 if err != nil {
-                        return err
+                        return EventV1{}, err
                 }
-                parents = []string{mergeID}
+                evs = append(evs, merge)
+                parents = []string{merge.EventID}
                 seq++
+                clock = clock.Next(now)
         } else if len(heads) == 1 {
                 parents = []string{heads[0]}
         }
 
         pb, err := json.Marshal(payload)
         if err != nil {
-                return err
+                return EventV1{}, err
         }
         eventID, err := newUUIDv4()
         if err != nil {
-                return err
+                return EventV1{}, err
         }
 
-        ev := EventV1{
+        evs = append(evs, EventV1{
                 EventID:     eventID,
                 WorkspaceID: wsID,
                 ReplicaID:   repID,
@@ -143,39 +159,67 @@ func (s Store) appendEventJSONL(ctx context.Context, actorID, typ, entityID stri
                 Parents: parents,
 
                 IssuedAt: now,
+                HLC:      clock,
                 ActorID:  actorID,
                 Payload:  json.RawMessage(pb),
 
                 LocalStatus:  "local",
                 ServerStatus: "pending",
+        })
+
+        // Sign with the actor's local key, if it has one (signing.go).
+        key, err := s.LoadLocalKey(actorID)
+        if err != nil {
+                return EventV1{}, err
+        }
+        if key != nil {
+                for i := range evs {
+                        if err := signEventV1(&evs[i], *key); err != nil {
+                                return EventV1{}, err
+                        }
+                }
         }
 
         if err := os.MkdirAll(s.eventsDir(), 0o755); err != nil {
-                return err
+                return EventV1{}, err
         }
         path := s.shardPath(repID)
 
+        var buf bytes.Buffer
+        for _, ev := range evs {
+                line, err := json.Marshal(ev)
+                if err != nil {
+                        return EventV1{}, err
+                }
+                buf.Write(line)
+                buf.WriteByte('\n')
+        }
+
         f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
         if err != nil {
-                return err
+                return EventV1{}, err
         }
         defer f.Close()
-
-        line, err := json.Marshal(ev)
+        if _, err := f.Write(buf.Bytes()); err != nil {
+                return EventV1{}, err
+        }
+        // With O_APPEND the write lands at the end of the file, wherever that is by then.
+        end, err := f.Seek(0, io.SeekCurrent)
         if err != nil {
-                return err
+                return EventV1{}, err
         }
-        if _, err := f.Write(append(line, '\n')); err != nil {
-                return err
+        if err := f.Close(); err != nil {
+                return EventV1{}, err
         }
-        return nil
-}
-
-func (s Store) appendMergeMarkerJSONL(kind EntityKind, wsID, repID, actorID, entityID string, now time.Time, seq int64, heads []string) (string, error) {
-        if err := os.MkdirAll(s.eventsDir(), 0o755); err != nil {
-                return "", err
+        start := end - int64(buf.Len())
+        recordLocalAppend(path, start, end)
+        if err := cache.recordAppend(ctx, path, start, end, evs, st); err != nil {
+                return EventV1{}, err
         }
+        return evs[len(evs)-1], nil
+}
 
+func newMergeMarkerV1(kind EntityKind, wsID, repID, actorID, entityID string, now time.Time, clock HLC, seq int64, heads []string) (EventV1, error) {
         parents := make([]string, 0, len(heads))
         for _, h := range heads {
                 h = strings.TrimSpace(h)
@@ -191,14 +235,14 @@ func (s Store) appendMergeMarkerJSONL(kind EntityKind, wsID, repID, actorID, ent
         }
         pb, err := json.Marshal(payload)
         if err != nil {
-                return "", err
+                return EventV1{}, err
         }
         eventID, err := newUUIDv4()
         if err != nil {
-                return "", err
+                return EventV1{}, err
         }
 
-        ev := EventV1{
+        return EventV1{
                 EventID:     eventID,
                 WorkspaceID: strings.TrimSpace(wsID),
                 ReplicaID:   strings.TrimSpace(repID),
@@ -211,28 +255,13 @@ func (s Store) appendMergeMarkerJSONL(kind EntityKind, wsID, repID, actorID, ent
                 Parents: parents,
 
                 IssuedAt: now,
+                HLC:      clock,
                 ActorID:  strings.TrimSpace(actorID),
                 Payload:  json.RawMessage(pb),
 
                 LocalStatus:  "local",
                 ServerStatus: "pending",
-        }
-
-        path := s.shardPath(repID)
-        f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
-        if err != nil {
-                return "", err
-        }
-        defer f.Close()
-
-        line, err := json.Marshal(ev)
-        if err != nil {
-                return "", err
-        }
-        if _, err := f.Write(append(line, '\n')); err != nil {
-                return "", err
-        }
-        return eventID, nil
+        }, nil
 }
 
 func (s Store) readEventsJSONL(limit int) ([]model.Event, error) {
@@ -240,14 +269,9 @@ func (s Store) readEventsJSONL(limit int) ([]model.Event, error) {
         if err != nil {
                 return nil, err
         }
-        sort.Slice(evs, func(i, j int) bool {
-                a := evs[i].Event
-                b := evs[j].Event
-                if a.IssuedAt.Equal(b.IssuedAt) {
-                        return a.EventID < b.EventID
-                }
-                return a.IssuedAt.Before(b.IssuedAt)
-        })
+        // Same order as replay.
+        sortEventV1Lines(evs)
+        sigs, _ := verifySignatures(evs)
 
         out := make([]model.Event, 0, len(evs))
         for _, l := range evs {
@@ -261,6 +285,8 @@ func (s Store) readEventsJSONL(limit int) ([]model.Event, error) {
                         Type:     e.Type,
                         EntityID: e.EntityID,
                         Payload:  payload,
+
+                        Signature: string(sigs[e.EventID].Status),
                 })
                 if limit > 0 && len(out) >= limit {
                         break
@@ -281,28 +307,20 @@ func (s Store) readEventsForEntityJSONL(entityID string, limit int) ([]model.Eve
         if err != nil {
                 return nil, err
         }
-        // Keep v1 semantics: per-entity order is seq, then timestamp/id tie-break.
-        sort.Slice(evs, func(i, j int) bool {
-                a := evs[i].Event
-                b := evs[j].Event
-                if strings.TrimSpace(a.EntityID) != entityID && strings.TrimSpace(b.EntityID) == entityID {
-                        return false
-                }
-                if strings.TrimSpace(a.EntityID) == entityID && strings.TrimSpace(b.EntityID) != entityID {
-                        return true
-                }
-                if a.IssuedAt.Equal(b.IssuedAt) {
-                        return a.EventID < b.EventID
+        // Signatures are checked against the whole log (keys come from identity events).
+        sigs := VerifyEventsV1(evs)
+        // Same order as replay (parents first, then clock/id tie-break).
+        own := evs[:0]
+        for _, l := range evs {
+                if strings.TrimSpace(l.Event.EntityID) == entityID {
+                        own = append(own, l)
                 }
-                return a.IssuedAt.Before(b.IssuedAt)
-        })
+        }
+        sortEventV1Lines(own)
 
         var out []model.Event
-        for _, l := range evs {
+        for _, l := range own {
                 e := l.Event
-                if strings.TrimSpace(e.EntityID) != entityID {
-                        continue
-                }
                 var payload any
                 _ = json.Unmarshal(e.Payload, &payload)
                 out = append(out, model.Event{
@@ -312,6 +330,8 @@ func (s Store) readEventsForEntityJSONL(entityID string, limit int) ([]model.Eve
                         Type:     e.Type,
                         EntityID: e.EntityID,
                         Payload:  payload,
+
+                        Signature: string(sigs[e.EventID].Status),
                 })
                 if limit > 0 && len(out) >= limit {
                         break
@@ -364,76 +384,62 @@ func (s Store) walkEventsV1LinesJSONL(fn func(EventV1Line) error) error {
         sort.Strings(paths)
 
         for _, p := range paths {
-                f, err := os.Open(p)
-                if err != nil {
+                if _, err := scanShardLines(p, 0, 0, false, fn); err != nil {
                         if errors.Is(err, os.ErrNotExist) {
                                 continue
                         }
                         return err
                 }
-
-                sc := bufio.NewScanner(f)
-                sc.Buffer(make([]byte, 0, 64*1024), 10*1024*1024)
-
-                lineNo := 0
-                for sc.Scan() {
-                        lineNo++
-                        b := bytes.TrimSpace(sc.Bytes())
-                        if len(b) == 0 {
-                                continue
-                        }
-                        var ev EventV1
-                        if err := json.Unmarshal(b, &ev); err != nil {
-                                _ = f.Close()
-                                return fmt.Errorf("%s:%d: %w", p, lineNo, err)
-                        }
-                        if err := fn(EventV1Line{Path: p, Line: lineNo, Event: ev}); err != nil {
-                                _ = f.Close()
-                                return err
-                        }
-                }
-                _ = f.Close()
-                if err := sc.Err(); err != nil {
-                        return err
-                }
         }
         return nil
 }
 
-func (s Store) scanEntityIDsParentsMaxSeqV1(kind EntityKind, entityID string) (map[string]struct{}, map[string]struct{}, int64, error) {
-        kind = EntityKind(strings.TrimSpace(string(kind)))
-        entityID = strings.TrimSpace(entityID)
-        if !kind.valid() || entityID == "" {
-                return map[string]struct{}{}, map[string]struct{}{}, 0, nil
+// scanShardLines calls fn for each event line of a shard, starting at byte offset from (which must
+// be a line start; lineNo is the number of lines before it). With completeOnly, a trailing line
+// without a newline (a write in progress) is left unread. It returns the offset after the last
+// line read.
+func scanShardLines(path string, from int64, lineNo int, completeOnly bool, fn func(EventV1Line) error) (int64, error) {
+        f, err := os.Open(path)
+        if err != nil {
+                return from, err
+        }
+        defer f.Close()
+        if from > 0 {
+                if _, err := f.Seek(from, io.SeekStart); err != nil {
+                        return from, err
+                }
         }
 
-        ids := map[string]struct{}{}
-        parentIDs := map[string]struct{}{}
-        var maxSeq int64
-        err := s.walkEventsV1LinesJSONL(func(l EventV1Line) error {
-                ev := l.Event
-                if ev.EntityKind != kind || strings.TrimSpace(ev.EntityID) != entityID {
-                        return nil
+        r := bufio.NewReaderSize(f, 64*1024)
+        offset := from
+        for {
+                raw, err := r.ReadBytes('\n')
+                if err != nil && !errors.Is(err, io.EOF) {
+                        return offset, err
                 }
-                id := strings.TrimSpace(ev.EventID)
-                if id != "" {
-                        ids[id] = struct{}{}
+                if len(raw) == 0 {
+                        return offset, nil
                 }
-                if ev.EntitySeq > maxSeq {
-                        maxSeq = ev.EntitySeq
+                if errors.Is(err, io.EOF) && completeOnly {
+                        return offset, nil
                 }
-                for _, p := range ev.Parents {
-                        p = strings.TrimSpace(p)
-                        if p != "" {
-                                parentIDs[p] = struct{}{}
+                start := offset
+                offset += int64(len(raw))
+                lineNo++
+                b := bytes.TrimSpace(raw)
+                if len(b) > 0 {
+                        var ev EventV1
+                        if err := json.Unmarshal(b, &ev); err != nil {
+                                return start, fmt.Errorf("%s:%d: %w", path, lineNo, err)
+                        }
+                        if err := fn(EventV1Line{Path: path, Line: lineNo, Offset: start, End: offset, Event: ev}); err != nil {
+                                return start, err
                         }
                 }
-                return nil
-        })
-        if err != nil {
-                return nil, nil, 0, err
+                if errors.Is(err, io.EOF) {
+                        return offset, nil
+                }
         }
-        return ids, parentIDs, maxSeq, nil
 }
 
 func computeHeads(ids, parentIDs map[string]struct{}) []string {
@@ -4,6 +4,7 @@ import (
         "bufio"
         "encoding/json"
         "os"
+        "path/filepath"
         "strings"
         "testing"
         "time"
@@ -162,3 +163,74 @@ func TestAppendEventJSONL_AutoMergesForkHeads(t *testing.T) {
                 t.Fatalf("expected last parent=%q, got %v", merge.EventID, last.Parents)
         }
 }
+
+func TestAppendEventJSONL_HLCStaysAheadOfSeenEvents(t *testing.T) {
+        dir := t.TempDir()
+
+        res, err := EnsureGitBackedV1Layout(dir)
+        if err != nil {
+                t.Fatalf("EnsureGitBackedV1Layout: %v", err)
+        }
+
+        // A teammate whose clock runs an hour ahead edited the item (pulled via Git).
+        ahead := time.Now().Add(time.Hour).UTC()
+        remote := EventV1{
+                EventID:     "ev-remote",
+                WorkspaceID: "ws-1",
+                ReplicaID:   "rep-remote",
+                EntityKind:  EntityKindItem,
+                EntityID:    "item-1",
+                EntitySeq:   1,
+                Type:        "item.set_title",
+                IssuedAt:    ahead,
+                HLC:         HLC{WallMs: ahead.UnixMilli()},
+                ActorID:     "act-2",
+                Payload:     json.RawMessage(`{"title":"remote"}`),
+        }
+        b, _ := json.Marshal(remote)
+        if err := os.WriteFile(shardPathFor(res.ShardPath, "rep-remote"), append(b, '\n'), 0o644); err != nil {
+                t.Fatalf("write remote shard: %v", err)
+        }
+
+        s := Store{Dir: dir}
+        for _, title := range []string{"local 1", "local 2"} {
+                if err := s.AppendEvent("act-1", "item.set_title", "item-1", map[string]any{"title": title}); err != nil {
+                        t.Fatalf("AppendEvent: %v", err)
+                }
+        }
+
+        lines, err := ReadEventsV1Lines(dir)
+        if err != nil {
+                t.Fatalf("ReadEventsV1Lines: %v", err)
+        }
+        var local []EventV1
+        for _, l := range lines {
+                if l.Event.ReplicaID != "rep-remote" {
+                        local = append(local, l.Event)
+                }
+        }
+        if len(local) != 2 {
+                t.Fatalf("expected 2 local events, got %d", len(local))
+        }
+        if local[0].HLC.Compare(remote.HLC) <= 0 || local[1].HLC.Compare(local[0].HLC) <= 0 {
+                t.Fatalf("expected increasing clocks after remote %v, got %v then %v", remote.HLC, local[0].HLC, local[1].HLC)
+        }
+        if !local[0].IssuedAt.Before(remote.IssuedAt) {
+                t.Fatalf("expected local issuedAt to keep the local wall clock")
+        }
+
+        // Replay order: the local edits (made after seeing the remote one) come last.
+        var order []string
+        sorted := append([]EventV1Line(nil), lines...)
+        sortEventV1Lines(sorted)
+        for _, l := range sorted {
+                order = append(order, l.Event.EventID)
+        }
+        if order[0] != "ev-remote" {
+                t.Fatalf("expected remote edit first in replay order, got %v", order)
+        }
+}
+
+func shardPathFor(localShard, replicaID string) string {
+        return filepath.Join(filepath.Dir(localShard), "events."+replicaID+".jsonl")
+}
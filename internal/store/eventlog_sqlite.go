@@ -268,37 +268,38 @@ func newUUIDv4() (string, error) {
         ), nil
 }
 
-func (s Store) appendEventSQLite(ctx context.Context, actorID, typ, entityID string, payload any) error {
+// appendEventSQLite appends the event (after a merge marker if the entity has forked) and returns it.
+func (s Store) appendEventSQLite(ctx context.Context, actorID, typ, entityID string, payload any) (EventV1, error) {
         kind := inferEntityKindFromType(typ)
         if !kind.valid() {
-                return formatErrEventContract("invalid entity kind for type %q", typ)
+                return EventV1{}, formatErrEventContract("invalid entity kind for type %q", typ)
         }
         entityID = strings.TrimSpace(entityID)
         if entityID == "" {
-                return formatErrEventContract("missing entity id")
+                return EventV1{}, formatErrEventContract("missing entity id")
         }
         typ = strings.TrimSpace(typ)
         if typ == "" {
-                return formatErrEventContract("missing type")
+                return EventV1{}, formatErrEventContract("missing type")
         }
         actorID = strings.TrimSpace(actorID)
         if actorID == "" {
-                return formatErrEventContract("missing actor id")
+                return EventV1{}, formatErrEventContract("missing actor id")
         }
 
         db, err := s.openSQLite(ctx)
         if err != nil {
-                return err
+                return EventV1{}, err
         }
         defer db.Close()
 
         wsID, err := ensureMetaUUID(ctx, db, "workspace_id")
         if err != nil {
-                return err
+                return EventV1{}, err
         }
         repID, err := ensureMetaUUID(ctx, db, "replica_id")
         if err != nil {
-                return err
+                return EventV1{}, err
         }
 
         now := time.Now().UTC()
@@ -307,31 +308,31 @@ func (s Store) appendEventSQLite(ctx context.Context, actorID, typ, entityID str
         // Marshal payload to JSON for durability (command events).
         pb, err := json.Marshal(payload)
         if err != nil {
-                return err
+                return EventV1{}, err
         }
 
         eventID, err := newUUIDv4()
         if err != nil {
-                return err
+                return EventV1{}, err
         }
 
         tx, err := db.BeginTx(ctx, &sql.TxOptions{})
         if err != nil {
-                return err
+                return EventV1{}, err
         }
         defer func() { _ = tx.Rollback() }()
 
         // Read current heads (allowing for sync-era forks; locally we require <=1).
         rows, err := tx.QueryContext(ctx, `SELECT head_event_id FROM entity_heads WHERE entity_kind = ? AND entity_id = ?`, kind.String(), entityID)
         if err != nil {
-                return err
+                return EventV1{}, err
         }
         var heads []string
         for rows.Next() {
                 var h string
                 if err := rows.Scan(&h); err != nil {
                         _ = rows.Close()
-                        return err
+                        return EventV1{}, err
                 }
                 h = strings.TrimSpace(h)
                 if h != "" {
@@ -340,7 +341,7 @@ func (s Store) appendEventSQLite(ctx context.Context, actorID, typ, entityID str
         }
         _ = rows.Close()
         if err := rows.Err(); err != nil {
-                return err
+                return EventV1{}, err
         }
         sort.Strings(heads)
 
@@ -354,7 +355,7 @@ func (s Store) appendEventSQLite(ctx context.Context, actorID, typ, entityID str
         if len(heads) > 1 {
                 mergeID, err = newUUIDv4()
                 if err != nil {
-                        return err
+                        return EventV1{}, err
                 }
                 mergeParentsJSON, _ = json.Marshal(heads)
         }
@@ -379,10 +380,10 @@ func (s Store) appendEventSQLite(ctx context.Context, actorID, typ, entityID str
         case errors.Is(err, sql.ErrNoRows):
                 next = 1
                 if _, err := tx.ExecContext(ctx, `INSERT INTO entity_seq(entity_kind, entity_id, next_seq) VALUES(?, ?, ?)`, kind.String(), entityID, int64(2)); err != nil {
-                        return err
+                        return EventV1{}, err
                 }
         default:
-                return err
+                return EventV1{}, err
         }
         step := int64(1)
         if mergeID != "" {
@@ -392,7 +393,7 @@ func (s Store) appendEventSQLite(ctx context.Context, actorID, typ, entityID str
         seq := next
         if next > 1 || step > 1 {
                 if _, err := tx.ExecContext(ctx, `UPDATE entity_seq SET next_seq = ? WHERE entity_kind = ? AND entity_id = ?`, next+step, kind.String(), entityID); err != nil {
-                        return err
+                        return EventV1{}, err
                 }
         }
 
@@ -413,7 +414,7 @@ func (s Store) appendEventSQLite(ctx context.Context, actorID, typ, entityID str
                 }
                 mb, err := json.Marshal(mp)
                 if err != nil {
-                        return err
+                        return EventV1{}, err
                 }
                 mergeType := fmt.Sprintf("%s.merge", strings.TrimSpace(kind.String()))
                 if _, err := tx.ExecContext(ctx, `
@@ -426,7 +427,7 @@ func (s Store) appendEventSQLite(ctx context.Context, actorID, typ, entityID str
                                 rejection_reason, published_at_unixms, created_at_unixms
                         ) VALUES(?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, NULL, NULL, ?)
                 `, mergeID, wsID, repID, kind.String(), entityID, mergeSeq, mergeType, string(mergeParentsJSON), nowMs, actorID, string(mb), "local", "pending", nowMs); err != nil {
-                        return err
+                        return EventV1{}, err
                 }
         }
 
@@ -441,18 +442,36 @@ func (s Store) appendEventSQLite(ctx context.Context, actorID, typ, entityID str
                         rejection_reason, published_at_unixms, created_at_unixms
                 ) VALUES(?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, NULL, NULL, ?)
         `, eventID, wsID, repID, kind.String(), entityID, eventSeq, typ, string(parentsJSON), nowMs, actorID, string(pb), "local", "pending", nowMs); err != nil {
-                return err
+                return EventV1{}, err
         }
 
         // Advance head: enforce linear local history by replacing any existing heads with the new one.
         if _, err := tx.ExecContext(ctx, `DELETE FROM entity_heads WHERE entity_kind = ? AND entity_id = ?`, kind.String(), entityID); err != nil {
-                return err
+                return EventV1{}, err
         }
         if _, err := tx.ExecContext(ctx, `INSERT INTO entity_heads(entity_kind, entity_id, head_event_id) VALUES(?, ?, ?)`, kind.String(), entityID, eventID); err != nil {
-                return err
-        }
-
-        return tx.Commit()
+                return EventV1{}, err
+        }
+
+        if err := tx.Commit(); err != nil {
+                return EventV1{}, err
+        }
+        return EventV1{
+                EventID:     eventID,
+                WorkspaceID: wsID,
+                ReplicaID:   repID,
+                EntityKind:  kind,
+                EntityID:    entityID,
+                EntitySeq:   eventSeq,
+                Type:        typ,
+                Parents:     parents,
+                IssuedAt:    time.UnixMilli(nowMs).UTC(),
+                ActorID:     actorID,
+                Payload:     json.RawMessage(pb),
+
+                LocalStatus:  "local",
+                ServerStatus: "pending",
+        }, nil
 }
 
 func (s Store) readEventsSQLite(ctx context.Context, limit int) ([]model.Event, error) {
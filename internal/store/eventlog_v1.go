@@ -37,7 +37,8 @@ const (
         EntityKindWorklog EntityKind = "worklog"
 
         // Legacy/other (not yet in the v1 “entity boundary” decision, but present today).
-        EntityKindDep EntityKind = "dep"
+        EntityKindDep    EntityKind = "dep"
+        EntityKindFollow EntityKind = "follow"
 )
 
 // EventV1 is the durable, per-entity ordered event envelope stored in SQLite.
@@ -56,9 +57,14 @@ type EventV1 struct {
         Type    string   `json:"type"`
         Parents []string `json:"parents,omitempty"`
 
-        IssuedAt time.Time       `json:"issuedAt"`
-        ActorID  string          `json:"actorId"`
+        IssuedAt time.Time `json:"issuedAt"`
+        // HLC orders events causally across replicas (see hlc.go); absent on events written before it.
+        HLC     HLC             `json:"hlc,omitzero"`
+        ActorID string          `json:"actorId"`
         Payload  json.RawMessage `json:"payload"`
+        // KeyID and Sig sign the event (see signing.go); absent when the actor had no local key.
+        KeyID string `json:"keyId,omitempty"`
+        Sig   string `json:"sig,omitempty"`
 
         LocalStatus       string  `json:"localStatus"`                 // e.g. "local"
         ServerStatus      string  `json:"serverStatus"`                // "pending"|"accepted"|"rejected"
@@ -167,6 +173,8 @@ func inferEntityKindFromType(typ string) EntityKind {
                 return EntityKindActor
         case "dep":
                 return EntityKindDep
+        case "follow":
+                return EntityKindFollow
         default:
                 return EntityKind(prefix)
         }
@@ -0,0 +1,38 @@
+package store
+
+import (
+	"strings"
+
+	"clarity-cli/internal/model"
+)
+
+// FindFollow returns actorID's follow of the given target, if any.
+func (db *DB) FindFollow(actorID, targetKind, targetID string) *model.Follow {
+	if db == nil {
+		return nil
+	}
+	actorID = strings.TrimSpace(actorID)
+	targetID = strings.TrimSpace(targetID)
+	for i := range db.Follows {
+		f := &db.Follows[i]
+		if f.ActorID == actorID && f.TargetKind == targetKind && f.TargetID == targetID {
+			return f
+		}
+	}
+	return nil
+}
+
+// FollowsForActor returns the follows recorded by actorID.
+func (db *DB) FollowsForActor(actorID string) []model.Follow {
+	if db == nil {
+		return nil
+	}
+	actorID = strings.TrimSpace(actorID)
+	out := make([]model.Follow, 0)
+	for _, f := range db.Follows {
+		if f.ActorID == actorID {
+			out = append(out, f)
+		}
+	}
+	return out
+}
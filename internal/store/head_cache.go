@@ -0,0 +1,297 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Per-entity head index.
+//
+// Appending an event needs the entity's current heads (parents of the new event), its max seq and
+// the latest clock across all shards. Computing those means reading every shard, so they are cached
+// in .clarity/heads.sqlite (local, gitignored) together with the size/mtime of every shard they
+// were computed from. Any shard that appeared, disappeared or changed size/mtime other than through
+// our own appends (a pull, a rebase, another process) invalidates the cache; the next append
+// rebuilds it with one full scan. An append only updates the cache when it landed right where the
+// shard ended at lookup: another process appending in between (with heads it looked up too) could
+// have forked the entity.
+
+const headCacheFile = "heads.sqlite"
+
+// entityAppendState is what appendEventJSONL needs to know about an entity stream.
+type entityAppendState struct {
+	Heads     []string
+	MaxSeq    int64
+	Events    int
+	LastClock HLC
+
+	// shardSizes are the shard sizes (by file name) at lookup.
+	shardSizes map[string]int64
+}
+
+type headCache struct {
+	s  Store
+	db *sql.DB
+}
+
+func (s Store) openHeadCache(ctx context.Context) (*headCache, error) {
+	if err := os.MkdirAll(s.localDir(), 0o755); err != nil {
+		return nil, err
+	}
+	db, err := sql.Open("sqlite", filepath.Join(s.localDir(), headCacheFile))
+	if err != nil {
+		return nil, err
+	}
+	stmts := []string{
+		"PRAGMA journal_mode=WAL;",
+		"PRAGMA synchronous=NORMAL;",
+		"PRAGMA busy_timeout=5000;",
+		`CREATE TABLE IF NOT EXISTS head_shards (
+			shard TEXT PRIMARY KEY,
+			size INTEGER NOT NULL,
+			mtime_unixnano INTEGER NOT NULL
+		);`,
+		`CREATE TABLE IF NOT EXISTS entity_heads (
+			entity_kind TEXT NOT NULL,
+			entity_id TEXT NOT NULL,
+			heads_json TEXT NOT NULL,
+			max_seq INTEGER NOT NULL,
+			events INTEGER NOT NULL,
+			PRIMARY KEY (entity_kind, entity_id)
+		);`,
+		`CREATE TABLE IF NOT EXISTS head_meta (
+			k TEXT PRIMARY KEY,
+			v TEXT NOT NULL
+		);`,
+	}
+	for _, st := range stmts {
+		if _, err := db.ExecContext(ctx, st); err != nil {
+			_ = db.Close()
+			return nil, err
+		}
+	}
+	return &headCache{s: s, db: db}, nil
+}
+
+func (c *headCache) Close() error { return c.db.Close() }
+
+type shardStat struct {
+	Size  int64
+	MTime int64
+}
+
+func (c *headCache) currentShardStats() (map[string]shardStat, error) {
+	paths, err := c.s.shardPaths()
+	if err != nil {
+		return nil, err
+	}
+	out := make(map[string]shardStat, len(paths))
+	for _, p := range paths {
+		st, err := os.Stat(p)
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				continue
+			}
+			return nil, err
+		}
+		out[filepath.Base(p)] = shardStat{Size: st.Size(), MTime: st.ModTime().UnixNano()}
+	}
+	return out, nil
+}
+
+// valid reports whether the cached shard stats match the shards on disk.
+func (c *headCache) valid(ctx context.Context, cur map[string]shardStat) (bool, error) {
+	rows, err := c.db.QueryContext(ctx, `SELECT shard, size, mtime_unixnano FROM head_shards`)
+	if err != nil {
+		return false, err
+	}
+	defer rows.Close()
+	n := 0
+	for rows.Next() {
+		var name string
+		var st shardStat
+		if err := rows.Scan(&name, &st.Size, &st.MTime); err != nil {
+			return false, err
+		}
+		if cur[name] != st {
+			return false, nil
+		}
+		n++
+	}
+	if err := rows.Err(); err != nil {
+		return false, err
+	}
+	var built int
+	if err := c.db.QueryRowContext(ctx, `SELECT COUNT(1) FROM head_meta WHERE k = 'built'`).Scan(&built); err != nil {
+		return false, err
+	}
+	return built > 0 && n == len(cur), nil
+}
+
+// rebuild recomputes every entity's heads/seq and the latest clock with one scan of all shards.
+func (c *headCache) rebuild(ctx context.Context) error {
+	// Stat before scanning: lines appended during the scan make the next check fail (and rebuild)
+	// rather than going missing.
+	stats, err := c.currentShardStats()
+	if err != nil {
+		return err
+	}
+
+	type agg struct {
+		ids       map[string]struct{}
+		parentIDs map[string]struct{}
+		maxSeq    int64
+		events    int
+	}
+	type key struct{ kind, id string }
+	aggs := map[key]*agg{}
+	var maxClock HLC
+	err = c.s.walkEventsV1LinesJSONL(func(l EventV1Line) error {
+		ev := l.Event
+		if cl := ev.Clock(); cl.Compare(maxClock) > 0 {
+			maxClock = cl
+		}
+		k := key{kind: strings.TrimSpace(string(ev.EntityKind)), id: strings.TrimSpace(ev.EntityID)}
+		if k.kind == "" || k.id == "" {
+			return nil
+		}
+		a := aggs[k]
+		if a == nil {
+			a = &agg{ids: map[string]struct{}{}, parentIDs: map[string]struct{}{}}
+			aggs[k] = a
+		}
+		if id := strings.TrimSpace(ev.EventID); id != "" {
+			a.ids[id] = struct{}{}
+		}
+		a.events++
+		if ev.EntitySeq > a.maxSeq {
+			a.maxSeq = ev.EntitySeq
+		}
+		for _, p := range ev.Parents {
+			if p = strings.TrimSpace(p); p != "" {
+				a.parentIDs[p] = struct{}{}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	tx, err := c.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+	for _, st := range []string{`DELETE FROM entity_heads`, `DELETE FROM head_shards`, `DELETE FROM head_meta`} {
+		if _, err := tx.ExecContext(ctx, st); err != nil {
+			return err
+		}
+	}
+	for k, a := range aggs {
+		hb, err := json.Marshal(computeHeads(a.ids, a.parentIDs))
+		if err != nil {
+			return err
+		}
+		if _, err := tx.ExecContext(ctx, `INSERT INTO entity_heads(entity_kind, entity_id, heads_json, max_seq, events) VALUES(?, ?, ?, ?, ?)`,
+			k.kind, k.id, string(hb), a.maxSeq, a.events); err != nil {
+			return err
+		}
+	}
+	for name, st := range stats {
+		if _, err := tx.ExecContext(ctx, `INSERT INTO head_shards(shard, size, mtime_unixnano) VALUES(?, ?, ?)`, name, st.Size, st.MTime); err != nil {
+			return err
+		}
+	}
+	if _, err := tx.ExecContext(ctx, `INSERT INTO head_meta(k, v) VALUES('built', 'v1'), ('clock', ?)`, maxClock.String()); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// lookup returns the entity's append state, rebuilding the cache first if it is stale.
+func (c *headCache) lookup(ctx context.Context, kind EntityKind, entityID string) (entityAppendState, error) {
+	cur, err := c.currentShardStats()
+	if err != nil {
+		return entityAppendState{}, err
+	}
+	ok, err := c.valid(ctx, cur)
+	if err != nil {
+		return entityAppendState{}, err
+	}
+	if !ok {
+		if err := c.rebuild(ctx); err != nil {
+			return entityAppendState{}, err
+		}
+	}
+
+	out := entityAppendState{shardSizes: make(map[string]int64, len(cur))}
+	for name, st := range cur {
+		out.shardSizes[name] = st.Size
+	}
+	var clock string
+	if err := c.db.QueryRowContext(ctx, `SELECT v FROM head_meta WHERE k = 'clock'`).Scan(&clock); err != nil {
+		return entityAppendState{}, err
+	}
+	if out.LastClock, err = ParseHLC(clock); err != nil {
+		return entityAppendState{}, err
+	}
+	var heads string
+	err = c.db.QueryRowContext(ctx, `SELECT heads_json, max_seq, events FROM entity_heads WHERE entity_kind = ? AND entity_id = ?`,
+		string(kind), entityID).Scan(&heads, &out.MaxSeq, &out.Events)
+	if errors.Is(err, sql.ErrNoRows) {
+		return out, nil
+	}
+	if err != nil {
+		return entityAppendState{}, err
+	}
+	if err := json.Unmarshal([]byte(heads), &out.Heads); err != nil {
+		return entityAppendState{}, err
+	}
+	return out, nil
+}
+
+// recordAppend applies our own append of evs (the bytes [start, end) of shard) to the cache, st
+// being the state looked up for it. If the shard grew by anything else since the lookup, the cache
+// is invalidated instead.
+func (c *headCache) recordAppend(ctx context.Context, shard string, start, end int64, evs []EventV1, st entityAppendState) error {
+	if len(evs) == 0 {
+		return nil
+	}
+	fi, err := os.Stat(shard)
+	if err != nil {
+		return err
+	}
+	if start != st.shardSizes[filepath.Base(shard)] || fi.Size() != end {
+		_, err := c.db.ExecContext(ctx, `DELETE FROM head_meta WHERE k = 'built'`)
+		return err
+	}
+
+	last := evs[len(evs)-1]
+	hb, err := json.Marshal([]string{strings.TrimSpace(last.EventID)})
+	if err != nil {
+		return err
+	}
+	tx, err := c.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+	if _, err := tx.ExecContext(ctx, `INSERT OR REPLACE INTO entity_heads(entity_kind, entity_id, heads_json, max_seq, events) VALUES(?, ?, ?, ?, ?)`,
+		string(last.EntityKind), strings.TrimSpace(last.EntityID), string(hb), last.EntitySeq, st.Events+len(evs)); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, `INSERT OR REPLACE INTO head_shards(shard, size, mtime_unixnano) VALUES(?, ?, ?)`,
+		filepath.Base(shard), fi.Size(), fi.ModTime().UnixNano()); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, `INSERT OR REPLACE INTO head_meta(k, v) VALUES('clock', ?)`, last.HLC.String()); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
@@ -0,0 +1,194 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func readShardEvents(t testing.TB, path string) []EventV1 {
+	t.Helper()
+	b, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read shard: %v", err)
+	}
+	var out []EventV1
+	for _, line := range strings.Split(strings.TrimSpace(string(b)), "\n") {
+		var ev EventV1
+		if err := json.Unmarshal([]byte(line), &ev); err != nil {
+			t.Fatalf("parse event: %v", err)
+		}
+		out = append(out, ev)
+	}
+	return out
+}
+
+func TestHeadCache_FollowsPulledShards(t *testing.T) {
+	dir := t.TempDir()
+	res, err := EnsureGitBackedV1Layout(dir)
+	if err != nil {
+		t.Fatalf("EnsureGitBackedV1Layout: %v", err)
+	}
+	s := Store{Dir: dir}
+	for _, title := range []string{"a", "b"} {
+		if err := s.AppendEvent("act-1", "item.set_title", "item-1", map[string]any{"title": title}); err != nil {
+			t.Fatalf("AppendEvent: %v", err)
+		}
+	}
+	local := readShardEvents(t, res.ShardPath)
+	if len(local) != 2 || local[1].EntitySeq != 2 || len(local[1].Parents) != 1 || local[1].Parents[0] != local[0].EventID {
+		t.Fatalf("unexpected local events: %+v", local)
+	}
+
+	// A teammate's edit on top of ours arrives via git pull: the next append must build on it.
+	remote := EventV1{
+		EventID: "ev-remote", WorkspaceID: "ws-1", ReplicaID: "rep-remote",
+		EntityKind: EntityKindItem, EntityID: "item-1", EntitySeq: 3, Type: "item.set_title",
+		Parents:  []string{local[1].EventID},
+		IssuedAt: time.Now().UTC(), HLC: local[1].HLC.Next(time.Now()),
+		ActorID: "act-2", Payload: json.RawMessage(`{"title":"remote"}`),
+	}
+	b, _ := json.Marshal(remote)
+	if err := os.WriteFile(filepath.Join(dir, "events", "events.rep-remote.jsonl"), append(b, '\n'), 0o644); err != nil {
+		t.Fatalf("write remote shard: %v", err)
+	}
+	if err := s.AppendEvent("act-1", "item.set_title", "item-1", map[string]any{"title": "c"}); err != nil {
+		t.Fatalf("AppendEvent: %v", err)
+	}
+	local = readShardEvents(t, res.ShardPath)
+	last := local[len(local)-1]
+	if last.EntitySeq != 4 || len(last.Parents) != 1 || last.Parents[0] != "ev-remote" || last.HLC.Compare(remote.HLC) <= 0 {
+		t.Fatalf("expected append on top of the pulled event, got %+v", last)
+	}
+
+	// A concurrent edit (same parent as ours) forks the stream: the next append merges.
+	fork := remote
+	fork.EventID = "ev-fork"
+	fork.Parents = []string{local[1].EventID}
+	b, _ = json.Marshal(fork)
+	f, err := os.OpenFile(filepath.Join(dir, "events", "events.rep-remote.jsonl"), os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		t.Fatalf("open remote shard: %v", err)
+	}
+	_, _ = f.Write(append(b, '\n'))
+	_ = f.Close()
+	if err := s.AppendEvent("act-1", "item.set_title", "item-1", map[string]any{"title": "d"}); err != nil {
+		t.Fatalf("AppendEvent: %v", err)
+	}
+	local = readShardEvents(t, res.ShardPath)
+	merge := local[len(local)-2]
+	if merge.Type != "item.merge" || len(merge.Parents) != 2 || !hasParent(merge, last.EventID) || !hasParent(merge, "ev-fork") {
+		t.Fatalf("expected merge of %s and ev-fork, got %+v", last.EventID, merge)
+	}
+
+	// Deleting the cache only costs a rebuild.
+	if err := os.Remove(filepath.Join(dir, ".clarity", headCacheFile)); err != nil {
+		t.Fatalf("remove cache: %v", err)
+	}
+	if err := s.AppendEvent("act-1", "item.set_title", "item-1", map[string]any{"title": "e"}); err != nil {
+		t.Fatalf("AppendEvent: %v", err)
+	}
+	local = readShardEvents(t, res.ShardPath)
+	if got := local[len(local)-1]; len(got.Parents) != 1 || got.Parents[0] != local[len(local)-2].EventID {
+		t.Fatalf("expected append after the previous head, got %+v", got)
+	}
+}
+
+func TestHeadCache_AppendRacingAnotherProcessKeepsTheFork(t *testing.T) {
+	dir := t.TempDir()
+	res, err := EnsureGitBackedV1Layout(dir)
+	if err != nil {
+		t.Fatalf("EnsureGitBackedV1Layout: %v", err)
+	}
+	s := Store{Dir: dir}
+	if err := s.AppendEvent("act-1", "item.set_title", "item-1", map[string]any{"title": "a"}); err != nil {
+		t.Fatalf("AppendEvent: %v", err)
+	}
+
+	// We look up the heads, then another process appends on top of the same head before we do.
+	ctx := context.Background()
+	cache, err := s.openHeadCache(ctx)
+	if err != nil {
+		t.Fatalf("openHeadCache: %v", err)
+	}
+	defer cache.Close()
+	st, err := cache.lookup(ctx, EntityKindItem, "item-1")
+	if err != nil {
+		t.Fatalf("lookup: %v", err)
+	}
+	if err := s.AppendEvent("act-1", "item.set_title", "item-1", map[string]any{"title": "other"}); err != nil {
+		t.Fatalf("AppendEvent: %v", err)
+	}
+	other := readShardEvents(t, res.ShardPath)[1]
+
+	ours := other
+	ours.EventID = "ev-ours"
+	ours.Payload = json.RawMessage(`{"title":"ours"}`)
+	b, _ := json.Marshal(ours)
+	fi, err := os.Stat(res.ShardPath)
+	if err != nil {
+		t.Fatalf("stat shard: %v", err)
+	}
+	appendShard(t, dir, strings.TrimSuffix(strings.TrimPrefix(filepath.Base(res.ShardPath), "events."), ".jsonl"), string(b)+"\n")
+	if err := cache.recordAppend(ctx, res.ShardPath, fi.Size(), fi.Size()+int64(len(b)+1), []EventV1{ours}, st); err != nil {
+		t.Fatalf("recordAppend: %v", err)
+	}
+
+	if err := s.AppendEvent("act-1", "item.set_title", "item-1", map[string]any{"title": "next"}); err != nil {
+		t.Fatalf("AppendEvent: %v", err)
+	}
+	local := readShardEvents(t, res.ShardPath)
+	merge := local[len(local)-2]
+	if merge.Type != "item.merge" || !hasParent(merge, other.EventID) || !hasParent(merge, "ev-ours") {
+		t.Fatalf("expected the next append to merge both heads, got %+v", merge)
+	}
+}
+
+// BenchmarkAppendEventJSONL appends to workspaces with growing logs: with the head cache warm, the
+// per-append cost should stay flat regardless of log size.
+func BenchmarkAppendEventJSONL(b *testing.B) {
+	for _, n := range []int{1_000, 10_000, 50_000} {
+		b.Run(fmt.Sprintf("log=%d", n), func(b *testing.B) {
+			dir := b.TempDir()
+			if _, err := EnsureGitBackedV1Layout(dir); err != nil {
+				b.Fatalf("EnsureGitBackedV1Layout: %v", err)
+			}
+			f, err := os.OpenFile(filepath.Join(dir, "events", "events.rep-old.jsonl"), os.O_CREATE|os.O_WRONLY, 0o644)
+			if err != nil {
+				b.Fatalf("open shard: %v", err)
+			}
+			start := time.Now().Add(-time.Hour).UTC()
+			for i := 0; i < n; i++ {
+				ev := EventV1{
+					EventID: fmt.Sprintf("ev-%d", i), WorkspaceID: "ws-1", ReplicaID: "rep-old",
+					EntityKind: EntityKindItem, EntityID: fmt.Sprintf("item-%d", i%500), EntitySeq: int64(i/500 + 1),
+					Type: "item.set_title", IssuedAt: start.Add(time.Duration(i) * time.Millisecond),
+					ActorID: "act-1", Payload: json.RawMessage(`{"title":"x"}`),
+				}
+				if i >= 500 {
+					ev.Parents = []string{fmt.Sprintf("ev-%d", i-500)}
+				}
+				line, _ := json.Marshal(ev)
+				_, _ = f.Write(append(line, '\n'))
+			}
+			_ = f.Close()
+
+			s := Store{Dir: dir}
+			// Warm the cache (one full scan).
+			if err := s.AppendEvent("act-1", "item.set_title", "item-0", map[string]any{"title": "warm"}); err != nil {
+				b.Fatalf("AppendEvent: %v", err)
+			}
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if err := s.AppendEvent("act-1", "item.set_title", fmt.Sprintf("item-%d", i%500), map[string]any{"title": "y"}); err != nil {
+					b.Fatalf("AppendEvent: %v", err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,85 @@
+package store
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// HLC is a hybrid logical clock timestamp: wall-clock milliseconds plus a logical counter.
+//
+// Every appended event gets a clock greater than every event its replica has seen (see Next), so
+// an edit made after pulling a teammate's change sorts after it even when the teammate's laptop
+// clock runs ahead. Encoded as "<wall-ms>:<logical>".
+type HLC struct {
+	WallMs  int64
+	Logical int64
+}
+
+func (h HLC) IsZero() bool { return h.WallMs == 0 && h.Logical == 0 }
+
+func (h HLC) Compare(o HLC) int {
+	switch {
+	case h.WallMs < o.WallMs:
+		return -1
+	case h.WallMs > o.WallMs:
+		return 1
+	case h.Logical < o.Logical:
+		return -1
+	case h.Logical > o.Logical:
+		return 1
+	}
+	return 0
+}
+
+// Next returns the clock for a local event issued at now, after h (the latest clock seen).
+func (h HLC) Next(now time.Time) HLC {
+	if pt := now.UnixMilli(); pt > h.WallMs {
+		return HLC{WallMs: pt}
+	}
+	return HLC{WallMs: h.WallMs, Logical: h.Logical + 1}
+}
+
+// Time returns the wall-clock component.
+func (h HLC) Time() time.Time { return time.UnixMilli(h.WallMs).UTC() }
+
+func (h HLC) String() string { return fmt.Sprintf("%d:%d", h.WallMs, h.Logical) }
+
+func (h HLC) MarshalText() ([]byte, error) { return []byte(h.String()), nil }
+
+func (h *HLC) UnmarshalText(b []byte) error {
+	v, err := ParseHLC(string(b))
+	if err != nil {
+		return err
+	}
+	*h = v
+	return nil
+}
+
+func ParseHLC(s string) (HLC, error) {
+	wall, logical, ok := strings.Cut(strings.TrimSpace(s), ":")
+	if !ok {
+		return HLC{}, fmt.Errorf("invalid hlc %q (expected <wall-ms>:<logical>)", s)
+	}
+	w, err := strconv.ParseInt(wall, 10, 64)
+	if err != nil || w < 0 {
+		return HLC{}, fmt.Errorf("invalid hlc %q (bad wall time)", s)
+	}
+	l, err := strconv.ParseInt(logical, 10, 64)
+	if err != nil || l < 0 {
+		return HLC{}, fmt.Errorf("invalid hlc %q (bad logical counter)", s)
+	}
+	return HLC{WallMs: w, Logical: l}, nil
+}
+
+// Clock returns the event's HLC; events written before HLCs existed fall back to issuedAt.
+func (ev EventV1) Clock() HLC {
+	if !ev.HLC.IsZero() {
+		return ev.HLC
+	}
+	if ev.IssuedAt.IsZero() {
+		return HLC{}
+	}
+	return HLC{WallMs: ev.IssuedAt.UnixMilli()}
+}
@@ -0,0 +1,655 @@
+package store
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"clarity-cli/internal/model"
+)
+
+// Hooks run a local command or POST to a URL after an event is appended on this machine.
+//
+// They are configured in meta/hooks.json (committed, like saved views) but only fire once the
+// file's current contents are trusted locally (`clarity hooks trust` records its hash under
+// .clarity/): a pull that adds or changes a hook must not run commands on anyone's machine
+// unreviewed. Events that arrive through a pull never fire hooks.
+//
+// Delivery is post-commit and in the background, in append order: a failing hook is retried, then
+// recorded in .clarity/hooks-dead-letter.jsonl; the append itself always succeeds and never waits.
+// Before exiting, a process gives outstanding deliveries up to hookDrainTimeout (WaitForHooks);
+// whatever is still undelivered then is dead-lettered too.
+
+const (
+	hooksTrustFileName      = "hooks-trust.json"
+	hookDeadLetterFileName  = "hooks-dead-letter.jsonl"
+	defaultHookRetries      = 2
+	defaultHookTimeoutSecs  = 10
+	noHooksEnv              = "CLARITY_NO_HOOKS"
+	hookFailureOutputMaxLen = 500
+)
+
+// hookRetryDelay is the wait before the first retry; it doubles for each further one.
+var hookRetryDelay = time.Second
+
+// hookDrainTimeout bounds how long WaitForHooks waits for outstanding deliveries, however many.
+var hookDrainTimeout = 15 * time.Second
+
+var errHookNotDelivered = errors.New("not delivered before the process exited")
+
+// Hook is one entry of meta/hooks.json.
+type Hook struct {
+	Name string `json:"name"`
+
+	// On lists event types: exact ("item.set_status"), a kind prefix ("item.*") or "*".
+	On []string `json:"on"`
+
+	// Outline restricts the hook to item events (and outline events) in this outline.
+	Outline string `json:"outline,omitempty"`
+
+	// EndState restricts the hook to status changes into an end state (e.g. "done").
+	EndState bool `json:"endState,omitempty"`
+
+	// Exactly one of Run (a shell command, run from the workspace root with the event JSON on
+	// stdin) and URL (POSTed the event JSON) is set.
+	Run     string            `json:"run,omitempty"`
+	URL     string            `json:"url,omitempty"`
+	Headers map[string]string `json:"headers,omitempty"`
+
+	Retries        *int `json:"retries,omitempty"`
+	TimeoutSeconds int  `json:"timeoutSeconds,omitempty"`
+}
+
+// HooksFile is meta/hooks.json.
+type HooksFile struct {
+	Version int    `json:"version"`
+	Hooks   []Hook `json:"hooks"`
+}
+
+// HookFailure is a dead-lettered delivery.
+type HookFailure struct {
+	Hook      string          `json:"hook"`
+	EventID   string          `json:"eventId"`
+	EventType string          `json:"eventType"`
+	Attempts  int             `json:"attempts"`
+	Error     string          `json:"error"`
+	FailedAt  time.Time       `json:"failedAt"`
+	Event     json.RawMessage `json:"event"`
+}
+
+type hooksTrust struct {
+	Version   int       `json:"version"`
+	SHA256    string    `json:"sha256"`
+	TrustedAt time.Time `json:"trustedAt"`
+}
+
+func (s Store) hooksPath() string {
+	return filepath.Join(s.workspaceRoot(), "meta", "hooks.json")
+}
+
+func (s Store) hooksTrustPath() string {
+	return filepath.Join(s.localDir(), hooksTrustFileName)
+}
+
+func (s Store) hookDeadLetterPath() string {
+	return filepath.Join(s.localDir(), hookDeadLetterFileName)
+}
+
+// LoadHooks reads and validates meta/hooks.json. A missing file yields no hooks.
+func (s Store) LoadHooks() (HooksFile, error) {
+	f := HooksFile{Version: 1, Hooks: []Hook{}}
+	b, err := os.ReadFile(s.hooksPath())
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return f, nil
+		}
+		return HooksFile{}, err
+	}
+	if err := json.Unmarshal(b, &f); err != nil {
+		return HooksFile{}, fmt.Errorf("meta/hooks.json: %w", err)
+	}
+	if f.Version == 0 {
+		f.Version = 1
+	}
+	if f.Version != 1 {
+		return HooksFile{}, errors.New("meta/hooks.json: unsupported version")
+	}
+	if f.Hooks == nil {
+		f.Hooks = []Hook{}
+	}
+	seen := map[string]bool{}
+	for i := range f.Hooks {
+		h := &f.Hooks[i]
+		h.Name = strings.TrimSpace(h.Name)
+		h.Outline = strings.TrimSpace(h.Outline)
+		h.Run = strings.TrimSpace(h.Run)
+		h.URL = strings.TrimSpace(h.URL)
+		if !viewNameRe.MatchString(h.Name) {
+			return HooksFile{}, fmt.Errorf("meta/hooks.json: invalid hook name %q (use letters, digits, '.', '_' or '-')", h.Name)
+		}
+		if seen[h.Name] {
+			return HooksFile{}, fmt.Errorf("meta/hooks.json: duplicate hook name %q", h.Name)
+		}
+		seen[h.Name] = true
+		if len(h.On) == 0 {
+			return HooksFile{}, fmt.Errorf("meta/hooks.json: hook %q: missing \"on\"", h.Name)
+		}
+		if (h.Run == "") == (h.URL == "") {
+			return HooksFile{}, fmt.Errorf("meta/hooks.json: hook %q: set exactly one of \"run\" and \"url\"", h.Name)
+		}
+		if h.URL != "" {
+			u, err := url.Parse(h.URL)
+			if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+				return HooksFile{}, fmt.Errorf("meta/hooks.json: hook %q: url must be http(s)", h.Name)
+			}
+		}
+		if h.Retries != nil && *h.Retries < 0 {
+			return HooksFile{}, fmt.Errorf("meta/hooks.json: hook %q: retries must be >= 0", h.Name)
+		}
+		if h.TimeoutSeconds < 0 {
+			return HooksFile{}, fmt.Errorf("meta/hooks.json: hook %q: timeoutSeconds must be >= 0", h.Name)
+		}
+	}
+	return f, nil
+}
+
+// hooksDigest returns the sha256 of meta/hooks.json ("" if there is none).
+func (s Store) hooksDigest() (string, error) {
+	b, err := os.ReadFile(s.hooksPath())
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return "", nil
+		}
+		return "", err
+	}
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// HooksTrusted reports whether meta/hooks.json is unchanged since `clarity hooks trust`.
+func (s Store) HooksTrusted() (bool, error) {
+	digest, err := s.hooksDigest()
+	if err != nil || digest == "" {
+		return false, err
+	}
+	b, err := os.ReadFile(s.hooksTrustPath())
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return false, nil
+		}
+		return false, err
+	}
+	var t hooksTrust
+	if err := json.Unmarshal(b, &t); err != nil {
+		return false, err
+	}
+	return t.SHA256 == digest, nil
+}
+
+// TrustHooks records the current meta/hooks.json as trusted on this machine and returns its hash.
+func (s Store) TrustHooks() (string, error) {
+	if _, err := s.LoadHooks(); err != nil {
+		return "", err
+	}
+	digest, err := s.hooksDigest()
+	if err != nil {
+		return "", err
+	}
+	if digest == "" {
+		return "", errors.New("no meta/hooks.json to trust")
+	}
+	if err := os.MkdirAll(s.localDir(), 0o755); err != nil {
+		return "", err
+	}
+	b, err := json.MarshalIndent(hooksTrust{Version: 1, SHA256: digest, TrustedAt: time.Now().UTC()}, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return digest, atomicWriteFile(s.localDir(), "hooks-trust.*.tmp", s.hooksTrustPath(), b, 0o644)
+}
+
+// runHooks queues ev for every matching hook. Nothing is returned: failures are dead-lettered.
+func (s Store) runHooks(ev EventV1) {
+	if s.NoHooks || strings.TrimSpace(os.Getenv(noHooksEnv)) != "" || strings.TrimSpace(s.Dir) == "" {
+		return
+	}
+	f, err := s.LoadHooks()
+	if err != nil || len(f.Hooks) == 0 {
+		return
+	}
+	if ok, err := s.HooksTrusted(); err != nil || !ok {
+		return
+	}
+	hc := hookContext{s: s}
+	defer hc.close()
+	for _, h := range f.Hooks {
+		if hc.matches(h, ev) {
+			hookQueue.push(hookJob{s: s, h: h, ev: ev})
+		}
+	}
+}
+
+type hookJob struct {
+	s  Store
+	h  Hook
+	ev EventV1
+}
+
+// hookQueue is the process's pending deliveries, worked through by one goroutine while non-empty.
+var hookQueue = &hookDeliveries{}
+
+type hookDeliveries struct {
+	mu     sync.Mutex
+	jobs   []hookJob
+	busy   bool
+	done   chan struct{} // closed when the worker has emptied the queue
+	cancel context.CancelFunc
+}
+
+func (q *hookDeliveries) push(j hookJob) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.jobs = append(q.jobs, j)
+	if q.busy {
+		return
+	}
+	q.busy = true
+	q.done = make(chan struct{})
+	var ctx context.Context
+	ctx, q.cancel = context.WithCancel(context.Background())
+	go q.work(ctx, q.done)
+}
+
+func (q *hookDeliveries) work(ctx context.Context, done chan struct{}) {
+	for {
+		q.mu.Lock()
+		if len(q.jobs) == 0 {
+			q.busy = false
+			q.cancel()
+			close(done)
+			q.mu.Unlock()
+			return
+		}
+		j := q.jobs[0]
+		q.jobs = q.jobs[1:]
+		q.mu.Unlock()
+
+		if attempts, err := j.s.deliverHook(ctx, j.h, j.ev); err != nil {
+			_ = j.s.recordHookFailure(j.h, j.ev, attempts, err)
+		}
+	}
+}
+
+// WaitForHooks waits up to hookDrainTimeout for queued hook deliveries. Deliveries still pending
+// (or in flight) after that are given up and dead-lettered; it returns once they are recorded.
+func WaitForHooks() {
+	q := hookQueue
+	q.mu.Lock()
+	if !q.busy {
+		q.mu.Unlock()
+		return
+	}
+	done, cancel := q.done, q.cancel
+	q.mu.Unlock()
+
+	t := time.NewTimer(hookDrainTimeout)
+	defer t.Stop()
+	select {
+	case <-done:
+		return
+	case <-t.C:
+	}
+	cancel()
+	<-done
+}
+
+func (h Hook) matchesType(typ string) bool {
+	typ = strings.TrimSpace(typ)
+	for _, on := range h.On {
+		on = strings.TrimSpace(on)
+		switch {
+		case on == "*", on == typ:
+			return true
+		case strings.HasSuffix(on, ".*") && strings.HasPrefix(typ, strings.TrimSuffix(on, "*")):
+			return true
+		}
+	}
+	return false
+}
+
+// hookContext looks up the outline (and its statuses) an event belongs to in the derived state,
+// opening it only when a hook needs it.
+type hookContext struct {
+	s  Store
+	db *sql.DB
+}
+
+func (c *hookContext) close() {
+	if c.db != nil {
+		_ = c.db.Close()
+	}
+}
+
+func (c *hookContext) matches(h Hook, ev EventV1) bool {
+	if !h.matchesType(ev.Type) {
+		return false
+	}
+	if h.Outline == "" && !h.EndState {
+		return true
+	}
+	outlineID := c.outlineOf(ev)
+	if h.Outline != "" && outlineID != h.Outline {
+		return false
+	}
+	if h.EndState {
+		if strings.TrimSpace(ev.Type) != "item.set_status" || outlineID == "" {
+			return false
+		}
+		var p struct {
+			To     string `json:"to"`
+			Status string `json:"status"`
+		}
+		if err := json.Unmarshal(ev.Payload, &p); err != nil {
+			return false
+		}
+		to := strings.TrimSpace(p.To)
+		if to == "" {
+			to = strings.TrimSpace(p.Status)
+		}
+		return c.isEndState(outlineID, to)
+	}
+	return true
+}
+
+func (c *hookContext) open() bool {
+	if c.db != nil {
+		return true
+	}
+	db, err := c.s.openSQLite(context.Background())
+	if err != nil {
+		return false
+	}
+	c.db = db
+	return true
+}
+
+func (c *hookContext) outlineOf(ev EventV1) string {
+	switch ev.EntityKind {
+	case EntityKindOutline:
+		return strings.TrimSpace(ev.EntityID)
+	case EntityKindItem:
+	default:
+		return ""
+	}
+	// The state is saved after the append, so a new item (or a move) only has it in the payload.
+	var p struct {
+		OutlineID string `json:"outlineId"`
+	}
+	if err := json.Unmarshal(ev.Payload, &p); err == nil && strings.TrimSpace(p.OutlineID) != "" {
+		return strings.TrimSpace(p.OutlineID)
+	}
+	if !c.open() {
+		return ""
+	}
+	var outlineID string
+	if err := c.db.QueryRow(`SELECT outline_id FROM items WHERE id = ?`, strings.TrimSpace(ev.EntityID)).Scan(&outlineID); err != nil {
+		return ""
+	}
+	return outlineID
+}
+
+func (c *hookContext) isEndState(outlineID, statusID string) bool {
+	if statusID == "" || !c.open() {
+		return false
+	}
+	var raw string
+	if err := c.db.QueryRow(`SELECT status_defs_json FROM outlines WHERE id = ?`, outlineID).Scan(&raw); err != nil {
+		return false
+	}
+	var defs []model.OutlineStatusDef
+	if err := json.Unmarshal([]byte(raw), &defs); err != nil {
+		return false
+	}
+	for _, d := range defs {
+		if strings.TrimSpace(d.ID) == statusID {
+			return d.IsEndState
+		}
+	}
+	return false
+}
+
+// deliverHook delivers ev to h, retrying with backoff, and returns the number of attempts made.
+// Once ctx is done it stops (errHookNotDelivered).
+func (s Store) deliverHook(ctx context.Context, h Hook, ev EventV1) (int, error) {
+	body, err := json.Marshal(ev)
+	if err != nil {
+		return 0, err
+	}
+	retries := defaultHookRetries
+	if h.Retries != nil {
+		retries = *h.Retries
+	}
+	delay := hookRetryDelay
+	for attempt := 1; ; attempt++ {
+		if ctx.Err() != nil {
+			if err != nil {
+				return attempt - 1, fmt.Errorf("%w (last error: %v)", errHookNotDelivered, err)
+			}
+			return attempt - 1, errHookNotDelivered
+		}
+		err = s.deliverHookOnce(ctx, h, ev, body)
+		if err == nil || attempt > retries {
+			if err != nil && ctx.Err() != nil {
+				err = fmt.Errorf("%w (last error: %v)", errHookNotDelivered, err)
+			}
+			return attempt, err
+		}
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+		}
+		delay *= 2
+	}
+}
+
+func (s Store) deliverHookOnce(ctx context.Context, h Hook, ev EventV1, body []byte) error {
+	timeout := time.Duration(h.TimeoutSeconds) * time.Second
+	if timeout == 0 {
+		timeout = defaultHookTimeoutSecs * time.Second
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	if h.Run != "" {
+		cmd := exec.CommandContext(ctx, "sh", "-c", h.Run)
+		cmd.Dir = s.workspaceRoot()
+		cmd.Stdin = bytes.NewReader(body)
+		cmd.Env = append(os.Environ(),
+			"CLARITY_HOOK="+h.Name,
+			"CLARITY_EVENT_ID="+ev.EventID,
+			"CLARITY_EVENT_TYPE="+ev.Type,
+			"CLARITY_ENTITY_ID="+ev.EntityID,
+			"CLARITY_DIR="+s.Dir,
+			// A hook that calls clarity must not trigger hooks again.
+			noHooksEnv+"=1",
+		)
+		out, err := cmd.CombinedOutput()
+		if err != nil {
+			if msg := truncateHookOutput(out); msg != "" {
+				return fmt.Errorf("%w: %s", err, msg)
+			}
+			return err
+		}
+		return nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, h.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "clarity-hooks")
+	req.Header.Set("X-Clarity-Hook", h.Name)
+	req.Header.Set("X-Clarity-Event", ev.Type)
+	req.Header.Set("X-Clarity-Delivery", ev.EventID)
+	for k, v := range h.Headers {
+		// Secrets stay out of the committed file: "Bearer ${NOTIFY_TOKEN}".
+		req.Header.Set(k, os.ExpandEnv(v))
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		var buf bytes.Buffer
+		_, _ = buf.ReadFrom(io.LimitReader(resp.Body, hookFailureOutputMaxLen+1))
+		if msg := truncateHookOutput(buf.Bytes()); msg != "" {
+			return fmt.Errorf("%s: %s", resp.Status, msg)
+		}
+		return errors.New(resp.Status)
+	}
+	return nil
+}
+
+func truncateHookOutput(b []byte) string {
+	msg := strings.TrimSpace(string(b))
+	if len(msg) > hookFailureOutputMaxLen {
+		msg = msg[:hookFailureOutputMaxLen] + "…"
+	}
+	return msg
+}
+
+func (s Store) recordHookFailure(h Hook, ev EventV1, attempts int, cause error) error {
+	body, err := json.Marshal(ev)
+	if err != nil {
+		return err
+	}
+	line, err := json.Marshal(HookFailure{
+		Hook:      h.Name,
+		EventID:   ev.EventID,
+		EventType: ev.Type,
+		Attempts:  attempts,
+		Error:     cause.Error(),
+		FailedAt:  time.Now().UTC(),
+		Event:     body,
+	})
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(s.localDir(), 0o755); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(s.hookDeadLetterPath(), os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	_, err = f.Write(append(line, '\n'))
+	return err
+}
+
+// ReadHookFailures returns the dead-lettered deliveries, oldest first, once this process's queued
+// ones are done.
+func (s Store) ReadHookFailures() ([]HookFailure, error) {
+	WaitForHooks()
+	f, err := os.Open(s.hookDeadLetterPath())
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return []HookFailure{}, nil
+		}
+		return nil, err
+	}
+	defer f.Close()
+	out := []HookFailure{}
+	sc := bufio.NewScanner(f)
+	sc.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for sc.Scan() {
+		line := bytes.TrimSpace(sc.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		var hf HookFailure
+		if err := json.Unmarshal(line, &hf); err != nil {
+			return nil, fmt.Errorf("%s: %w", hookDeadLetterFileName, err)
+		}
+		out = append(out, hf)
+	}
+	return out, sc.Err()
+}
+
+// RetryHookFailures redelivers every dead-lettered event to its hook (as configured now) and
+// keeps only the ones that fail again. Failures of hooks that no longer exist are kept.
+func (s Store) RetryHookFailures() (delivered int, remaining []HookFailure, err error) {
+	f, err := s.LoadHooks()
+	if err != nil {
+		return 0, nil, err
+	}
+	if ok, err := s.HooksTrusted(); err != nil {
+		return 0, nil, err
+	} else if !ok && len(f.Hooks) > 0 {
+		return 0, nil, errors.New("meta/hooks.json is not trusted on this machine (run `clarity hooks trust`)")
+	}
+	// ReadHookFailures waits for queued deliveries: none append to the log while it's rewritten.
+	failures, err := s.ReadHookFailures()
+	if err != nil {
+		return 0, nil, err
+	}
+	byName := map[string]Hook{}
+	for _, h := range f.Hooks {
+		byName[h.Name] = h
+	}
+	remaining = []HookFailure{}
+	for _, hf := range failures {
+		h, ok := byName[hf.Hook]
+		if !ok {
+			remaining = append(remaining, hf)
+			continue
+		}
+		var ev EventV1
+		if err := json.Unmarshal(hf.Event, &ev); err != nil {
+			return 0, nil, err
+		}
+		attempts, err := s.deliverHook(context.Background(), h, ev)
+		if err != nil {
+			hf.Attempts += attempts
+			hf.Error = err.Error()
+			hf.FailedAt = time.Now().UTC()
+			remaining = append(remaining, hf)
+			continue
+		}
+		delivered++
+	}
+
+	var buf bytes.Buffer
+	for _, hf := range remaining {
+		line, err := json.Marshal(hf)
+		if err != nil {
+			return 0, nil, err
+		}
+		buf.Write(line)
+		buf.WriteByte('\n')
+	}
+	if err := os.MkdirAll(s.localDir(), 0o755); err != nil {
+		return 0, nil, err
+	}
+	if err := atomicWriteFile(s.localDir(), "hooks-dead-letter.*.tmp", s.hookDeadLetterPath(), buf.Bytes(), 0o644); err != nil {
+		return 0, nil, err
+	}
+	return delivered, remaining, nil
+}
@@ -0,0 +1,209 @@
+package store
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestHooks_FireAfterAppendWithRetriesAndDeadLetter(t *testing.T) {
+	prev := hookRetryDelay
+	hookRetryDelay = time.Millisecond
+	t.Cleanup(func() { hookRetryDelay = prev })
+
+	var mu sync.Mutex
+	var doneEvents []EventV1
+	var flakyCalls atomic.Int32
+	var flakyUp atomic.Bool
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/done":
+			if r.Header.Get("Authorization") != "Bearer s3cret" || r.Header.Get("X-Clarity-Hook") != "done-webhook" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			var ev EventV1
+			b, _ := io.ReadAll(r.Body)
+			if err := json.Unmarshal(b, &ev); err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			mu.Lock()
+			doneEvents = append(doneEvents, ev)
+			mu.Unlock()
+		case "/flaky":
+			flakyCalls.Add(1)
+			if !flakyUp.Load() {
+				http.Error(w, "down for maintenance", http.StatusServiceUnavailable)
+			}
+		}
+	}))
+	defer srv.Close()
+	t.Setenv("NOTIFY_TOKEN", "s3cret")
+	t.Setenv(noHooksEnv, "")
+
+	s := newWatermarkWorkspace(t)
+	hooks := `{"version":1,"hooks":[
+		{"name":"done-webhook","on":["item.set_status"],"outline":"out-1","endState":true,"url":"` + srv.URL + `/done","headers":{"Authorization":"Bearer ${NOTIFY_TOKEN}"}},
+		{"name":"log-items","on":["item.*"],"run":"cat >> hook-out.jsonl; echo >> hook-out.jsonl"},
+		{"name":"flaky","on":["*"],"url":"` + srv.URL + `/flaky","retries":1}
+	]}`
+	if err := os.MkdirAll(filepath.Join(s.Dir, "meta"), 0o755); err != nil {
+		t.Fatalf("mkdir meta: %v", err)
+	}
+	if err := os.WriteFile(s.hooksPath(), []byte(hooks), 0o644); err != nil {
+		t.Fatalf("write hooks: %v", err)
+	}
+
+	// Untrusted hooks don't fire.
+	if err := s.AppendEvent("act-1", "item.set_status", "item-1", map[string]any{"to": "done"}); err != nil {
+		t.Fatalf("AppendEvent: %v", err)
+	}
+	if flakyCalls.Load() != 0 || len(doneEvents) != 0 {
+		t.Fatalf("untrusted hooks fired")
+	}
+
+	if _, err := s.TrustHooks(); err != nil {
+		t.Fatalf("TrustHooks: %v", err)
+	}
+	if err := s.AppendEvent("act-1", "item.set_status", "item-1", map[string]any{"to": "todo"}); err != nil {
+		t.Fatalf("AppendEvent: %v", err)
+	}
+	if err := s.AppendEvent("act-1", "item.set_status", "item-1", map[string]any{"to": "done"}); err != nil {
+		t.Fatalf("AppendEvent: %v", err)
+	}
+	WaitForHooks()
+	if len(doneEvents) != 1 || doneEvents[0].Type != "item.set_status" || !strings.Contains(string(doneEvents[0].Payload), `"done"`) {
+		t.Fatalf("expected one end-state delivery, got %+v", doneEvents)
+	}
+	out, err := os.ReadFile(filepath.Join(s.Dir, "hook-out.jsonl"))
+	if err != nil {
+		t.Fatalf("read script output: %v", err)
+	}
+	if n := len(strings.Fields(string(out))); n != 2 {
+		t.Fatalf("expected 2 script runs, got %d:\n%s", n, out)
+	}
+	if got := flakyCalls.Load(); got != 4 {
+		t.Fatalf("expected 2 attempts per event, got %d calls", got)
+	}
+	failures, err := s.ReadHookFailures()
+	if err != nil {
+		t.Fatalf("ReadHookFailures: %v", err)
+	}
+	if len(failures) != 2 || failures[0].Hook != "flaky" || failures[0].Attempts != 2 || !strings.Contains(failures[0].Error, "down for maintenance") {
+		t.Fatalf("unexpected dead letters: %+v", failures)
+	}
+
+	// --no-hooks.
+	noHooks := Store{Dir: s.Dir, NoHooks: true}
+	if err := noHooks.AppendEvent("act-1", "item.set_status", "item-1", map[string]any{"to": "todo"}); err != nil {
+		t.Fatalf("AppendEvent: %v", err)
+	}
+	WaitForHooks()
+	if got := flakyCalls.Load(); got != 4 {
+		t.Fatalf("hooks fired with NoHooks: %d calls", got)
+	}
+
+	flakyUp.Store(true)
+	delivered, remaining, err := s.RetryHookFailures()
+	if err != nil {
+		t.Fatalf("RetryHookFailures: %v", err)
+	}
+	if delivered != 2 || len(remaining) != 0 {
+		t.Fatalf("expected both redelivered, got delivered=%d remaining=%+v", delivered, remaining)
+	}
+	if failures, _ := s.ReadHookFailures(); len(failures) != 0 {
+		t.Fatalf("expected an empty dead-letter log, got %+v", failures)
+	}
+
+	// Editing the file (e.g. through a pull) requires trusting it again.
+	if err := os.WriteFile(s.hooksPath(), []byte(strings.Replace(hooks, `"retries":1`, `"retries":0`, 1)), 0o644); err != nil {
+		t.Fatalf("write hooks: %v", err)
+	}
+	if ok, err := s.HooksTrusted(); err != nil || ok {
+		t.Fatalf("expected changed hooks to be untrusted (ok=%v err=%v)", ok, err)
+	}
+}
+
+func TestHooks_SlowDeliveryDoesNotBlockAppendAndIsDeadLetteredAtTheDeadline(t *testing.T) {
+	prev := hookDrainTimeout
+	hookDrainTimeout = 100 * time.Millisecond
+	t.Cleanup(func() { hookDrainTimeout = prev })
+
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer srv.Close()
+	defer close(release)
+	t.Setenv(noHooksEnv, "")
+
+	s := newWatermarkWorkspace(t)
+	if err := os.MkdirAll(filepath.Join(s.Dir, "meta"), 0o755); err != nil {
+		t.Fatalf("mkdir meta: %v", err)
+	}
+	if err := os.WriteFile(s.hooksPath(), []byte(`{"version":1,"hooks":[{"name":"slow","on":["*"],"url":"`+srv.URL+`","timeoutSeconds":30}]}`), 0o644); err != nil {
+		t.Fatalf("write hooks: %v", err)
+	}
+	if _, err := s.TrustHooks(); err != nil {
+		t.Fatalf("TrustHooks: %v", err)
+	}
+
+	start := time.Now()
+	for _, title := range []string{"One", "Two"} {
+		if err := s.AppendEvent("act-1", "item.set_title", "item-1", map[string]any{"title": title}); err != nil {
+			t.Fatalf("AppendEvent: %v", err)
+		}
+	}
+	if d := time.Since(start); d > 5*time.Second {
+		t.Fatalf("appends waited on the hook: %s", d)
+	}
+
+	WaitForHooks()
+	if d := time.Since(start); d > 10*time.Second {
+		t.Fatalf("WaitForHooks took %s, past its deadline", d)
+	}
+	failures, err := s.ReadHookFailures()
+	if err != nil {
+		t.Fatalf("ReadHookFailures: %v", err)
+	}
+	if len(failures) != 2 || !strings.Contains(failures[0].Error, errHookNotDelivered.Error()) || failures[1].Attempts != 0 {
+		t.Fatalf("expected both deliveries dead-lettered at the deadline, got %+v", failures)
+	}
+}
+
+func TestLoadHooks_Validates(t *testing.T) {
+	s := Store{Dir: t.TempDir()}
+	if f, err := s.LoadHooks(); err != nil || len(f.Hooks) != 0 {
+		t.Fatalf("missing file: %+v %v", f, err)
+	}
+	if err := os.MkdirAll(filepath.Join(s.Dir, "meta"), 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	for _, bad := range []string{
+		`{"hooks":[{"name":"a","on":["item.create"]}]}`,
+		`{"hooks":[{"name":"a","on":["item.create"],"run":"x","url":"http://h"}]}`,
+		`{"hooks":[{"name":"a","run":"x"}]}`,
+		`{"hooks":[{"name":"a b","on":["*"],"run":"x"}]}`,
+		`{"hooks":[{"name":"a","on":["*"],"url":"file:///etc/passwd"}]}`,
+		`{"hooks":[{"name":"a","on":["*"],"run":"x"},{"name":"a","on":["*"],"run":"y"}]}`,
+	} {
+		if err := os.WriteFile(s.hooksPath(), []byte(bad), 0o644); err != nil {
+			t.Fatalf("write: %v", err)
+		}
+		if _, err := s.LoadHooks(); err == nil {
+			t.Fatalf("expected error for %s", bad)
+		}
+	}
+}
@@ -80,5 +80,10 @@ func idExists(db *DB, id string) bool {
                         return true
                 }
         }
+        for _, f := range db.Follows {
+                if f.ID == id {
+                        return true
+                }
+        }
         return false
 }
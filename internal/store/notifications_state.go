@@ -0,0 +1,79 @@
+package store
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const notificationsStateFileName = "notifications.json"
+
+// NotificationCursor marks the newest event an actor has seen in their notifications feed.
+type NotificationCursor struct {
+	EventID string    `json:"eventId,omitempty"`
+	TS      time.Time `json:"ts"`
+}
+
+// NotificationsState stores per-actor "last seen" cursors for the derived notifications feed.
+//
+// Read state is local (it lives next to the derived SQLite state under .clarity/ and is never
+// committed), so it survives `clarity reindex` but isn't shared across devices.
+type NotificationsState struct {
+	Version  int                           `json:"version"`
+	LastSeen map[string]NotificationCursor `json:"lastSeen,omitempty"`
+}
+
+func (s Store) notificationsStatePath() string {
+	return filepath.Join(s.localDir(), notificationsStateFileName)
+}
+
+func (s Store) LoadNotificationsState() (*NotificationsState, error) {
+	empty := &NotificationsState{Version: 1, LastSeen: map[string]NotificationCursor{}}
+	if strings.TrimSpace(s.Dir) == "" {
+		return empty, nil
+	}
+	b, err := os.ReadFile(s.notificationsStatePath())
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return empty, nil
+		}
+		return nil, err
+	}
+	var st NotificationsState
+	if err := json.Unmarshal(b, &st); err != nil {
+		// Best-effort; if corrupted, everything is unread again.
+		return empty, nil
+	}
+	if st.Version == 0 {
+		st.Version = 1
+	}
+	if st.LastSeen == nil {
+		st.LastSeen = map[string]NotificationCursor{}
+	}
+	return &st, nil
+}
+
+func (s Store) SaveNotificationsState(st *NotificationsState) error {
+	if st == nil || strings.TrimSpace(s.Dir) == "" {
+		return nil
+	}
+	if err := os.MkdirAll(s.localDir(), 0o755); err != nil {
+		return err
+	}
+	if st.Version == 0 {
+		st.Version = 1
+	}
+	b, err := json.MarshalIndent(st, "", "  ")
+	if err != nil {
+		return err
+	}
+	path := s.notificationsStatePath()
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, b, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
@@ -0,0 +1,163 @@
+package store
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// Field-level merge semantics for concurrent edits.
+//
+// Two events of the same entity are concurrent when neither descends from the other through
+// parents (a fork: both replicas edited on top of the same head, see appendEventJSONL). Replay
+// applies them in replay order, which gives per field:
+//   - title, status and other single-value fields: last writer (in replay order) wins
+//   - tags: set union; a tags_set/tags_remove never drops a tag added by a concurrent event
+//   - description: last writer wins in the state, but the concurrent writes are surfaced as a
+//     conflict (see ListConflictsV1) until an edit that descends from all of them resolves it
+
+// causalGraph indexes the per-entity parent chains of a set of events.
+type causalGraph struct {
+	parents map[string][]string
+	forked  map[string]bool // entity id -> has concurrent events
+}
+
+func newCausalGraph(lines []EventV1Line) *causalGraph {
+	g := &causalGraph{parents: map[string][]string{}, forked: map[string]bool{}}
+	children := map[string]int{}
+	roots := map[string]int{}
+	for _, l := range lines {
+		id := strings.TrimSpace(l.Event.EventID)
+		ent := strings.TrimSpace(l.Event.EntityID)
+		if id == "" || ent == "" {
+			continue
+		}
+		var ps []string
+		for _, p := range l.Event.Parents {
+			if p = strings.TrimSpace(p); p != "" {
+				ps = append(ps, p)
+				children[p]++
+				if children[p] > 1 {
+					g.forked[ent] = true
+				}
+			}
+		}
+		if len(ps) == 0 {
+			roots[ent]++
+			if roots[ent] > 1 {
+				g.forked[ent] = true
+			}
+		}
+		g.parents[id] = ps
+	}
+	return g
+}
+
+// isAncestor reports whether a is b or one of b's ancestors.
+func (g *causalGraph) isAncestor(a, b string) bool {
+	if a == b {
+		return true
+	}
+	seen := map[string]bool{b: true}
+	stack := []string{b}
+	for len(stack) > 0 {
+		cur := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		for _, p := range g.parents[cur] {
+			if p == a {
+				return true
+			}
+			if !seen[p] {
+				seen[p] = true
+				stack = append(stack, p)
+			}
+		}
+	}
+	return false
+}
+
+func (g *causalGraph) concurrent(a, b string) bool {
+	return !g.isAncestor(a, b) && !g.isAncestor(b, a)
+}
+
+// mergeReplayer applies events like applyEventV1, with the merge semantics above for forked entities.
+type mergeReplayer struct {
+	g         *causalGraph
+	tagAdders map[string]map[string][]string // item id -> tag -> event ids that added it
+}
+
+func newMergeReplayer(lines []EventV1Line) *mergeReplayer {
+	return &mergeReplayer{g: newCausalGraph(lines), tagAdders: map[string]map[string][]string{}}
+}
+
+func (r *mergeReplayer) apply(db *DB, ev EventV1) (bool, error) {
+	typ := strings.TrimSpace(ev.Type)
+	entID := strings.TrimSpace(ev.EntityID)
+	if !strings.HasPrefix(typ, "item.tags_") || !r.g.forked[entID] {
+		return applyEventV1(db, ev)
+	}
+
+	var before []string
+	if it, ok := db.FindItem(entID); ok && it != nil {
+		before = append(before, it.Tags...)
+	}
+	applied, err := applyEventV1(db, ev)
+	if err != nil || !applied {
+		return applied, err
+	}
+	it, ok := db.FindItem(entID)
+	if !ok || it == nil {
+		return applied, nil
+	}
+
+	evID := strings.TrimSpace(ev.EventID)
+	adders := r.tagAdders[entID]
+	if adders == nil {
+		adders = map[string][]string{}
+		r.tagAdders[entID] = adders
+	}
+	have := map[string]bool{}
+	for _, t := range it.Tags {
+		have[t] = true
+	}
+	// Keep tags this event dropped if a concurrent event added them.
+	for _, t := range before {
+		if have[t] {
+			continue
+		}
+		for _, by := range adders[t] {
+			if r.g.concurrent(by, evID) {
+				it.Tags = append(it.Tags, t)
+				have[t] = true
+				break
+			}
+		}
+	}
+
+	switch typ {
+	case "item.tags_add":
+		var p struct {
+			Tag string `json:"tag"`
+		}
+		if json.Unmarshal(ev.Payload, &p) == nil {
+			if t := strings.TrimSpace(p.Tag); t != "" {
+				adders[t] = append(adders[t], evID)
+			}
+		}
+	case "item.tags_set":
+		had := map[string]bool{}
+		for _, t := range before {
+			had[t] = true
+		}
+		var p struct {
+			Tags []string `json:"tags"`
+		}
+		if json.Unmarshal(ev.Payload, &p) == nil {
+			for _, t := range p.Tags {
+				if !had[t] {
+					adders[t] = append(adders[t], evID)
+				}
+			}
+		}
+	}
+	return applied, nil
+}
@@ -0,0 +1,134 @@
+package store
+
+import (
+	"container/heap"
+	"sort"
+	"strings"
+)
+
+// sortEventV1Lines puts lines in replay order: causal first, deterministic otherwise.
+//
+// An event always comes after
+//   - its parents (the per-entity chain written by appendEventJSONL), and
+//   - the events before it in the same shard (a replica appends in the order it issued events).
+//
+// Among events that are free to go next, the lowest (HLC, issuedAt, eventId, replica, path, line)
+// wins. Because a replica's HLC is ahead of everything it has seen, this is also causal across
+// entities; falling back to parents/shard order keeps a skewed wall clock from reordering a
+// replica's own edits or an edit made on top of another replica's.
+func sortEventV1Lines(lines []EventV1Line) {
+	sort.SliceStable(lines, func(i, j int) bool { return replayLess(lines[i], lines[j]) })
+	n := len(lines)
+	if n < 2 {
+		return
+	}
+
+	succ := make([][]int, n)
+	indeg := make([]int, n)
+	edge := func(from, to int) {
+		if from == to {
+			return
+		}
+		succ[from] = append(succ[from], to)
+		indeg[to]++
+	}
+
+	byID := make(map[string]int, n)
+	for i, l := range lines {
+		if id := strings.TrimSpace(l.Event.EventID); id != "" {
+			if _, ok := byID[id]; !ok {
+				byID[id] = i
+			}
+		}
+	}
+	for i, l := range lines {
+		for _, p := range l.Event.Parents {
+			if j, ok := byID[strings.TrimSpace(p)]; ok {
+				edge(j, i)
+			}
+		}
+	}
+
+	inShard := make([]int, n)
+	for i := range inShard {
+		inShard[i] = i
+	}
+	sort.Slice(inShard, func(a, b int) bool {
+		la, lb := lines[inShard[a]], lines[inShard[b]]
+		if la.Path != lb.Path {
+			return la.Path < lb.Path
+		}
+		return la.Line < lb.Line
+	})
+	for k := 1; k < n; k++ {
+		if lines[inShard[k-1]].Path == lines[inShard[k]].Path {
+			edge(inShard[k-1], inShard[k])
+		}
+	}
+
+	// Kahn's algorithm; the sorted index is the tie-break priority.
+	ready := &intHeap{}
+	for i := 0; i < n; i++ {
+		if indeg[i] == 0 {
+			heap.Push(ready, i)
+		}
+	}
+	order := make([]int, 0, n)
+	done := make([]bool, n)
+	for ready.Len() > 0 {
+		i := heap.Pop(ready).(int)
+		order = append(order, i)
+		done[i] = true
+		for _, j := range succ[i] {
+			indeg[j]--
+			if indeg[j] == 0 {
+				heap.Push(ready, j)
+			}
+		}
+	}
+	// Corrupt parents can form a cycle (doctor reports it); replay the rest in clock order.
+	for i := 0; i < n; i++ {
+		if !done[i] {
+			order = append(order, i)
+		}
+	}
+
+	sorted := make([]EventV1Line, n)
+	for k, i := range order {
+		sorted[k] = lines[i]
+	}
+	copy(lines, sorted)
+}
+
+func replayLess(x, y EventV1Line) bool {
+	a, b := x.Event, y.Event
+	if c := a.Clock().Compare(b.Clock()); c != 0 {
+		return c < 0
+	}
+	if !a.IssuedAt.Equal(b.IssuedAt) {
+		return a.IssuedAt.Before(b.IssuedAt)
+	}
+	if a.EventID != b.EventID {
+		return a.EventID < b.EventID
+	}
+	if a.ReplicaID != b.ReplicaID {
+		return a.ReplicaID < b.ReplicaID
+	}
+	if x.Path != y.Path {
+		return x.Path < y.Path
+	}
+	return x.Line < y.Line
+}
+
+type intHeap []int
+
+func (h intHeap) Len() int           { return len(h) }
+func (h intHeap) Less(i, j int) bool { return h[i] < h[j] }
+func (h intHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+func (h *intHeap) Push(x any)        { *h = append(*h, x.(int)) }
+func (h *intHeap) Pop() any {
+	old := *h
+	x := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return x
+}
@@ -15,37 +15,36 @@ type ReplayResult struct {
 	AppliedCount int
 	SkippedCount int
 	SkippedTypes map[string]int
+	// Rejected are events skipped because their actor lacked the role for them (see access.go).
+	Rejected []RejectedEvent
+	// Snapshot is the snapshot file the replay started from ("" for a full replay).
+	Snapshot string
 }
 
 // ReplayEventsV1 materializes workspace state from the Git-backed EventV1 JSONL logs.
 //
 // Notes:
 // - This is a best-effort V1 reducer. Unknown event types are skipped (counted), not fatal.
-// - Ordering uses (issuedAt, eventId, replicaId, path, line) for determinism.
+// - Ordering is causal with deterministic tie-breaks (see sortEventV1Lines); concurrent edits
+//   merge per field (see replay_merge.go).
 func ReplayEventsV1(dir string) (ReplayResult, error) {
 	lines, err := ReadEventsV1Lines(dir)
 	if err != nil {
 		return ReplayResult{}, err
 	}
 
-	sort.Slice(lines, func(i, j int) bool {
-		a := lines[i].Event
-		b := lines[j].Event
-		if !a.IssuedAt.Equal(b.IssuedAt) {
-			return a.IssuedAt.Before(b.IssuedAt)
-		}
-		if a.EventID != b.EventID {
-			return a.EventID < b.EventID
-		}
-		if a.ReplicaID != b.ReplicaID {
-			return a.ReplicaID < b.ReplicaID
-		}
-		if lines[i].Path != lines[j].Path {
-			return lines[i].Path < lines[j].Path
-		}
-		return lines[i].Line < lines[j].Line
-	})
+	access, err := Store{Dir: dir}.loadAccessRules()
+	if err != nil {
+		return ReplayResult{}, err
+	}
 
+	sortEventV1Lines(lines)
+	return replayEventV1Lines(lines, access, nil)
+}
+
+// replayEventV1Lines applies lines (already in replay order) to an empty DB, skipping events access
+// doesn't allow, and calls after (if set) once each event has been applied or skipped.
+func replayEventV1Lines(lines []EventV1Line, access *AccessRules, after func(l EventV1Line, db *DB)) (ReplayResult, error) {
 	db := &DB{
 		Version:     1,
 		NextIDs:     map[string]int{},
@@ -57,6 +56,7 @@ func ReplayEventsV1(dir string) (ReplayResult, error) {
 		Comments:    []model.Comment{},
 		Worklog:     []model.WorklogEntry{},
 		Attachments: []model.Attachment{},
+		Follows:     []model.Follow{},
 	}
 
 	res := ReplayResult{
@@ -66,17 +66,32 @@ func ReplayEventsV1(dir string) (ReplayResult, error) {
 		SkippedTypes: map[string]int{},
 	}
 
+	db.replay = newReplayWatermark()
+	db.replay.access = access.Digest()
+	merge := newMergeReplayer(lines)
 	for _, l := range lines {
-		applied, err := applyEventV1(db, l.Event)
+		if reason := access.checkEvent(db, l.Event); reason != "" {
+			db.replay.observe(l)
+			res.Rejected = append(res.Rejected, RejectedEvent{Line: l, Reason: reason})
+			if after != nil {
+				after(l, db)
+			}
+			continue
+		}
+		applied, err := merge.apply(db, l.Event)
 		if err != nil {
 			return ReplayResult{}, fmt.Errorf("%s:%d: %w", l.Path, l.Line, err)
 		}
+		db.replay.observe(l)
 		if applied {
 			res.AppliedCount++
 		} else {
 			res.SkippedCount++
 			res.SkippedTypes[strings.TrimSpace(l.Event.Type)]++
 		}
+		if after != nil {
+			after(l, db)
+		}
 	}
 
 	return res, nil
@@ -119,6 +134,19 @@ func applyEventV1(db *DB, ev EventV1) (bool, error) {
 		}
 		return true, nil
 
+	case "identity.key_add":
+		// Keys that break the rules in signing.go (checkKeyAdd) are ignored.
+		key, reason := checkKeyAdd(db, ev)
+		if reason != "" {
+			return false, nil
+		}
+		a, _ := db.FindActor(strings.TrimSpace(ev.EntityID))
+		if hasKey(a.Keys, key.ID) {
+			return false, nil
+		}
+		a.Keys = append(a.Keys, key)
+		return true, nil
+
 	case "identity.seed":
 		// Local helper event used when seeding identity across workspaces.
 		// Not part of the materialized workspace state.
@@ -447,6 +475,30 @@ func applyEventV1(db *DB, ev EventV1) (bool, error) {
 		it.UpdatedAt = issuedOrNow(ev.IssuedAt)
 		return true, nil
 
+	case "item.repeat":
+		// A completed repeating item was reopened with its schedule/due rolled forward.
+		var p struct {
+			To       string          `json:"to"`
+			Due      *model.DateTime `json:"due"`
+			Schedule *model.DateTime `json:"schedule"`
+		}
+		if err := json.Unmarshal(ev.Payload, &p); err != nil {
+			return false, err
+		}
+		it, ok := db.FindItem(ev.EntityID)
+		if !ok || it == nil {
+			return true, nil
+		}
+		it.StatusID = strings.TrimSpace(p.To)
+		if p.Due != nil {
+			it.Due = p.Due
+		}
+		if p.Schedule != nil {
+			it.Schedule = p.Schedule
+		}
+		it.UpdatedAt = issuedOrNow(ev.IssuedAt)
+		return true, nil
+
 	case "item.set_children_kind":
 		var p struct {
 			Kind string `json:"kind"`
@@ -860,6 +912,50 @@ func applyEventV1(db *DB, ev EventV1) (bool, error) {
 		db.Deps = append(db.Deps, d)
 		return true, nil
 
+	case "dep.remove":
+		// Payload: {"id":"dep-...","fromItemId":"...","toItemId":"...","type":"blocks"}
+		var p struct {
+			ID string `json:"id"`
+		}
+		if err := json.Unmarshal(ev.Payload, &p); err != nil {
+			return false, err
+		}
+		id := strings.TrimSpace(p.ID)
+		if id == "" {
+			id = strings.TrimSpace(ev.EntityID)
+		}
+		next := db.Deps[:0]
+		for _, d := range db.Deps {
+			if strings.TrimSpace(d.ID) == id {
+				continue
+			}
+			next = append(next, d)
+		}
+		db.Deps = next
+		return true, nil
+
+	case "dep.update":
+		// Payload: {"id":"dep-...","fromItemId":"...","toItemId":"...","type":"related","from":"blocks"}
+		var p struct {
+			ID   string               `json:"id"`
+			Type model.DependencyType `json:"type"`
+		}
+		if err := json.Unmarshal(ev.Payload, &p); err != nil {
+			return false, err
+		}
+		id := strings.TrimSpace(p.ID)
+		if id == "" {
+			id = strings.TrimSpace(ev.EntityID)
+		}
+		d, ok := findDepByID(db, id)
+		if !ok || d == nil {
+			return true, nil
+		}
+		if strings.TrimSpace(string(p.Type)) != "" {
+			d.Type = p.Type
+		}
+		return true, nil
+
 	case "comment.add":
 		var c model.Comment
 		if err := json.Unmarshal(ev.Payload, &c); err != nil {
@@ -871,6 +967,55 @@ func applyEventV1(db *DB, ev EventV1) (bool, error) {
 		db.Comments = append(db.Comments, c)
 		return true, nil
 
+	case "comment.edit":
+		// Payload: {"id":"cmt-...","itemId":"...","body":"...","editedAt":"..."}
+		var p struct {
+			ID       string    `json:"id"`
+			Body     string    `json:"body"`
+			EditedAt time.Time `json:"editedAt"`
+		}
+		if err := json.Unmarshal(ev.Payload, &p); err != nil {
+			return false, err
+		}
+		id := strings.TrimSpace(p.ID)
+		if id == "" {
+			id = strings.TrimSpace(ev.EntityID)
+		}
+		c, ok := findCommentByID(db, id)
+		if !ok || c == nil || !authoredBy(c, ev) {
+			return true, nil
+		}
+		at := p.EditedAt
+		if at.IsZero() {
+			at = issuedOrNow(ev.IssuedAt)
+		}
+		ApplyCommentEdit(c, p.Body, at)
+		return true, nil
+
+	case "comment.redact":
+		// Payload: {"id":"cmt-...","itemId":"...","redactedAt":"..."}
+		var p struct {
+			ID         string    `json:"id"`
+			RedactedAt time.Time `json:"redactedAt"`
+		}
+		if err := json.Unmarshal(ev.Payload, &p); err != nil {
+			return false, err
+		}
+		id := strings.TrimSpace(p.ID)
+		if id == "" {
+			id = strings.TrimSpace(ev.EntityID)
+		}
+		c, ok := findCommentByID(db, id)
+		if !ok || c == nil || !authoredBy(c, ev) {
+			return true, nil
+		}
+		at := p.RedactedAt
+		if at.IsZero() {
+			at = issuedOrNow(ev.IssuedAt)
+		}
+		ApplyCommentRedact(c, at)
+		return true, nil
+
 	case "worklog.add":
 		var w model.WorklogEntry
 		if err := json.Unmarshal(ev.Payload, &w); err != nil {
@@ -895,6 +1040,10 @@ func applyEventV1(db *DB, ev EventV1) (bool, error) {
 		}
 		for i := range db.Attachments {
 			if strings.TrimSpace(db.Attachments[i].ID) == strings.TrimSpace(a.ID) {
+				if !sameAttachmentEntity(db.Attachments[i], a) {
+					// Attachments don't move between entities.
+					return true, nil
+				}
 				db.Attachments[i] = a
 				return true, nil
 			}
@@ -902,7 +1051,7 @@ func applyEventV1(db *DB, ev EventV1) (bool, error) {
 		db.Attachments = append(db.Attachments, a)
 		return true, nil
 
-	case "attachment.update":
+	case "attachment.update", "attachment.replace":
 		var a model.Attachment
 		if err := json.Unmarshal(ev.Payload, &a); err != nil {
 			return false, err
@@ -915,6 +1064,10 @@ func applyEventV1(db *DB, ev EventV1) (bool, error) {
 		}
 		for i := range db.Attachments {
 			if strings.TrimSpace(db.Attachments[i].ID) == strings.TrimSpace(a.ID) {
+				if !sameAttachmentEntity(db.Attachments[i], a) {
+					// Attachments don't move between entities.
+					return true, nil
+				}
 				db.Attachments[i] = a
 				return true, nil
 			}
@@ -922,6 +1075,65 @@ func applyEventV1(db *DB, ev EventV1) (bool, error) {
 		db.Attachments = append(db.Attachments, a)
 		return true, nil
 
+	case "attachment.remove":
+		id := attachmentRemovedID(ev)
+		for i := range db.Attachments {
+			if strings.TrimSpace(db.Attachments[i].ID) == id {
+				db.Attachments = append(db.Attachments[:i], db.Attachments[i+1:]...)
+				return true, nil
+			}
+		}
+		return true, nil
+
+	case "follow.add":
+		var f model.Follow
+		if err := json.Unmarshal(ev.Payload, &f); err != nil {
+			return false, err
+		}
+		if strings.TrimSpace(f.ID) == "" {
+			f.ID = strings.TrimSpace(ev.EntityID)
+		}
+		if strings.TrimSpace(f.ActorID) == "" {
+			f.ActorID = strings.TrimSpace(ev.ActorID)
+		}
+		if f.CreatedAt.IsZero() {
+			f.CreatedAt = issuedOrNow(ev.IssuedAt)
+		}
+		// Following the same target twice (e.g. from two replicas) keeps the first follow.
+		if db.FindFollow(f.ActorID, f.TargetKind, f.TargetID) != nil {
+			return true, nil
+		}
+		db.Follows = append(db.Follows, f)
+		return true, nil
+
+	case "follow.remove":
+		// Payload: {"id":"fol-...","actorId":"...","targetKind":"outline","targetId":"..."}
+		var p struct {
+			ID         string `json:"id"`
+			ActorID    string `json:"actorId"`
+			TargetKind string `json:"targetKind"`
+			TargetID   string `json:"targetId"`
+		}
+		if err := json.Unmarshal(ev.Payload, &p); err != nil {
+			return false, err
+		}
+		id := strings.TrimSpace(p.ID)
+		if id == "" {
+			id = strings.TrimSpace(ev.EntityID)
+		}
+		next := db.Follows[:0]
+		for _, f := range db.Follows {
+			if strings.TrimSpace(f.ID) == id {
+				continue
+			}
+			if p.TargetID != "" && f.ActorID == p.ActorID && f.TargetKind == p.TargetKind && f.TargetID == p.TargetID {
+				continue
+			}
+			next = append(next, f)
+		}
+		db.Follows = next
+		return true, nil
+
 	default:
 		return false, nil
 	}
@@ -1029,6 +1241,16 @@ func findCommentByID(db *DB, id string) (*model.Comment, bool) {
 	return nil, false
 }
 
+// sameAttachmentEntity reports whether b is attached to the same item or comment as a.
+func sameAttachmentEntity(a, b model.Attachment) bool {
+	return strings.TrimSpace(a.EntityKind) == strings.TrimSpace(b.EntityKind) && strings.TrimSpace(a.EntityID) == strings.TrimSpace(b.EntityID)
+}
+
+// authoredBy reports whether ev was written by c's author: only they edit or redact it.
+func authoredBy(c *model.Comment, ev EventV1) bool {
+	return strings.TrimSpace(c.AuthorID) == strings.TrimSpace(ev.ActorID)
+}
+
 func findWorklogByID(db *DB, id string) (*model.WorklogEntry, bool) {
 	id = strings.TrimSpace(id)
 	if id == "" {
@@ -138,3 +138,175 @@ func TestReplayEventsV1_ItemMoveBeforeAfterWithoutRank(t *testing.T) {
                 t.Fatalf("expected c to be first by rank, got %q (%q)", lowID, lowRank)
         }
 }
+
+func TestReplayEventsV1_DepRemoveAndUpdate(t *testing.T) {
+        dir := t.TempDir()
+        if err := os.MkdirAll(filepath.Join(dir, "events"), 0o755); err != nil {
+                t.Fatalf("mkdir events: %v", err)
+        }
+        eventsPath := filepath.Join(dir, "events", "events.rep-a.jsonl")
+
+        lines := "" +
+                `{"eventId":"evt-1","workspaceId":"ws-1","replicaId":"rep-a","entityKind":"dep","entityId":"dep-1","entitySeq":0,"type":"dep.add","issuedAt":"2025-12-31T00:00:00Z","actorId":"act-1","payload":{"id":"dep-1","fromItemId":"item-a","toItemId":"item-b","type":"blocks","createdBy":"act-1","createdAt":"2025-12-31T00:00:00Z"}}` + "\n" +
+                `{"eventId":"evt-2","workspaceId":"ws-1","replicaId":"rep-a","entityKind":"dep","entityId":"dep-2","entitySeq":0,"type":"dep.add","issuedAt":"2025-12-31T00:00:01Z","actorId":"act-1","payload":{"id":"dep-2","fromItemId":"item-a","toItemId":"item-c","type":"blocks","createdBy":"act-1","createdAt":"2025-12-31T00:00:01Z"}}` + "\n" +
+                `{"eventId":"evt-3","workspaceId":"ws-1","replicaId":"rep-a","entityKind":"dep","entityId":"dep-1","entitySeq":1,"type":"dep.remove","issuedAt":"2025-12-31T00:00:02Z","actorId":"act-1","payload":{"id":"dep-1","fromItemId":"item-a","toItemId":"item-b","type":"blocks"}}` + "\n" +
+                `{"eventId":"evt-4","workspaceId":"ws-1","replicaId":"rep-a","entityKind":"dep","entityId":"dep-2","entitySeq":1,"type":"dep.update","issuedAt":"2025-12-31T00:00:03Z","actorId":"act-1","payload":{"id":"dep-2","fromItemId":"item-a","toItemId":"item-c","type":"related","from":"blocks"}}` + "\n"
+
+        if err := os.WriteFile(eventsPath, []byte(lines), 0o644); err != nil {
+                t.Fatalf("write events: %v", err)
+        }
+
+        res, err := ReplayEventsV1(dir)
+        if err != nil {
+                t.Fatalf("replay: %v", err)
+        }
+        if len(res.DB.Deps) != 1 || res.DB.Deps[0].ID != "dep-2" {
+                t.Fatalf("expected only dep-2 after dep.remove, got %#v", res.DB.Deps)
+        }
+        if res.DB.Deps[0].Type != "related" {
+                t.Fatalf("expected dep-2 type=related after dep.update, got %#v", res.DB.Deps[0])
+        }
+        if res.SkippedCount != 0 {
+                t.Fatalf("expected no skipped events, got %#v", res.SkippedTypes)
+        }
+}
+
+func TestReplayEventsV1_CommentEditAndRedact(t *testing.T) {
+        dir := t.TempDir()
+        if err := os.MkdirAll(filepath.Join(dir, "events"), 0o755); err != nil {
+                t.Fatalf("mkdir events: %v", err)
+        }
+        eventsPath := filepath.Join(dir, "events", "events.rep-a.jsonl")
+
+        lines := "" +
+                `{"eventId":"evt-1","workspaceId":"ws-1","replicaId":"rep-a","entityKind":"comment","entityId":"cmt-1","entitySeq":0,"type":"comment.add","issuedAt":"2025-12-31T00:00:00Z","actorId":"act-1","payload":{"id":"cmt-1","itemId":"item-1","authorId":"act-1","body":"first","createdAt":"2025-12-31T00:00:00Z"}}` + "\n" +
+                `{"eventId":"evt-2","workspaceId":"ws-1","replicaId":"rep-a","entityKind":"comment","entityId":"cmt-2","entitySeq":0,"type":"comment.add","issuedAt":"2025-12-31T00:00:01Z","actorId":"act-1","payload":{"id":"cmt-2","itemId":"item-1","authorId":"act-1","body":"token=abc","createdAt":"2025-12-31T00:00:01Z"}}` + "\n" +
+                `{"eventId":"evt-3","workspaceId":"ws-1","replicaId":"rep-a","entityKind":"comment","entityId":"cmt-1","entitySeq":1,"type":"comment.edit","issuedAt":"2025-12-31T00:00:02Z","actorId":"act-1","payload":{"id":"cmt-1","itemId":"item-1","body":"second","editedAt":"2025-12-31T00:00:02Z"}}` + "\n" +
+                `{"eventId":"evt-4","workspaceId":"ws-1","replicaId":"rep-a","entityKind":"comment","entityId":"cmt-2","entitySeq":1,"type":"comment.redact","issuedAt":"2025-12-31T00:00:03Z","actorId":"act-1","payload":{"id":"cmt-2","itemId":"item-1","redactedAt":"2025-12-31T00:00:03Z"}}` + "\n" +
+                // Edits and redactions by anyone but the author are ignored.
+                `{"eventId":"evt-5","workspaceId":"ws-1","replicaId":"rep-b","entityKind":"comment","entityId":"cmt-1","entitySeq":2,"type":"comment.edit","issuedAt":"2025-12-31T00:00:04Z","actorId":"act-2","payload":{"id":"cmt-1","itemId":"item-1","body":"forged","editedAt":"2025-12-31T00:00:04Z"}}` + "\n" +
+                `{"eventId":"evt-6","workspaceId":"ws-1","replicaId":"rep-b","entityKind":"comment","entityId":"cmt-1","entitySeq":3,"type":"comment.redact","issuedAt":"2025-12-31T00:00:05Z","actorId":"act-2","payload":{"id":"cmt-1","itemId":"item-1","redactedAt":"2025-12-31T00:00:05Z"}}` + "\n"
+
+        if err := os.WriteFile(eventsPath, []byte(lines), 0o644); err != nil {
+                t.Fatalf("write events: %v", err)
+        }
+
+        res, err := ReplayEventsV1(dir)
+        if err != nil {
+                t.Fatalf("replay: %v", err)
+        }
+        edited, ok := findCommentByID(res.DB, "cmt-1")
+        if !ok || edited.Body != "second" || edited.EditedAt == nil || edited.Redacted {
+                t.Fatalf("expected edited comment, got %#v", edited)
+        }
+        if len(edited.Revisions) != 1 || edited.Revisions[0].Body != "first" {
+                t.Fatalf("expected prior revision, got %#v", edited.Revisions)
+        }
+        redacted, ok := findCommentByID(res.DB, "cmt-2")
+        if !ok || !redacted.Redacted || redacted.Body != "[redacted]" {
+                t.Fatalf("expected redacted comment, got %#v", redacted)
+        }
+        if res.SkippedCount != 0 {
+                t.Fatalf("expected no skipped events, got %#v", res.SkippedTypes)
+        }
+}
+
+func TestReplayEventsV1_FollowAddAndRemove(t *testing.T) {
+        dir := t.TempDir()
+        if err := os.MkdirAll(filepath.Join(dir, "events"), 0o755); err != nil {
+                t.Fatalf("mkdir events: %v", err)
+        }
+        eventsPath := filepath.Join(dir, "events", "events.rep-a.jsonl")
+
+        // fol-3 duplicates fol-1 (e.g. followed concurrently on two replicas) and is dropped.
+        lines := "" +
+                `{"eventId":"evt-1","workspaceId":"ws-1","replicaId":"rep-a","entityKind":"follow","entityId":"fol-1","entitySeq":0,"type":"follow.add","issuedAt":"2025-12-31T00:00:00Z","actorId":"act-1","payload":{"id":"fol-1","actorId":"act-1","targetKind":"outline","targetId":"out-1","createdAt":"2025-12-31T00:00:00Z"}}` + "\n" +
+                `{"eventId":"evt-2","workspaceId":"ws-1","replicaId":"rep-a","entityKind":"follow","entityId":"fol-2","entitySeq":0,"type":"follow.add","issuedAt":"2025-12-31T00:00:01Z","actorId":"act-1","payload":{"id":"fol-2","actorId":"act-1","targetKind":"item","targetId":"item-1","createdAt":"2025-12-31T00:00:01Z"}}` + "\n" +
+                `{"eventId":"evt-3","workspaceId":"ws-1","replicaId":"rep-a","entityKind":"follow","entityId":"fol-3","entitySeq":0,"type":"follow.add","issuedAt":"2025-12-31T00:00:02Z","actorId":"act-1","payload":{"id":"fol-3","actorId":"act-1","targetKind":"outline","targetId":"out-1","createdAt":"2025-12-31T00:00:02Z"}}` + "\n" +
+                `{"eventId":"evt-4","workspaceId":"ws-1","replicaId":"rep-a","entityKind":"follow","entityId":"fol-2","entitySeq":1,"type":"follow.remove","issuedAt":"2025-12-31T00:00:03Z","actorId":"act-1","payload":{"id":"fol-2","actorId":"act-1","targetKind":"item","targetId":"item-1"}}` + "\n"
+
+        if err := os.WriteFile(eventsPath, []byte(lines), 0o644); err != nil {
+                t.Fatalf("write events: %v", err)
+        }
+
+        res, err := ReplayEventsV1(dir)
+        if err != nil {
+                t.Fatalf("replay: %v", err)
+        }
+        if len(res.DB.Follows) != 1 || res.DB.Follows[0].ID != "fol-1" || res.DB.Follows[0].TargetID != "out-1" {
+                t.Fatalf("expected only fol-1 after follow.remove, got %#v", res.DB.Follows)
+        }
+        if res.SkippedCount != 0 {
+                t.Fatalf("expected no skipped events, got %#v", res.SkippedTypes)
+        }
+}
+
+func TestReplayEventsV1_ItemRepeatReopensWithNextDates(t *testing.T) {
+        dir := t.TempDir()
+        if err := os.MkdirAll(filepath.Join(dir, "events"), 0o755); err != nil {
+                t.Fatalf("mkdir events: %v", err)
+        }
+        eventsPath := filepath.Join(dir, "events", "events.rep-a.jsonl")
+
+        lines := "" +
+                `{"eventId":"evt-1","workspaceId":"ws-1","replicaId":"rep-a","entityKind":"item","entityId":"item-1","entitySeq":0,"type":"item.create","issuedAt":"2025-12-31T00:00:00Z","actorId":"act-1","payload":{"id":"item-1","projectId":"proj-1","outlineId":"out-1","rank":"h","title":"Chore","status":"todo","priority":false,"onHold":false,"archived":false,"ownerActorId":"act-1","createdBy":"act-1","createdAt":"2025-12-31T00:00:00Z","updatedAt":"2025-12-31T00:00:00Z"}}` + "\n" +
+                `{"eventId":"evt-2","workspaceId":"ws-1","replicaId":"rep-a","entityKind":"item","entityId":"item-1","entitySeq":1,"type":"item.set_schedule","issuedAt":"2025-12-31T00:00:01Z","actorId":"act-1","payload":{"schedule":{"date":"2026-01-05","repeat":"+1w"}}}` + "\n" +
+                `{"eventId":"evt-3","workspaceId":"ws-1","replicaId":"rep-a","entityKind":"item","entityId":"item-1","entitySeq":2,"type":"item.set_status","issuedAt":"2026-01-05T09:00:00Z","actorId":"act-1","payload":{"from":"todo","to":"done","status":"done"}}` + "\n" +
+                `{"eventId":"evt-4","workspaceId":"ws-1","replicaId":"rep-a","entityKind":"item","entityId":"item-1","entitySeq":3,"type":"item.repeat","issuedAt":"2026-01-05T09:00:00Z","actorId":"act-1","payload":{"from":"done","to":"todo","previousSchedule":{"date":"2026-01-05","repeat":"+1w"},"schedule":{"date":"2026-01-12","repeat":"+1w"}}}` + "\n"
+
+        if err := os.WriteFile(eventsPath, []byte(lines), 0o644); err != nil {
+                t.Fatalf("write events: %v", err)
+        }
+
+        res, err := ReplayEventsV1(dir)
+        if err != nil {
+                t.Fatalf("replay: %v", err)
+        }
+        it, ok := res.DB.FindItem("item-1")
+        if !ok || it == nil {
+                t.Fatalf("expected item-1")
+        }
+        if it.StatusID != "todo" {
+                t.Fatalf("expected item reopened as todo, got %q", it.StatusID)
+        }
+        if it.Schedule == nil || it.Schedule.Date != "2026-01-12" || it.Schedule.Repeat != "+1w" {
+                t.Fatalf("expected schedule rolled to 2026-01-12 (+1w), got %#v", it.Schedule)
+        }
+        if res.SkippedCount != 0 {
+                t.Fatalf("expected no skipped events, got %#v", res.SkippedTypes)
+        }
+}
+
+func TestReplayEventsV1_CausalOrderBeatsSkewedClock(t *testing.T) {
+        dir := t.TempDir()
+        if err := os.MkdirAll(filepath.Join(dir, "events"), 0o755); err != nil {
+                t.Fatalf("mkdir events: %v", err)
+        }
+
+        // rep-a's clock runs an hour ahead. rep-b pulled rep-a's "done" and then reopened the item:
+        // its event names rep-a's as parent, but its issuedAt is earlier.
+        repA := "" +
+                `{"eventId":"evt-1","workspaceId":"ws-1","replicaId":"rep-a","entityKind":"item","entityId":"item-1","entitySeq":1,"type":"item.create","issuedAt":"2025-12-31T11:00:00Z","hlc":"1767178800000:0","actorId":"act-1","payload":{"id":"item-1","projectId":"proj-1","outlineId":"out-1","rank":"h","title":"T","status":"todo","ownerActorId":"act-1","createdBy":"act-1","createdAt":"2025-12-31T11:00:00Z","updatedAt":"2025-12-31T11:00:00Z"}}` + "\n" +
+                `{"eventId":"evt-2","workspaceId":"ws-1","replicaId":"rep-a","entityKind":"item","entityId":"item-1","entitySeq":2,"type":"item.set_status","parents":["evt-1"],"issuedAt":"2025-12-31T11:01:00Z","hlc":"1767178860000:0","actorId":"act-1","payload":{"status":"done"}}` + "\n"
+        repB := "" +
+                // Legacy event without an HLC: parents alone must order it after evt-2.
+                `{"eventId":"evt-0","workspaceId":"ws-1","replicaId":"rep-b","entityKind":"item","entityId":"item-1","entitySeq":3,"type":"item.set_status","parents":["evt-2"],"issuedAt":"2025-12-31T10:05:00Z","actorId":"act-2","payload":{"status":"todo"}}` + "\n" +
+                // Same shard, later line: after evt-0 even with an older timestamp.
+                `{"eventId":"evt-00","workspaceId":"ws-1","replicaId":"rep-b","entityKind":"item","entityId":"item-1","entitySeq":4,"type":"item.set_title","parents":["evt-0"],"issuedAt":"2025-12-31T10:04:00Z","actorId":"act-2","payload":{"title":"Reopened"}}` + "\n"
+        for name, content := range map[string]string{"events.rep-a.jsonl": repA, "events.rep-b.jsonl": repB} {
+                if err := os.WriteFile(filepath.Join(dir, "events", name), []byte(content), 0o644); err != nil {
+                        t.Fatalf("write events: %v", err)
+                }
+        }
+
+        res, err := ReplayEventsV1(dir)
+        if err != nil {
+                t.Fatalf("replay: %v", err)
+        }
+        if len(res.DB.Items) != 1 {
+                t.Fatalf("unexpected items: %#v", res.DB.Items)
+        }
+        if it := res.DB.Items[0]; it.StatusID != "todo" || it.Title != "Reopened" {
+                t.Fatalf("expected rep-b's later edits to win, got status=%q title=%q", it.StatusID, it.Title)
+        }
+}
@@ -0,0 +1,582 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"clarity-cli/internal/model"
+)
+
+// Incremental replay.
+//
+// The derived SQLite state records, per shard (events/events.<replica-id>.jsonl), how far it has
+// been applied (byte offset + the id of the last applied line) and, per entity, the newest applied
+// event. Every Load then only applies the lines appended since (e.g. by `git pull`), and falls back
+// to a full replay when:
+//   - history was rewritten: a shard shrank, disappeared or no longer has the recorded line at the
+//     recorded offset (rebase/force-push/manual edits)
+//   - a new event doesn't extend its entity's chain (it forks it, or sorts before an already applied
+//     event), so applying it on top would not match the replay order or merge semantics; legacy
+//     events without parents only must not sort before an applied one
+//   - the state has no watermark yet (created before incremental replay, or a fresh clone)
+//   - the roles in meta/users.json changed: events may be rejected (or allowed) differently
+//
+// Local mutations (AppendEvent + Save) advance the local replica's shard on Save: the state being
+// saved already reflects those events. Only the lines this process appended count; lines another
+// local process (TUI, `serve --api`, an agent run) appended meanwhile are left for the next Load.
+
+const replayWatermarkMetaKey = "replay_watermark"
+
+// replayAccessMetaKey records the digest of the roles the state was replayed under (access.go).
+const replayAccessMetaKey = "replay_access"
+
+type shardMark struct {
+	Offset        int64
+	Lines         int
+	LastLineStart int64
+	LastEventID   string
+}
+
+// eventKey is an event's position in replay order (see replayLess).
+type eventKey struct {
+	Clock    HLC
+	IssuedAt time.Time
+	EventID  string
+}
+
+func eventKeyOf(ev EventV1) eventKey {
+	return eventKey{Clock: ev.Clock(), IssuedAt: ev.IssuedAt.UTC(), EventID: ev.EventID}
+}
+
+func (k eventKey) before(o eventKey) bool {
+	if c := k.Clock.Compare(o.Clock); c != 0 {
+		return c < 0
+	}
+	if !k.IssuedAt.Equal(o.IssuedAt) {
+		return k.IssuedAt.Before(o.IssuedAt)
+	}
+	return k.EventID < o.EventID
+}
+
+type replayWatermark struct {
+	shards map[string]shardMark
+	heads  map[string]eventKey
+
+	// dirtyHeads are heads changed since the watermark was loaded; allHeadsDirty rewrites them all.
+	dirtyHeads    map[string]bool
+	allHeadsDirty bool
+
+	// access is the digest of the roles events were checked against ("" for none).
+	access string
+}
+
+func newReplayWatermark() *replayWatermark {
+	return &replayWatermark{
+		shards:        map[string]shardMark{},
+		heads:         map[string]eventKey{},
+		dirtyHeads:    map[string]bool{},
+		allHeadsDirty: true,
+	}
+}
+
+// observe records l as applied.
+func (w *replayWatermark) observe(l EventV1Line) {
+	name := filepath.Base(l.Path)
+	if l.End > w.shards[name].Offset {
+		w.shards[name] = shardMark{Offset: l.End, Lines: l.Line, LastLineStart: l.Offset, LastEventID: strings.TrimSpace(l.Event.EventID)}
+	}
+	id := strings.TrimSpace(l.Event.EntityID)
+	if id == "" {
+		return
+	}
+	k := eventKeyOf(l.Event)
+	if cur, ok := w.heads[id]; ok && !cur.before(k) {
+		return
+	}
+	w.heads[id] = k
+	w.dirtyHeads[id] = true
+}
+
+// CatchUpResult describes what Load did to bring the derived state up to date with the event logs.
+type CatchUpResult struct {
+	// Mode is "none", "incremental" or "full".
+	Mode    string `json:"mode"`
+	Reason  string `json:"reason,omitempty"`
+	Applied int    `json:"applied"`
+	// Snapshot is the snapshot a full rebuild started from, if any.
+	Snapshot string `json:"snapshot,omitempty"`
+}
+
+// catchUp applies events appended to the JSONL shards since the state was saved. It returns the
+// (possibly rebuilt) db; callers persist it when Mode != "none".
+func (s Store) catchUp(ctx context.Context, db *DB, access *AccessRules) (*DB, CatchUpResult, error) {
+	none := CatchUpResult{Mode: "none"}
+	if db == nil || s.eventLogBackend() != EventLogBackendJSONL {
+		return db, none, nil
+	}
+	paths, err := s.shardPaths()
+	if err != nil {
+		return nil, none, err
+	}
+
+	w := db.replay
+	if w == nil {
+		hasEvents := false
+		for _, p := range paths {
+			if st, err := os.Stat(p); err == nil && st.Size() > 0 {
+				hasEvents = true
+				break
+			}
+		}
+		if !hasEvents {
+			// Nothing to replay yet: the state is trivially up to date.
+			db.replay = newReplayWatermark()
+			return db, none, nil
+		}
+		return s.rebuildFromEvents(db, "no replay watermark")
+	}
+	if w.access != access.Digest() {
+		return s.rebuildFromEvents(db, "roles in meta/users.json changed")
+	}
+
+	db, res, fullReason, err := s.applyPending(db, access)
+	if err != nil {
+		return nil, none, err
+	}
+	if fullReason != "" {
+		return s.rebuildFromEvents(db, fullReason)
+	}
+	return db, res, nil
+}
+
+// applyPending applies the lines after db's watermark (from the saved state or a snapshot), skipping
+// events access doesn't allow. fullReason is set, with nothing applied, when only a full replay
+// gives the right state.
+func (s Store) applyPending(db *DB, access *AccessRules) (*DB, CatchUpResult, string, error) {
+	none := CatchUpResult{Mode: "none"}
+	w := db.replay
+	paths, err := s.shardPaths()
+	if err != nil {
+		return nil, none, "", err
+	}
+	present := map[string]string{}
+	for _, p := range paths {
+		present[filepath.Base(p)] = p
+	}
+	for name, mark := range w.shards {
+		p, ok := present[name]
+		if !ok {
+			if mark.Offset == 0 {
+				continue
+			}
+			return db, none, "history rewritten: " + name + " removed", nil
+		}
+		if ok, err := shardMarkStillValid(p, mark); err != nil {
+			return nil, none, "", err
+		} else if !ok {
+			return db, none, "history rewritten: " + name + " changed before the applied offset", nil
+		}
+	}
+
+	var pending []EventV1Line
+	for _, p := range paths {
+		mark := w.shards[filepath.Base(p)]
+		_, err := scanShardLines(p, mark.Offset, mark.Lines, true, func(l EventV1Line) error {
+			pending = append(pending, l)
+			return nil
+		})
+		if err != nil {
+			return nil, none, "", err
+		}
+	}
+	if len(pending) == 0 {
+		return db, none, "", nil
+	}
+
+	sortEventV1Lines(pending)
+	// Each new event must extend its entity's chain; anything else (a fork, or an event sorting
+	// before what was applied) needs the full replay's ordering and merge semantics.
+	tips := map[string]string{}
+	for _, l := range pending {
+		id := strings.TrimSpace(l.Event.EntityID)
+		h, ok := w.heads[id]
+		if !ok {
+			continue
+		}
+		tip, ok := tips[id]
+		if !ok {
+			tip = h.EventID
+		}
+		if len(l.Event.Parents) == 0 {
+			// Legacy event (written before parents were recorded): there is no chain to extend, so,
+			// as before, it only must not sort before what was applied.
+			if eventKeyOf(l.Event).before(h) {
+				return db, none, "out-of-order event for " + id, nil
+			}
+		} else if !hasParent(l.Event, tip) {
+			if eventKeyOf(l.Event).before(h) {
+				return db, none, "out-of-order event for " + id, nil
+			}
+			return db, none, "concurrent edits to " + id, nil
+		}
+		tips[id] = strings.TrimSpace(l.Event.EventID)
+	}
+
+	applied := 0
+	for _, l := range pending {
+		if access.checkEvent(db, l.Event) != "" {
+			// Doctor reports it (event_unauthorized).
+			w.observe(l)
+			continue
+		}
+		ok, err := applyEventV1(db, l.Event)
+		if err != nil {
+			return nil, none, "", fmt.Errorf("%s:%d: %w", l.Path, l.Line, err)
+		}
+		if ok {
+			applied++
+		}
+		w.observe(l)
+	}
+	db.idxBuilt = false
+	return db, CatchUpResult{Mode: "incremental", Applied: applied}, "", nil
+}
+
+// rebuildFromEvents rebuilds from the latest snapshot (or replays all shards), carrying forward
+// local-only meta from prev.
+func (s Store) rebuildFromEvents(prev *DB, reason string) (*DB, CatchUpResult, error) {
+	res, err := s.replayFromLatestSnapshot()
+	if err != nil {
+		return nil, CatchUpResult{Mode: "none"}, err
+	}
+	carryLocalMeta(prev, res.DB)
+	return res.DB, CatchUpResult{Mode: "full", Reason: reason, Applied: res.AppliedCount, Snapshot: res.Snapshot}, nil
+}
+
+// carryLocalMeta preserves local UI/session meta (current actor/project) across a rebuild: it is
+// not part of the canonical event stream.
+func carryLocalMeta(prev *DB, next *DB) {
+	if next == nil {
+		return
+	}
+	if prev != nil {
+		if id := strings.TrimSpace(prev.CurrentActorID); id != "" {
+			if _, ok := next.FindActor(id); ok {
+				next.CurrentActorID = id
+			}
+		}
+		if id := strings.TrimSpace(prev.CurrentProjectID); id != "" {
+			if _, ok := next.FindProject(id); ok {
+				next.CurrentProjectID = id
+			}
+		}
+	}
+	if strings.TrimSpace(next.CurrentActorID) == "" {
+		// First-time bootstrap convenience: if there's exactly one human actor (common for
+		// personal workspaces and migrations), pick it.
+		pick := ""
+		for _, a := range next.Actors {
+			if a.Kind == model.ActorKindHuman && strings.TrimSpace(a.ID) != "" {
+				pick = strings.TrimSpace(a.ID)
+				break
+			}
+		}
+		if pick == "" && len(next.Actors) > 0 {
+			pick = strings.TrimSpace(next.Actors[0].ID)
+		}
+		next.CurrentActorID = pick
+	}
+}
+
+// Reindex rebuilds the derived state from the latest snapshot plus the events after it (or from
+// all JSONL shards) and saves it.
+func (s Store) Reindex() (ReplayResult, error) {
+	return s.reindex(true)
+}
+
+// ReindexFull is Reindex ignoring snapshots: a full replay of every shard.
+func (s Store) ReindexFull() (ReplayResult, error) {
+	return s.reindex(false)
+}
+
+func (s Store) reindex(fromSnapshot bool) (ReplayResult, error) {
+	var prev *DB
+	if existing, err := s.LoadSQLite(context.Background()); err == nil {
+		prev = existing
+	}
+	replay := func() (ReplayResult, error) { return ReplayEventsV1(s.Dir) }
+	if fromSnapshot {
+		replay = s.replayFromLatestSnapshot
+	}
+	res, err := replay()
+	if err != nil {
+		return ReplayResult{}, err
+	}
+	carryLocalMeta(prev, res.DB)
+	if err := s.Save(res.DB); err != nil {
+		return ReplayResult{}, err
+	}
+	return res, nil
+}
+
+func shardMarkStillValid(path string, mark shardMark) (bool, error) {
+	if mark.Offset == 0 {
+		return true, nil
+	}
+	st, err := os.Stat(path)
+	if err != nil {
+		return false, err
+	}
+	if st.Size() < mark.Offset || mark.LastLineStart >= mark.Offset {
+		return false, nil
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return false, err
+	}
+	defer f.Close()
+	buf := make([]byte, mark.Offset-mark.LastLineStart)
+	if _, err := f.ReadAt(buf, mark.LastLineStart); err != nil {
+		return false, err
+	}
+	var ev struct {
+		EventID string `json:"eventId"`
+	}
+	if err := json.Unmarshal(buf, &ev); err != nil {
+		return false, nil
+	}
+	return strings.TrimSpace(ev.EventID) == mark.LastEventID, nil
+}
+
+func (s Store) shardPaths() ([]string, error) {
+	entries, err := os.ReadDir(s.eventsDir())
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var paths []string
+	for _, ent := range entries {
+		name := ent.Name()
+		if ent.IsDir() || !strings.HasPrefix(name, "events") || !strings.HasSuffix(name, ".jsonl") {
+			continue
+		}
+		paths = append(paths, filepath.Join(s.eventsDir(), name))
+	}
+	sort.Strings(paths)
+	return paths, nil
+}
+
+// localAppends records the byte ranges of the shards this process appended (start offset -> end).
+var localAppends = struct {
+	mu     sync.Mutex
+	ranges map[string]map[int64]int64
+}{ranges: map[string]map[int64]int64{}}
+
+func recordLocalAppend(path string, start, end int64) {
+	localAppends.mu.Lock()
+	defer localAppends.mu.Unlock()
+	if localAppends.ranges[path] == nil {
+		localAppends.ranges[path] = map[int64]int64{}
+	}
+	localAppends.ranges[path][start] = end
+}
+
+// appendedLocally returns the end of the range this process appended to path starting at offset.
+func appendedLocally(path string, offset int64) (int64, bool) {
+	localAppends.mu.Lock()
+	defer localAppends.mu.Unlock()
+	end, ok := localAppends.ranges[path][offset]
+	return end, ok
+}
+
+var errNotAppendedLocally = errors.New("line not appended by this process")
+
+// advanceLocalShard marks the lines this process appended to the local replica's shard as applied:
+// Save persists state that already includes them. It stops at the first line it didn't append.
+func (s Store) advanceLocalShard(w *replayWatermark) error {
+	if w == nil || s.eventLogBackend() != EventLogBackendJSONL {
+		return nil
+	}
+	b, err := os.ReadFile(s.devicePath())
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+	var d DeviceFile
+	if err := json.Unmarshal(b, &d); err != nil || strings.TrimSpace(d.ReplicaID) == "" {
+		return nil
+	}
+	path := s.shardPath(d.ReplicaID)
+	mark := w.shards[filepath.Base(path)]
+	if ok, err := shardMarkStillValid(path, mark); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return err
+	} else if !ok {
+		// Leave it: the next Load sees the rewrite and rebuilds.
+		return nil
+	}
+	var rangeEnd int64 = -1
+	_, err = scanShardLines(path, mark.Offset, mark.Lines, true, func(l EventV1Line) error {
+		if l.Offset >= rangeEnd {
+			end, ok := appendedLocally(path, l.Offset)
+			if !ok {
+				return errNotAppendedLocally
+			}
+			rangeEnd = end
+		}
+		if l.End > rangeEnd {
+			return errNotAppendedLocally
+		}
+		w.observe(l)
+		return nil
+	})
+	if errors.Is(err, os.ErrNotExist) || errors.Is(err, errNotAppendedLocally) {
+		return nil
+	}
+	return err
+}
+
+// dropOutdatedReplayWatermark drops a watermark written before heads carried HLCs; the next Load
+// does a full replay and writes a new one.
+func dropOutdatedReplayWatermark(ctx context.Context, db *sql.DB) error {
+	var tables, hlcCols int
+	if err := db.QueryRowContext(ctx, `SELECT COUNT(1) FROM sqlite_master WHERE type = 'table' AND name = 'replay_heads'`).Scan(&tables); err != nil || tables == 0 {
+		return err
+	}
+	if err := db.QueryRowContext(ctx, `SELECT COUNT(1) FROM pragma_table_info('replay_heads') WHERE name = 'hlc_wall_ms'`).Scan(&hlcCols); err != nil || hlcCols > 0 {
+		return err
+	}
+	for _, st := range []string{
+		`DROP TABLE replay_heads`,
+		`DELETE FROM replay_shards`,
+		`DELETE FROM state_meta WHERE k = '` + replayWatermarkMetaKey + `'`,
+	} {
+		if _, err := db.ExecContext(ctx, st); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type sqlQueryer interface {
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// loadReplayWatermark returns nil when the state has no watermark yet.
+func loadReplayWatermark(ctx context.Context, q sqlQueryer) (*replayWatermark, error) {
+	var v string
+	if err := q.QueryRowContext(ctx, `SELECT v FROM state_meta WHERE k = ?`, replayWatermarkMetaKey).Scan(&v); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	w := newReplayWatermark()
+	w.allHeadsDirty = false
+
+	rows, err := q.QueryContext(ctx, `SELECT shard, offset_bytes, lines, last_line_start, last_event_id FROM replay_shards`)
+	if err != nil {
+		return nil, err
+	}
+	for rows.Next() {
+		var name string
+		var m shardMark
+		if err := rows.Scan(&name, &m.Offset, &m.Lines, &m.LastLineStart, &m.LastEventID); err != nil {
+			_ = rows.Close()
+			return nil, err
+		}
+		w.shards[name] = m
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+
+	rows, err = q.QueryContext(ctx, `SELECT entity_id, hlc_wall_ms, hlc_logical, issued_at_unixnano, event_id FROM replay_heads`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var id, eventID string
+		var k eventKey
+		var ns int64
+		if err := rows.Scan(&id, &k.Clock.WallMs, &k.Clock.Logical, &ns, &eventID); err != nil {
+			return nil, err
+		}
+		k.IssuedAt = time.Unix(0, ns).UTC()
+		k.EventID = eventID
+		w.heads[id] = k
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if err := q.QueryRowContext(ctx, `SELECT v FROM state_meta WHERE k = ?`, replayAccessMetaKey).Scan(&w.access); err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+	return w, nil
+}
+
+func saveReplayWatermark(ctx context.Context, tx *sql.Tx, w *replayWatermark) error {
+	if _, err := tx.ExecContext(ctx, `INSERT OR REPLACE INTO state_meta(k, v) VALUES(?, ?)`, replayWatermarkMetaKey, "v1"); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, `INSERT OR REPLACE INTO state_meta(k, v) VALUES(?, ?)`, replayAccessMetaKey, w.access); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM replay_shards`); err != nil {
+		return err
+	}
+	for name, m := range w.shards {
+		if _, err := tx.ExecContext(ctx, `INSERT INTO replay_shards(shard, offset_bytes, lines, last_line_start, last_event_id) VALUES(?, ?, ?, ?, ?)`,
+			name, m.Offset, m.Lines, m.LastLineStart, m.LastEventID); err != nil {
+			return err
+		}
+	}
+
+	ids := w.dirtyHeads
+	if w.allHeadsDirty {
+		if _, err := tx.ExecContext(ctx, `DELETE FROM replay_heads`); err != nil {
+			return err
+		}
+		ids = make(map[string]bool, len(w.heads))
+		for id := range w.heads {
+			ids[id] = true
+		}
+	}
+	for id := range ids {
+		k, ok := w.heads[id]
+		if !ok {
+			continue
+		}
+		if _, err := tx.ExecContext(ctx, `INSERT OR REPLACE INTO replay_heads(entity_id, hlc_wall_ms, hlc_logical, issued_at_unixnano, event_id) VALUES(?, ?, ?, ?, ?)`,
+			id, k.Clock.WallMs, k.Clock.Logical, k.IssuedAt.UnixNano(), k.EventID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func hasParent(ev EventV1, id string) bool {
+	for _, p := range ev.Parents {
+		if strings.TrimSpace(p) == id {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,298 @@
+package store
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"clarity-cli/internal/model"
+)
+
+const watermarkBaseEvents = "" +
+	`{"eventId":"evt-1","workspaceId":"ws-1","replicaId":"rep-a","entityKind":"actor","entityId":"act-1","entitySeq":0,"type":"identity.create","issuedAt":"2025-12-31T00:00:00Z","actorId":"act-1","payload":{"name":"A","kind":"human"}}` + "\n" +
+	`{"eventId":"evt-2","workspaceId":"ws-1","replicaId":"rep-a","entityKind":"project","entityId":"proj-1","entitySeq":0,"type":"project.create","issuedAt":"2025-12-31T00:00:01Z","actorId":"act-1","payload":{"id":"proj-1","name":"P","createdBy":"act-1","createdAt":"2025-12-31T00:00:01Z","archived":false}}` + "\n" +
+	`{"eventId":"evt-3","workspaceId":"ws-1","replicaId":"rep-a","entityKind":"outline","entityId":"out-1","entitySeq":0,"type":"outline.create","issuedAt":"2025-12-31T00:00:02Z","actorId":"act-1","payload":{"id":"out-1","projectId":"proj-1","statusDefs":[{"id":"todo","label":"Todo","isEndState":false},{"id":"done","label":"Done","isEndState":true}],"createdBy":"act-1","createdAt":"2025-12-31T00:00:02Z","archived":false}}` + "\n" +
+	`{"eventId":"evt-4","workspaceId":"ws-1","replicaId":"rep-a","entityKind":"item","entityId":"item-1","entitySeq":0,"type":"item.create","issuedAt":"2025-12-31T00:00:03Z","actorId":"act-1","payload":{"id":"item-1","projectId":"proj-1","outlineId":"out-1","rank":"h","title":"T","status":"todo","priority":false,"onHold":false,"archived":false,"ownerActorId":"act-1","createdBy":"act-1","createdAt":"2025-12-31T00:00:03Z","updatedAt":"2025-12-31T00:00:03Z"}}` + "\n" +
+	`{"eventId":"evt-5","workspaceId":"ws-1","replicaId":"rep-a","entityKind":"item","entityId":"item-1","entitySeq":0,"type":"item.set_title","issuedAt":"2025-12-31T00:00:05Z","actorId":"act-1","payload":{"title":"Base"}}` + "\n"
+
+func setTitleLine(eventID, parentID, replicaID, issuedAt, title string) string {
+	return `{"eventId":"` + eventID + `","workspaceId":"ws-1","replicaId":"` + replicaID + `","entityKind":"item","entityId":"item-1","entitySeq":0,"type":"item.set_title","parents":["` + parentID + `"],"issuedAt":"` + issuedAt + `","actorId":"act-1","payload":{"title":"` + title + `"}}` + "\n"
+}
+
+// newWatermarkWorkspace replays the base events into saved state, like a workspace after `clarity reindex`.
+func newWatermarkWorkspace(t *testing.T) Store {
+	t.Helper()
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "events"), 0o755); err != nil {
+		t.Fatalf("mkdir events: %v", err)
+	}
+	writeShard(t, dir, "rep-a", watermarkBaseEvents)
+	s := Store{Dir: dir}
+	if _, err := s.Reindex(); err != nil {
+		t.Fatalf("Reindex: %v", err)
+	}
+	return s
+}
+
+func writeShard(t *testing.T, dir, replicaID, content string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(dir, "events", "events."+replicaID+".jsonl"), []byte(content), 0o644); err != nil {
+		t.Fatalf("write shard: %v", err)
+	}
+}
+
+func appendShard(t *testing.T, dir, replicaID, content string) {
+	t.Helper()
+	f, err := os.OpenFile(filepath.Join(dir, "events", "events."+replicaID+".jsonl"), os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		t.Fatalf("open shard: %v", err)
+	}
+	defer f.Close()
+	if _, err := f.WriteString(content); err != nil {
+		t.Fatalf("append shard: %v", err)
+	}
+}
+
+func catchUpOnce(t *testing.T, s Store) (*DB, CatchUpResult) {
+	t.Helper()
+	ctx := context.Background()
+	db, err := s.LoadSQLite(ctx)
+	if err != nil {
+		t.Fatalf("LoadSQLite: %v", err)
+	}
+	access, err := s.loadAccessRules()
+	if err != nil {
+		t.Fatalf("loadAccessRules: %v", err)
+	}
+	db, res, err := s.catchUp(ctx, db, access)
+	if err != nil {
+		t.Fatalf("catchUp: %v", err)
+	}
+	if res.Mode != "none" {
+		if err := s.SaveSQLite(ctx, db); err != nil {
+			t.Fatalf("SaveSQLite: %v", err)
+		}
+	}
+	return db, res
+}
+
+func itemTitle(t *testing.T, db *DB) string {
+	t.Helper()
+	it, ok := db.FindItem("item-1")
+	if !ok {
+		t.Fatalf("item-1 missing")
+	}
+	return it.Title
+}
+
+func TestCatchUp_AppliesPulledShardIncrementally(t *testing.T) {
+	s := newWatermarkWorkspace(t)
+
+	if _, res := catchUpOnce(t, s); res.Mode != "none" {
+		t.Fatalf("expected nothing to catch up after reindex, got %+v", res)
+	}
+
+	// A teammate's shard arrives via git pull, and the local one grows.
+	writeShard(t, s.Dir, "rep-b", setTitleLine("evt-b1", "evt-5", "rep-b", "2025-12-31T00:00:06Z", "From B"))
+	db, res := catchUpOnce(t, s)
+	if res.Mode != "incremental" || res.Applied != 1 {
+		t.Fatalf("expected incremental apply of 1 event, got %+v", res)
+	}
+	if got := itemTitle(t, db); got != "From B" {
+		t.Fatalf("title = %q, want From B", got)
+	}
+
+	appendShard(t, s.Dir, "rep-b", setTitleLine("evt-b2", "evt-b1", "rep-b", "2025-12-31T00:00:07Z", "From B again"))
+	appendShard(t, s.Dir, "rep-a", setTitleLine("evt-a6", "evt-b2", "rep-a", "2025-12-31T00:00:08Z", "From A"))
+	db, res = catchUpOnce(t, s)
+	if res.Mode != "incremental" || res.Applied != 2 {
+		t.Fatalf("expected incremental apply of 2 events, got %+v", res)
+	}
+	if got := itemTitle(t, db); got != "From A" {
+		t.Fatalf("title = %q, want From A", got)
+	}
+
+	// A partially written line is left for the next load.
+	appendShard(t, s.Dir, "rep-b", strings.TrimSuffix(setTitleLine("evt-b3", "evt-a6", "rep-b", "2025-12-31T00:00:09Z", "Partial"), "\n"))
+	if _, res := catchUpOnce(t, s); res.Mode != "none" {
+		t.Fatalf("expected partial line to be skipped, got %+v", res)
+	}
+	appendShard(t, s.Dir, "rep-b", "\n")
+	db, res = catchUpOnce(t, s)
+	if res.Mode != "incremental" || itemTitle(t, db) != "Partial" {
+		t.Fatalf("expected completed line to apply, got %+v title=%q", res, itemTitle(t, db))
+	}
+
+	full, err := ReplayEventsV1(s.Dir)
+	if err != nil {
+		t.Fatalf("ReplayEventsV1: %v", err)
+	}
+	if got, want := itemTitle(t, db), itemTitle(t, full.DB); got != want {
+		t.Fatalf("incremental title %q != full replay title %q", got, want)
+	}
+}
+
+func TestCatchUp_AppliesLegacyEventsWithoutParentsIncrementally(t *testing.T) {
+	s := newWatermarkWorkspace(t)
+
+	// Written by a client that predates parents: nothing to chain, but it sorts after the head.
+	legacy := strings.Replace(setTitleLine("evt-b1", "", "rep-b", "2025-12-31T00:00:06Z", "Legacy"), `"parents":[""],`, "", 1)
+	writeShard(t, s.Dir, "rep-b", legacy)
+	appendShard(t, s.Dir, "rep-a", setTitleLine("evt-a6", "evt-b1", "rep-a", "2025-12-31T00:00:07Z", "On top"))
+	db, res := catchUpOnce(t, s)
+	if res.Mode != "incremental" || res.Applied != 2 {
+		t.Fatalf("expected incremental apply of 2 events, got %+v", res)
+	}
+	if got := itemTitle(t, db); got != "On top" {
+		t.Fatalf("title = %q, want On top", got)
+	}
+
+	// One sorting before the head still needs the full replay.
+	older := strings.Replace(setTitleLine("evt-c1", "", "rep-c", "2025-12-31T00:00:04Z", "Older"), `"parents":[""],`, "", 1)
+	writeShard(t, s.Dir, "rep-c", older)
+	if _, res := catchUpOnce(t, s); res.Mode != "full" || !strings.Contains(res.Reason, "out-of-order") {
+		t.Fatalf("expected full replay for an older legacy event, got %+v", res)
+	}
+}
+
+func TestCatchUp_LocalAppendIsNotReapplied(t *testing.T) {
+	dir := t.TempDir()
+	if _, err := EnsureGitBackedV1Layout(dir); err != nil {
+		t.Fatalf("EnsureGitBackedV1Layout: %v", err)
+	}
+	s := Store{Dir: dir}
+	db, err := s.Load()
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if err := s.AppendEvent("act-1", "project.create", "proj-1", map[string]any{"id": "proj-1", "name": "P", "createdBy": "act-1"}); err != nil {
+		t.Fatalf("AppendEvent: %v", err)
+	}
+	db.Projects = append(db.Projects, model.Project{ID: "proj-1", Name: "P", CreatedBy: "act-1"})
+	if err := s.Save(db); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	if _, res := catchUpOnce(t, s); res.Mode != "none" {
+		t.Fatalf("expected local events to be marked applied on save, got %+v", res)
+	}
+}
+
+func TestCatchUp_OtherLocalProcessAppendIsApplied(t *testing.T) {
+	dir := t.TempDir()
+	res, err := EnsureGitBackedV1Layout(dir)
+	if err != nil {
+		t.Fatalf("EnsureGitBackedV1Layout: %v", err)
+	}
+	s := Store{Dir: dir}
+	db, err := s.Load()
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+
+	// Another process on this machine (say the TUI) appends to the same shard after our Load.
+	other := Store{Dir: dir}
+	if err := other.AppendEvent("act-1", "project.create", "proj-2", map[string]any{"id": "proj-2", "name": "Q", "createdBy": "act-1"}); err != nil {
+		t.Fatalf("AppendEvent: %v", err)
+	}
+	localAppends.mu.Lock()
+	delete(localAppends.ranges, res.ShardPath)
+	localAppends.mu.Unlock()
+
+	if err := s.AppendEvent("act-1", "project.create", "proj-1", map[string]any{"id": "proj-1", "name": "P", "createdBy": "act-1"}); err != nil {
+		t.Fatalf("AppendEvent: %v", err)
+	}
+	db.Projects = append(db.Projects, model.Project{ID: "proj-1", Name: "P", CreatedBy: "act-1"})
+	if err := s.Save(db); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	db, cu := catchUpOnce(t, s)
+	if cu.Mode != "incremental" || cu.Applied != 2 {
+		t.Fatalf("expected the other process's event (and ours after it) to be applied, got %+v", cu)
+	}
+	if _, ok := db.FindProject("proj-2"); !ok || len(db.Projects) != 2 {
+		t.Fatalf("expected proj-1 and proj-2, got %+v", db.Projects)
+	}
+}
+
+func TestCatchUp_FallsBackToFullReplay(t *testing.T) {
+	t.Run("history rewritten", func(t *testing.T) {
+		s := newWatermarkWorkspace(t)
+		rewritten := strings.Replace(watermarkBaseEvents, `"evt-5"`, `"evt-5x"`, 1)
+		rewritten = strings.Replace(rewritten, `"title":"Base"`, `"title":"Rewritten"`, 1)
+		writeShard(t, s.Dir, "rep-a", rewritten+setTitleLine("evt-a6", "evt-5x", "rep-a", "2025-12-31T00:00:06Z", "Rewritten 2"))
+
+		db, res := catchUpOnce(t, s)
+		if res.Mode != "full" || !strings.Contains(res.Reason, "history rewritten") {
+			t.Fatalf("expected full replay for rewritten history, got %+v", res)
+		}
+		if got := itemTitle(t, db); got != "Rewritten 2" {
+			t.Fatalf("title = %q", got)
+		}
+	})
+
+	t.Run("shard truncated", func(t *testing.T) {
+		s := newWatermarkWorkspace(t)
+		lines := strings.SplitAfter(watermarkBaseEvents, "\n")
+		writeShard(t, s.Dir, "rep-a", strings.Join(lines[:4], ""))
+
+		db, res := catchUpOnce(t, s)
+		if res.Mode != "full" {
+			t.Fatalf("expected full replay for truncated shard, got %+v", res)
+		}
+		if got := itemTitle(t, db); got != "T" {
+			t.Fatalf("title = %q, want T", got)
+		}
+	})
+
+	t.Run("out of order", func(t *testing.T) {
+		s := newWatermarkWorkspace(t)
+		// Issued before evt-5 (already applied): replay order puts it first, so "Base" must win.
+		writeShard(t, s.Dir, "rep-b", setTitleLine("evt-b1", "evt-4", "rep-b", "2025-12-31T00:00:04Z", "Older"))
+
+		db, res := catchUpOnce(t, s)
+		if res.Mode != "full" || !strings.Contains(res.Reason, "out-of-order") {
+			t.Fatalf("expected full replay for out-of-order event, got %+v", res)
+		}
+		if got := itemTitle(t, db); got != "Base" {
+			t.Fatalf("title = %q, want Base", got)
+		}
+	})
+
+	t.Run("concurrent edit", func(t *testing.T) {
+		s := newWatermarkWorkspace(t)
+		// Edited on top of evt-4, concurrently with evt-5: later clock wins the title.
+		writeShard(t, s.Dir, "rep-b", setTitleLine("evt-b1", "evt-4", "rep-b", "2025-12-31T00:00:06Z", "Concurrent"))
+
+		db, res := catchUpOnce(t, s)
+		if res.Mode != "full" || !strings.Contains(res.Reason, "concurrent") {
+			t.Fatalf("expected full replay for a concurrent edit, got %+v", res)
+		}
+		if got := itemTitle(t, db); got != "Concurrent" {
+			t.Fatalf("title = %q, want Concurrent", got)
+		}
+	})
+
+	t.Run("no watermark", func(t *testing.T) {
+		s := newWatermarkWorkspace(t)
+		ctx := context.Background()
+		sqldb, err := s.openSQLite(ctx)
+		if err != nil {
+			t.Fatalf("open sqlite: %v", err)
+		}
+		if _, err := sqldb.ExecContext(ctx, `DELETE FROM state_meta WHERE k = ?`, replayWatermarkMetaKey); err != nil {
+			t.Fatalf("delete watermark: %v", err)
+		}
+		_ = sqldb.Close()
+
+		if _, res := catchUpOnce(t, s); res.Mode != "full" {
+			t.Fatalf("expected full replay without a watermark, got %+v", res)
+		}
+		if _, res := catchUpOnce(t, s); res.Mode != "none" {
+			t.Fatalf("expected the rebuilt state to carry a watermark, got %+v", res)
+		}
+	})
+}
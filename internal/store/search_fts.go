@@ -0,0 +1,137 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"unicode"
+
+	"clarity-cli/internal/model"
+)
+
+// searchIndexVersion is recorded in state_meta once SaveSQLite has populated search_fts.
+// Workspaces saved by older binaries have an empty index, so callers must fall back to a full scan.
+const searchIndexVersion = "1"
+
+// writeSearchIndex rebuilds the FTS5 full-text index (search_fts) inside the SaveSQLite transaction.
+//
+// Rows hold every searchable text (including all worklog); visibility is applied by the caller,
+// since the index is only used to narrow down candidate items.
+func writeSearchIndex(ctx context.Context, tx *sql.Tx, st *DB) error {
+	if _, err := tx.ExecContext(ctx, `DELETE FROM search_fts`); err != nil {
+		return err
+	}
+	ins, err := tx.PrepareContext(ctx, `INSERT INTO search_fts(item_id, field, source_id, body) VALUES(?, ?, ?, ?)`)
+	if err != nil {
+		return err
+	}
+	defer ins.Close()
+
+	add := func(itemID, field, sourceID, body string) error {
+		if strings.TrimSpace(itemID) == "" || strings.TrimSpace(body) == "" {
+			return nil
+		}
+		_, err := ins.ExecContext(ctx, itemID, field, sourceID, body)
+		return err
+	}
+
+	commentItem := map[string]string{}
+	for _, c := range st.Comments {
+		commentItem[c.ID] = c.ItemID
+		if err := add(c.ItemID, "comment", c.ID, c.Body); err != nil {
+			return err
+		}
+	}
+	for _, it := range st.Items {
+		if err := add(it.ID, "title", "", it.Title); err != nil {
+			return err
+		}
+		if err := add(it.ID, "tags", "", strings.Join(it.Tags, " ")); err != nil {
+			return err
+		}
+		if err := add(it.ID, "description", "", it.Description); err != nil {
+			return err
+		}
+	}
+	for _, w := range st.Worklog {
+		if err := add(w.ItemID, "worklog", w.ID, w.Body); err != nil {
+			return err
+		}
+	}
+	for _, a := range st.Attachments {
+		itemID := strings.TrimSpace(a.EntityID)
+		if strings.TrimSpace(a.EntityKind) == "comment" {
+			itemID = commentItem[itemID]
+		}
+		if err := add(itemID, "attachment", a.ID, attachmentSearchText(a)); err != nil {
+			return err
+		}
+	}
+
+	_, err = tx.ExecContext(ctx, `INSERT OR REPLACE INTO state_meta(k, v) VALUES(?, ?)`, "search_index_version", searchIndexVersion)
+	return err
+}
+
+func attachmentSearchText(a model.Attachment) string {
+	return strings.TrimSpace(a.Title + " " + a.OriginalName + " " + a.Text)
+}
+
+// SearchCandidates returns the ids of items whose indexed text contains every term as a
+// word prefix (FTS5 `"term"*`). It returns nil (meaning: scan everything) when there is
+// nothing to narrow on or the index hasn't been built yet.
+func (s Store) SearchCandidates(ctx context.Context, terms []string) (map[string]bool, error) {
+	db, err := s.openSQLite(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+
+	if err := migrateSQLiteState(ctx, db); err != nil {
+		return nil, err
+	}
+	var v string
+	_ = db.QueryRowContext(ctx, `SELECT v FROM state_meta WHERE k = ?`, "search_index_version").Scan(&v)
+	if strings.TrimSpace(v) != searchIndexVersion {
+		return nil, nil
+	}
+
+	var out map[string]bool
+	for _, term := range terms {
+		if !strings.ContainsFunc(term, func(r rune) bool { return unicode.IsLetter(r) || unicode.IsDigit(r) }) {
+			// Nothing FTS can tokenize (e.g. "--"); let the in-memory scan decide.
+			continue
+		}
+		ids, err := searchFTSTerm(ctx, db, term)
+		if err != nil {
+			return nil, err
+		}
+		if out == nil {
+			out = ids
+			continue
+		}
+		for id := range out {
+			if !ids[id] {
+				delete(out, id)
+			}
+		}
+	}
+	return out, nil
+}
+
+func searchFTSTerm(ctx context.Context, db *sql.DB, term string) (map[string]bool, error) {
+	match := `"` + strings.ReplaceAll(term, `"`, `""`) + `"*`
+	rows, err := db.QueryContext(ctx, `SELECT DISTINCT item_id FROM search_fts WHERE search_fts MATCH ?`, match)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	out := map[string]bool{}
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		out[id] = true
+	}
+	return out, rows.Err()
+}
@@ -0,0 +1,651 @@
+package store
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"clarity-cli/internal/model"
+)
+
+// Signed events.
+//
+// An actor can have Ed25519 keys. The private key stays on the machine that uses it
+// (.clarity/keys/<actor-id>.json, gitignored); the public key is published by an identity.key_add
+// event (entity: the actor), which replay records in model.Actor.Keys. Every event an actor with a
+// local key appends carries keyId and sig, an Ed25519 signature over its canonical bytes
+// (eventSignedBytes), so someone with write access to the repo can no longer author events "as" a
+// teammate without it showing.
+//
+// Who may add a key for actor A:
+//   - A, signed with one of its keys; for A's first key, signed with the new key itself (trust on
+//     first use) unless A is an agent whose human already has keys
+//   - A's human (for agents), signed with one of the human's keys
+//
+// Replay ignores key_add events that break these rules. Signatures don't change what replay
+// applies (older logs are unsigned); `clarity doctor` reports forged and unsigned events, and the
+// TUI marks them in item history. Keys can't be revoked yet.
+//
+// Trust on first use can be raced: anyone with write access can publish a first key for A dated
+// before A's own. So when more than one key claims to be A's first, none is trusted over the
+// other: doctor reports the conflict (actor_key_conflict) and events signed with those keys, or
+// with keys they vouched for, are SignatureContested rather than verified or invalid.
+
+const (
+	eventSigContext    = "clarity-event-v1\n"
+	snapshotSigContext = "clarity-snapshot-v1\n"
+)
+
+var (
+	ErrSigningUnsupported = errors.New("event signing needs a Git-backed (JSONL) workspace")
+	ErrNoLocalKey         = errors.New("no local signing key")
+)
+
+// SignatureStatus is the outcome of checking an event's signature.
+type SignatureStatus string
+
+const (
+	SignatureVerified SignatureStatus = "verified"
+	// SignatureUnsigned: the event is unsigned and its actor had no key yet.
+	SignatureUnsigned SignatureStatus = "unsigned"
+	// SignatureMissing: the event is unsigned but its actor had keys.
+	SignatureMissing SignatureStatus = "missing"
+	// SignatureInvalid: the signature doesn't verify with a key of the actor (forged or edited).
+	SignatureInvalid SignatureStatus = "invalid"
+	// SignatureContested: the signature verifies, but with a key that traces back to one of an
+	// actor's competing first keys.
+	SignatureContested SignatureStatus = "contested"
+)
+
+type EventSignature struct {
+	Status SignatureStatus
+	KeyID  string
+	Reason string
+}
+
+// LocalKey is an actor's private key, as stored in .clarity/keys/<actor-id>.json.
+type LocalKey struct {
+	ActorID   string    `json:"actorId"`
+	KeyID     string    `json:"keyId"`
+	PublicKey string    `json:"publicKey"`
+	Seed      string    `json:"seed"` // base64 Ed25519 seed
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// NewLocalKey generates a key for actorID (not saved).
+func NewLocalKey(actorID string) (LocalKey, error) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return LocalKey{}, err
+	}
+	return LocalKey{
+		ActorID:   strings.TrimSpace(actorID),
+		KeyID:     keyIDFor(pub),
+		PublicKey: base64.StdEncoding.EncodeToString(pub),
+		Seed:      base64.StdEncoding.EncodeToString(priv.Seed()),
+		CreatedAt: time.Now().UTC(),
+	}, nil
+}
+
+// keyIDFor is "ed25519:" and the first 8 bytes of the key's sha256, in hex.
+func keyIDFor(pub ed25519.PublicKey) string {
+	sum := sha256.Sum256(pub)
+	return "ed25519:" + hex.EncodeToString(sum[:8])
+}
+
+func (k LocalKey) sign(msg []byte) (string, error) {
+	seed, err := base64.StdEncoding.DecodeString(k.Seed)
+	if err != nil || len(seed) != ed25519.SeedSize {
+		return "", fmt.Errorf("local key %s for %s: invalid seed", k.KeyID, k.ActorID)
+	}
+	return base64.StdEncoding.EncodeToString(ed25519.Sign(ed25519.NewKeyFromSeed(seed), msg)), nil
+}
+
+func (s Store) keysDir() string {
+	return filepath.Join(s.clarityDir(), "keys")
+}
+
+func (s Store) localKeyPath(actorID string) string {
+	return filepath.Join(s.keysDir(), strings.TrimSpace(actorID)+".json")
+}
+
+// LoadLocalKey returns actorID's local private key, or nil when this machine has none.
+func (s Store) LoadLocalKey(actorID string) (*LocalKey, error) {
+	actorID = strings.TrimSpace(actorID)
+	if actorID == "" {
+		return nil, nil
+	}
+	path := s.localKeyPath(actorID)
+	b, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var k LocalKey
+	if err := json.Unmarshal(b, &k); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	if k.ActorID != actorID || strings.TrimSpace(k.KeyID) == "" {
+		return nil, fmt.Errorf("%s: not a key for %s", path, actorID)
+	}
+	return &k, nil
+}
+
+func (s Store) saveLocalKey(k LocalKey) error {
+	dir := s.keysDir()
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return err
+	}
+	b, err := json.MarshalIndent(k, "", "  ")
+	if err != nil {
+		return err
+	}
+	return atomicWriteFile(dir, ".key-*.tmp", s.localKeyPath(k.ActorID), append(b, '\n'), 0o600)
+}
+
+// AddActorKey generates a key for actorID, publishes it with an identity.key_add event issued by
+// byID (the actor itself or, for an agent, its human) and keeps the private key locally. byID signs
+// with its local key; an actor's first key signs its own key_add.
+func (s Store) AddActorKey(db *DB, actorID, byID string) (model.ActorKey, error) {
+	if s.eventLogBackend() != EventLogBackendJSONL {
+		return model.ActorKey{}, ErrSigningUnsupported
+	}
+	actorID, byID = strings.TrimSpace(actorID), strings.TrimSpace(byID)
+	a, ok := db.FindActor(actorID)
+	if !ok {
+		return model.ActorKey{}, fmt.Errorf("unknown actor %s", actorID)
+	}
+	if byID != actorID {
+		if human, ok := db.HumanUserIDForActor(actorID); !ok || human != byID || a.Kind != model.ActorKindAgent {
+			return model.ActorKey{}, fmt.Errorf("%s can't add keys for %s (only the actor itself, or an agent's human)", byID, actorID)
+		}
+	}
+	signer, err := s.LoadLocalKey(byID)
+	if err != nil {
+		return model.ActorKey{}, err
+	}
+	if by, ok := db.FindActor(byID); signer != nil && (!ok || !hasKey(by.Keys, signer.KeyID)) {
+		// A local key that was never (validly) published can't sign.
+		signer = nil
+	}
+	k, err := NewLocalKey(actorID)
+	if err != nil {
+		return model.ActorKey{}, err
+	}
+	if signer == nil {
+		if byID != actorID {
+			return model.ActorKey{}, fmt.Errorf("%w for %s", ErrNoLocalKey, byID)
+		}
+		if len(a.Keys) > 0 {
+			return model.ActorKey{}, fmt.Errorf("%w for %s: it already has keys, so the new one must be signed with one of them (or, for an agent, added by its human)", ErrNoLocalKey, actorID)
+		}
+		if human, ok := db.HumanUserIDForActor(actorID); ok && human != actorID {
+			if h, ok := db.FindActor(human); ok && len(h.Keys) > 0 {
+				return model.ActorKey{}, fmt.Errorf("%w for %s: agent %s's first key must be added by its human", ErrNoLocalKey, human, actorID)
+			}
+		}
+		// First key: the key_add is signed with the key it adds.
+		if err := s.saveLocalKey(k); err != nil {
+			return model.ActorKey{}, err
+		}
+	}
+	if err := s.AppendEvent(byID, "identity.key_add", actorID, map[string]any{"keyId": k.KeyID, "publicKey": k.PublicKey}); err != nil {
+		return model.ActorKey{}, err
+	}
+	if signer != nil {
+		if err := s.saveLocalKey(k); err != nil {
+			return model.ActorKey{}, err
+		}
+	}
+	key := model.ActorKey{ID: k.KeyID, PublicKey: k.PublicKey, AddedBy: byID}
+	a.Keys = append(a.Keys, key)
+	return key, nil
+}
+
+// eventSignedBytes are the canonical bytes an event's signature covers: its envelope without the
+// local/server status fields and the signature itself.
+func eventSignedBytes(ev EventV1) ([]byte, error) {
+	b, err := json.Marshal(struct {
+		EventID     string          `json:"eventId"`
+		WorkspaceID string          `json:"workspaceId"`
+		ReplicaID   string          `json:"replicaId"`
+		EntityKind  EntityKind      `json:"entityKind"`
+		EntityID    string          `json:"entityId"`
+		EntitySeq   int64           `json:"entitySeq"`
+		Type        string          `json:"type"`
+		Parents     []string        `json:"parents,omitempty"`
+		IssuedAt    time.Time       `json:"issuedAt"`
+		HLC         HLC             `json:"hlc,omitzero"`
+		ActorID     string          `json:"actorId"`
+		Payload     json.RawMessage `json:"payload"`
+		KeyID       string          `json:"keyId"`
+	}{ev.EventID, ev.WorkspaceID, ev.ReplicaID, ev.EntityKind, ev.EntityID, ev.EntitySeq, ev.Type, ev.Parents, ev.IssuedAt, ev.HLC, ev.ActorID, ev.Payload, ev.KeyID})
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(eventSigContext), b...), nil
+}
+
+func signEventV1(ev *EventV1, k LocalKey) error {
+	ev.KeyID = k.KeyID
+	ev.Sig = ""
+	msg, err := eventSignedBytes(*ev)
+	if err != nil {
+		return err
+	}
+	ev.Sig, err = k.sign(msg)
+	return err
+}
+
+// verifiedSigs remembers signatures already checked (keys are never removed, so a signature that
+// verified once stays verified); it keeps rereading the log in the TUI cheap.
+var verifiedSigs sync.Map // sha256(signed bytes) + keyID + "\x00" + sig -> struct{}
+
+func verifySig(keys []model.ActorKey, keyID, sig string, msg []byte) bool {
+	for _, k := range keys {
+		if k.ID != keyID {
+			continue
+		}
+		pub, err := base64.StdEncoding.DecodeString(k.PublicKey)
+		if err != nil || len(pub) != ed25519.PublicKeySize {
+			return false
+		}
+		raw, err := base64.StdEncoding.DecodeString(sig)
+		if err != nil {
+			return false
+		}
+		return ed25519.Verify(ed25519.PublicKey(pub), msg, raw)
+	}
+	return false
+}
+
+// checkEventSig checks ev's signature against the keys its actor has on db.
+func checkEventSig(db *DB, ev EventV1) EventSignature {
+	actorID := strings.TrimSpace(ev.ActorID)
+	var keys []model.ActorKey
+	if a, ok := db.FindActor(actorID); ok {
+		keys = a.Keys
+	}
+	out := EventSignature{KeyID: strings.TrimSpace(ev.KeyID)}
+	if strings.TrimSpace(ev.Sig) == "" {
+		if len(keys) == 0 {
+			out.Status = SignatureUnsigned
+			return out
+		}
+		out.Status, out.Reason = SignatureMissing, fmt.Sprintf("unsigned, but actor %s has signing keys", actorID)
+		return out
+	}
+	if !hasKey(keys, out.KeyID) {
+		out.Status, out.Reason = SignatureInvalid, fmt.Sprintf("signed with key %s, which actor %s doesn't have", out.KeyID, actorID)
+		return out
+	}
+	msg, err := eventSignedBytes(ev)
+	if err != nil {
+		out.Status, out.Reason = SignatureInvalid, err.Error()
+		return out
+	}
+	sum := sha256.Sum256(msg)
+	cacheKey := string(sum[:]) + out.KeyID + "\x00" + ev.Sig
+	if _, ok := verifiedSigs.Load(cacheKey); ok {
+		out.Status = SignatureVerified
+		return out
+	}
+	if !verifySig(keys, out.KeyID, ev.Sig, msg) {
+		out.Status, out.Reason = SignatureInvalid, "signature doesn't match the event (edited or forged)"
+		return out
+	}
+	verifiedSigs.Store(cacheKey, struct{}{})
+	out.Status = SignatureVerified
+	return out
+}
+
+func hasKey(keys []model.ActorKey, keyID string) bool {
+	for _, k := range keys {
+		if k.ID == keyID {
+			return true
+		}
+	}
+	return false
+}
+
+// keyAddPayload is the payload of identity.key_add.
+type keyAddPayload struct {
+	KeyID     string `json:"keyId"`
+	PublicKey string `json:"publicKey"`
+}
+
+// keyOfKeyAdd returns the key the identity.key_add event ev publishes, or why its payload is invalid.
+func keyOfKeyAdd(ev EventV1) (model.ActorKey, string) {
+	var p keyAddPayload
+	if err := json.Unmarshal(ev.Payload, &p); err != nil {
+		return model.ActorKey{}, "invalid payload: " + err.Error()
+	}
+	pub, err := base64.StdEncoding.DecodeString(strings.TrimSpace(p.PublicKey))
+	if err != nil || len(pub) != ed25519.PublicKeySize {
+		return model.ActorKey{}, "invalid public key"
+	}
+	key := model.ActorKey{ID: keyIDFor(pub), PublicKey: base64.StdEncoding.EncodeToString(pub), AddedBy: strings.TrimSpace(ev.ActorID)}
+	if strings.TrimSpace(p.KeyID) != key.ID {
+		return model.ActorKey{}, fmt.Sprintf("keyId %s doesn't match the public key (%s)", strings.TrimSpace(p.KeyID), key.ID)
+	}
+	return key, ""
+}
+
+// checkKeyAdd returns the key the identity.key_add event ev adds, or why db (the state just before
+// ev) doesn't accept it.
+func checkKeyAdd(db *DB, ev EventV1) (model.ActorKey, string) {
+	key, reason := keyOfKeyAdd(ev)
+	if reason != "" {
+		return model.ActorKey{}, reason
+	}
+	actorID, byID := strings.TrimSpace(ev.EntityID), key.AddedBy
+	a, ok := db.FindActor(actorID)
+	if !ok {
+		return model.ActorKey{}, "unknown actor " + actorID
+	}
+	if strings.TrimSpace(ev.Sig) == "" {
+		return model.ActorKey{}, "unsigned key_add"
+	}
+
+	var signers []model.ActorKey
+	switch {
+	case byID == actorID && len(a.Keys) > 0:
+		signers = a.Keys
+	case byID == actorID:
+		if human, ok := db.HumanUserIDForActor(actorID); ok && human != actorID {
+			if h, ok := db.FindActor(human); ok && len(h.Keys) > 0 {
+				return model.ActorKey{}, fmt.Sprintf("agent %s's first key must be added by its human %s", actorID, human)
+			}
+		}
+		signers = []model.ActorKey{key}
+	case a.Kind == model.ActorKindAgent && a.UserID != nil && *a.UserID == byID:
+		if h, ok := db.FindActor(byID); ok {
+			signers = h.Keys
+		}
+	default:
+		return model.ActorKey{}, fmt.Sprintf("%s can't add keys for %s", byID, actorID)
+	}
+	msg, err := eventSignedBytes(ev)
+	if err != nil || !verifySig(signers, strings.TrimSpace(ev.KeyID), ev.Sig, msg) {
+		return model.ActorKey{}, fmt.Sprintf("key_add for %s isn't signed with a key of %s", actorID, byID)
+	}
+	return key, ""
+}
+
+// VerifyEventsV1 checks every event's signature against the keys its actor had at that point of
+// the log, by event id.
+func VerifyEventsV1(lines []EventV1Line) map[string]EventSignature {
+	ordered := append([]EventV1Line(nil), lines...)
+	sortEventV1Lines(ordered)
+	sigs, _ := verifySignatures(ordered)
+	return sigs
+}
+
+// verifySignatures walks lines (in replay order) applying only identity events, and returns each
+// event's signature check and the keys the log publishes.
+func verifySignatures(lines []EventV1Line) (map[string]EventSignature, publishedKeys) {
+	out := make(map[string]EventSignature, len(lines))
+	keys := replayIdentities(lines, func(ev EventV1, db *DB, rejected string) {
+		if rejected != "" {
+			out[ev.EventID] = EventSignature{Status: SignatureInvalid, KeyID: strings.TrimSpace(ev.KeyID), Reason: rejected}
+			return
+		}
+		out[ev.EventID] = checkEventSig(db, ev)
+	})
+	if len(keys.contested) == 0 {
+		return out, keys
+	}
+	for _, l := range lines {
+		ev := l.Event
+		c, ok := keys.contested[strings.TrimSpace(ev.KeyID)]
+		if !ok || strings.TrimSpace(ev.Sig) == "" {
+			continue
+		}
+		msg, err := eventSignedBytes(ev)
+		if err != nil || !verifySig([]model.ActorKey{c.key}, c.key.ID, ev.Sig, msg) {
+			continue
+		}
+		out[ev.EventID] = EventSignature{Status: SignatureContested, KeyID: c.key.ID, Reason: c.reason()}
+	}
+	return out, keys
+}
+
+// publishedKeys are the actors with the keys the identity events of a log publish for them, and
+// the keys that trace back to competing first keys.
+type publishedKeys struct {
+	db        *DB
+	contested map[string]contestedKey // by key id
+	conflicts []keyConflict
+}
+
+// keyConflict is an actor with more than one key published as its first (trust on first use).
+type keyConflict struct {
+	ActorID string
+	Claims  []keyClaim
+}
+
+type keyClaim struct {
+	Key     model.ActorKey
+	EventID string
+}
+
+// contestedKey is a key of a conflict, or one such a key vouched for.
+type contestedKey struct {
+	key     model.ActorKey
+	actorID string // the actor with the conflict
+}
+
+func (c contestedKey) reason() string {
+	return fmt.Sprintf("signed with key %s, which traces back to competing first keys of actor %s", c.key.ID, c.actorID)
+}
+
+// replayIdentities applies only the identity events of lines (in replay order) and returns the
+// keys the log publishes. visit, when set, sees every event with the state just after it, and why
+// an identity.key_add was ignored.
+func replayIdentities(lines []EventV1Line, visit func(ev EventV1, db *DB, rejected string)) publishedKeys {
+	db := &DB{Actors: []model.Actor{}}
+	claims := map[string][]keyClaim{}
+	type vouch struct {
+		signerKeyID string
+		key         model.ActorKey
+	}
+	var vouches []vouch
+	for _, l := range lines {
+		ev := l.Event
+		rejected := ""
+		switch strings.TrimSpace(ev.Type) {
+		case "identity.key_add":
+			var key model.ActorKey
+			if key, rejected = checkKeyAdd(db, ev); rejected == "" {
+				_, _ = applyEventV1(db, ev)
+			}
+			if c, ok := firstKeyClaim(db, ev, rejected); ok {
+				id := strings.TrimSpace(ev.EntityID)
+				claims[id] = append(claims[id], c)
+			} else if rejected == "" {
+				vouches = append(vouches, vouch{signerKeyID: strings.TrimSpace(ev.KeyID), key: key})
+			}
+		case "identity.create":
+			_, _ = applyEventV1(db, ev)
+		}
+		if visit != nil {
+			visit(ev, db, rejected)
+		}
+	}
+
+	out := publishedKeys{db: db, contested: map[string]contestedKey{}}
+	for actorID, cs := range claims {
+		if len(cs) < 2 {
+			continue
+		}
+		out.conflicts = append(out.conflicts, keyConflict{ActorID: actorID, Claims: cs})
+		for _, c := range cs {
+			out.contested[c.Key.ID] = contestedKey{key: c.Key, actorID: actorID}
+		}
+	}
+	sort.Slice(out.conflicts, func(i, j int) bool { return out.conflicts[i].ActorID < out.conflicts[j].ActorID })
+	for _, v := range vouches {
+		if c, ok := out.contested[v.signerKeyID]; ok {
+			out.contested[v.key.ID] = contestedKey{key: v.key, actorID: c.actorID}
+		}
+	}
+	return out
+}
+
+// firstKeyClaim reports whether the identity.key_add ev publishes a first key for its (own) actor,
+// signed with that key: accepted (rejected == ""), or refused only because the actor already had
+// a key. The latter is a competing claim, which may well be the genuine one.
+func firstKeyClaim(db *DB, ev EventV1, rejected string) (keyClaim, bool) {
+	actorID := strings.TrimSpace(ev.EntityID)
+	if strings.TrimSpace(ev.ActorID) != actorID {
+		return keyClaim{}, false
+	}
+	key, reason := keyOfKeyAdd(ev)
+	if reason != "" || strings.TrimSpace(ev.KeyID) != key.ID {
+		return keyClaim{}, false
+	}
+	if rejected != "" {
+		a, ok := db.FindActor(actorID)
+		if !ok || len(a.Keys) == 0 || hasKey(a.Keys, key.ID) {
+			return keyClaim{}, false
+		}
+		keys := a.Keys
+		a.Keys = nil
+		_, rejected = checkKeyAdd(db, ev)
+		a.Keys = keys
+		if rejected != "" {
+			return keyClaim{}, false
+		}
+	}
+	return keyClaim{Key: key, EventID: strings.TrimSpace(ev.EventID)}, true
+}
+
+// signatureIssues reports forged and unsigned events (those by actors without keys as a count per
+// actor), competing first keys, and checks the latest snapshot's signature.
+func signatureIssues(st Store, lines []EventV1Line) []DoctorIssue {
+	ordered := append([]EventV1Line(nil), lines...)
+	sortEventV1Lines(ordered)
+	sigs, keys := verifySignatures(ordered)
+
+	var issues []DoctorIssue
+	for _, c := range keys.conflicts {
+		claimed := make([]string, 0, len(c.Claims))
+		for _, k := range c.Claims {
+			claimed = append(claimed, k.Key.ID+" ("+k.EventID+")")
+		}
+		issues = append(issues, DoctorIssue{
+			Level:      DoctorIssueLevelError,
+			Code:       "actor_key_conflict",
+			Message:    fmt.Sprintf("actor %s has competing first keys %s: any of them may be someone else's, so events signed with them are marked contested (ask %s which is theirs)", c.ActorID, strings.Join(claimed, ", "), c.ActorID),
+			EntityKind: string(EntityKindActor),
+			EntityID:   c.ActorID,
+		})
+	}
+	unsigned := map[string]int{}
+	for _, l := range ordered {
+		ev := l.Event
+		sig := sigs[ev.EventID]
+		if sig.Status == SignatureUnsigned {
+			unsigned[strings.TrimSpace(ev.ActorID)]++
+			continue
+		}
+		issue := DoctorIssue{
+			Path:       l.Path,
+			Line:       l.Line,
+			EventID:    strings.TrimSpace(ev.EventID),
+			ReplicaID:  strings.TrimSpace(ev.ReplicaID),
+			EntityKind: strings.TrimSpace(string(ev.EntityKind)),
+			EntityID:   strings.TrimSpace(ev.EntityID),
+			Type:       strings.TrimSpace(ev.Type),
+		}
+		switch sig.Status {
+		case SignatureInvalid:
+			issue.Level, issue.Code, issue.Message = DoctorIssueLevelError, "event_signature_invalid", sig.Reason
+		case SignatureMissing:
+			issue.Level, issue.Code, issue.Message = DoctorIssueLevelWarn, "event_unsigned", sig.Reason
+		default:
+			continue
+		}
+		issues = append(issues, issue)
+	}
+	// Unsigned events by actors without keys are expected in older workspaces; one line per actor
+	// keeps them visible without drowning the rest.
+	actorIDs := make([]string, 0, len(unsigned))
+	for id := range unsigned {
+		actorIDs = append(actorIDs, id)
+	}
+	sort.Strings(actorIDs)
+	for _, id := range actorIDs {
+		issues = append(issues, DoctorIssue{
+			Level:      DoctorIssueLevelWarn,
+			Code:       "actor_unsigned_events",
+			Message:    fmt.Sprintf("%d unsigned events by actor %s (it had no signing key when writing them)", unsigned[id], id),
+			EntityKind: string(EntityKindActor),
+			EntityID:   id,
+		})
+	}
+
+	if st.eventLogBackend() != EventLogBackendJSONL {
+		return issues
+	}
+	paths, err := st.snapshotPaths()
+	if err != nil || len(paths) == 0 {
+		// Read errors are reported by snapshotIssues.
+		return issues
+	}
+	path := paths[len(paths)-1]
+	snap, err := readSnapshot(path)
+	if err != nil {
+		return issues
+	}
+	if reason := snap.checkSig(keys); reason != "" {
+		level, code := DoctorIssueLevelError, "snapshot_signature_invalid"
+		if snap.Sig == "" {
+			level, code = DoctorIssueLevelWarn, "snapshot_unsigned"
+		}
+		msg := reason + "; rebuilds replay the full log instead of starting from it"
+		issues = append(issues, DoctorIssue{Level: level, Code: code, Message: msg, Path: path})
+	}
+	return issues
+}
+
+// sign signs the snapshot's digest (set KeyID before computing it).
+func (snap *Snapshot) sign(k LocalKey) error {
+	var err error
+	snap.Sig, err = k.sign([]byte(snapshotSigContext + snap.Digest))
+	return err
+}
+
+// checkSig returns why the snapshot's signature doesn't check out against the keys the identity
+// events of the log publish for its creator, or "" when it does. A key that traces back to
+// competing first keys doesn't count. Rebuilds only start from a snapshot that checks out.
+func (snap *Snapshot) checkSig(keys publishedKeys) string {
+	by := strings.TrimSpace(snap.CreatedBy)
+	var actorKeys []model.ActorKey
+	if a, ok := keys.db.FindActor(by); ok {
+		actorKeys = a.Keys
+	}
+	switch {
+	case snap.Sig == "":
+		return "snapshot is unsigned"
+	case !verifySig(actorKeys, snap.KeyID, snap.Sig, []byte(snapshotSigContext+snap.Digest)):
+		return fmt.Sprintf("snapshot signature doesn't verify with a key of its creator %s", by)
+	}
+	if c, ok := keys.contested[strings.TrimSpace(snap.KeyID)]; ok {
+		return "snapshot is " + c.reason()
+	}
+	return ""
+}
@@ -0,0 +1,221 @@
+package store
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"clarity-cli/internal/model"
+)
+
+func newSigningWorkspace(t *testing.T) (Store, string, *DB) {
+	t.Helper()
+	dir := t.TempDir()
+	res, err := EnsureGitBackedV1Layout(dir)
+	if err != nil {
+		t.Fatalf("EnsureGitBackedV1Layout: %v", err)
+	}
+	s := Store{Dir: dir}
+	if err := s.AppendEvent("act-1", "identity.create", "act-1", map[string]any{"name": "A", "kind": "human"}); err != nil {
+		t.Fatalf("AppendEvent: %v", err)
+	}
+	if err := s.AppendEvent("act-2", "identity.create", "act-2", map[string]any{"name": "A's agent", "kind": "agent", "userId": "act-1"}); err != nil {
+		t.Fatalf("AppendEvent: %v", err)
+	}
+	human := "act-1"
+	db := &DB{Actors: []model.Actor{
+		{ID: "act-1", Kind: model.ActorKindHuman, Name: "A"},
+		{ID: "act-2", Kind: model.ActorKindAgent, Name: "A's agent", UserID: &human},
+	}}
+	return s, res.ShardPath, db
+}
+
+func mustVerify(t *testing.T, dir string) map[string]EventSignature {
+	t.Helper()
+	lines, err := ReadEventsV1Lines(dir)
+	if err != nil {
+		t.Fatalf("ReadEventsV1Lines: %v", err)
+	}
+	return VerifyEventsV1(lines)
+}
+
+func sigsByType(t *testing.T, dir string) map[string][]SignatureStatus {
+	t.Helper()
+	lines, err := ReadEventsV1Lines(dir)
+	if err != nil {
+		t.Fatalf("ReadEventsV1Lines: %v", err)
+	}
+	sigs := VerifyEventsV1(lines)
+	out := map[string][]SignatureStatus{}
+	for _, l := range lines {
+		out[l.Event.Type] = append(out[l.Event.Type], sigs[l.Event.EventID].Status)
+	}
+	return out
+}
+
+// appendSelfSignedKeyAdd appends an identity.key_add for actorID signed with the (new) key it adds,
+// the way anyone claiming actorID's first key would write it.
+func appendSelfSignedKeyAdd(t *testing.T, shard, eventID, actorID string, issuedAt time.Time) LocalKey {
+	t.Helper()
+	k, err := NewLocalKey(actorID)
+	if err != nil {
+		t.Fatalf("NewLocalKey: %v", err)
+	}
+	payload, _ := json.Marshal(map[string]any{"keyId": k.KeyID, "publicKey": k.PublicKey})
+	ev := EventV1{
+		EventID:     eventID,
+		WorkspaceID: "ws",
+		ReplicaID:   "rep-x",
+		EntityKind:  EntityKindActor,
+		EntityID:    actorID,
+		EntitySeq:   1,
+		Type:        "identity.key_add",
+		IssuedAt:    issuedAt,
+		ActorID:     actorID,
+		Payload:     payload,
+	}
+	if err := signEventV1(&ev, k); err != nil {
+		t.Fatalf("sign: %v", err)
+	}
+	line, _ := json.Marshal(ev)
+	f, err := os.OpenFile(shard, os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		t.Fatalf("open shard: %v", err)
+	}
+	defer f.Close()
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		t.Fatalf("append: %v", err)
+	}
+	return k
+}
+
+func TestSigning_EventsAreSignedAndTamperingIsReported(t *testing.T) {
+	s, shard, db := newSigningWorkspace(t)
+	if _, err := s.AddActorKey(db, "act-1", "act-1"); err != nil {
+		t.Fatalf("AddActorKey: %v", err)
+	}
+	if info, err := os.Stat(s.localKeyPath("act-1")); err != nil || info.Mode().Perm() != 0o600 {
+		t.Fatalf("expected a 0600 local key, got %v %v", info, err)
+	}
+	if err := s.AppendEvent("act-1", "item.create", "item-1", map[string]any{"id": "item-1", "title": "Signed"}); err != nil {
+		t.Fatalf("AppendEvent: %v", err)
+	}
+
+	got := sigsByType(t, s.Dir)
+	if got["identity.key_add"][0] != SignatureVerified || got["item.create"][0] != SignatureVerified || got["identity.create"][0] != SignatureUnsigned {
+		t.Fatalf("unexpected signature checks: %+v", got)
+	}
+	// The identity.create events predate the keys: one warning per actor.
+	if codes := doctorCodes(s.Dir); codes["event_signature_invalid"] != 0 || codes["event_unsigned"] != 0 || codes["actor_unsigned_events"] != 2 {
+		t.Fatalf("expected only the unsigned events per actor, got %+v", codes)
+	}
+
+	// Replay records the key, so a rebuild knows it too.
+	res, err := ReplayEventsV1(s.Dir)
+	if err != nil {
+		t.Fatalf("ReplayEventsV1: %v", err)
+	}
+	if a, ok := res.DB.FindActor("act-1"); !ok || len(a.Keys) != 1 || a.Keys[0].ID != db.Actors[0].Keys[0].ID {
+		t.Fatalf("expected replay to record act-1's key, got %+v", a)
+	}
+
+	// Editing a signed event breaks its signature.
+	b, err := os.ReadFile(shard)
+	if err != nil {
+		t.Fatalf("read shard: %v", err)
+	}
+	if err := os.WriteFile(shard, []byte(strings.Replace(string(b), `"title":"Signed"`, `"title":"Edited"`, 1)), 0o644); err != nil {
+		t.Fatalf("write shard: %v", err)
+	}
+	if got := sigsByType(t, s.Dir); got["item.create"][0] != SignatureInvalid {
+		t.Fatalf("expected the edited event to be invalid, got %+v", got)
+	}
+
+	// An event "by" act-1 from someone without its key is unsigned.
+	if err := os.Remove(s.localKeyPath("act-1")); err != nil {
+		t.Fatalf("remove key: %v", err)
+	}
+	if err := s.AppendEvent("act-1", "item.set_title", "item-1", map[string]any{"title": "Forged"}); err != nil {
+		t.Fatalf("AppendEvent: %v", err)
+	}
+	codes := doctorCodes(s.Dir)
+	if codes["event_signature_invalid"] != 1 || codes["event_unsigned"] != 1 {
+		t.Fatalf("expected one invalid and one unsigned event, got %+v", codes)
+	}
+	if _, err := s.AddActorKey(db, "act-1", "act-1"); !errors.Is(err, ErrNoLocalKey) {
+		t.Fatalf("expected a new key without an existing one to fail, got %v", err)
+	}
+}
+
+func TestSigning_AgentKeysAreAddedByTheirHuman(t *testing.T) {
+	s, shard, db := newSigningWorkspace(t)
+	if _, err := s.AddActorKey(db, "act-1", "act-1"); err != nil {
+		t.Fatalf("AddActorKey: %v", err)
+	}
+	if _, err := s.AddActorKey(db, "act-2", "act-2"); !errors.Is(err, ErrNoLocalKey) {
+		t.Fatalf("expected the agent's own first key to be refused once its human has keys, got %v", err)
+	}
+	if _, err := s.AddActorKey(db, "act-1", "act-2"); err == nil {
+		t.Fatalf("expected an agent not to add keys for its human")
+	}
+	// A key_add for the agent signed with the new key itself (trust on first use) isn't accepted:
+	// its human vouches for its keys.
+	appendSelfSignedKeyAdd(t, shard, "evt-rogue", "act-2", time.Now().UTC())
+
+	if sig := mustVerify(t, s.Dir)["evt-rogue"]; sig.Status != SignatureInvalid || !strings.Contains(sig.Reason, "human") {
+		t.Fatalf("expected the rogue key_add to be invalid, got %+v", sig)
+	}
+	key, err := s.AddActorKey(db, "act-2", "act-1")
+	if err != nil {
+		t.Fatalf("AddActorKey (by human): %v", err)
+	}
+	if err := s.AppendEvent("act-2", "item.create", "item-1", map[string]any{"id": "item-1", "title": "By agent"}); err != nil {
+		t.Fatalf("AppendEvent: %v", err)
+	}
+	if got := sigsByType(t, s.Dir); got["item.create"][0] != SignatureVerified {
+		t.Fatalf("expected the agent's event to verify, got %+v", got)
+	}
+
+	res, err := ReplayEventsV1(s.Dir)
+	if err != nil {
+		t.Fatalf("ReplayEventsV1: %v", err)
+	}
+	a, _ := res.DB.FindActor("act-2")
+	if len(a.Keys) != 1 || a.Keys[0].ID != key.ID || a.Keys[0].AddedBy != "act-1" {
+		t.Fatalf("expected replay to keep only the human's key for the agent, got %+v", a.Keys)
+	}
+}
+
+func TestSigning_CompetingFirstKeysAreReported(t *testing.T) {
+	s, shard, db := newSigningWorkspace(t)
+	if _, err := s.AddActorKey(db, "act-1", "act-1"); err != nil {
+		t.Fatalf("AddActorKey: %v", err)
+	}
+	if err := s.AppendEvent("act-1", "item.create", "item-1", map[string]any{"id": "item-1", "title": "Signed"}); err != nil {
+		t.Fatalf("AppendEvent: %v", err)
+	}
+	// Someone else publishes a first key for act-1, backdated so it sorts before act-1's own.
+	appendSelfSignedKeyAdd(t, shard, "evt-rogue", "act-1", time.Now().UTC().Add(-time.Hour))
+
+	got := sigsByType(t, s.Dir)
+	for _, st := range append(got["identity.key_add"], got["item.create"]...) {
+		if st != SignatureContested {
+			t.Fatalf("expected both keys and the item to be contested, got %+v", got)
+		}
+	}
+	codes := doctorCodes(s.Dir)
+	if codes["actor_key_conflict"] != 1 || codes["event_signature_invalid"] != 0 {
+		t.Fatalf("expected one key conflict and no invalid events, got %+v", codes)
+	}
+
+	// Neither key counts for a snapshot.
+	if _, err := s.WriteSnapshot("act-1"); err != nil {
+		t.Fatalf("WriteSnapshot: %v", err)
+	}
+	if codes := doctorCodes(s.Dir); codes["snapshot_signature_invalid"] != 1 {
+		t.Fatalf("expected the snapshot signed with a contested key to be reported, got %+v", codes)
+	}
+}
@@ -0,0 +1,483 @@
+package store
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"clarity-cli/internal/model"
+)
+
+// Snapshots.
+//
+// A snapshot (snapshots/snapshot-<time>-<replica>.json, committed) is the materialized state at an
+// event watermark: for each shard, how many bytes/lines it covers, plus each entity's newest
+// event. Rebuilding the derived state starts from the latest snapshot and applies only the events
+// after its watermark, with the same checks as incremental replay (replay_watermark.go): when the
+// log was rewritten under it or a later event forks an entity it covers, the rebuild falls back to
+// a full replay. The raw log is never truncated, and `clarity doctor` checks that the latest
+// snapshot equals a replay of the events it covers.
+//
+// Anyone who can push can commit a snapshot, so a rebuild only starts from one whose watermark
+// names events actually in the log and that is signed by its creator with a key the log publishes
+// (signing.go); otherwise it replays the full log.
+
+const snapshotVersion = 1
+
+var (
+	ErrSnapshotsUnsupported = errors.New("snapshots need a Git-backed (JSONL) workspace")
+
+	errSnapshotVersion = errors.New("unsupported snapshot version")
+	errSnapshotDigest  = errors.New("snapshot digest mismatch")
+)
+
+type Snapshot struct {
+	Version   int       `json:"version"`
+	CreatedAt time.Time `json:"createdAt"`
+	CreatedBy string    `json:"createdBy,omitempty"`
+	ReplicaID string    `json:"replicaId,omitempty"`
+
+	// Events is how many events were applied into State; Shards and Heads are its watermark.
+	Events int                      `json:"events"`
+	Shards map[string]SnapshotShard `json:"shards"`
+	Heads  map[string]SnapshotHead  `json:"heads"`
+	// Access is the digest of the roles (meta/users.json) events were checked against; a snapshot
+	// replayed under other roles is not used.
+	Access string `json:"access,omitempty"`
+
+	State SnapshotState `json:"state"`
+
+	// Digest is "sha256:<hex>" over the snapshot with Digest and Sig empty; a snapshot whose digest
+	// doesn't match is never used.
+	Digest string `json:"digest"`
+	// KeyID and Sig sign Digest with CreatedBy's local key, when it has one (signing.go).
+	KeyID string `json:"keyId,omitempty"`
+	Sig   string `json:"sig,omitempty"`
+}
+
+type SnapshotShard struct {
+	Offset        int64  `json:"offset"`
+	Lines         int    `json:"lines"`
+	LastLineStart int64  `json:"lastLineStart"`
+	LastEventID   string `json:"lastEventId"`
+}
+
+type SnapshotHead struct {
+	HLC      HLC       `json:"hlc"`
+	IssuedAt time.Time `json:"issuedAt"`
+	EventID  string    `json:"eventId"`
+}
+
+// SnapshotState is the replayed (canonical) part of DB; local meta like the current actor is not
+// included.
+type SnapshotState struct {
+	NextIDs     map[string]int       `json:"nextIds"`
+	Actors      []model.Actor        `json:"actors"`
+	Projects    []model.Project      `json:"projects"`
+	Outlines    []model.Outline      `json:"outlines"`
+	Items       []model.Item         `json:"items"`
+	Deps        []model.Dependency   `json:"deps"`
+	Comments    []model.Comment      `json:"comments"`
+	Worklog     []model.WorklogEntry `json:"worklog"`
+	Attachments []model.Attachment   `json:"attachments"`
+	Follows     []model.Follow       `json:"follows"`
+}
+
+func snapshotStateOf(db *DB) SnapshotState {
+	return SnapshotState{
+		NextIDs:     db.NextIDs,
+		Actors:      db.Actors,
+		Projects:    db.Projects,
+		Outlines:    db.Outlines,
+		Items:       db.Items,
+		Deps:        db.Deps,
+		Comments:    db.Comments,
+		Worklog:     db.Worklog,
+		Attachments: db.Attachments,
+		Follows:     db.Follows,
+	}
+}
+
+func (s Store) snapshotsDir() string {
+	return filepath.Join(s.workspaceRoot(), "snapshots")
+}
+
+// SnapshotInfo describes a written or loaded snapshot without its state.
+type SnapshotInfo struct {
+	Path      string    `json:"path"`
+	CreatedAt time.Time `json:"createdAt"`
+	Events    int       `json:"events"`
+	Digest    string    `json:"digest"`
+}
+
+// WriteSnapshot replays the whole log and writes its state as a new snapshot.
+func (s Store) WriteSnapshot(actorID string) (SnapshotInfo, error) {
+	if s.eventLogBackend() != EventLogBackendJSONL {
+		return SnapshotInfo{}, ErrSnapshotsUnsupported
+	}
+	lines, err := s.readEventsV1LinesJSONL()
+	if err != nil {
+		return SnapshotInfo{}, err
+	}
+	access, err := s.loadAccessRules()
+	if err != nil {
+		return SnapshotInfo{}, err
+	}
+	sortEventV1Lines(lines)
+	res, err := replayEventV1Lines(lines, access, nil)
+	if err != nil {
+		return SnapshotInfo{}, err
+	}
+	device, _, err := s.loadOrInitDeviceFile()
+	if err != nil {
+		return SnapshotInfo{}, err
+	}
+
+	snap := Snapshot{
+		Version:   snapshotVersion,
+		CreatedAt: time.Now().UTC(),
+		CreatedBy: strings.TrimSpace(actorID),
+		ReplicaID: strings.TrimSpace(device.ReplicaID),
+		Events:    res.AppliedCount,
+		Shards:    map[string]SnapshotShard{},
+		Heads:     map[string]SnapshotHead{},
+		Access:    access.Digest(),
+		State:     snapshotStateOf(res.DB),
+	}
+	for name, m := range res.DB.replay.shards {
+		snap.Shards[name] = SnapshotShard(m)
+	}
+	for id, k := range res.DB.replay.heads {
+		snap.Heads[id] = SnapshotHead{HLC: k.Clock, IssuedAt: k.IssuedAt, EventID: k.EventID}
+	}
+	key, err := s.LoadLocalKey(snap.CreatedBy)
+	if err != nil {
+		return SnapshotInfo{}, err
+	}
+	if a, ok := res.DB.FindActor(snap.CreatedBy); key == nil || !ok || !hasKey(a.Keys, key.KeyID) {
+		return SnapshotInfo{}, fmt.Errorf("%w for %q: rebuilds only start from signed snapshots (clarity identity key add)", ErrNoLocalKey, snap.CreatedBy)
+	}
+	snap.KeyID = key.KeyID
+	if snap.Digest, err = snap.computeDigest(); err != nil {
+		return SnapshotInfo{}, err
+	}
+	if err := snap.sign(*key); err != nil {
+		return SnapshotInfo{}, err
+	}
+
+	b, err := json.Marshal(snap)
+	if err != nil {
+		return SnapshotInfo{}, err
+	}
+	dir := s.snapshotsDir()
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return SnapshotInfo{}, err
+	}
+	// Millisecond precision keeps names (and so "latest") ordered even for back-to-back snapshots.
+	stamp := strings.Replace(snap.CreatedAt.Format("20060102T150405.000"), ".", "", 1) + "Z"
+	path := filepath.Join(dir, fmt.Sprintf("snapshot-%s-%s.json", stamp, snap.ReplicaID))
+	if err := atomicWriteFile(dir, ".snapshot-*.tmp", path, append(b, '\n'), 0o644); err != nil {
+		return SnapshotInfo{}, err
+	}
+	return SnapshotInfo{Path: path, CreatedAt: snap.CreatedAt, Events: snap.Events, Digest: snap.Digest}, nil
+}
+
+func (snap Snapshot) computeDigest() (string, error) {
+	snap.Digest = ""
+	snap.Sig = ""
+	b, err := json.Marshal(snap)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(b)
+	return "sha256:" + hex.EncodeToString(sum[:]), nil
+}
+
+// snapshotPaths returns the snapshot files, oldest first (names sort by creation time).
+func (s Store) snapshotPaths() ([]string, error) {
+	entries, err := os.ReadDir(s.snapshotsDir())
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var out []string
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || !strings.HasPrefix(name, "snapshot-") || !strings.HasSuffix(name, ".json") {
+			continue
+		}
+		out = append(out, filepath.Join(s.snapshotsDir(), name))
+	}
+	sort.Strings(out)
+	return out, nil
+}
+
+// readSnapshot reads and checks a snapshot file (version and digest).
+func readSnapshot(path string) (*Snapshot, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var snap Snapshot
+	if err := json.Unmarshal(b, &snap); err != nil {
+		return nil, err
+	}
+	if snap.Version != snapshotVersion {
+		return nil, fmt.Errorf("%w %d", errSnapshotVersion, snap.Version)
+	}
+	want, err := snap.computeDigest()
+	if err != nil {
+		return nil, err
+	}
+	if snap.Digest != want {
+		return nil, fmt.Errorf("%w: recorded %s, computed %s", errSnapshotDigest, snap.Digest, want)
+	}
+	return &snap, nil
+}
+
+// LatestSnapshot returns the newest snapshot and its path, or nil when there is none.
+func (s Store) LatestSnapshot() (*Snapshot, string, error) {
+	paths, err := s.snapshotPaths()
+	if err != nil || len(paths) == 0 {
+		return nil, "", err
+	}
+	p := paths[len(paths)-1]
+	snap, err := readSnapshot(p)
+	if err != nil {
+		return nil, p, err
+	}
+	return snap, p, nil
+}
+
+// db turns the snapshot into a DB carrying the snapshot's watermark, ready for applyPending.
+func (snap *Snapshot) db() *DB {
+	st := snap.State
+	db := &DB{
+		Version:     1,
+		NextIDs:     st.NextIDs,
+		Actors:      st.Actors,
+		Projects:    st.Projects,
+		Outlines:    st.Outlines,
+		Items:       st.Items,
+		Deps:        st.Deps,
+		Comments:    st.Comments,
+		Worklog:     st.Worklog,
+		Attachments: st.Attachments,
+		Follows:     st.Follows,
+	}
+	if db.NextIDs == nil {
+		db.NextIDs = map[string]int{}
+	}
+	w := newReplayWatermark()
+	for name, m := range snap.Shards {
+		w.shards[name] = shardMark(m)
+	}
+	for id, h := range snap.Heads {
+		w.heads[id] = eventKey{Clock: h.HLC, IssuedAt: h.IssuedAt.UTC(), EventID: h.EventID}
+	}
+	w.access = snap.Access
+	db.replay = w
+	return db
+}
+
+// replayFromLatestSnapshot rebuilds state from the latest snapshot plus the events after it, or
+// replays the whole log when there is no usable snapshot.
+func (s Store) replayFromLatestSnapshot() (ReplayResult, error) {
+	if s.eventLogBackend() == EventLogBackendJSONL {
+		access, err := s.loadAccessRules()
+		if err != nil {
+			return ReplayResult{}, err
+		}
+		// An unreadable, mismatching or untrusted snapshot is skipped here; doctor reports it.
+		if snap, path, err := s.LatestSnapshot(); err == nil && snap != nil && snap.Access == access.Digest() {
+			trusted, err := s.snapshotTrusted(snap)
+			if err != nil {
+				return ReplayResult{}, err
+			}
+			if !trusted {
+				return ReplayEventsV1(s.Dir)
+			}
+			db, res, fullReason, err := s.applyPending(snap.db(), access)
+			if err != nil {
+				return ReplayResult{}, err
+			}
+			if fullReason == "" {
+				return ReplayResult{
+					DB:           db,
+					AppliedCount: snap.Events + res.Applied,
+					SkippedTypes: map[string]int{},
+					Snapshot:     filepath.Base(path),
+				}, nil
+			}
+		}
+	}
+	return ReplayEventsV1(s.Dir)
+}
+
+// snapshotTrusted reports whether a rebuild may start from snap: its watermark is the one the events
+// it covers give, and it is signed by its creator with a key the log publishes.
+func (s Store) snapshotTrusted(snap *Snapshot) (bool, error) {
+	lines, err := s.readEventsV1LinesJSONL()
+	if err != nil {
+		return false, err
+	}
+	if snapshotWatermarkIssue(snap, lines) != "" {
+		return false, nil
+	}
+	sortEventV1Lines(lines)
+	return snap.checkSig(replayIdentities(lines, nil)) == "", nil
+}
+
+// snapshotWatermarkIssue returns why snap's shard marks and heads aren't those of the events of
+// lines it covers (a mark past or between lines, a head that isn't an event of its entity), or "".
+func snapshotWatermarkIssue(snap *Snapshot, lines []EventV1Line) string {
+	w := newReplayWatermark()
+	for _, l := range lines {
+		if l.End <= snap.Shards[filepath.Base(l.Path)].Offset {
+			w.observe(l)
+		}
+	}
+	for name, m := range snap.Shards {
+		if got := w.shards[name]; got != shardMark(m) && (m.Offset != 0 || got.Offset != 0) {
+			return fmt.Sprintf("the snapshot's watermark for %s doesn't end at an event of that shard", name)
+		}
+	}
+	if len(w.heads) != len(snap.Heads) {
+		return fmt.Sprintf("the snapshot has %d entity heads, the events it covers %d", len(snap.Heads), len(w.heads))
+	}
+	for id, h := range snap.Heads {
+		k, ok := w.heads[id]
+		want := eventKey{Clock: h.HLC, IssuedAt: h.IssuedAt.UTC(), EventID: h.EventID}
+		if !ok || k.EventID != want.EventID || k.before(want) || want.before(k) {
+			return fmt.Sprintf("the snapshot's head for %s (%s) isn't the newest event it covers for it", id, h.EventID)
+		}
+	}
+	return ""
+}
+
+// snapshotIssues checks the latest snapshot: that it is intact, that its state equals a replay of
+// the events it covers, and that rebuilding from it gives the same state as a full replay.
+func snapshotIssues(st Store) []DoctorIssue {
+	if st.eventLogBackend() != EventLogBackendJSONL {
+		return nil
+	}
+	paths, err := st.snapshotPaths()
+	if err != nil {
+		return []DoctorIssue{{Level: DoctorIssueLevelError, Code: "snapshot_read_failed", Message: err.Error(), Path: st.snapshotsDir()}}
+	}
+	if len(paths) == 0 {
+		return nil
+	}
+	path := paths[len(paths)-1]
+	issue := func(level DoctorIssueLevel, code, msg string) []DoctorIssue {
+		return []DoctorIssue{{Level: level, Code: code, Message: msg, Path: path}}
+	}
+
+	snap, err := readSnapshot(path)
+	switch {
+	case errors.Is(err, errSnapshotVersion):
+		return issue(DoctorIssueLevelError, "snapshot_version_unsupported", err.Error())
+	case errors.Is(err, errSnapshotDigest):
+		return issue(DoctorIssueLevelError, "snapshot_digest_mismatch", err.Error()+"; the snapshot was edited after it was written")
+	case err != nil:
+		return issue(DoctorIssueLevelError, "snapshot_invalid", err.Error())
+	}
+
+	access, err := st.loadAccessRules()
+	if err != nil {
+		// Reported as users_invalid.
+		return nil
+	}
+	if snap.Access != access.Digest() {
+		return issue(DoctorIssueLevelWarn, "snapshot_stale", "roles in meta/users.json changed since the snapshot; rebuilds replay the full log (write a new snapshot)")
+	}
+	lines, err := st.readEventsV1LinesJSONL()
+	if err != nil {
+		return issue(DoctorIssueLevelError, "snapshot_check_failed", err.Error())
+	}
+	for name, mark := range snap.Shards {
+		ok, err := shardMarkStillValid(filepath.Join(st.eventsDir(), name), shardMark(mark))
+		if errors.Is(err, os.ErrNotExist) {
+			ok, err = mark.Offset == 0, nil
+		}
+		if err != nil {
+			return issue(DoctorIssueLevelError, "snapshot_check_failed", err.Error())
+		}
+		if !ok {
+			return issue(DoctorIssueLevelWarn, "snapshot_stale", name+" was rewritten below the snapshot's watermark; rebuilds replay the full log (write a new snapshot)")
+		}
+	}
+	if reason := snapshotWatermarkIssue(snap, lines); reason != "" {
+		return issue(DoctorIssueLevelError, "snapshot_watermark_mismatch", reason+"; rebuilds replay the full log")
+	}
+
+	var covered []EventV1Line
+	for _, l := range lines {
+		if l.End <= snap.Shards[filepath.Base(l.Path)].Offset {
+			covered = append(covered, l)
+		}
+	}
+	sortEventV1Lines(covered)
+	replayed, err := replayEventV1Lines(covered, access, nil)
+	if err != nil {
+		return issue(DoctorIssueLevelError, "snapshot_check_failed", err.Error())
+	}
+	if diff := snapshotStateDiff(snap.State, snapshotStateOf(replayed.DB)); diff != "" {
+		return issue(DoctorIssueLevelError, "snapshot_state_mismatch", "snapshot state differs from a replay of the events it covers ("+diff+")")
+	}
+
+	db, _, fullReason, err := st.applyPending(snap.db(), access)
+	if err != nil {
+		return issue(DoctorIssueLevelError, "snapshot_check_failed", err.Error())
+	}
+	if fullReason != "" {
+		return issue(DoctorIssueLevelWarn, "snapshot_stale", "rebuilds replay the full log: "+fullReason+" (write a new snapshot)")
+	}
+	sortEventV1Lines(lines)
+	full, err := replayEventV1Lines(lines, access, nil)
+	if err != nil {
+		return issue(DoctorIssueLevelError, "snapshot_check_failed", err.Error())
+	}
+	if diff := snapshotStateDiff(snapshotStateOf(db), snapshotStateOf(full.DB)); diff != "" {
+		return issue(DoctorIssueLevelError, "snapshot_replay_mismatch", "state rebuilt from the snapshot differs from a full replay ("+diff+")")
+	}
+	return nil
+}
+
+// snapshotStateDiff names the collections that differ between a and b ("" when equal).
+func snapshotStateDiff(a, b SnapshotState) string {
+	ja, _ := json.Marshal(a)
+	jb, _ := json.Marshal(b)
+	var ma, mb map[string]json.RawMessage
+	_ = json.Unmarshal(ja, &ma)
+	_ = json.Unmarshal(jb, &mb)
+	var diff []string
+	for k, v := range ma {
+		if !bytes.Equal(normalizeEmptyJSON(v), normalizeEmptyJSON(mb[k])) {
+			diff = append(diff, k)
+		}
+	}
+	sort.Strings(diff)
+	return strings.Join(diff, ", ")
+}
+
+// normalizeEmptyJSON treats null and an empty array/object alike: a nil and an empty collection
+// are the same state.
+func normalizeEmptyJSON(b json.RawMessage) []byte {
+	switch s := string(bytes.TrimSpace(b)); s {
+	case "", "null", "[]", "{}":
+		return nil
+	default:
+		return []byte(s)
+	}
+}
@@ -0,0 +1,206 @@
+package store
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func snapshotIssueCodes(s Store) map[string]bool {
+	codes := map[string]bool{}
+	for _, it := range DoctorEventsV1(s.Dir).Issues {
+		codes[it.Code] = true
+	}
+	return codes
+}
+
+// newSnapshotWorkspace is a watermark workspace whose act-1 has a signing key (snapshots are signed).
+func newSnapshotWorkspace(t *testing.T) Store {
+	t.Helper()
+	s := newWatermarkWorkspace(t)
+	db, err := s.Load()
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if _, err := s.AddActorKey(db, "act-1", "act-1"); err != nil {
+		t.Fatalf("AddActorKey: %v", err)
+	}
+	return s
+}
+
+func TestSnapshot_ReindexStartsFromLatestSnapshot(t *testing.T) {
+	s := newSnapshotWorkspace(t)
+	info, err := s.WriteSnapshot("act-1")
+	if err != nil {
+		t.Fatalf("WriteSnapshot: %v", err)
+	}
+	if info.Events != 6 {
+		t.Fatalf("expected 6 events in snapshot, got %d", info.Events)
+	}
+	appendShard(t, s.Dir, "rep-a", setTitleLine("evt-6", "evt-5", "rep-a", "2025-12-31T00:00:06Z", "After"))
+
+	res, err := s.Reindex()
+	if err != nil {
+		t.Fatalf("Reindex: %v", err)
+	}
+	if res.Snapshot != filepath.Base(info.Path) {
+		t.Fatalf("expected reindex to start from %s, got %q", filepath.Base(info.Path), res.Snapshot)
+	}
+	if got := itemTitle(t, res.DB); got != "After" {
+		t.Fatalf("expected title After, got %q", got)
+	}
+	if res.DB.CurrentActorID != "act-1" {
+		t.Fatalf("expected current actor carried over, got %q", res.DB.CurrentActorID)
+	}
+
+	full, err := s.ReindexFull()
+	if err != nil {
+		t.Fatalf("ReindexFull: %v", err)
+	}
+	if full.Snapshot != "" {
+		t.Fatalf("expected a full replay, got snapshot %q", full.Snapshot)
+	}
+	if diff := snapshotStateDiff(snapshotStateOf(res.DB), snapshotStateOf(full.DB)); diff != "" {
+		t.Fatalf("snapshot rebuild differs from full replay in %s", diff)
+	}
+
+	for code := range snapshotIssueCodes(s) {
+		if strings.HasPrefix(code, "snapshot_") {
+			t.Fatalf("unexpected doctor issue %s", code)
+		}
+	}
+}
+
+func TestSnapshot_ForkAfterSnapshotFallsBackToFullReplay(t *testing.T) {
+	s := newSnapshotWorkspace(t)
+	if _, err := s.WriteSnapshot("act-1"); err != nil {
+		t.Fatalf("WriteSnapshot: %v", err)
+	}
+	// A concurrent edit from another replica, parented before the snapshot's head for item-1.
+	writeShard(t, s.Dir, "rep-b", setTitleLine("evt-b1", "evt-4", "rep-b", "2025-12-31T00:00:04Z", "Theirs"))
+
+	res, err := s.Reindex()
+	if err != nil {
+		t.Fatalf("Reindex: %v", err)
+	}
+	if res.Snapshot != "" {
+		t.Fatalf("expected a full replay, got snapshot %q", res.Snapshot)
+	}
+	if !snapshotIssueCodes(s)["snapshot_stale"] {
+		t.Fatalf("expected snapshot_stale")
+	}
+}
+
+func TestSnapshot_DoctorDetectsTampering(t *testing.T) {
+	s := newSnapshotWorkspace(t)
+	info, err := s.WriteSnapshot("act-1")
+	if err != nil {
+		t.Fatalf("WriteSnapshot: %v", err)
+	}
+	b, err := os.ReadFile(info.Path)
+	if err != nil {
+		t.Fatalf("read snapshot: %v", err)
+	}
+	var snap Snapshot
+	if err := json.Unmarshal(b, &snap); err != nil {
+		t.Fatalf("parse snapshot: %v", err)
+	}
+	snap.State.Items[0].Title = "Edited"
+	write := func() {
+		t.Helper()
+		b, err := json.Marshal(snap)
+		if err != nil {
+			t.Fatalf("marshal: %v", err)
+		}
+		if err := os.WriteFile(info.Path, b, 0o644); err != nil {
+			t.Fatalf("write snapshot: %v", err)
+		}
+	}
+
+	// Edited without updating the digest: never used, reported.
+	write()
+	if !snapshotIssueCodes(s)["snapshot_digest_mismatch"] {
+		t.Fatalf("expected snapshot_digest_mismatch")
+	}
+	res, err := s.Reindex()
+	if err != nil {
+		t.Fatalf("Reindex: %v", err)
+	}
+	if res.Snapshot != "" || itemTitle(t, res.DB) != "Base" {
+		t.Fatalf("expected the tampered snapshot to be ignored, got snapshot %q title %q", res.Snapshot, itemTitle(t, res.DB))
+	}
+
+	// Digest recomputed: the state no longer matches a replay of the log.
+	if snap.Digest, err = snap.computeDigest(); err != nil {
+		t.Fatalf("digest: %v", err)
+	}
+	write()
+	codes := snapshotIssueCodes(s)
+	if !codes["snapshot_state_mismatch"] || !codes["snapshot_signature_invalid"] {
+		t.Fatalf("expected snapshot_state_mismatch and snapshot_signature_invalid, got %v", codes)
+	}
+	// ... and, its signature no longer matching, a rebuild doesn't start from it.
+	if res, err = s.Reindex(); err != nil || res.Snapshot != "" || itemTitle(t, res.DB) != "Base" {
+		t.Fatalf("expected the re-digested snapshot to be ignored, got snapshot %q (err=%v)", res.Snapshot, err)
+	}
+
+	// Nor from an unsigned one.
+	snap.Sig, snap.KeyID = "", ""
+	if snap.Digest, err = snap.computeDigest(); err != nil {
+		t.Fatalf("digest: %v", err)
+	}
+	write()
+	if !snapshotIssueCodes(s)["snapshot_unsigned"] {
+		t.Fatalf("expected snapshot_unsigned")
+	}
+	if res, err = s.Reindex(); err != nil || res.Snapshot != "" || itemTitle(t, res.DB) != "Base" {
+		t.Fatalf("expected the unsigned snapshot to be ignored, got snapshot %q (err=%v)", res.Snapshot, err)
+	}
+}
+
+func TestSnapshot_RebuildIgnoresSnapshotWithForeignWatermark(t *testing.T) {
+	s := newSnapshotWorkspace(t)
+	info, err := s.WriteSnapshot("act-1")
+	if err != nil {
+		t.Fatalf("WriteSnapshot: %v", err)
+	}
+	snap, err := readSnapshot(info.Path)
+	if err != nil {
+		t.Fatalf("readSnapshot: %v", err)
+	}
+	// Signed by its creator, but its head for item-1 is no event in the log.
+	snap.State.Items[0].Title = "Forged"
+	h := snap.Heads["item-1"]
+	h.EventID = "evt-forged"
+	snap.Heads["item-1"] = h
+	if snap.Digest, err = snap.computeDigest(); err != nil {
+		t.Fatalf("digest: %v", err)
+	}
+	key, err := s.LoadLocalKey("act-1")
+	if err != nil || key == nil {
+		t.Fatalf("LoadLocalKey: %v", err)
+	}
+	if err := snap.sign(*key); err != nil {
+		t.Fatalf("sign: %v", err)
+	}
+	b, err := json.Marshal(snap)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	if err := os.WriteFile(info.Path, b, 0o644); err != nil {
+		t.Fatalf("write snapshot: %v", err)
+	}
+
+	if !snapshotIssueCodes(s)["snapshot_watermark_mismatch"] {
+		t.Fatalf("expected snapshot_watermark_mismatch")
+	}
+	res, err := s.Reindex()
+	if err != nil {
+		t.Fatalf("Reindex: %v", err)
+	}
+	if res.Snapshot != "" || itemTitle(t, res.DB) != "Base" {
+		t.Fatalf("expected a full replay, got snapshot %q title %q", res.Snapshot, itemTitle(t, res.DB))
+	}
+}
@@ -72,7 +72,14 @@ func (s Store) LoadSQLite(ctx context.Context) (*DB, error) {
                 }
         }
 
-        return loadStateFromSQLite(ctx, db)
+        out, err := loadStateFromSQLite(ctx, db)
+        if err != nil {
+                return nil, err
+        }
+        if out.replay, err = loadReplayWatermark(ctx, db); err != nil {
+                return nil, err
+        }
+        return out, nil
 }
 
 func (s Store) SaveSQLite(ctx context.Context, st *DB) error {
@@ -116,6 +123,7 @@ func (s Store) SaveSQLite(ctx context.Context, st *DB) error {
                 "comments",
                 "worklog",
                 "attachments",
+                "follows",
         }
         for _, t := range tables {
                 if _, err := tx.ExecContext(ctx, `DELETE FROM `+t); err != nil {
@@ -229,10 +237,50 @@ func (s Store) SaveSQLite(ctx context.Context, st *DB) error {
                 }
         }
 
-        return tx.Commit()
+        for _, f := range st.Follows {
+                raw, _ := json.Marshal(f)
+                if _, err := tx.ExecContext(ctx, `INSERT INTO follows(id, actor_id, target_kind, target_id, json, updated_at_unixms) VALUES(?, ?, ?, ?, ?, ?)`,
+                        f.ID, f.ActorID, f.TargetKind, f.TargetID, string(raw), nowMs); err != nil {
+                        return err
+                }
+        }
+
+        if err := writeSearchIndex(ctx, tx, st); err != nil {
+                return err
+        }
+
+        // State that wasn't loaded/replayed with a watermark (e.g. a legacy import) keeps the stored one;
+        // without any, the next Load does a full replay.
+        w := st.replay
+        if w == nil {
+                if w, err = loadReplayWatermark(ctx, tx); err != nil {
+                        return err
+                }
+        }
+        if w != nil {
+                if err := s.advanceLocalShard(w); err != nil {
+                        return err
+                }
+                if err := saveReplayWatermark(ctx, tx, w); err != nil {
+                        return err
+                }
+        }
+
+        if err := tx.Commit(); err != nil {
+                return err
+        }
+        if w != nil {
+                w.dirtyHeads = map[string]bool{}
+                w.allHeadsDirty = false
+                st.replay = w
+        }
+        return nil
 }
 
 func migrateSQLiteState(ctx context.Context, db *sql.DB) error {
+        if err := dropOutdatedReplayWatermark(ctx, db); err != nil {
+                return err
+        }
         stmts := []string{
                 `CREATE TABLE IF NOT EXISTS state_meta (
                         k TEXT PRIMARY KEY,
@@ -323,6 +371,37 @@ func migrateSQLiteState(ctx context.Context, db *sql.DB) error {
                         updated_at_unixms INTEGER NOT NULL
                 );`,
                 `CREATE INDEX IF NOT EXISTS idx_attachments_entity ON attachments(entity_kind, entity_id, updated_at_unixms);`,
+                `CREATE TABLE IF NOT EXISTS follows (
+                        id TEXT PRIMARY KEY,
+                        actor_id TEXT NOT NULL,
+                        target_kind TEXT NOT NULL,
+                        target_id TEXT NOT NULL,
+                        json TEXT NOT NULL,
+                        updated_at_unixms INTEGER NOT NULL
+                );`,
+                `CREATE INDEX IF NOT EXISTS idx_follows_actor ON follows(actor_id);`,
+                // Incremental replay watermark (see replay_watermark.go).
+                `CREATE TABLE IF NOT EXISTS replay_shards (
+                        shard TEXT PRIMARY KEY,
+                        offset_bytes INTEGER NOT NULL,
+                        lines INTEGER NOT NULL,
+                        last_line_start INTEGER NOT NULL,
+                        last_event_id TEXT NOT NULL
+                );`,
+                `CREATE TABLE IF NOT EXISTS replay_heads (
+                        entity_id TEXT PRIMARY KEY,
+                        hlc_wall_ms INTEGER NOT NULL,
+                        hlc_logical INTEGER NOT NULL,
+                        issued_at_unixnano INTEGER NOT NULL,
+                        event_id TEXT NOT NULL
+                );`,
+                // Full-text index over item titles/descriptions/tags, comments, worklog and attachment titles.
+                `CREATE VIRTUAL TABLE IF NOT EXISTS search_fts USING fts5(
+                        item_id UNINDEXED,
+                        field UNINDEXED,
+                        source_id UNINDEXED,
+                        body
+                );`,
         }
         for _, st := range stmts {
                 if _, err := db.ExecContext(ctx, st); err != nil {
@@ -410,6 +489,11 @@ func loadStateFromSQLite(ctx context.Context, db *sql.DB) (*DB, error) {
         } else {
                 return nil, err
         }
+        if xs, err := readJSONRows[model.Follow](ctx, db, `SELECT json FROM follows`); err == nil {
+                out.Follows = xs
+        } else {
+                return nil, err
+        }
 
         // Ensure nil slices are empty for stable callers.
         if out.Actors == nil {
@@ -436,6 +520,9 @@ func loadStateFromSQLite(ctx context.Context, db *sql.DB) (*DB, error) {
         if out.Attachments == nil {
                 out.Attachments = []model.Attachment{}
         }
+        if out.Follows == nil {
+                out.Follows = []model.Follow{}
+        }
 
         return out, nil
 }
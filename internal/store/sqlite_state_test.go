@@ -1,6 +1,7 @@
 package store
 
 import (
+        "context"
         "encoding/json"
         "os"
         "testing"
@@ -93,3 +94,48 @@ func TestSQLiteStateStore_ImportsLegacyDBJSONOnce(t *testing.T) {
 }
 
 func filepathJoin(a, b string) string { return a + string(os.PathSeparator) + b }
+
+func TestSQLiteStateStore_SearchCandidates(t *testing.T) {
+        withEnv(t, envEventLogBackend, string(EventLogBackendSQLite), func() {
+                withEnv(t, "CLARITY_CONFIG_DIR", t.TempDir(), func() {
+                        s := Store{Dir: t.TempDir()}
+
+                        // No index yet: callers fall back to a full scan.
+                        if got, err := s.SearchCandidates(context.Background(), []string{"deploy"}); err != nil || got != nil {
+                                t.Fatalf("expected nil candidates before first save, got %v err=%v", got, err)
+                        }
+
+                        now := time.Now().UTC()
+                        db := &DB{
+                                Version:  1,
+                                NextIDs:  map[string]int{},
+                                Actors:   []model.Actor{{ID: "act-a", Kind: model.ActorKindHuman, Name: "A"}},
+                                Projects: []model.Project{{ID: "proj-a", Name: "P", CreatedBy: "act-a", CreatedAt: now}},
+                                Outlines: []model.Outline{{ID: "out-a", ProjectID: "proj-a", StatusDefs: DefaultOutlineStatusDefs(), CreatedBy: "act-a", CreatedAt: now}},
+                                Items: []model.Item{
+                                        {ID: "item-a", ProjectID: "proj-a", OutlineID: "out-a", Title: "Deploy pipeline", OwnerActorID: "act-a", CreatedAt: now, UpdatedAt: now},
+                                        {ID: "item-b", ProjectID: "proj-a", OutlineID: "out-a", Title: "Other", Tags: []string{"infra"}, OwnerActorID: "act-a", CreatedAt: now, UpdatedAt: now},
+                                },
+                                Comments: []model.Comment{{ID: "c-1", ItemID: "item-b", AuthorID: "act-a", Body: "deployment notes", CreatedAt: now}},
+                        }
+                        if err := s.Save(db); err != nil {
+                                t.Fatalf("save sqlite: %v", err)
+                        }
+
+                        got, err := s.SearchCandidates(context.Background(), []string{"deploy"})
+                        if err != nil {
+                                t.Fatalf("search: %v", err)
+                        }
+                        if len(got) != 2 || !got["item-a"] || !got["item-b"] {
+                                t.Fatalf("expected both items for prefix 'deploy', got %v", got)
+                        }
+                        got, err = s.SearchCandidates(context.Background(), []string{"deploy", "infra"})
+                        if err != nil {
+                                t.Fatalf("search: %v", err)
+                        }
+                        if len(got) != 1 || !got["item-b"] {
+                                t.Fatalf("expected terms to intersect across fields, got %v", got)
+                        }
+                })
+        })
+}
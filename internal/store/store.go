@@ -33,6 +33,7 @@ type DB struct {
         Comments         []model.Comment      `json:"comments"`
         Worklog          []model.WorklogEntry `json:"worklog"`
         Attachments      []model.Attachment   `json:"attachments,omitempty"`
+        Follows          []model.Follow       `json:"follows,omitempty"`
 
         // Derived indexes for fast per-item lookups in the TUI. These are not persisted.
         idxBuilt               bool                            `json:"-"`
@@ -40,10 +41,20 @@ type DB struct {
         idxCommentsByItem      map[string][]model.Comment      `json:"-"`
         idxWorklogByItem       map[string][]model.WorklogEntry `json:"-"`
         idxAttachmentsByEntity map[string][]model.Attachment   `json:"-"`
+
+        // replay is the incremental replay watermark this state was loaded/replayed with (see
+        // replay_watermark.go). Not persisted in JSON.
+        replay *replayWatermark `json:"-"`
+
+        // access are the roles of meta/users.json that mutations are checked against (see access.go).
+        access *AccessRules `json:"-"`
 }
 
 type Store struct {
         Dir string
+
+        // NoHooks disables meta/hooks.json for appends through this store (see `clarity docs hooks`).
+        NoHooks bool
 }
 
 var appendEventCounter uint64
@@ -150,7 +161,29 @@ func (s Store) Load() (*DB, error) {
         }
 
         // SQLite is the only source of truth. LoadSQLite will auto-import legacy db.json once if needed.
-        return s.LoadSQLite(context.Background())
+        ctx := context.Background()
+        db, err := s.LoadSQLite(ctx)
+        if err != nil {
+                return nil, err
+        }
+
+        access, err := s.loadAccessRules()
+        if err != nil {
+                return nil, err
+        }
+
+        // Apply events appended to the JSONL shards since the state was saved (e.g. by `git pull`).
+        db, res, err := s.catchUp(ctx, db, access)
+        if err != nil {
+                return nil, err
+        }
+        if res.Mode != "none" {
+                if err := s.SaveSQLite(ctx, db); err != nil {
+                        return nil, err
+                }
+        }
+        db.access = access
+        return db, nil
 }
 
 func migrateRanks(db *DB, legacyOrderByID map[string]int) bool {
@@ -412,23 +445,24 @@ func (s Store) NextID(db *DB, prefix string) string {
 }
 
 func (s Store) AppendEvent(actorID, typ, entityID string, payload any) error {
+        var ev EventV1
+        var err error
         switch s.eventLogBackend() {
         case EventLogBackendJSONL:
                 if err := s.ensureWritableForAppend(context.Background()); err != nil {
                         return err
                 }
-                if err := s.appendEventJSONL(context.Background(), actorID, typ, entityID, payload); err != nil {
-                        return err
-                }
-                atomic.AddUint64(&appendEventCounter, 1)
-                return nil
+                ev, err = s.appendEventJSONL(context.Background(), actorID, typ, entityID, payload)
         default:
-                if err := s.appendEventSQLite(context.Background(), actorID, typ, entityID, payload); err != nil {
-                        return err
-                }
-                atomic.AddUint64(&appendEventCounter, 1)
-                return nil
+                ev, err = s.appendEventSQLite(context.Background(), actorID, typ, entityID, payload)
+        }
+        if err != nil {
+                return err
         }
+        atomic.AddUint64(&appendEventCounter, 1)
+        // Post-commit and queued: hook failures are retried and dead-lettered, never returned.
+        s.runHooks(ev)
+        return nil
 }
 
 func (db *DB) FindActor(id string) (*model.Actor, bool) {
@@ -0,0 +1,155 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"clarity-cli/internal/model"
+)
+
+// ErrEventNotFound is returned when an event id given as a position in the log doesn't exist.
+var ErrEventNotFound = errors.New("event not found")
+
+// AsOf selects a point in the event log. Exactly one field is set.
+type AsOf struct {
+	// EventID: the state right after this event, in replay order.
+	EventID string
+	// Time: the state after every event issued at or before Time.
+	Time time.Time
+}
+
+func (a AsOf) String() string {
+	if strings.TrimSpace(a.EventID) != "" {
+		return strings.TrimSpace(a.EventID)
+	}
+	return a.Time.UTC().Format(time.RFC3339)
+}
+
+// AsOfResult is the workspace state at an AsOf point. The DB is for reading only: it must never
+// be saved over the current state.
+type AsOfResult struct {
+	DB      *DB
+	Last    *EventV1 // last event applied (nil if the log was empty at that point)
+	Applied int
+}
+
+// ReplayAsOf replays the event log up to at.
+func (s Store) ReplayAsOf(at AsOf) (AsOfResult, error) {
+	lines, err := s.replayOrderedLines()
+	if err != nil {
+		return AsOfResult{}, err
+	}
+	if id := strings.TrimSpace(at.EventID); id != "" {
+		idx := -1
+		for i, l := range lines {
+			if strings.TrimSpace(l.Event.EventID) == id {
+				idx = i
+				break
+			}
+		}
+		if idx < 0 {
+			return AsOfResult{}, fmt.Errorf("%w: %s", ErrEventNotFound, id)
+		}
+		lines = lines[:idx+1]
+	} else {
+		// An event's clock is ahead of its parents', so this keeps every event's causal past.
+		kept := lines[:0]
+		for _, l := range lines {
+			if !l.Event.Clock().Time().After(at.Time) {
+				kept = append(kept, l)
+			}
+		}
+		lines = kept
+	}
+
+	access, err := s.loadAccessRules()
+	if err != nil {
+		return AsOfResult{}, err
+	}
+	res, err := replayEventV1Lines(lines, access, nil)
+	if err != nil {
+		return AsOfResult{}, err
+	}
+	out := AsOfResult{DB: res.DB, Applied: res.AppliedCount}
+	if len(lines) > 0 {
+		last := lines[len(lines)-1].Event
+		out.Last = &last
+	}
+	return out, nil
+}
+
+// ItemRevision is an item right after one of its own events; Item is nil if the event left no
+// item (e.g. one that arrived before the item's create event).
+type ItemRevision struct {
+	Event EventV1     `json:"event"`
+	Item  *model.Item `json:"item,omitempty"`
+}
+
+// ItemRevisions replays the log once and returns the item after each of its events, oldest first.
+func (s Store) ItemRevisions(itemID string) ([]ItemRevision, error) {
+	itemID = strings.TrimSpace(itemID)
+	lines, err := s.replayOrderedLines()
+	if err != nil {
+		return nil, err
+	}
+	access, err := s.loadAccessRules()
+	if err != nil {
+		return nil, err
+	}
+	out := []ItemRevision{}
+	var snapErr error
+	_, err = replayEventV1Lines(lines, access, func(l EventV1Line, db *DB) {
+		ev := l.Event
+		if snapErr != nil || ev.EntityKind != EntityKindItem || strings.TrimSpace(ev.EntityID) != itemID {
+			return
+		}
+		rev := ItemRevision{Event: ev}
+		if it, ok := db.FindItem(itemID); ok && it != nil {
+			// Later events update the item in place; keep an independent copy.
+			b, err := json.Marshal(it)
+			if err != nil {
+				snapErr = err
+				return
+			}
+			var cp model.Item
+			if err := json.Unmarshal(b, &cp); err != nil {
+				snapErr = err
+				return
+			}
+			rev.Item = &cp
+		}
+		out = append(out, rev)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if snapErr != nil {
+		return nil, snapErr
+	}
+	return out, nil
+}
+
+// replayOrderedLines returns the whole event log in replay order, for either backend.
+func (s Store) replayOrderedLines() ([]EventV1Line, error) {
+	var lines []EventV1Line
+	if s.eventLogBackend() == EventLogBackendJSONL {
+		var err error
+		if lines, err = s.readEventsV1LinesJSONL(); err != nil {
+			return nil, err
+		}
+	} else {
+		evs, err := s.ReadEventsV1(context.Background(), 0)
+		if err != nil {
+			return nil, err
+		}
+		for i, ev := range evs {
+			lines = append(lines, EventV1Line{Path: "sqlite", Line: i + 1, Event: ev})
+		}
+	}
+	sortEventV1Lines(lines)
+	return lines, nil
+}
@@ -0,0 +1,78 @@
+package store
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestReplayAsOf_EventAndTime(t *testing.T) {
+	s := newWatermarkWorkspace(t)
+	at := func(ts string) time.Time {
+		v, err := time.Parse(time.RFC3339, ts)
+		if err != nil {
+			t.Fatalf("parse: %v", err)
+		}
+		return v
+	}
+
+	for _, tc := range []struct {
+		name  string
+		at    AsOf
+		title string // "" = item doesn't exist yet
+		last  string
+	}{
+		{"event", AsOf{EventID: "evt-4"}, "T", "evt-4"},
+		{"latest event", AsOf{EventID: "evt-5"}, "Base", "evt-5"},
+		{"between events", AsOf{Time: at("2025-12-31T00:00:04Z")}, "T", "evt-4"},
+		{"at an event", AsOf{Time: at("2025-12-31T00:00:05Z")}, "Base", "evt-5"},
+		{"before create", AsOf{Time: at("2025-12-31T00:00:02Z")}, "", "evt-3"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			res, err := s.ReplayAsOf(tc.at)
+			if err != nil {
+				t.Fatalf("ReplayAsOf: %v", err)
+			}
+			it, ok := res.DB.FindItem("item-1")
+			switch {
+			case tc.title == "" && ok:
+				t.Fatalf("expected no item, got %+v", it)
+			case tc.title != "" && (!ok || it.Title != tc.title):
+				t.Fatalf("expected title %q, got %+v", tc.title, it)
+			}
+			if res.Last == nil || res.Last.EventID != tc.last {
+				t.Fatalf("expected last event %s, got %+v", tc.last, res.Last)
+			}
+		})
+	}
+
+	if _, err := s.ReplayAsOf(AsOf{EventID: "evt-nope"}); !errors.Is(err, ErrEventNotFound) {
+		t.Fatalf("expected ErrEventNotFound, got %v", err)
+	}
+}
+
+func TestItemRevisions_SnapshotsAfterEachEvent(t *testing.T) {
+	s := newWatermarkWorkspace(t)
+	appendShard(t, s.Dir, "rep-a", setTitleLine("evt-a6", "evt-5", "rep-a", "2025-12-31T00:00:06Z", "Later"))
+
+	revs, err := s.ItemRevisions("item-1")
+	if err != nil {
+		t.Fatalf("ItemRevisions: %v", err)
+	}
+	var got []string
+	for _, r := range revs {
+		if r.Item == nil {
+			t.Fatalf("missing item after %s", r.Event.EventID)
+		}
+		got = append(got, r.Event.EventID+"="+r.Item.Title)
+	}
+	want := []string{"evt-4=T", "evt-5=Base", "evt-a6=Later"}
+	if len(got) != len(want) {
+		t.Fatalf("revisions = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("revisions = %v, want %v", got, want)
+		}
+	}
+}
@@ -0,0 +1,379 @@
+package store
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"clarity-cli/internal/model"
+)
+
+var (
+	ErrNothingToUndo = errors.New("nothing to undo")
+	ErrNothingToRedo = errors.New("nothing to redo")
+)
+
+// UndoConflictError means someone else changed the entity after the action being undone (or
+// redone); compensating it would silently overwrite their change.
+type UndoConflictError struct {
+	Op       string // "undo" or "redo"
+	EventID  string
+	EntityID string
+	ActorIDs []string
+}
+
+func (e UndoConflictError) Error() string {
+	return fmt.Sprintf("cannot %s %s: %s was changed since by %s", e.Op, e.EventID, e.EntityID, strings.Join(e.ActorIDs, ", "))
+}
+
+// Payload keys that mark compensating events; they are ignored by replay.
+const (
+	undoOfKey = "undoOf"
+	redoOfKey = "redoOf"
+)
+
+// undoableEventTypes are the item edits undo can compensate. item.repeat is folded into the
+// item.set_status that caused it.
+var undoableEventTypes = map[string]bool{
+	"item.set_title":       true,
+	"item.set_description": true,
+	"item.set_status":      true,
+	"item.set_priority":    true,
+	"item.set_on_hold":     true,
+	"item.set_due":         true,
+	"item.set_schedule":    true,
+	"item.tags_add":        true,
+	"item.tags_remove":     true,
+	"item.tags_set":        true,
+	"item.move":            true,
+	"item.set_parent":      true,
+	"item.indent":          true,
+	"item.outdent":         true,
+	"item.archive":         true,
+}
+
+// PlannedEvent is an event an undo or redo will append.
+type PlannedEvent struct {
+	Type     string         `json:"type"`
+	EntityID string         `json:"entityId"`
+	Payload  map[string]any `json:"payload"`
+}
+
+// UndoPlan is the set of compensating events for one action.
+type UndoPlan struct {
+	// Of is the action being undone or redone (the actor's original event).
+	Of     EventV1        `json:"of"`
+	Events []PlannedEvent `json:"events"`
+}
+
+// Apply applies the planned events to db, as replay would once they're appended.
+func (p UndoPlan) Apply(db *DB) error {
+	now := time.Now().UTC()
+	for _, pe := range p.Events {
+		b, err := json.Marshal(pe.Payload)
+		if err != nil {
+			return err
+		}
+		ev := EventV1{Type: pe.Type, EntityKind: EntityKindItem, EntityID: pe.EntityID, IssuedAt: now, Payload: b}
+		if _, err := applyEventV1(db, ev); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// undoEntry is one step on an actor's undo or redo stack: the event the step is about, and the
+// range of log lines (in replay order) that performed it last.
+type undoEntry struct {
+	eventID     string
+	first, last int
+}
+
+// PlanUndo plans undoing actorID's most recent not-yet-undone action. Actions issued before
+// since (if set) are out of reach.
+func (s Store) PlanUndo(actorID string, since time.Time) (UndoPlan, error) {
+	return s.planUndoRedo(actorID, since, false)
+}
+
+// PlanRedo plans redoing actorID's most recent undo, as long as they haven't made a new edit
+// since.
+func (s Store) PlanRedo(actorID string, since time.Time) (UndoPlan, error) {
+	return s.planUndoRedo(actorID, since, true)
+}
+
+func (s Store) planUndoRedo(actorID string, since time.Time, redo bool) (UndoPlan, error) {
+	actorID = strings.TrimSpace(actorID)
+	lines, err := s.replayOrderedLines()
+	if err != nil {
+		return UndoPlan{}, err
+	}
+	undoStack, redoStack := undoStacks(lines, actorID)
+
+	op, stack, marker, none := "undo", undoStack, undoOfKey, ErrNothingToUndo
+	if redo {
+		op, stack, marker, none = "redo", redoStack, redoOfKey, ErrNothingToRedo
+	}
+	if len(stack) == 0 {
+		return UndoPlan{}, none
+	}
+	top := stack[len(stack)-1]
+	if !since.IsZero() && lines[top.first].Event.IssuedAt.Before(since) {
+		return UndoPlan{}, none
+	}
+
+	of, ok := EventV1{}, false
+	for _, l := range lines {
+		if l.Event.EventID == top.eventID {
+			of, ok = l.Event, true
+			break
+		}
+	}
+	if !ok {
+		return UndoPlan{}, fmt.Errorf("%w: %s", ErrEventNotFound, top.eventID)
+	}
+	entityID := strings.TrimSpace(lines[top.first].Event.EntityID)
+
+	seen := map[string]bool{}
+	var others []string
+	for _, l := range lines[top.last+1:] {
+		ev := l.Event
+		if strings.TrimSpace(ev.EntityID) != entityID || strings.TrimSpace(ev.ActorID) == actorID || seen[ev.ActorID] {
+			continue
+		}
+		seen[ev.ActorID] = true
+		others = append(others, ev.ActorID)
+	}
+	if len(others) > 0 {
+		return UndoPlan{}, UndoConflictError{Op: op, EventID: top.eventID, EntityID: entityID, ActorIDs: others}
+	}
+
+	access, err := s.loadAccessRules()
+	if err != nil {
+		return UndoPlan{}, err
+	}
+	before, after, err := snapshotAround(lines, access, top.first, top.last, entityID)
+	if err != nil {
+		return UndoPlan{}, err
+	}
+	events := compensatingEvents(before, after)
+	if len(events) == 0 {
+		return UndoPlan{}, fmt.Errorf("%w: %s changed nothing", none, top.eventID)
+	}
+	for i := range events {
+		events[i].Payload[marker] = top.eventID
+	}
+	return UndoPlan{Of: of, Events: events}, nil
+}
+
+// undoStacks walks the actor's events in replay order and returns what is left to undo and to
+// redo. Compensating events are recognised by their undoOf/redoOf marker.
+func undoStacks(lines []EventV1Line, actorID string) (undo, redo []undoEntry) {
+	remove := func(stack []undoEntry, id string) []undoEntry {
+		for i := len(stack) - 1; i >= 0; i-- {
+			if stack[i].eventID == id {
+				return append(stack[:i], stack[i+1:]...)
+			}
+		}
+		return stack
+	}
+	prev := -1
+	for i, l := range lines {
+		ev := l.Event
+		if strings.TrimSpace(ev.ActorID) != actorID {
+			continue
+		}
+		undoOf, redoOf := undoMarkers(ev)
+		switch {
+		case undoOf != "":
+			if n := len(redo); n > 0 && redo[n-1].eventID == undoOf && redo[n-1].last == prev {
+				redo[n-1].last = i
+			} else {
+				undo = remove(undo, undoOf)
+				redo = append(redo, undoEntry{eventID: undoOf, first: i, last: i})
+			}
+		case redoOf != "":
+			if n := len(undo); n > 0 && undo[n-1].eventID == redoOf && undo[n-1].last == prev {
+				undo[n-1].last = i
+			} else {
+				redo = remove(redo, redoOf)
+				undo = append(undo, undoEntry{eventID: redoOf, first: i, last: i})
+			}
+		case ev.Type == "item.repeat":
+			// Completing a repeating item appends item.repeat right after item.set_status.
+			if n := len(undo); n > 0 && undo[n-1].last == prev && lines[prev].Event.Type == "item.set_status" && lines[prev].Event.EntityID == ev.EntityID {
+				undo[n-1].last = i
+			}
+		case undoableEventTypes[ev.Type]:
+			undo = append(undo, undoEntry{eventID: ev.EventID, first: i, last: i})
+			redo = nil
+		}
+		prev = i
+	}
+	return undo, redo
+}
+
+func undoMarkers(ev EventV1) (undoOf, redoOf string) {
+	if !strings.HasPrefix(ev.Type, "item.") || len(ev.Payload) == 0 {
+		return "", ""
+	}
+	var p struct {
+		UndoOf string `json:"undoOf"`
+		RedoOf string `json:"redoOf"`
+	}
+	if err := json.Unmarshal(ev.Payload, &p); err != nil {
+		return "", ""
+	}
+	return strings.TrimSpace(p.UndoOf), strings.TrimSpace(p.RedoOf)
+}
+
+// itemSnapshot is an item, plus the ranks of the items in its outline, at one point in the log.
+type itemSnapshot struct {
+	item  *model.Item
+	ranks map[string]string
+}
+
+// snapshotAround replays the log (under access) and captures the entity just before lines[first]
+// and just after lines[last].
+func snapshotAround(lines []EventV1Line, access *AccessRules, first, last int, itemID string) (before, after itemSnapshot, err error) {
+	take := func(db *DB) (itemSnapshot, error) {
+		snap := itemSnapshot{ranks: map[string]string{}}
+		it, ok := db.FindItem(itemID)
+		if !ok || it == nil {
+			return snap, nil
+		}
+		b, err := json.Marshal(it)
+		if err != nil {
+			return snap, err
+		}
+		var cp model.Item
+		if err := json.Unmarshal(b, &cp); err != nil {
+			return snap, err
+		}
+		snap.item = &cp
+		for _, x := range db.Items {
+			if x.OutlineID == cp.OutlineID && x.ID != cp.ID {
+				snap.ranks[x.ID] = x.Rank
+			}
+		}
+		return snap, nil
+	}
+
+	var snapErr error
+	i := -1
+	_, err = replayEventV1Lines(lines[:last+1], access, func(_ EventV1Line, db *DB) {
+		i++
+		if snapErr != nil {
+			return
+		}
+		switch i {
+		case first - 1:
+			before, snapErr = take(db)
+		case last:
+			after, snapErr = take(db)
+		}
+	})
+	if err != nil {
+		return itemSnapshot{}, itemSnapshot{}, err
+	}
+	return before, after, snapErr
+}
+
+// compensatingEvents returns events that set every field that differs between before and after
+// back to its before value.
+func compensatingEvents(before, after itemSnapshot) []PlannedEvent {
+	b, a := before.item, after.item
+	if b == nil || a == nil {
+		return nil
+	}
+	id := a.ID
+	var out []PlannedEvent
+	add := func(typ string, payload map[string]any) {
+		out = append(out, PlannedEvent{Type: typ, EntityID: id, Payload: payload})
+	}
+
+	if b.Title != a.Title {
+		add("item.set_title", map[string]any{"title": b.Title})
+	}
+	if b.Description != a.Description {
+		add("item.set_description", map[string]any{"description": b.Description})
+	}
+	if b.StatusID != a.StatusID {
+		add("item.set_status", map[string]any{"from": a.StatusID, "to": b.StatusID, "status": b.StatusID})
+	}
+	if b.Priority != a.Priority {
+		add("item.set_priority", map[string]any{"priority": b.Priority})
+	}
+	if b.OnHold != a.OnHold {
+		add("item.set_on_hold", map[string]any{"onHold": b.OnHold})
+	}
+	if !reflect.DeepEqual(b.Due, a.Due) {
+		add("item.set_due", map[string]any{"due": b.Due})
+	}
+	if !reflect.DeepEqual(b.Schedule, a.Schedule) {
+		add("item.set_schedule", map[string]any{"schedule": b.Schedule})
+	}
+	if !sameTags(b.Tags, a.Tags) {
+		tags := b.Tags
+		if tags == nil {
+			tags = []string{}
+		}
+		add("item.tags_set", map[string]any{"tags": tags})
+	}
+	if b.Archived != a.Archived {
+		add("item.archive", map[string]any{"archived": b.Archived})
+	}
+
+	parentChanged := derefParent(b.ParentID) != derefParent(a.ParentID)
+	if parentChanged || b.Rank != a.Rank {
+		// Siblings the action re-ranked go back to their ranks too.
+		rebalance := map[string]string{}
+		ids := make([]string, 0, len(after.ranks))
+		for x := range after.ranks {
+			ids = append(ids, x)
+		}
+		sort.Strings(ids)
+		for _, x := range ids {
+			if r, ok := before.ranks[x]; ok && r != after.ranks[x] {
+				rebalance[x] = r
+			}
+		}
+		payload := map[string]any{"rank": b.Rank}
+		if len(rebalance) > 0 {
+			payload["rebalance"] = rebalance
+		}
+		if parentChanged {
+			parent := derefParent(b.ParentID)
+			if parent == "" {
+				parent = "none"
+			}
+			payload["parent"] = parent
+			add("item.set_parent", payload)
+		} else {
+			add("item.move", payload)
+		}
+	}
+	return out
+}
+
+func derefParent(p *string) string {
+	if p == nil {
+		return ""
+	}
+	return strings.TrimSpace(*p)
+}
+
+func sameTags(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,120 @@
+package store
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newUndoWorkspace(t *testing.T, events string) (Store, *DB) {
+	t.Helper()
+	dir := t.TempDir()
+	if _, err := EnsureGitBackedV1Layout(dir); err != nil {
+		t.Fatalf("EnsureGitBackedV1Layout: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "events", "events.rep-old.jsonl"), []byte(events), 0o644); err != nil {
+		t.Fatalf("write events: %v", err)
+	}
+	s := Store{Dir: dir}
+	db, err := s.Load()
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	return s, db
+}
+
+func appendPlan(t *testing.T, s Store, db *DB, actorID string, p UndoPlan) {
+	t.Helper()
+	for _, pe := range p.Events {
+		if err := s.AppendEvent(actorID, pe.Type, pe.EntityID, pe.Payload); err != nil {
+			t.Fatalf("AppendEvent: %v", err)
+		}
+	}
+	if err := p.Apply(db); err != nil {
+		t.Fatalf("Apply: %v", err)
+	}
+	if err := s.Save(db); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+}
+
+func TestUndoRedo_TitleRoundTrip(t *testing.T) {
+	s, db := newUndoWorkspace(t, watermarkBaseEvents)
+
+	p, err := s.PlanUndo("act-1", time.Time{})
+	if err != nil {
+		t.Fatalf("PlanUndo: %v", err)
+	}
+	if p.Of.EventID != "evt-5" || len(p.Events) != 1 || p.Events[0].Type != "item.set_title" || p.Events[0].Payload["title"] != "T" || p.Events[0].Payload["undoOf"] != "evt-5" {
+		t.Fatalf("unexpected plan: %+v", p)
+	}
+	appendPlan(t, s, db, "act-1", p)
+	if got := itemTitle(t, db); got != "T" {
+		t.Fatalf("title after undo = %q", got)
+	}
+	if _, err := s.PlanUndo("act-1", time.Time{}); !errors.Is(err, ErrNothingToUndo) {
+		t.Fatalf("expected nothing left to undo, got %v", err)
+	}
+
+	p, err = s.PlanRedo("act-1", time.Time{})
+	if err != nil {
+		t.Fatalf("PlanRedo: %v", err)
+	}
+	if p.Of.EventID != "evt-5" || p.Events[0].Payload["title"] != "Base" || p.Events[0].Payload["redoOf"] != "evt-5" {
+		t.Fatalf("unexpected redo plan: %+v", p)
+	}
+	appendPlan(t, s, db, "act-1", p)
+	if _, err := s.PlanRedo("act-1", time.Time{}); !errors.Is(err, ErrNothingToRedo) {
+		t.Fatalf("expected nothing left to redo, got %v", err)
+	}
+	// The redone action can be undone again.
+	if p, err = s.PlanUndo("act-1", time.Time{}); err != nil || p.Of.EventID != "evt-5" {
+		t.Fatalf("expected evt-5 back on the undo stack, got %+v, %v", p, err)
+	}
+
+	full, err := ReplayEventsV1(s.Dir)
+	if err != nil {
+		t.Fatalf("ReplayEventsV1: %v", err)
+	}
+	if got := itemTitle(t, full.DB); got != "Base" {
+		t.Fatalf("replayed title = %q", got)
+	}
+
+	if _, err := s.PlanUndo("act-1", time.Now().Add(time.Hour)); !errors.Is(err, ErrNothingToUndo) {
+		t.Fatalf("expected actions before since to be out of reach, got %v", err)
+	}
+}
+
+func TestUndo_RefusesWhenSomeoneElseChangedTheEntity(t *testing.T) {
+	other := strings.Replace(setTitleLine("evt-6", "evt-5", "rep-b", "2025-12-31T00:00:06Z", "Theirs"), `"actorId":"act-1"`, `"actorId":"act-2"`, 1)
+	s, _ := newUndoWorkspace(t, watermarkBaseEvents+other)
+
+	_, err := s.PlanUndo("act-1", time.Time{})
+	var conflict UndoConflictError
+	if !errors.As(err, &conflict) || conflict.EntityID != "item-1" || len(conflict.ActorIDs) != 1 || conflict.ActorIDs[0] != "act-2" {
+		t.Fatalf("expected a conflict with act-2, got %v", err)
+	}
+}
+
+func TestUndo_MoveRestoresParentAndRank(t *testing.T) {
+	events := watermarkBaseEvents +
+		`{"eventId":"evt-6","workspaceId":"ws-1","replicaId":"rep-a","entityKind":"item","entityId":"item-2","entitySeq":0,"type":"item.create","issuedAt":"2025-12-31T00:00:06Z","actorId":"act-1","payload":{"id":"item-2","projectId":"proj-1","outlineId":"out-1","rank":"m","title":"Other","status":"todo","ownerActorId":"act-1","createdBy":"act-1","createdAt":"2025-12-31T00:00:06Z","updatedAt":"2025-12-31T00:00:06Z"}}` + "\n" +
+		`{"eventId":"evt-7","workspaceId":"ws-1","replicaId":"rep-a","entityKind":"item","entityId":"item-1","entitySeq":0,"type":"item.set_parent","issuedAt":"2025-12-31T00:00:07Z","actorId":"act-1","payload":{"parent":"item-2","rank":"a"}}` + "\n"
+	s, db := newUndoWorkspace(t, events)
+
+	p, err := s.PlanUndo("act-1", time.Time{})
+	if err != nil {
+		t.Fatalf("PlanUndo: %v", err)
+	}
+	if len(p.Events) != 1 || p.Events[0].Type != "item.set_parent" || p.Events[0].Payload["parent"] != "none" || p.Events[0].Payload["rank"] != "h" {
+		t.Fatalf("unexpected plan: %+v", p.Events)
+	}
+	appendPlan(t, s, db, "act-1", p)
+	it, _ := db.FindItem("item-1")
+	if it.ParentID != nil || it.Rank != "h" {
+		t.Fatalf("item-1 not restored: parent=%v rank=%q", it.ParentID, it.Rank)
+	}
+}
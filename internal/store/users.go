@@ -3,19 +3,23 @@ package store
 import (
         "encoding/json"
         "errors"
+        "fmt"
         "os"
         "path/filepath"
         "sort"
         "strings"
+        "time"
 )
 
 // UsersFile is a workspace-committed mapping from human identities (email) to Clarity actors.
 //
 // Intended path: <workspaceRoot>/meta/users.json
 //
-// V1: only email → actorId is modeled. Future versions can add roles, scopes, and project-level access rules.
+// Roles grant an actor viewer/contributor/maintainer access to a project or an outline (see
+// access.go and `clarity docs identity-ownership`).
 type UsersFile struct {
-        Users []UserRef `json:"users"`
+        Users []UserRef   `json:"users"`
+        Roles []RoleGrant `json:"roles,omitempty"`
 }
 
 type UserRef struct {
@@ -23,6 +27,18 @@ type UserRef struct {
         ActorID string `json:"actorId"`
 }
 
+// RoleGrant gives ActorID (and, unless they have their own grant, the agents of that human) Role on
+// one project or one outline. Since/Until bound when the grant applies, by event time: ending a
+// grant with Until keeps the actor's earlier events valid, deleting it does not.
+type RoleGrant struct {
+        ActorID   string     `json:"actorId"`
+        Role      string     `json:"role"`
+        ProjectID string     `json:"projectId,omitempty"`
+        OutlineID string     `json:"outlineId,omitempty"`
+        Since     *time.Time `json:"since,omitempty"`
+        Until     *time.Time `json:"until,omitempty"`
+}
+
 func UsersPath(workspaceDir string) string {
         workspaceDir = filepath.Clean(strings.TrimSpace(workspaceDir))
         if filepath.Base(workspaceDir) == ".clarity" {
@@ -68,6 +84,26 @@ func LoadUsers(workspaceDir string) (UsersFile, bool, error) {
         sort.Slice(out, func(i, j int) bool { return out[i].Email < out[j].Email })
         f.Users = out
 
+        roles := make([]RoleGrant, 0, len(f.Roles))
+        for i, g := range f.Roles {
+                g.ActorID = strings.TrimSpace(g.ActorID)
+                g.Role = strings.ToLower(strings.TrimSpace(g.Role))
+                g.ProjectID = strings.TrimSpace(g.ProjectID)
+                g.OutlineID = strings.TrimSpace(g.OutlineID)
+                switch {
+                case g.ActorID == "":
+                        return UsersFile{}, true, fmt.Errorf("meta/users.json: roles[%d]: missing actorId", i)
+                case roleRank(g.Role) == 0:
+                        return UsersFile{}, true, fmt.Errorf("meta/users.json: roles[%d]: invalid role %q (expected viewer|contributor|maintainer)", i, g.Role)
+                case (g.ProjectID == "") == (g.OutlineID == ""):
+                        return UsersFile{}, true, fmt.Errorf("meta/users.json: roles[%d]: set exactly one of projectId, outlineId", i)
+                case g.Since != nil && g.Until != nil && !g.Until.After(*g.Since):
+                        return UsersFile{}, true, fmt.Errorf("meta/users.json: roles[%d]: until must be after since", i)
+                }
+                roles = append(roles, g)
+        }
+        f.Roles = roles
+
         return f, true, nil
 }
 
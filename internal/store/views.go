@@ -0,0 +1,154 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// SavedView is a named query (see `clarity docs views`), like an Org custom agenda command.
+type SavedView struct {
+	Name  string `json:"name"`
+	Query string `json:"query"`
+
+	// Description is an optional display label.
+	Description string `json:"description,omitempty"`
+
+	// Key is an optional single-character shortcut in the TUI agenda menu.
+	Key string `json:"key,omitempty"`
+}
+
+// ViewsFile is the workspace-committed list of saved views (meta/views.json), shared via Git.
+type ViewsFile struct {
+	Version int         `json:"version"`
+	Views   []SavedView `json:"views"`
+}
+
+var viewNameRe = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
+
+// ValidateViewName checks that name is usable as a CLI argument (letters, digits, '.', '_', '-').
+func ValidateViewName(name string) error {
+	if !viewNameRe.MatchString(strings.TrimSpace(name)) {
+		return fmt.Errorf("invalid view name %q (use letters, digits, '.', '_' or '-')", name)
+	}
+	return nil
+}
+
+func (s Store) viewsPath() string {
+	return filepath.Join(s.workspaceRoot(), "meta", "views.json")
+}
+
+// LoadViews reads meta/views.json. A missing file yields an empty list.
+func (s Store) LoadViews() (ViewsFile, error) {
+	f := ViewsFile{Version: 1, Views: []SavedView{}}
+	b, err := os.ReadFile(s.viewsPath())
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return f, nil
+		}
+		return ViewsFile{}, err
+	}
+	if err := json.Unmarshal(b, &f); err != nil {
+		return ViewsFile{}, fmt.Errorf("meta/views.json: %w", err)
+	}
+	if f.Version == 0 {
+		f.Version = 1
+	}
+	if f.Version != 1 {
+		return ViewsFile{}, errors.New("meta/views.json: unsupported version")
+	}
+	if f.Views == nil {
+		f.Views = []SavedView{}
+	}
+	for i := range f.Views {
+		f.Views[i].Name = strings.TrimSpace(f.Views[i].Name)
+		f.Views[i].Query = strings.TrimSpace(f.Views[i].Query)
+		f.Views[i].Description = strings.TrimSpace(f.Views[i].Description)
+		f.Views[i].Key = strings.TrimSpace(f.Views[i].Key)
+	}
+	return f, nil
+}
+
+// SaveViews validates and writes meta/views.json (sorted by name).
+func (s Store) SaveViews(f ViewsFile) error {
+	if err := s.ensureWritableForAppend(context.Background()); err != nil {
+		return err
+	}
+	seenNames := map[string]bool{}
+	seenKeys := map[string]string{}
+	for _, v := range f.Views {
+		if err := ValidateViewName(v.Name); err != nil {
+			return err
+		}
+		if strings.TrimSpace(v.Query) == "" {
+			return fmt.Errorf("view %q: empty query", v.Name)
+		}
+		lname := strings.ToLower(v.Name)
+		if seenNames[lname] {
+			return fmt.Errorf("duplicate view name %q", v.Name)
+		}
+		seenNames[lname] = true
+		if v.Key != "" {
+			if utf8.RuneCountInString(v.Key) != 1 || strings.TrimSpace(v.Key) != v.Key {
+				return fmt.Errorf("view %q: key must be a single character", v.Name)
+			}
+			if other, ok := seenKeys[v.Key]; ok {
+				return fmt.Errorf("views %q and %q use the same key %q", other, v.Name, v.Key)
+			}
+			seenKeys[v.Key] = v.Name
+		}
+	}
+
+	f.Version = 1
+	sort.SliceStable(f.Views, func(i, j int) bool { return strings.ToLower(f.Views[i].Name) < strings.ToLower(f.Views[j].Name) })
+	path := s.viewsPath()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	b, err := json.MarshalIndent(f, "", "  ")
+	if err != nil {
+		return err
+	}
+	b = append(b, '\n')
+	return atomicWriteFile(filepath.Dir(path), "views.json.*.tmp", path, b, 0o644)
+}
+
+// Find returns the view named name (case-insensitive).
+func (f ViewsFile) Find(name string) (SavedView, bool) {
+	name = strings.TrimSpace(name)
+	for _, v := range f.Views {
+		if strings.EqualFold(v.Name, name) {
+			return v, true
+		}
+	}
+	return SavedView{}, false
+}
+
+// Upsert replaces the view with the same name, or appends it.
+func (f *ViewsFile) Upsert(v SavedView) {
+	for i := range f.Views {
+		if strings.EqualFold(f.Views[i].Name, v.Name) {
+			f.Views[i] = v
+			return
+		}
+	}
+	f.Views = append(f.Views, v)
+}
+
+// Remove deletes the view named name and reports whether it existed.
+func (f *ViewsFile) Remove(name string) bool {
+	for i := range f.Views {
+		if strings.EqualFold(f.Views[i].Name, strings.TrimSpace(name)) {
+			f.Views = append(f.Views[:i], f.Views[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,58 @@
+package store
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestViews_SaveLoadRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	s := Store{Dir: dir}
+
+	f, err := s.LoadViews()
+	if err != nil {
+		t.Fatalf("LoadViews (missing): %v", err)
+	}
+	if len(f.Views) != 0 {
+		t.Fatalf("expected no views, got %#v", f.Views)
+	}
+
+	f.Upsert(SavedView{Name: "week", Query: "due<=+7d is:open", Key: "w"})
+	f.Upsert(SavedView{Name: "blocked", Query: "is:blocked"})
+	f.Upsert(SavedView{Name: "WEEK", Query: "due<=+7d is:open sort:due", Key: "w"})
+	if err := s.SaveViews(f); err != nil {
+		t.Fatalf("SaveViews: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "meta", "views.json")); err != nil {
+		t.Fatalf("expected meta/views.json: %v", err)
+	}
+
+	got, err := s.LoadViews()
+	if err != nil {
+		t.Fatalf("LoadViews: %v", err)
+	}
+	if len(got.Views) != 2 || got.Views[0].Name != "blocked" || got.Views[1].Name != "WEEK" {
+		t.Fatalf("expected 2 views sorted by name, got %#v", got.Views)
+	}
+	if v, ok := got.Find("week"); !ok || v.Query != "due<=+7d is:open sort:due" {
+		t.Fatalf("expected case-insensitive upsert/find, got %#v", v)
+	}
+	if !got.Remove("blocked") || got.Remove("blocked") {
+		t.Fatalf("expected remove to report existence")
+	}
+}
+
+func TestViews_SaveValidates(t *testing.T) {
+	s := Store{Dir: t.TempDir()}
+	for _, f := range []ViewsFile{
+		{Views: []SavedView{{Name: "has space", Query: "is:open"}}},
+		{Views: []SavedView{{Name: "empty", Query: " "}}},
+		{Views: []SavedView{{Name: "a", Query: "is:open", Key: "xy"}}},
+		{Views: []SavedView{{Name: "a", Query: "is:open", Key: "x"}, {Name: "b", Query: "is:done", Key: "x"}}},
+	} {
+		if err := s.SaveViews(f); err == nil {
+			t.Fatalf("expected validation error for %#v", f.Views)
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package store
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const watchStateFileName = "watch.json"
+
+// WatchState stores named `clarity events watch --cursor` positions.
+//
+// Like the notifications cursor it is local (under .clarity/, never committed): a cursor is a
+// byte offset per shard of this clone's event logs.
+type WatchState struct {
+	Version int                    `json:"version"`
+	Cursors map[string]EventCursor `json:"cursors,omitempty"`
+}
+
+func (s Store) watchStatePath() string {
+	return filepath.Join(s.localDir(), watchStateFileName)
+}
+
+// LoadWatchCursor returns the named cursor and whether it exists.
+func (s Store) LoadWatchCursor(name string) (EventCursor, bool, error) {
+	st, err := s.loadWatchState()
+	if err != nil {
+		return EventCursor{}, false, err
+	}
+	cur, ok := st.Cursors[strings.TrimSpace(name)]
+	return cur, ok, nil
+}
+
+func (s Store) SaveWatchCursor(name string, cur EventCursor) error {
+	name = strings.TrimSpace(name)
+	if name == "" || strings.TrimSpace(s.Dir) == "" {
+		return nil
+	}
+	st, err := s.loadWatchState()
+	if err != nil {
+		return err
+	}
+	st.Cursors[name] = cur
+	if err := os.MkdirAll(s.localDir(), 0o755); err != nil {
+		return err
+	}
+	b, err := json.MarshalIndent(st, "", "  ")
+	if err != nil {
+		return err
+	}
+	path := s.watchStatePath()
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, b, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+func (s Store) loadWatchState() (*WatchState, error) {
+	empty := &WatchState{Version: 1, Cursors: map[string]EventCursor{}}
+	if strings.TrimSpace(s.Dir) == "" {
+		return empty, nil
+	}
+	b, err := os.ReadFile(s.watchStatePath())
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return empty, nil
+		}
+		return nil, err
+	}
+	var st WatchState
+	if err := json.Unmarshal(b, &st); err != nil {
+		return nil, err
+	}
+	if st.Version == 0 {
+		st.Version = 1
+	}
+	if st.Cursors == nil {
+		st.Cursors = map[string]EventCursor{}
+	}
+	return &st, nil
+}
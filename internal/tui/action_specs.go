@@ -39,6 +39,7 @@ var itemActionsItemViewExtrasSpecs = []actionSpec{
 	{key: "L", label: "Open links…"},
 	{key: "u", label: "Attach file…"},
 	{key: "H", label: "View history"},
+	{key: "T", label: "Step through history (fields after each change)"},
 }
 
 var itemActionsItemViewReadOnlySpecs = []actionSpec{
@@ -47,4 +48,5 @@ var itemActionsItemViewReadOnlySpecs = []actionSpec{
 	{key: "y", label: "Copy item ref (includes --workspace)"},
 	{key: "Y", label: "Copy CLI show command (includes --workspace)"},
 	{key: "H", label: "View history"},
+	{key: "T", label: "Step through history (fields after each change)"},
 }
@@ -1,7 +1,6 @@
 package tui
 
 import (
-	"encoding/json"
 	"fmt"
 	"sort"
 	"strings"
@@ -63,7 +62,7 @@ func (m *appModel) openModalForActivityRow(act outlineActivityRowItem) bool {
 			m.showMinibuffer("Comment: not found")
 			return true
 		}
-		title := fmt.Sprintf("Comment — %s — %s", fmtTS(c.CreatedAt), actorAtLabel(m.db, c.AuthorID))
+		title := fmt.Sprintf("Comment — %s — %s%s", fmtTS(c.CreatedAt), actorAtLabel(m.db, c.AuthorID), commentEditedMarker(c))
 		m.openViewEntryModal(title, commentMarkdownWithAttachments(m.db, c))
 		return true
 	case outlineActivityWorklogRoot:
@@ -214,7 +213,7 @@ func commentsModalItems(db *store.DB, itemID string) []list.Item {
 		}
 		first = strings.TrimSpace(first)
 		indent := strings.Repeat("  ", max(0, r.Depth))
-		label := fmt.Sprintf("%s %s %s", fmtTS(c.CreatedAt), actorAtLabel(db, c.AuthorID), truncateInline(first, 120))
+		label := fmt.Sprintf("%s %s%s %s", fmtTS(c.CreatedAt), actorAtLabel(db, c.AuthorID), commentEditedMarker(c), truncateInline(first, 120))
 		title := indent + label
 		items = append(items, activityModalRowItem{
 			kind:      activityModalRowComment,
@@ -263,8 +262,8 @@ func historyModalItems(db *store.DB, events []model.Event, itemID string) []list
 	items := make([]list.Item, 0, len(history))
 	for i := range history {
 		ev := history[i]
-		label := fmt.Sprintf("%s %s %s", fmtTS(ev.TS), actorAtLabel(db, ev.ActorID), eventSummary(ev))
-		body := historyEventMarkdown(ev)
+		label := fmt.Sprintf("%s %s %s%s", fmtTS(ev.TS), actorAtLabel(db, ev.ActorID), eventSigTag(ev), eventSummary(db, ev))
+		body := historyEventMarkdown(db, ev)
 		evCopy := ev
 		items = append(items, activityModalRowItem{
 			kind:   activityModalRowHistory,
@@ -277,9 +276,8 @@ func historyModalItems(db *store.DB, events []model.Event, itemID string) []list
 	return items
 }
 
-func historyEventMarkdown(ev model.Event) string {
-	payload, _ := json.MarshalIndent(ev.Payload, "", "  ")
-	payloadStr := strings.TrimSpace(string(payload))
+func historyEventMarkdown(db *store.DB, ev model.Event) string {
+	payloadStr := strings.TrimSpace(eventPayloadJSON(db, ev))
 	if payloadStr == "" || payloadStr == "null" {
 		payloadStr = "(none)"
 	}
@@ -287,12 +285,17 @@ func historyEventMarkdown(ev model.Event) string {
 		"Type: " + strings.TrimSpace(ev.Type),
 		"Actor: " + strings.TrimSpace(ev.ActorID),
 		"At: " + fmtTS(ev.TS),
+	}
+	if sig := eventSigDetail(ev); sig != "" {
+		lines = append(lines, "Signature: "+sig)
+	}
+	lines = append(lines,
 		"",
 		"Payload:",
 		"```json",
 		payloadStr,
 		"```",
-	}
+	)
 	return strings.Join(lines, "\n")
 }
 
@@ -551,7 +554,7 @@ func buildActivityCommentItems(db *store.DB, itemID string, collapsed map[string
 			}
 		}
 
-		label := fmt.Sprintf("%s %s", fmtTS(c.CreatedAt), actorAtLabel(db, c.AuthorID))
+		label := fmt.Sprintf("%s %s%s", fmtTS(c.CreatedAt), actorAtLabel(db, c.AuthorID), commentEditedMarker(c))
 		out = append(out, outlineActivityRowItem{
 			id:             cid,
 			itemID:         itemID,
@@ -645,14 +648,14 @@ func buildActivityHistoryItems(db *store.DB, events []model.Event, itemID string
 		if eid == "" {
 			continue
 		}
-		body := strings.TrimSpace(historyEventMarkdown(ev))
+		body := strings.TrimSpace(historyEventMarkdown(db, ev))
 		hasDescription := body != ""
 		if hasDescription {
 			if _, ok := collapsed[eid]; !ok {
 				collapsed[eid] = true
 			}
 		}
-		label := fmt.Sprintf("%s %s %s", fmtTS(ev.TS), actorAtLabel(db, ev.ActorID), eventSummary(ev))
+		label := fmt.Sprintf("%s %s %s%s", fmtTS(ev.TS), actorAtLabel(db, ev.ActorID), eventSigTag(ev), eventSummary(db, ev))
 		out = append(out, outlineActivityRowItem{
 			id:             eid,
 			itemID:         itemID,
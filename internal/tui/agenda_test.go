@@ -220,3 +220,141 @@ func TestAgendaView_IsLeftAlignedLikeOtherPages(t *testing.T) {
 		t.Fatalf("expected agenda content to not be centered/over-indented; got %q", first)
 	}
 }
+
+func TestAgendaView_WeekAgendaShowsScheduledItemsByDay(t *testing.T) {
+	dir := t.TempDir()
+	s := store.Store{Dir: dir}
+
+	actorID := "act-human"
+	now := time.Now().UTC()
+	today := time.Now().Format("2006-01-02")
+	nextWeek := time.Now().AddDate(0, 0, 7).Format("2006-01-02")
+
+	db := &store.DB{
+		CurrentActorID: actorID,
+		Actors:         []model.Actor{{ID: actorID, Kind: model.ActorKindHuman, Name: "human"}},
+		Projects:       []model.Project{{ID: "proj-a", Name: "Alpha", CreatedBy: actorID, CreatedAt: now}},
+		Outlines:       []model.Outline{{ID: "out-a", ProjectID: "proj-a", StatusDefs: store.DefaultOutlineStatusDefs(), CreatedBy: actorID, CreatedAt: now}},
+		Items: []model.Item{
+			{ID: "item-today", ProjectID: "proj-a", OutlineID: "out-a", Rank: "h", Title: "Today", StatusID: "todo", Schedule: &model.DateTime{Date: today}, OwnerActorID: actorID, CreatedBy: actorID, CreatedAt: now, UpdatedAt: now},
+			{ID: "item-next", ProjectID: "proj-a", OutlineID: "out-a", Rank: "i", Title: "Next week", StatusID: "todo", Schedule: &model.DateTime{Date: nextWeek}, OwnerActorID: actorID, CreatedBy: actorID, CreatedAt: now, UpdatedAt: now},
+			{ID: "item-undated", ProjectID: "proj-a", OutlineID: "out-a", Rank: "j", Title: "Undated", StatusID: "todo", OwnerActorID: actorID, CreatedBy: actorID, CreatedAt: now, UpdatedAt: now},
+		},
+	}
+	if err := s.Save(db); err != nil {
+		t.Fatalf("save db: %v", err)
+	}
+
+	m := newAppModel(dir, db)
+	mAny, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'a'}})
+	m2 := mAny.(appModel)
+	mAny, _ = m2.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'a'}})
+	m2 = mAny.(appModel)
+	if m2.view != viewAgenda || m2.agendaSpan != "week" {
+		t.Fatalf("expected week agenda after a then a, got view=%v span=%q", m2.view, m2.agendaSpan)
+	}
+
+	agendaRowIDs := func(m appModel) (days int, ids []string) {
+		for _, it := range m.agendaList.Items() {
+			switch r := it.(type) {
+			case agendaDayHeadingItem:
+				days++
+			case agendaRowItem:
+				if r.entry == nil {
+					t.Fatalf("expected dated agenda rows to carry an entry")
+				}
+				ids = append(ids, r.row.item.ID)
+			}
+		}
+		return days, ids
+	}
+
+	days, ids := agendaRowIDs(m2)
+	if days != 7 {
+		t.Fatalf("expected 7 day headings, got %d", days)
+	}
+	if len(ids) != 1 || ids[0] != "item-today" {
+		t.Fatalf("expected only item-today this week, got %v", ids)
+	}
+	if it, ok := m2.agendaList.SelectedItem().(agendaRowItem); !ok || it.row.item.ID != "item-today" {
+		t.Fatalf("expected today's row to be selected")
+	}
+
+	// f: forward one week.
+	mAny, _ = m2.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'f'}})
+	m3 := mAny.(appModel)
+	if _, ids := agendaRowIDs(m3); len(ids) != 1 || ids[0] != "item-next" {
+		t.Fatalf("expected item-next after moving forward a week, got %v", ids)
+	}
+
+	// .: back to the current week.
+	mAny, _ = m3.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'.'}})
+	m4 := mAny.(appModel)
+	if _, ids := agendaRowIDs(m4); len(ids) != 1 || ids[0] != "item-today" {
+		t.Fatalf("expected item-today after returning to current week, got %v", ids)
+	}
+}
+
+func TestAgendaView_SavedViewVariantRunsQuery(t *testing.T) {
+	dir := t.TempDir()
+	s := store.Store{Dir: dir}
+
+	actorID := "act-human"
+	now := time.Now().UTC()
+
+	db := &store.DB{
+		CurrentActorID: actorID,
+		Actors:         []model.Actor{{ID: actorID, Kind: model.ActorKindHuman, Name: "human"}},
+		Projects:       []model.Project{{ID: "proj-a", Name: "Alpha", CreatedBy: actorID, CreatedAt: now}},
+		Outlines:       []model.Outline{{ID: "out-a", ProjectID: "proj-a", StatusDefs: store.DefaultOutlineStatusDefs(), CreatedBy: actorID, CreatedAt: now}},
+		Items: []model.Item{
+			{ID: "item-infra", ProjectID: "proj-a", OutlineID: "out-a", Rank: "h", Title: "Infra", StatusID: "todo", Tags: []string{"infra"}, OwnerActorID: actorID, CreatedBy: actorID, CreatedAt: now, UpdatedAt: now},
+			{ID: "item-other", ProjectID: "proj-a", OutlineID: "out-a", Rank: "i", Title: "Other", StatusID: "todo", OwnerActorID: actorID, CreatedBy: actorID, CreatedAt: now, UpdatedAt: now},
+		},
+	}
+	if err := s.Save(db); err != nil {
+		t.Fatalf("save db: %v", err)
+	}
+	if err := s.SaveViews(store.ViewsFile{Views: []store.SavedView{
+		{Name: "infra", Query: "tag:infra is:open", Key: "i"},
+		{Name: "taken-key", Query: "is:open", Key: "t"},
+	}}); err != nil {
+		t.Fatalf("save views: %v", err)
+	}
+
+	m := newAppModel(dir, db)
+	mAny, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'a'}})
+	m2 := mAny.(appModel)
+	actions := m2.actionPanelActions()
+	if a, ok := actions["i"]; !ok || !strings.Contains(a.label, "infra") {
+		t.Fatalf("expected saved view under its own key, got %#v", actions["i"])
+	}
+	if a, ok := actions["1"]; !ok || !strings.Contains(a.label, "taken-key") {
+		t.Fatalf("expected view with a taken key to fall back to a digit, got %#v", actions["1"])
+	}
+
+	mAny, _ = m2.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'i'}})
+	m2 = mAny.(appModel)
+	if m2.view != viewAgenda || m2.agendaView == nil || m2.agendaView.Name != "infra" {
+		t.Fatalf("expected agenda for saved view, got view=%v agendaView=%v", m2.view, m2.agendaView)
+	}
+	var ids []string
+	for _, it := range m2.agendaList.Items() {
+		if r, ok := it.(agendaRowItem); ok {
+			ids = append(ids, r.row.item.ID)
+		}
+	}
+	if len(ids) != 1 || ids[0] != "item-infra" {
+		t.Fatalf("expected only item-infra, got %v", ids)
+	}
+	if got := m2.breadcrumbText(); !strings.HasSuffix(got, "agenda > view: infra") {
+		t.Fatalf("unexpected breadcrumb %q", got)
+	}
+
+	// Built-in agenda commands clear the saved view.
+	mAny, _ = m2.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'a'}})
+	mAny, _ = mAny.(appModel).Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'t'}})
+	if mAny.(appModel).agendaView != nil {
+		t.Fatalf("expected built-in agenda to clear the saved view")
+	}
+}
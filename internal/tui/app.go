@@ -11,9 +11,11 @@ import (
 	"strings"
 	"time"
 
+	"clarity-cli/internal/agenda"
 	"clarity-cli/internal/model"
 	"clarity-cli/internal/mutate"
 	"clarity-cli/internal/perm"
+	"clarity-cli/internal/readiness"
 	"clarity-cli/internal/statusutil"
 	"clarity-cli/internal/store"
 
@@ -136,6 +138,15 @@ func (m *appModel) returnFromItemView() {
 	case viewArchived:
 		m.view = viewArchived
 		m.refreshArchived()
+	case viewSearch:
+		m.view = viewSearch
+		m.refreshSearch()
+	case viewNotifications:
+		m.view = viewNotifications
+		m.refreshNotifications()
+	case viewConflicts:
+		m.view = viewConflicts
+		m.refreshConflicts()
 	case viewItem:
 		// Return to the previous item (best-effort).
 		if retOpen != "" {
@@ -399,6 +410,12 @@ func (m *appModel) openActionPanel(kind actionPanelKind) {
 	if kind == actionPanelCapture {
 		m.captureKeySeq = nil
 	}
+	if kind == actionPanelNav {
+		m.refreshNotificationsUnread()
+	}
+	if kind == actionPanelAgenda {
+		m.refreshSavedViews()
+	}
 	m.ensureActionPanelSelection()
 	m.pendingEsc = false
 }
@@ -462,6 +479,12 @@ func (m *appModel) pushActionPanel(kind actionPanelKind) {
 	if kind == actionPanelCapture {
 		m.captureKeySeq = nil
 	}
+	if kind == actionPanelNav {
+		m.refreshNotificationsUnread()
+	}
+	if kind == actionPanelAgenda {
+		m.refreshSavedViews()
+	}
 	m.ensureActionPanelSelection()
 }
 
@@ -629,7 +652,7 @@ func (m appModel) actionPanelKeyLayout() actionPanelKeyLayout {
 	//   (We don't want to "steal" exec actions like "v" Cycle view mode from the View section.)
 	// - In the Go to panel, show destinations explicitly.
 	if m.curActionPanelKind() == actionPanelNav {
-		addSection("Destinations", []string{"p", "o", "l", "i", "A", "W", "/", "s"})
+		addSection("Destinations", []string{"p", "o", "l", "i", "A", "W", "/", "f", "n", "s"})
 		// Recent digits are rendered in a special full-width block below; mark them as seen so
 		// they don't fall into "Other".
 		for _, k := range []string{"1", "2", "3", "4", "5", "6", "7", "8", "9"} {
@@ -666,7 +689,7 @@ func (m appModel) actionPanelKeyLayout() actionPanelKeyLayout {
 			addSection("Item", []string{"e", "D", "p", "o", "A", "u", "t", "d", "s", " ", "C", "R", "w", "V", "m", "y", "Y", "r"})
 
 			globalKeys := []string{}
-			for _, k := range []string{"g", "a", "W", "s", "c", "ctrl+t", "q"} {
+			for _, k := range []string{"g", "a", "W", "s", "c", "ctrl+t", "ctrl+z", "ctrl+r", "q"} {
 				if _, ok := actions[k]; ok {
 					globalKeys = append(globalKeys, k)
 				}
@@ -687,7 +710,7 @@ func (m appModel) actionPanelKeyLayout() actionPanelKeyLayout {
 			})
 
 			globalKeys := []string{}
-			for _, k := range []string{"g", "a", "W", "s", "c", "ctrl+t", "q"} {
+			for _, k := range []string{"g", "a", "W", "s", "c", "ctrl+t", "ctrl+z", "ctrl+r", "q"} {
 				if _, ok := actions[k]; ok {
 					globalKeys = append(globalKeys, k)
 				}
@@ -1103,6 +1126,22 @@ func (m appModel) actionPanelActions() map[string]actionPanelAction {
 			},
 		}
 		actions["s"] = actionPanelAction{label: "Sync…", kind: actionPanelActionNav, next: actionPanelSync}
+		actions["ctrl+z"] = actionPanelAction{
+			label: "Undo last edit (this session)",
+			kind:  actionPanelActionExec,
+			handler: func(mm appModel) (appModel, tea.Cmd) {
+				(&mm).undoLast(false)
+				return mm, nil
+			},
+		}
+		actions["ctrl+r"] = actionPanelAction{
+			label: "Redo",
+			kind:  actionPanelActionExec,
+			handler: func(mm appModel) (appModel, tea.Cmd) {
+				(&mm).undoLast(true)
+				return mm, nil
+			},
+		}
 	}
 
 	switch cur {
@@ -1331,6 +1370,34 @@ func (m appModel) actionPanelActions() map[string]actionPanelAction {
 			},
 		}
 		actions["s"] = actionPanelAction{label: "Sync…", kind: actionPanelActionNav, next: actionPanelSync}
+		actions["f"] = actionPanelAction{
+			label: "Search all projects…",
+			kind:  actionPanelActionExec,
+			handler: func(mm appModel) (appModel, tea.Cmd) {
+				(&mm).openSearchModal()
+				return mm, nil
+			},
+		}
+		notifLabel := "Notifications"
+		if m.notificationsUnread > 0 {
+			notifLabel = fmt.Sprintf("Notifications (%d unread)", m.notificationsUnread)
+		}
+		actions["n"] = actionPanelAction{
+			label: notifLabel,
+			kind:  actionPanelActionExec,
+			handler: func(mm appModel) (appModel, tea.Cmd) {
+				(&mm).openNotifications()
+				return mm, nil
+			},
+		}
+		actions["C"] = actionPanelAction{
+			label: "Conflicts",
+			kind:  actionPanelActionExec,
+			handler: func(mm appModel) (appModel, tea.Cmd) {
+				(&mm).openConflicts()
+				return mm, nil
+			},
+		}
 		actions["/"] = actionPanelAction{
 			label: "Jump to item by id…",
 			kind:  actionPanelActionExec,
@@ -1513,15 +1580,26 @@ func (m appModel) actionPanelActions() map[string]actionPanelAction {
 		}
 
 	case actionPanelAgenda:
-		actions["t"] = actionPanelAction{label: "List all unfinished items", kind: actionPanelActionExec, handler: func(mm appModel) (appModel, tea.Cmd) {
-			if mm.view != viewAgenda {
-				mm.hasAgendaReturnView = true
-				mm.agendaReturnView = mm.view
+		openAgenda := func(span agenda.Span) func(mm appModel) (appModel, tea.Cmd) {
+			return func(mm appModel) (appModel, tea.Cmd) {
+				if mm.view != viewAgenda {
+					mm.hasAgendaReturnView = true
+					mm.agendaReturnView = mm.view
+				}
+				mm.view = viewAgenda
+				mm.agendaSpan = span
+				mm.agendaFrom = time.Time{}
+				mm.agendaView = nil
+				mm.refreshAgenda()
+				return mm, nil
 			}
-			mm.view = viewAgenda
-			mm.refreshAgenda()
-			return mm, nil
-		}}
+		}
+		actions["a"] = actionPanelAction{label: "Agenda for current week", kind: actionPanelActionExec, handler: openAgenda(agenda.SpanWeek)}
+		actions["d"] = actionPanelAction{label: "Agenda for today", kind: actionPanelActionExec, handler: openAgenda(agenda.SpanDay)}
+		actions["t"] = actionPanelAction{label: "List all unfinished items", kind: actionPanelActionExec, handler: openAgenda("")}
+		for key, v := range savedViewKeys(m.savedViews, actions) {
+			actions[key] = actionPanelAction{label: savedViewLabel(v), kind: actionPanelActionExec, handler: openSavedView(v)}
+		}
 
 	case actionPanelCapture:
 		actions["ctrl+t"] = actionPanelAction{
@@ -1583,6 +1661,26 @@ func (m appModel) actionPanelActions() map[string]actionPanelAction {
 				return mm, nil
 			},
 		}
+		followOID := strings.TrimSpace(m.selectedOutlineID)
+		if m.view == viewOutlines {
+			if it, ok := m.outlinesList.SelectedItem().(outlineItem); ok {
+				followOID = strings.TrimSpace(it.outline.ID)
+			}
+		}
+		actions["f"] = actionPanelAction{
+			label: m.followLabel(model.FollowTargetOutline, followOID),
+			kind:  actionPanelActionExec,
+			handler: func(mm appModel) (appModel, tea.Cmd) {
+				if followOID == "" {
+					mm.showMinibuffer("No outline selected")
+					return mm, nil
+				}
+				if err := (&mm).toggleFollow(followOID); err != nil {
+					mm.showMinibuffer("Follow: " + err.Error())
+				}
+				return mm, nil
+			},
+		}
 
 	case actionPanelSync:
 		actions["s"] = actionPanelAction{
@@ -1672,6 +1770,15 @@ func (m appModel) actionPanelActions() map[string]actionPanelAction {
 		actions["s"] = actionPanelAction{label: "Sync…", kind: actionPanelActionNav, next: actionPanelSync}
 
 		switch m.view {
+		case viewNotifications:
+			actions["enter"] = actionPanelAction{label: "Open item", kind: actionPanelActionExec}
+			actions["R"] = actionPanelAction{label: "Mark all read", kind: actionPanelActionExec}
+			actions["q"] = actionPanelAction{label: "Quit", kind: actionPanelActionExec}
+		case viewConflicts:
+			actions["enter"] = actionPanelAction{label: "Open item", kind: actionPanelActionExec}
+			actions["1"] = actionPanelAction{label: "Take side 1", kind: actionPanelActionExec}
+			actions["2"] = actionPanelAction{label: "Take side 2", kind: actionPanelActionExec}
+			actions["q"] = actionPanelAction{label: "Quit", kind: actionPanelActionExec}
 		case viewProjects:
 			actions["enter"] = actionPanelAction{label: "Select project", kind: actionPanelActionExec}
 			actions["n"] = actionPanelAction{label: "New project", kind: actionPanelActionExec}
@@ -1703,6 +1810,18 @@ func (m appModel) actionPanelActions() map[string]actionPanelAction {
 					}
 				}
 			}
+			if id := strings.TrimSpace(m.openItemID); id != "" {
+				actions["F"] = actionPanelAction{
+					label: m.followLabel(model.FollowTargetItem, id),
+					kind:  actionPanelActionExec,
+					handler: func(mm appModel) (appModel, tea.Cmd) {
+						if err := (&mm).toggleFollow(id); err != nil {
+							mm.showMinibuffer("Follow: " + err.Error())
+						}
+						return mm, nil
+					},
+				}
+			}
 			if readOnly {
 				addActionSpecs(actions, itemActionsItemViewReadOnlySpecs)
 				actions["q"] = actionPanelAction{label: "Quit", kind: actionPanelActionExec}
@@ -1713,6 +1832,9 @@ func (m appModel) actionPanelActions() map[string]actionPanelAction {
 				if m.pane == paneDetail && m.itemFocus == itemFocusComments {
 					actions["R"] = actionPanelAction{label: "Reply to comment", kind: actionPanelActionExec}
 				}
+				if act, ok := m.itemsList.SelectedItem().(outlineActivityRowItem); ok && act.kind == outlineActivityDepEdge {
+					actions["r"] = actionPanelAction{label: "Unlink dependency", kind: actionPanelActionExec}
+				}
 				actions["q"] = actionPanelAction{label: "Quit", kind: actionPanelActionExec}
 			}
 		case viewOutline:
@@ -1752,6 +1874,19 @@ func (m appModel) actionPanelActions() map[string]actionPanelAction {
 			actions["D"] = actionPanelAction{label: "Edit description", kind: actionPanelActionExec}
 			actions["r"] = actionPanelAction{label: "Archive item", kind: actionPanelActionExec}
 			actions["q"] = actionPanelAction{label: "Quit", kind: actionPanelActionExec}
+			if it, ok := m.itemsList.SelectedItem().(outlineRowItem); ok {
+				id := it.row.item.ID
+				actions["F"] = actionPanelAction{
+					label: m.followLabel(model.FollowTargetItem, id),
+					kind:  actionPanelActionExec,
+					handler: func(mm appModel) (appModel, tea.Cmd) {
+						if err := (&mm).toggleFollow(id); err != nil {
+							mm.showMinibuffer("Follow: " + err.Error())
+						}
+						return mm, nil
+					},
+				}
+			}
 
 			// Item mutations should be discoverable from both panes when preview is visible.
 			if m.pane == paneOutline || (m.pane == paneDetail && m.splitPreviewVisible()) {
@@ -2315,6 +2450,12 @@ func (m appModel) View() string {
 		body = m.viewAgenda()
 	case viewArchived:
 		body = m.viewArchived()
+	case viewSearch:
+		body = m.viewSearch()
+	case viewNotifications:
+		body = m.viewNotifications()
+	case viewConflicts:
+		body = m.viewConflicts()
 	case viewOutline:
 		body = m.viewOutline()
 	case viewItem:
@@ -2357,11 +2498,26 @@ func (m appModel) View() string {
 func (m *appModel) breadcrumbText() string {
 	parts := []string{m.workspaceLabel()}
 	if m.view == viewAgenda {
-		return strings.Join(append(parts, "agenda"), " > ")
+		parts = append(parts, "agenda")
+		if m.agendaView != nil {
+			parts = append(parts, "view: "+m.agendaView.Name)
+		} else if m.agendaSpan != "" && !m.agendaFrom.IsZero() {
+			parts = append(parts, string(m.agendaSpan)+" of "+m.agendaFrom.Format("2006-01-02"))
+		}
+		return strings.Join(parts, " > ")
 	}
 	if m.view == viewArchived {
 		return strings.Join(append(parts, "archived"), " > ")
 	}
+	if m.view == viewSearch {
+		return strings.Join(append(parts, m.searchBreadcrumb()), " > ")
+	}
+	if m.view == viewNotifications {
+		return strings.Join(append(parts, m.notificationsBreadcrumb()), " > ")
+	}
+	if m.view == viewConflicts {
+		return strings.Join(append(parts, m.conflictsBreadcrumb()), " > ")
+	}
 	if m.view == viewProjects {
 		return strings.Join(parts, " > ")
 	}
@@ -2518,8 +2674,18 @@ func (m *appModel) viewProjectAttachments() string {
 	}
 
 	crumb := lipgloss.NewStyle().Width(contentW).Foreground(colorChromeSubtleFg).Render(m.breadcrumbText())
-	body := m.listBodyWithOverflowHint(&m.projectAttachmentsList, contentW, bodyHeight)
-	main := strings.Repeat("\n", topPadLines) + crumb + strings.Repeat("\n", breadcrumbGap+1) + body
+	var main string
+	if it, ok := m.projectAttachmentsList.SelectedItem().(projectAttachmentListItem); ok && contentW >= attachmentPreviewMinW {
+		// Wide enough: preview the selected upload on the right.
+		leftW, rightW := splitPaneWidths(contentW)
+		body := m.listBodyWithOverflowHint(&m.projectAttachmentsList, leftW, bodyHeight)
+		contentH := frameH - topPadLines
+		preview := renderAttachmentPreview(loadAttachmentPreview(m.store, it.Attachment), it.Attachment, rightW, maxInt(0, contentH))
+		main = renderSplitWithLeftHeader(contentW, frameH, leftW, rightW, crumb, body, strings.Join(preview, "\n"))
+	} else {
+		body := m.listBodyWithOverflowHint(&m.projectAttachmentsList, contentW, bodyHeight)
+		main = strings.Repeat("\n", topPadLines) + crumb + strings.Repeat("\n", breadcrumbGap+1) + body
+	}
 	main = lipgloss.NewStyle().Width(w).Padding(0, splitOuterMargin).Render(main)
 	if m.modal == modalNone {
 		return main
@@ -2894,7 +3060,7 @@ func (m appModel) renderActionPanel() string {
 	//   (We don't want to "steal" exec actions like "v" Cycle view mode from the View section.)
 	// - In the Go to panel, show destinations explicitly.
 	if m.curActionPanelKind() == actionPanelNav {
-		addSection("Destinations", []string{"p", "o", "l", "i", "A", "W", "/", "s"})
+		addSection("Destinations", []string{"p", "o", "l", "i", "A", "W", "/", "f", "n", "s"})
 		// Note: "Recently visited/captured" are rendered as special full-width blocks at the bottom.
 		// Mark them as seen so they don't fall into "Other".
 		for _, k := range []string{"1", "2", "3", "4", "5", "6", "7", "8", "9"} {
@@ -2932,7 +3098,7 @@ func (m appModel) renderActionPanel() string {
 			addSection("Item", []string{"e", "D", "p", "o", "A", "u", "t", "d", "s", " ", "C", "R", "w", "V", "m", "y", "Y", "r"})
 
 			globalKeys := []string{}
-			for _, k := range []string{"g", "a", "W", "s", "c", "ctrl+t", "q"} {
+			for _, k := range []string{"g", "a", "W", "s", "c", "ctrl+t", "ctrl+z", "ctrl+r", "q"} {
 				if _, ok := actions[k]; ok {
 					globalKeys = append(globalKeys, k)
 				}
@@ -2961,7 +3127,7 @@ func (m appModel) renderActionPanel() string {
 
 			// Global entrypoints.
 			globalKeys := []string{}
-			for _, k := range []string{"g", "a", "W", "s", "c", "ctrl+t", "q"} {
+			for _, k := range []string{"g", "a", "W", "s", "c", "ctrl+t", "ctrl+z", "ctrl+r", "q"} {
 				if _, ok := actions[k]; ok {
 					globalKeys = append(globalKeys, k)
 				}
@@ -4000,6 +4166,7 @@ func (m *appModel) refreshItems(outline model.Outline) {
 	var items []list.Item
 	showInlineDescriptions := m.outlineViewModeForID(outline.ID) != outlineViewModeColumns
 
+	blockedBy := readiness.OpenBlockerCounts(m.db)
 	depsTouched := map[string]bool{}
 	for _, d := range m.db.Deps {
 		fromID := strings.TrimSpace(d.FromItemID)
@@ -4014,6 +4181,7 @@ func (m *appModel) refreshItems(outline model.Outline) {
 
 	for _, row := range flat {
 		row.commentsCount = len(m.db.CommentsForItem(row.item.ID))
+		row.blockedBy = blockedBy[strings.TrimSpace(row.item.ID)]
 		worklogCount := len(m.db.WorklogForItem(row.item.ID))
 		if row.commentsCount > 0 || worklogCount > 0 || depsTouched[strings.TrimSpace(row.item.ID)] {
 			row.hasChildren = true
@@ -4192,10 +4360,12 @@ func (m *appModel) refreshItemSubtree(outline model.Outline, rootItemID string)
 		}
 	}
 
+	blockedBy := readiness.OpenBlockerCounts(m.db)
 	flat := flattenOutline(m.db, outline, its, collapsed)
 	items := make([]list.Item, 0, len(flat))
 	for _, row := range flat {
 		row.commentsCount = len(m.db.CommentsForItem(row.item.ID))
+		row.blockedBy = blockedBy[strings.TrimSpace(row.item.ID)]
 		worklogCount := len(m.db.WorklogForItem(row.item.ID))
 		if row.commentsCount > 0 || worklogCount > 0 || depsTouched[strings.TrimSpace(row.item.ID)] {
 			row.hasChildren = true
@@ -4286,11 +4456,20 @@ func (m *appModel) refreshAgenda() {
 	if m == nil || m.db == nil {
 		return
 	}
+	if m.agendaView != nil {
+		m.refreshSavedViewAgenda()
+		return
+	}
+	if m.agendaSpan != "" {
+		m.refreshDatedAgenda()
+		return
+	}
 
 	curID := ""
 	if it, ok := m.agendaList.SelectedItem().(agendaRowItem); ok {
 		curID = it.row.item.ID
 	}
+	blockedBy := readiness.OpenBlockerCounts(m.db)
 
 	// Sort projects by name for a stable agenda ordering.
 	projects := make([]model.Project, 0, len(m.db.Projects))
@@ -4408,6 +4587,7 @@ func (m *appModel) refreshAgenda() {
 			}
 			flat := flattenOutline(m.db, o, its, m.agendaCollapsed)
 			for _, row := range flat {
+				row.blockedBy = blockedBy[strings.TrimSpace(row.item.ID)]
 				if row.item.AssignedActorID != nil && strings.TrimSpace(*row.item.AssignedActorID) != "" {
 					row.assignedLabel = actorCompactLabel(m.db, *row.item.AssignedActorID)
 				}
@@ -4444,6 +4624,103 @@ func (m *appModel) refreshAgenda() {
 	}
 }
 
+// refreshDatedAgenda renders the Org-style day/week agenda (scheduled items and deadlines).
+func (m *appModel) refreshDatedAgenda() {
+	curID := ""
+	if it, ok := m.agendaList.SelectedItem().(agendaRowItem); ok {
+		curID = it.row.item.ID
+	}
+
+	today := time.Now()
+	if m.agendaFrom.IsZero() {
+		m.agendaFrom = agenda.DefaultFrom(m.agendaSpan, today)
+	}
+	res := agenda.Build(m.db, agenda.Options{From: m.agendaFrom, Span: m.agendaSpan, Today: today})
+	blockedBy := readiness.OpenBlockerCounts(m.db)
+
+	var items []list.Item
+	for _, day := range res.Days {
+		items = append(items, agendaDayHeadingItem{day: day})
+		for _, e := range day.Rows {
+			it, ok := m.db.FindItem(e.ItemID)
+			if !ok || it == nil {
+				continue
+			}
+			o, ok := m.db.FindOutline(it.OutlineID)
+			if !ok || o == nil {
+				continue
+			}
+			row := outlineRow{item: *it, blockedBy: blockedBy[strings.TrimSpace(it.ID)]}
+			if it.ParentID != nil {
+				if p, ok := m.db.FindItem(*it.ParentID); ok && p != nil {
+					row.checkbox = strings.TrimSpace(p.ChildrenKind) == "checkbox"
+				}
+			}
+			switch strings.TrimSpace(it.ItemKind) {
+			case "checkbox":
+				row.checkbox = true
+			case "status":
+				row.checkbox = false
+			}
+			if it.AssignedActorID != nil && strings.TrimSpace(*it.AssignedActorID) != "" {
+				row.assignedLabel = actorCompactLabel(m.db, *it.AssignedActorID)
+			}
+			if len(it.Tags) > 0 {
+				cleaned := make([]string, 0, len(it.Tags))
+				for _, t := range it.Tags {
+					t = normalizeTag(t)
+					if t == "" {
+						continue
+					}
+					cleaned = append(cleaned, t)
+				}
+				row.item.Tags = uniqueSortedStrings(cleaned)
+			}
+			entry := e
+			items = append(items, agendaRowItem{row: row, outline: *o, entry: &entry})
+		}
+	}
+
+	m.agendaList.SetItems(items)
+	if curID != "" && listHasItemID(&m.agendaList, curID) {
+		selectListItemByID(&m.agendaList, curID)
+		return
+	}
+	// Prefer selecting the first row of today (or the first row in the span).
+	first := -1
+	inToday := false
+	for i := 0; i < len(items); i++ {
+		if h, ok := items[i].(agendaDayHeadingItem); ok {
+			inToday = h.day.Today
+			continue
+		}
+		if first < 0 {
+			first = i
+		}
+		if inToday {
+			first = i
+			break
+		}
+	}
+	if first >= 0 {
+		m.agendaList.Select(first)
+	}
+}
+
+// shiftAgenda moves the dated agenda by n spans (n=0 jumps back to the current span).
+func (m *appModel) shiftAgenda(n int) {
+	if m == nil || m.agendaSpan == "" {
+		return
+	}
+	if n == 0 || m.agendaFrom.IsZero() {
+		m.agendaFrom = agenda.DefaultFrom(m.agendaSpan, time.Now())
+	} else {
+		m.agendaFrom = m.agendaFrom.AddDate(0, 0, n*m.agendaSpan.Days())
+	}
+	m.agendaList.Select(0)
+	m.refreshAgenda()
+}
+
 func (m *appModel) refreshArchived() {
 	if m == nil || m.db == nil {
 		return
@@ -4997,6 +5274,8 @@ func (m *appModel) renderModal() string {
 		return m.renderInputModal("Rename status")
 	case modalJumpToItem:
 		return m.renderInputModal("Jump to item")
+	case modalSearch:
+		return m.renderInputModalWithDescription("Search", "Titles, descriptions, comments, your worklog, tags and attachment titles. Qualifiers: status: tag: assignee:me project: outline: is:open|done|blocked due<DATE schedule>=DATE")
 	case modalAddComment:
 		return m.renderTextAreaModal("Add comment")
 	case modalReplyComment:
@@ -5007,6 +5286,8 @@ func (m *appModel) renderModal() string {
 		return m.renderViewEntryModal()
 	case modalActivityList:
 		return m.renderActivityListModal()
+	case modalHistoryScrubber:
+		return m.renderHistoryScrubberModal()
 	case modalConfirmArchive:
 		title := ""
 		cascade := ""
@@ -5108,6 +5389,12 @@ func (m *appModel) renderDateTimeModal(title string) string {
 		timeLine = toggleLine + "  " + lipgloss.JoinHorizontal(lipgloss.Left, hh, ":", min)
 	}
 
+	rep := strings.TrimSpace(m.dateRepeat)
+	if rep == "" {
+		rep = "none"
+	}
+	repeatLine := renderPill(m.dateFocus == dateFocusRepeat, rep) + "  " + styleMuted().Render(repeatDescription(m.dateRepeat))
+
 	body := strings.Join([]string{
 		styleMuted().Width(bodyW).Render("Date"),
 		lipgloss.JoinHorizontal(lipgloss.Left, y, "-", mo, "-", da),
@@ -5115,6 +5402,9 @@ func (m *appModel) renderDateTimeModal(title string) string {
 		styleMuted().Width(bodyW).Render("Time (optional)"),
 		timeLine,
 		"",
+		styleMuted().Width(bodyW).Render("Repeat (j/k to change)"),
+		repeatLine,
+		"",
 		lipgloss.JoinHorizontal(lipgloss.Left, save, clear, cancel),
 		"",
 		help,
@@ -5548,6 +5838,12 @@ func (m *appModel) reloadFromDisk() error {
 		m.refreshAgenda()
 	case viewArchived:
 		m.refreshArchived()
+	case viewSearch:
+		m.refreshSearch()
+	case viewNotifications:
+		m.refreshNotifications()
+	case viewConflicts:
+		m.refreshConflicts()
 	case viewOutline:
 		if o, ok := m.db.FindOutline(m.selectedOutlineID); ok {
 			m.refreshItems(*o)
@@ -5995,6 +6291,12 @@ func (m appModel) runActionPanelAction(key string, a actionPanelAction) (appMode
 		if a.next == actionPanelCapture {
 			m.captureKeySeq = nil
 		}
+		if a.next == actionPanelNav {
+			(&m).refreshNotificationsUnread()
+		}
+		if a.next == actionPanelAgenda {
+			(&m).refreshSavedViews()
+		}
 		m.actionPanelSelectedKey = ""
 		m.ensureActionPanelSelection()
 		return m, nil
@@ -6326,11 +6628,13 @@ func (m appModel) updateOutline(msg tea.Msg) (tea.Model, tea.Cmd) {
 						if m.timeEnabled {
 							m.dateFocus = dateFocusHour
 						} else {
-							m.dateFocus = dateFocusSave
+							m.dateFocus = dateFocusRepeat
 						}
 					case dateFocusHour:
 						m.dateFocus = dateFocusMinute
 					case dateFocusMinute:
+						m.dateFocus = dateFocusRepeat
+					case dateFocusRepeat:
 						m.dateFocus = dateFocusSave
 					case dateFocusSave:
 						m.dateFocus = dateFocusClear
@@ -6348,6 +6652,8 @@ func (m appModel) updateOutline(msg tea.Msg) (tea.Model, tea.Cmd) {
 					case dateFocusClear:
 						m.dateFocus = dateFocusSave
 					case dateFocusSave:
+						m.dateFocus = dateFocusRepeat
+					case dateFocusRepeat:
 						if m.timeEnabled {
 							m.dateFocus = dateFocusMinute
 						} else {
@@ -6379,7 +6685,10 @@ func (m appModel) updateOutline(msg tea.Msg) (tea.Model, tea.Cmd) {
 				case "left", "h":
 					switch m.dateFocus {
 					case dateFocusYear:
-						// wrap to last field (depends on time enabled)
+						// wrap to last field
+						m.dateFocus = dateFocusRepeat
+						return m, nil
+					case dateFocusRepeat:
 						if m.timeEnabled {
 							m.dateFocus = dateFocusMinute
 						} else {
@@ -6417,13 +6726,16 @@ func (m appModel) updateOutline(msg tea.Msg) (tea.Model, tea.Cmd) {
 						if m.timeEnabled {
 							m.dateFocus = dateFocusHour
 						} else {
-							m.dateFocus = dateFocusSave
+							m.dateFocus = dateFocusRepeat
 						}
 						return m, nil
 					case dateFocusHour:
 						m.dateFocus = dateFocusMinute
 						return m, nil
 					case dateFocusMinute:
+						m.dateFocus = dateFocusRepeat
+						return m, nil
+					case dateFocusRepeat:
 						m.dateFocus = dateFocusYear
 						return m, nil
 					}
@@ -6445,6 +6757,10 @@ func (m appModel) updateOutline(msg tea.Msg) (tea.Model, tea.Cmd) {
 						}
 						return m, nil
 					}
+					if m.dateFocus == dateFocusRepeat {
+						m.dateRepeat = cycleRepeatPreset(m.dateRepeat, +1)
+						return m, nil
+					}
 					if m.bumpDateTimeField(+1) {
 						return m, nil
 					}
@@ -6464,6 +6780,10 @@ func (m appModel) updateOutline(msg tea.Msg) (tea.Model, tea.Cmd) {
 						}
 						return m, nil
 					}
+					if m.dateFocus == dateFocusRepeat {
+						m.dateRepeat = cycleRepeatPreset(m.dateRepeat, -1)
+						return m, nil
+					}
 					if m.bumpDateTimeField(-1) {
 						return m, nil
 					}
@@ -6529,6 +6849,7 @@ func (m appModel) updateOutline(msg tea.Msg) (tea.Model, tea.Cmd) {
 						m.showMinibuffer(err.Error())
 						return m, nil
 					}
+					dt.Repeat = strings.TrimSpace(m.dateRepeat)
 					if m.modal == modalSetDue {
 						if err := m.setDue(itemID, dt); err != nil {
 							return m, m.reportError(itemID, err)
@@ -7347,6 +7668,24 @@ func (m appModel) updateOutline(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, cmd
 		}
 
+		if m.modal == modalHistoryScrubber {
+			if km, ok := msg.(tea.KeyMsg); ok {
+				switch km.String() {
+				case "esc", "q", "T":
+					(&m).closeAllModals()
+				case "left", "h", "p", "k", "up":
+					(&m).stepHistoryScrubber(-1)
+				case "right", "l", "n", "j", "down":
+					(&m).stepHistoryScrubber(1)
+				case "g", "home":
+					(&m).stepHistoryScrubber(-len(m.scrubberRevisions))
+				case "G", "end":
+					(&m).stepHistoryScrubber(len(m.scrubberRevisions))
+				}
+			}
+			return m, nil
+		}
+
 		if m.modal == modalActivityList {
 			switch km := msg.(type) {
 			case tea.KeyMsg:
@@ -7393,7 +7732,7 @@ func (m appModel) updateOutline(msg tea.Msg) (tea.Model, tea.Cmd) {
 						if !ok {
 							return m, nil
 						}
-						title := fmt.Sprintf("Comment — %s — %s", fmtTS(c.CreatedAt), actorAtLabel(m.db, c.AuthorID))
+						title := fmt.Sprintf("Comment — %s — %s%s", fmtTS(c.CreatedAt), actorAtLabel(m.db, c.AuthorID), commentEditedMarker(c))
 						body := commentMarkdownWithAttachments(m.db, c)
 						(&m).openViewEntryModalReturning(title, body, modalActivityList)
 						return m, nil
@@ -7429,7 +7768,7 @@ func (m appModel) updateOutline(msg tea.Msg) (tea.Model, tea.Cmd) {
 							return m, nil
 						}
 						title := fmt.Sprintf("History — %s — %s", fmtTS(ev.TS), actorAtLabel(m.db, ev.ActorID))
-						(&m).openViewEntryModalReturning(title, strings.TrimSpace(historyEventMarkdown(ev)), modalActivityList)
+						(&m).openViewEntryModalReturning(title, strings.TrimSpace(historyEventMarkdown(m.db, ev)), modalActivityList)
 						return m, nil
 					}
 				}
@@ -8067,6 +8406,11 @@ func (m appModel) updateOutline(msg tea.Msg) (tea.Model, tea.Cmd) {
 						return m, nil
 					}
 					(&m).applyReturnSnapshot(snap)
+				case modalSearch:
+					if err := (&m).runSearch(val); err != nil {
+						m.showMinibuffer("Search: " + err.Error())
+						return m, nil
+					}
 				case modalNewProject:
 					if val == "" {
 						return m, nil
@@ -9103,6 +9447,21 @@ func (m appModel) updateAgenda(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, nil
 		}
 
+		// Dated agenda: step through days/weeks (Org-style f/b/.).
+		if m.agendaSpan != "" {
+			switch km.String() {
+			case "f":
+				m.shiftAgenda(1)
+				return m, nil
+			case "b":
+				m.shiftAgenda(-1)
+				return m, nil
+			case ".":
+				m.shiftAgenda(0)
+				return m, nil
+			}
+		}
+
 		// Disallow structural/move keys in agenda.
 		if strings.HasPrefix(km.String(), "alt+") {
 			m.showMinibuffer("Move/indent is not available in agenda")
@@ -9479,6 +9838,9 @@ func (m *appModel) archiveOutlineTree(outlineID string) (int, error) {
 	if o.Archived {
 		return 0, nil
 	}
+	if err := roleRequiredErr(m.db, actorID, o.ProjectID, o.ID, store.RoleMaintainer); err != nil {
+		return 0, err
+	}
 
 	// Archive all items in this outline (best-effort respecting ownership).
 	now := time.Now().UTC()
@@ -9535,6 +9897,9 @@ func (m *appModel) archiveProjectTree(projectID string) (int, int, error) {
 	if p.Archived {
 		return 0, 0, nil
 	}
+	if err := roleRequiredErr(m.db, actorID, p.ID, "", store.RoleMaintainer); err != nil {
+		return 0, 0, err
+	}
 
 	// Archive all outlines + items in this project.
 	outlinesArchived := 0
@@ -9543,7 +9908,7 @@ func (m *appModel) archiveProjectTree(projectID string) (int, int, error) {
 		if o.ProjectID != projectID {
 			continue
 		}
-		if o.Archived {
+		if o.Archived || !perm.CanMaintain(m.db, actorID, o.ProjectID, o.ID) {
 			continue
 		}
 		o.Archived = true
@@ -10770,6 +11135,9 @@ func (m *appModel) createItemFromModal(title string) error {
 		}
 	}
 
+	if err := roleRequiredErr(m.db, actorID, outline.ProjectID, outline.ID, store.RoleContributor); err != nil {
+		return err
+	}
 	assigned := defaultAssignedActorID(m.db, actorID)
 	now := time.Now().UTC()
 	statusID := store.FirstStatusID(outline.StatusDefs)
@@ -10895,6 +11263,9 @@ func (m *appModel) duplicateItem(itemID string, openInItemView bool) (string, er
 		tags = append([]string(nil), src.Tags...)
 	}
 
+	if err := roleRequiredErr(m.db, actorID, outline.ProjectID, outline.ID, store.RoleContributor); err != nil {
+		return "", err
+	}
 	assigned := defaultAssignedActorID(m.db, actorID)
 	now := time.Now().UTC()
 	newID := m.store.NextID(m.db, "item")
@@ -10977,6 +11348,9 @@ type itemMutationResult struct {
 	// refreshPreview clears the preview cache (useful when description/fields affecting
 	// the preview pane are updated).
 	refreshPreview bool
+	// repeatPayload is appended as an item.repeat event after the main event (a completed
+	// repeating item was reopened with its dates rolled forward).
+	repeatPayload map[string]any
 }
 
 type projectMutationResult struct {
@@ -11032,6 +11406,11 @@ func (m *appModel) mutateItem(itemID string, mutate func(db *store.DB, it *model
 		if err := m.appendEvent(actorID, res.eventType, it.ID, res.eventPayload); err != nil {
 			return err
 		}
+		if res.repeatPayload != nil {
+			if err := m.appendEvent(actorID, "item.repeat", it.ID, res.repeatPayload); err != nil {
+				return err
+			}
+		}
 		// Keep in-memory history fresh for the item detail "History" section.
 		m.refreshEventsTail()
 	}
@@ -11081,6 +11460,9 @@ func (m *appModel) mutateProject(projectID string, mutate func(db *store.DB, p *
 	if !ok {
 		return nil
 	}
+	if err := roleRequiredErr(m.db, actorID, p.ID, "", store.RoleMaintainer); err != nil {
+		return err
+	}
 
 	changed, res, err := mutate(m.db, p)
 	if err != nil {
@@ -11144,6 +11526,9 @@ func (m *appModel) mutateOutline(outlineID string, mutate func(db *store.DB, o *
 	if !ok {
 		return nil
 	}
+	if err := roleRequiredErr(m.db, actorID, o.ProjectID, o.ID, store.RoleMaintainer); err != nil {
+		return err
+	}
 
 	changed, res, err := mutate(m.db, o)
 	if err != nil {
@@ -11466,7 +11851,7 @@ func (m *appModel) setDue(itemID string, dt *model.DateTime) error {
 			if b.Time != nil {
 				bt = strings.TrimSpace(*b.Time)
 			}
-			return at == bt
+			return at == bt && strings.TrimSpace(a.Repeat) == strings.TrimSpace(b.Repeat)
 		}
 		if same(it.Due, dt) {
 			return false, itemMutationResult{}, nil
@@ -11508,7 +11893,7 @@ func (m *appModel) setSchedule(itemID string, dt *model.DateTime) error {
 			if b.Time != nil {
 				bt = strings.TrimSpace(*b.Time)
 			}
-			return at == bt
+			return at == bt && strings.TrimSpace(a.Repeat) == strings.TrimSpace(b.Repeat)
 		}
 		if same(it.Schedule, dt) {
 			return false, itemMutationResult{}, nil
@@ -11661,6 +12046,9 @@ func (m *appModel) createOutlineFromModal(name string) error {
 	if _, ok := m.db.FindProject(projectID); !ok {
 		return nil
 	}
+	if err := roleRequiredErr(m.db, actorID, projectID, "", store.RoleMaintainer); err != nil {
+		return err
+	}
 
 	var namePtr *string
 	trim := strings.TrimSpace(name)
@@ -12734,6 +13122,10 @@ func (m *appModel) moveItemToOutline(itemID, toOutlineID, statusOverride string,
 				return false, itemMutationResult{}, errors.New("permission denied")
 			}
 		}
+		// Moving in adds to the target outline (replay requires it too).
+		if err := roleRequiredErr(db, actorID, o.ProjectID, o.ID, store.RoleContributor); err != nil {
+			return false, itemMutationResult{}, err
+		}
 
 		changed := false
 		now := time.Now().UTC()
@@ -12965,11 +13357,15 @@ func (m *appModel) setStatusForItemWithNote(itemID, statusID string, note *strin
 		} else {
 			msg += statusID
 		}
+		if res.RepeatPayload != nil {
+			msg += " (repeats: " + repeatNextLabel(it) + ")"
+		}
 
 		return true, itemMutationResult{
-			eventType:    "item.set_status",
-			eventPayload: res.EventPayload,
-			minibuffer:   msg,
+			eventType:     "item.set_status",
+			eventPayload:  res.EventPayload,
+			minibuffer:    msg,
+			repeatPayload: res.RepeatPayload,
 		}, nil
 	})
 }
@@ -13428,6 +13824,23 @@ func canEditItem(db *store.DB, actorID string, t *model.Item) bool {
 	return perm.CanEditItem(db, actorID, t)
 }
 
+// roleRequiredErr returns nil when actorID has at least role on the outline (or the project, when
+// outlineID is "") under the roles of meta/users.json.
+func roleRequiredErr(db *store.DB, actorID, projectID, outlineID, role string) error {
+	ok := perm.CanContribute(db, actorID, projectID, outlineID)
+	if role == store.RoleMaintainer {
+		ok = perm.CanMaintain(db, actorID, projectID, outlineID)
+	}
+	if ok {
+		return nil
+	}
+	scope := "project " + projectID
+	if outlineID != "" {
+		scope = "outline " + outlineID
+	}
+	return fmt.Errorf("permission denied: needs the %s role on %s", role, scope)
+}
+
 // editActorID returns the human actor id to attribute mutations to.
 //
 // The interactive TUI is primarily for humans. If the current actor is an agent (often due to
@@ -13603,6 +14016,10 @@ func (m *appModel) openDateModal(kind modalKind, itemID string, initial *model.D
 	m.modalForID = itemID
 	m.dateFocus = dateFocusYear
 	m.timeEnabled = initial != nil && initial.Time != nil && strings.TrimSpace(*initial.Time) != ""
+	m.dateRepeat = ""
+	if initial != nil {
+		m.dateRepeat = strings.TrimSpace(initial.Repeat)
+	}
 
 	// Seed values from existing field (if any); default to today.
 	y, mo, d, h, mi := parseDateTimeFieldsOrNow(initial)
@@ -13723,9 +14140,13 @@ func (m *appModel) addComment(itemID, body string, replyToCommentID *string) (st
 	}
 	m.db = db
 
-	if _, ok := m.db.FindItem(itemID); !ok {
+	it, ok := m.db.FindItem(itemID)
+	if !ok {
 		return "", nil
 	}
+	if err := roleRequiredErr(m.db, actorID, it.ProjectID, it.OutlineID, store.RoleContributor); err != nil {
+		return "", err
+	}
 
 	var replyPtr *string
 	if replyToCommentID != nil {
@@ -13798,9 +14219,13 @@ func (m *appModel) addWorklog(itemID, body string) error {
 	}
 	m.db = db
 
-	if _, ok := m.db.FindItem(itemID); !ok {
+	it, ok := m.db.FindItem(itemID)
+	if !ok {
 		return nil
 	}
+	if err := roleRequiredErr(m.db, actorID, it.ProjectID, it.OutlineID, store.RoleContributor); err != nil {
+		return err
+	}
 
 	w := model.WorklogEntry{
 		ID:        m.store.NextID(m.db, "wlg"),
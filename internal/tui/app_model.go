@@ -6,6 +6,7 @@ import (
 	"strings"
 	"time"
 
+	"clarity-cli/internal/agenda"
 	"clarity-cli/internal/gitrepo"
 	"clarity-cli/internal/model"
 	"clarity-cli/internal/store"
@@ -53,6 +54,9 @@ type appModel struct {
 	showArchivedWorkspaces bool
 	agendaList             list.Model
 	archivedList           list.Model
+	searchList             list.Model
+	notificationsList      list.Model
+	conflictsList          list.Model
 	// outlineStatusDefsList is used in the outline statuses editor modal.
 	outlineStatusDefsList list.Model
 
@@ -89,8 +93,15 @@ type appModel struct {
 	hasAgendaReturnView     bool
 	archivedReturnView      view
 	hasArchivedReturnView   bool
+	searchReturnView        view
+	hasSearchReturnView     bool
+	searchQuery             string // last global search; re-run on reload
+	searchTotal             int
 	agendaCollapsed         map[string]bool
 	collapsed               map[string]bool
+	// agendaSpan selects the date-driven agenda (day/week); empty shows all unfinished items.
+	agendaSpan agenda.Span
+	agendaFrom time.Time
 	// itemFocus is used on the full-screen item view to allow Tab navigation across
 	// editable fields (title/status/description/comment/worklog).
 	itemFocus itemPageFocus
@@ -125,6 +136,11 @@ type appModel struct {
 	activityModalList      list.Model
 	activityModalCollapsed map[string]bool
 	activityModalContentW  int
+	// scrubberRevisions/scrubberIndex back the item history scrubber (modalHistoryScrubber).
+	scrubberRevisions []store.ItemRevision
+	scrubberIndex     int
+	// sessionStart bounds TUI undo to edits made in this session.
+	sessionStart time.Time
 	// capture holds the embedded capture model when modal == modalCapture.
 	capture                       *captureModel
 	returnToCaptureAfterTemplates bool
@@ -142,6 +158,7 @@ type appModel struct {
 	dateFocus    dateModalFocus
 	tagsFocus    tagsModalFocus
 	timeEnabled  bool
+	dateRepeat   string // repeater in the due/schedule modal ("" = none)
 	replyQuoteMD string
 
 	attachmentAddKind      string
@@ -221,6 +238,18 @@ type appModel struct {
 	previewDbg   previewDebug
 	inputDbg     inputDebug
 
+	notificationsReturnView    view
+	hasNotificationsReturnView bool
+	notificationsUnread        int // unread count for the current actor; shown in the nav panel
+
+	conflictsReturnView    view
+	hasConflictsReturnView bool
+
+	// savedViews are loaded from meta/views.json when the agenda panel opens; agendaView is the
+	// saved view the agenda currently shows (nil: the built-in agenda).
+	savedViews []store.SavedView
+	agendaView *store.SavedView
+
 	lastDBModTime     time.Time
 	lastEventsModTime time.Time
 
@@ -298,6 +327,9 @@ func (m *appModel) applyAppearanceStyles() {
 	m.itemsList.SetDelegate(newFocusAwareOutlineItemDelegate(m.itemsListActive))
 	m.agendaList.SetDelegate(newCompactItemDelegate())
 	m.archivedList.SetDelegate(newCompactItemDelegate())
+	m.searchList.SetDelegate(newSearchHitDelegate())
+	m.notificationsList.SetDelegate(newNotificationDelegate())
+	m.conflictsList.SetDelegate(newConflictDelegate())
 
 	m.statusList.SetDelegate(newCompactItemDelegate())
 	m.activityModalList.SetDelegate(newOutlineItemDelegate())
@@ -374,6 +406,7 @@ func newAppModelWithWorkspace(dir string, db *store.DB, workspace string) appMod
 		db:             db,
 		view:           viewProjects,
 		pane:           paneOutline,
+		sessionStart:   time.Now().UTC(),
 	}
 
 	if shouldAutoCommit() && m.jsonlWorkspace {
@@ -416,6 +449,15 @@ func newAppModelWithWorkspace(dir string, db *store.DB, workspace string) appMod
 	m.archivedList = newList("Archived", "Archived content", []list.Item{})
 	m.archivedList.SetDelegate(newCompactItemDelegate())
 
+	m.searchList = newList("Search", "Search results", []list.Item{})
+	m.searchList.SetDelegate(newSearchHitDelegate())
+
+	m.notificationsList = newList("Notifications", "Followed outlines and items", []list.Item{})
+	m.notificationsList.SetDelegate(newNotificationDelegate())
+
+	m.conflictsList = newList("Conflicts", "Concurrent edits", []list.Item{})
+	m.conflictsList.SetDelegate(newConflictDelegate())
+
 	m.statusList = newList("Status", "Select a status", []list.Item{})
 	m.statusList.SetDelegate(newCompactItemDelegate())
 	m.statusList.SetFilteringEnabled(false)
@@ -19,6 +19,9 @@ const (
 	viewItem
 	viewAgenda
 	viewArchived
+	viewSearch
+	viewNotifications
+	viewConflicts
 )
 
 type reloadTickMsg struct{}
@@ -140,6 +143,7 @@ const (
 	modalAddOutlineStatus
 	modalRenameOutlineStatus
 	modalJumpToItem
+	modalSearch
 	modalActionPanel
 	modalCaptureTemplates
 	modalCaptureTemplateName
@@ -167,6 +171,7 @@ const (
 	modalEditAttachmentAlt
 	modalCapture
 	modalGitSetupRemote
+	modalHistoryScrubber
 )
 
 type activityModalKind int
@@ -247,6 +252,7 @@ const (
 	dateFocusTimeToggle
 	dateFocusHour
 	dateFocusMinute
+	dateFocusRepeat
 	dateFocusSave
 	dateFocusClear
 	dateFocusCancel
@@ -278,6 +284,8 @@ func (m *appModel) closeAllModals() {
 	m.activityModalKind = activityModalKindComments
 	m.activityModalCollapsed = nil
 	m.activityModalContentW = 0
+	m.scrubberRevisions = nil
+	m.scrubberIndex = 0
 	m.capture = nil
 	m.replyQuoteMD = ""
 	m.pendingMoveOutlineTo = ""
@@ -295,6 +303,7 @@ func (m *appModel) closeAllModals() {
 	m.dateFocus = dateFocusYear
 	m.tagsFocus = tagsFocusInput
 	m.timeEnabled = false
+	m.dateRepeat = ""
 
 	// Reset inputs (safe even if not currently used).
 	m.input.Placeholder = "Title"
@@ -182,6 +182,10 @@ func (m appModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.showMinibuffer("Sync: " + msg.op + ": " + msg.err)
 		} else {
 			m.showMinibuffer("Sync: " + msg.op)
+			if msg.op == "pull" {
+				// Load applies the pulled events incrementally.
+				_ = m.reloadFromDisk()
+			}
 		}
 		return m, nil
 
@@ -233,6 +237,15 @@ func (m appModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if m.view == viewAgenda {
 			return m.updateAgenda(msg)
 		}
+		if m.view == viewSearch {
+			return m.updateSearch(msg)
+		}
+		if m.view == viewNotifications {
+			return m.updateNotifications(msg)
+		}
+		if m.view == viewConflicts {
+			return m.updateConflicts(msg)
+		}
 
 		switch msg.String() {
 		case "ctrl+c", "q":
@@ -249,6 +262,18 @@ func (m appModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, nil
 		case "c":
 			return (&m).openCaptureModal()
+		case "u":
+			// In the item view "u" attaches a file; ctrl+z undoes there.
+			if m.view != viewItem {
+				(&m).undoLast(false)
+				return m, nil
+			}
+		case "ctrl+z":
+			(&m).undoLast(false)
+			return m, nil
+		case "ctrl+r":
+			(&m).undoLast(true)
+			return m, nil
 		case "y":
 			if m.view == viewItem && strings.TrimSpace(m.openItemID) != "" {
 				id := selectedOutlineListItemID(&m.itemsList)
@@ -531,6 +556,17 @@ func (m appModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			//
 			// Note: item view is otherwise read-only, but archiving is a safe global action.
 			if m.view == viewItem && strings.TrimSpace(m.openItemID) != "" {
+				// On a dependency row, "r" unlinks the dependency instead of archiving the item.
+				if act, ok := m.itemsList.SelectedItem().(outlineActivityRowItem); ok && act.kind == outlineActivityDepEdge {
+					if m.itemArchivedReadOnly {
+						m.showMinibuffer("Archived item: read-only")
+						return m, nil
+					}
+					if err := (&m).unlinkDependency(act.itemID, act.depID); err != nil {
+						return m, m.reportError(act.itemID, err)
+					}
+					return m, nil
+				}
 				m.modal = modalConfirmArchive
 				m.confirmFocus = confirmFocusConfirm
 				m.modalForID = strings.TrimSpace(m.openItemID)
@@ -811,6 +847,18 @@ func (m appModel) updateItem(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 			(&m).openHistoryModal(itemID)
 			return m, nil
+		case "T":
+			itemID := strings.TrimSpace(selectedOutlineListItemID(&m.itemsList))
+			if itemID == "" {
+				if it, ok := m.itemsList.SelectedItem().(outlineActivityRowItem); ok {
+					itemID = strings.TrimSpace(it.itemID)
+				}
+			}
+			if itemID == "" {
+				itemID = rootID
+			}
+			(&m).openHistoryScrubber(itemID)
+			return m, nil
 		case "tab":
 			m.toggleCollapseSelected()
 			return m, nil
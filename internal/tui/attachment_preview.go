@@ -0,0 +1,347 @@
+package tui
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"image"
+	"image/color"
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+	"os"
+	"strings"
+	"sync"
+
+	"clarity-cli/internal/model"
+	"clarity-cli/internal/store"
+
+	"github.com/charmbracelet/lipgloss"
+	xansi "github.com/charmbracelet/x/ansi"
+)
+
+const (
+	// Preview sources are capped so selecting a large file stays fast.
+	attachmentPreviewMaxTextBytes = 64 << 10
+	attachmentPreviewMaxEntries   = 200
+	// Images are downscaled to this many pixels per side when loaded.
+	attachmentPreviewMaxImagePx = 200
+	// CSV previews show at most this many rows/columns.
+	attachmentPreviewMaxCSVRows = 30
+	attachmentPreviewMaxCSVCols = 8
+
+	// The Uploads view shows the preview pane only when there's room for it.
+	attachmentPreviewMinW = 80
+)
+
+// attachmentPreview is the loaded source of a preview, plus the last rendering of it.
+type attachmentPreview struct {
+	kind      string
+	text      string
+	truncated bool
+	img       image.Image
+	entries   []store.ArchiveEntry
+	notice    string // shown instead of a preview (not fetched yet, load errors)
+
+	renderedW, renderedH int
+	rendered             []string
+}
+
+var (
+	attachmentPreviewMu sync.Mutex
+	// Loaded previews by attachment content (sha256), so a file is read and decoded once.
+	attachmentPreviews = map[string]*attachmentPreview{}
+)
+
+func attachmentPreviewKey(a model.Attachment) string {
+	if sha := strings.TrimSpace(a.Sha256Hex); sha != "" {
+		return sha
+	}
+	return strings.TrimSpace(a.ID) + ":" + strings.TrimSpace(a.Path)
+}
+
+// cachedAttachmentPreview returns the preview of a if it has been loaded.
+func cachedAttachmentPreview(a model.Attachment) *attachmentPreview {
+	attachmentPreviewMu.Lock()
+	defer attachmentPreviewMu.Unlock()
+	return attachmentPreviews[attachmentPreviewKey(a)]
+}
+
+// loadAttachmentPreview returns the preview of a, reading the file on first use. Files in an
+// external blob store are previewed once they're in the local cache (open/export fetch them), so
+// rendering never waits on the network.
+func loadAttachmentPreview(st store.Store, a model.Attachment) *attachmentPreview {
+	if p := cachedAttachmentPreview(a); p != nil {
+		return p
+	}
+	kind := store.AttachmentPreviewKind(a.OriginalName, a.MimeType)
+	if kind == "" {
+		return &attachmentPreview{notice: "No preview for this file type (enter opens it)."}
+	}
+	if store.AttachmentIsExternal(a) {
+		if _, err := os.Stat(st.AttachmentAbsPath(a)); err != nil {
+			return &attachmentPreview{notice: "Not downloaded yet (enter fetches and opens it)."}
+		}
+	}
+	path, err := st.AttachmentLocalPath(context.Background(), a)
+	if err != nil {
+		// Not cached: the file may show up after a pull.
+		return &attachmentPreview{notice: "Preview unavailable: " + err.Error()}
+	}
+
+	p := &attachmentPreview{kind: kind}
+	switch kind {
+	case store.AttachmentPreviewImage:
+		p.img, err = loadPreviewImage(path)
+	case store.AttachmentPreviewArchive:
+		p.entries, p.truncated, err = store.ListAttachmentArchive(path, a.OriginalName, attachmentPreviewMaxEntries)
+	default:
+		p.text, p.truncated, err = store.ReadAttachmentText(path, attachmentPreviewMaxTextBytes)
+	}
+	if err != nil {
+		p = &attachmentPreview{notice: "Preview unavailable: " + err.Error()}
+	}
+
+	attachmentPreviewMu.Lock()
+	attachmentPreviews[attachmentPreviewKey(a)] = p
+	attachmentPreviewMu.Unlock()
+	return p
+}
+
+func loadPreviewImage(path string) (image.Image, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	img, _, err := image.Decode(f)
+	if err != nil {
+		return nil, err
+	}
+	b := img.Bounds()
+	w, h := fitSize(b.Dx(), b.Dy(), attachmentPreviewMaxImagePx, attachmentPreviewMaxImagePx)
+	return downscaleImage(img, w, h), nil
+}
+
+// renderAttachmentPreview renders p into at most height lines of width columns.
+func renderAttachmentPreview(p *attachmentPreview, a model.Attachment, width, height int) []string {
+	if p == nil || width <= 0 || height <= 0 {
+		return nil
+	}
+	attachmentPreviewMu.Lock()
+	defer attachmentPreviewMu.Unlock()
+	if p.rendered != nil && p.renderedW == width && p.renderedH == height {
+		return p.rendered
+	}
+
+	var lines []string
+	switch {
+	case p.notice != "":
+		lines = strings.Split(lipgloss.NewStyle().Width(width).Render(styleMuted().Render(p.notice)), "\n")
+	case p.kind == store.AttachmentPreviewImage:
+		lines = renderHalfBlockImage(p.img, width, height)
+	case p.kind == store.AttachmentPreviewArchive:
+		lines = renderArchiveListing(p.entries, p.truncated, width)
+	default:
+		md := attachmentPreviewMarkdown(p.kind, p.text, a.OriginalName)
+		lines = strings.Split(renderMarkdownComment(md, maxInt(10, width)), "\n")
+		if p.truncated {
+			lines = append(lines, styleMuted().Render("… (truncated)"))
+		}
+	}
+	for i := range lines {
+		if xansi.StringWidth(lines[i]) > width {
+			lines[i] = cutToWidth(lines[i], width)
+		}
+	}
+	if len(lines) > height {
+		lines = lines[:height]
+	}
+	p.renderedW, p.renderedH, p.rendered = width, height, lines
+	return lines
+}
+
+// attachmentPreviewMarkdown turns a text-like file into markdown for the glamour pipeline used
+// for descriptions: Markdown as-is, JSON pretty-printed, CSV as a table, anything else as a
+// code block.
+func attachmentPreviewMarkdown(kind, text, name string) string {
+	switch kind {
+	case store.AttachmentPreviewMarkdown:
+		return text
+	case store.AttachmentPreviewJSON:
+		var buf bytes.Buffer
+		if err := json.Indent(&buf, []byte(text), "", "  "); err == nil {
+			text = buf.String()
+		}
+		return fenced(text, "json")
+	case store.AttachmentPreviewCSV:
+		if md, ok := csvMarkdownTable(text, strings.HasSuffix(strings.ToLower(name), ".tsv")); ok {
+			return md
+		}
+		return fenced(text, "")
+	default:
+		return fenced(text, "")
+	}
+}
+
+func fenced(text, lang string) string {
+	fence := "```"
+	for strings.Contains(text, fence) {
+		fence += "`"
+	}
+	return fence + lang + "\n" + strings.TrimRight(text, "\n") + "\n" + fence
+}
+
+func csvMarkdownTable(text string, tabs bool) (string, bool) {
+	r := csv.NewReader(strings.NewReader(text))
+	r.FieldsPerRecord = -1
+	r.LazyQuotes = true
+	if tabs {
+		r.Comma = '\t'
+	}
+	var rows [][]string
+	for len(rows) <= attachmentPreviewMaxCSVRows {
+		rec, err := r.Read()
+		if err != nil {
+			// io.EOF, or a cut-off last line of a truncated file.
+			break
+		}
+		rows = append(rows, rec)
+	}
+	if len(rows) == 0 {
+		return "", false
+	}
+	cols := 0
+	for _, rec := range rows {
+		cols = maxInt(cols, len(rec))
+	}
+	if cols > attachmentPreviewMaxCSVCols {
+		cols = attachmentPreviewMaxCSVCols
+	}
+	cell := func(rec []string, i int) string {
+		if i >= len(rec) {
+			return ""
+		}
+		s := strings.Join(strings.Fields(rec[i]), " ")
+		return strings.ReplaceAll(s, "|", `\|`)
+	}
+	var b strings.Builder
+	for ri, rec := range rows {
+		if ri == attachmentPreviewMaxCSVRows {
+			break
+		}
+		b.WriteString("|")
+		for i := 0; i < cols; i++ {
+			b.WriteString(" " + cell(rec, i) + " |")
+		}
+		b.WriteString("\n")
+		if ri == 0 {
+			b.WriteString("|" + strings.Repeat(" --- |", cols) + "\n")
+		}
+	}
+	if len(rows) > attachmentPreviewMaxCSVRows {
+		b.WriteString("\n…")
+	}
+	return b.String(), true
+}
+
+func renderArchiveListing(entries []store.ArchiveEntry, truncated bool, width int) []string {
+	if len(entries) == 0 {
+		return []string{styleMuted().Render("(empty archive)")}
+	}
+	out := make([]string, 0, len(entries)+1)
+	for _, e := range entries {
+		size := ""
+		if !e.Dir {
+			size = formatBytes(e.Size)
+		}
+		name := e.Name
+		if pad := width - xansi.StringWidth(name) - len(size) - 1; pad > 0 {
+			out = append(out, name+strings.Repeat(" ", pad+1)+styleMuted().Render(size))
+			continue
+		}
+		out = append(out, name)
+	}
+	if truncated {
+		out = append(out, styleMuted().Render("… more files"))
+	}
+	return out
+}
+
+func formatBytes(n int64) string {
+	switch {
+	case n >= 1<<20:
+		return fmt.Sprintf("%.1f MB", float64(n)/(1<<20))
+	case n >= 1<<10:
+		return fmt.Sprintf("%.1f KB", float64(n)/(1<<10))
+	default:
+		return fmt.Sprintf("%d B", n)
+	}
+}
+
+// renderHalfBlockImage draws img with "▀" cells: each cell is two pixels, the top one in the
+// foreground color and the bottom one in the background color.
+func renderHalfBlockImage(img image.Image, width, height int) []string {
+	if img == nil {
+		return nil
+	}
+	b := img.Bounds()
+	w, h := fitSize(b.Dx(), b.Dy(), width, height*2)
+	small := downscaleImage(img, w, h)
+	hex := func(c color.Color) lipgloss.Color {
+		r, g, b, _ := c.RGBA()
+		return lipgloss.Color(fmt.Sprintf("#%02x%02x%02x", r>>8, g>>8, b>>8))
+	}
+	out := make([]string, 0, (h+1)/2)
+	for y := 0; y < h; y += 2 {
+		var line strings.Builder
+		for x := 0; x < w; x++ {
+			st := lipgloss.NewStyle().Foreground(hex(small.At(x, y)))
+			if y+1 < h {
+				st = st.Background(hex(small.At(x, y+1)))
+			}
+			line.WriteString(st.Render("▀"))
+		}
+		out = append(out, line.String())
+	}
+	return out
+}
+
+// fitSize scales w×h to fit in maxW×maxH, keeping the aspect ratio (never upscaling).
+func fitSize(w, h, maxW, maxH int) (int, int) {
+	if w <= 0 || h <= 0 || maxW <= 0 || maxH <= 0 {
+		return 0, 0
+	}
+	if w <= maxW && h <= maxH {
+		return w, h
+	}
+	if w*maxH > h*maxW {
+		return maxW, maxInt(1, h*maxW/w)
+	}
+	return maxInt(1, w*maxH/h), maxH
+}
+
+// downscaleImage resizes img to w×h by averaging the source pixels under each target pixel.
+func downscaleImage(img image.Image, w, h int) *image.RGBA {
+	out := image.NewRGBA(image.Rect(0, 0, w, h))
+	b := img.Bounds()
+	for y := 0; y < h; y++ {
+		y0 := b.Min.Y + y*b.Dy()/h
+		y1 := maxInt(y0+1, b.Min.Y+(y+1)*b.Dy()/h)
+		for x := 0; x < w; x++ {
+			x0 := b.Min.X + x*b.Dx()/w
+			x1 := maxInt(x0+1, b.Min.X+(x+1)*b.Dx()/w)
+			var r, g, bl, n uint64
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					cr, cg, cb, _ := img.At(sx, sy).RGBA()
+					r, g, bl, n = r+uint64(cr), g+uint64(cg), bl+uint64(cb), n+1
+				}
+			}
+			out.Set(x, y, color.RGBA64{R: uint16(r / n), G: uint16(g / n), B: uint16(bl / n), A: 0xffff})
+		}
+	}
+	return out
+}
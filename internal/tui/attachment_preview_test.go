@@ -0,0 +1,103 @@
+package tui
+
+import (
+	"archive/zip"
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"clarity-cli/internal/model"
+	"clarity-cli/internal/store"
+
+	xansi "github.com/charmbracelet/x/ansi"
+)
+
+func writePreviewFile(t *testing.T, dir, rel string, write func(f *os.File) error) model.Attachment {
+	t.Helper()
+	p := filepath.Join(dir, filepath.FromSlash(rel))
+	if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	f, err := os.Create(p)
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if err := write(f); err != nil {
+		t.Fatalf("write %s: %v", rel, err)
+	}
+	_ = f.Close()
+	return model.Attachment{ID: "att-" + filepath.Base(rel), OriginalName: filepath.Base(rel), Path: rel}
+}
+
+func TestAttachmentPreview_TextJSONCSVImageAndArchive(t *testing.T) {
+	dir := t.TempDir()
+	st := store.Store{Dir: dir}
+	text := func(s string) func(f *os.File) error {
+		return func(f *os.File) error { _, err := f.WriteString(s); return err }
+	}
+	plain := func(lines []string) string {
+		return stripANSIEscapes(strings.Join(lines, "\n"))
+	}
+
+	md := writePreviewFile(t, dir, "resources/attachments/notes.md", text("# Rollback\n\nDrain the **canary** first."))
+	got := plain(renderAttachmentPreview(loadAttachmentPreview(st, md), md, 40, 20))
+	if !strings.Contains(got, "Rollback") || !strings.Contains(got, "canary") || strings.Contains(got, "**") {
+		t.Fatalf("expected rendered markdown, got:\n%s", got)
+	}
+
+	js := writePreviewFile(t, dir, "resources/attachments/cfg.json", text(`{"replicas":3,"image":"api"}`))
+	got = plain(renderAttachmentPreview(loadAttachmentPreview(st, js), js, 40, 20))
+	if !strings.Contains(got, `"replicas": 3`) {
+		t.Fatalf("expected pretty-printed JSON, got:\n%s", got)
+	}
+
+	csvA := writePreviewFile(t, dir, "resources/attachments/hosts.csv", text("host,zone\nweb-1,eu\nweb-2,us\n"))
+	if md, ok := csvMarkdownTable("host,zone\nweb-1,eu\n", false); !ok || !strings.HasPrefix(md, "| host | zone |\n| --- | --- |\n| web-1 | eu |") {
+		t.Fatalf("unexpected CSV table %q", md)
+	}
+	got = plain(renderAttachmentPreview(loadAttachmentPreview(st, csvA), csvA, 40, 20))
+	if !strings.Contains(got, "web-2") {
+		t.Fatalf("expected CSV rows, got:\n%s", got)
+	}
+
+	img := writePreviewFile(t, dir, "resources/attachments/shot.png", func(f *os.File) error {
+		m := image.NewRGBA(image.Rect(0, 0, 40, 20))
+		for y := 0; y < 20; y++ {
+			for x := 0; x < 40; x++ {
+				m.Set(x, y, color.RGBA{R: uint8(x * 6), B: uint8(y * 12), A: 0xff})
+			}
+		}
+		return png.Encode(f, m)
+	})
+	lines := renderAttachmentPreview(loadAttachmentPreview(st, img), img, 20, 20)
+	// 40×20 px fits in 20 columns as 20×10 px, i.e. 5 rows of half blocks.
+	if len(lines) != 5 || xansi.StringWidth(lines[0]) != 20 || !strings.Contains(lines[0], "▀") {
+		t.Fatalf("unexpected image preview: %d lines, width %d", len(lines), xansi.StringWidth(lines[0]))
+	}
+
+	arc := writePreviewFile(t, dir, "resources/attachments/logs.zip", func(f *os.File) error {
+		zw := zip.NewWriter(f)
+		w, err := zw.Create("logs/kubelet.log")
+		if err != nil {
+			return err
+		}
+		if _, err := w.Write([]byte("hello")); err != nil {
+			return err
+		}
+		return zw.Close()
+	})
+	got = plain(renderAttachmentPreview(loadAttachmentPreview(st, arc), arc, 40, 20))
+	if !strings.Contains(got, "logs/kubelet.log") || !strings.Contains(got, "5 B") {
+		t.Fatalf("expected archive listing, got:\n%s", got)
+	}
+
+	ext := model.Attachment{ID: "att-ext", OriginalName: "big.md", Path: "s3:sha256/ab/abc.md", Sha256Hex: "abc"}
+	got = plain(renderAttachmentPreview(loadAttachmentPreview(st, ext), ext, 40, 20))
+	if !strings.Contains(got, "Not downloaded yet") {
+		t.Fatalf("expected external blobs to wait for a fetch, got:\n%s", got)
+	}
+}
@@ -1,6 +1,7 @@
 package tui
 
 import (
+        "context"
         "errors"
         "fmt"
         "io"
@@ -49,12 +50,17 @@ func (m *appModel) openAttachment(a model.Attachment) tea.Cmd {
         if m == nil {
                 return nil
         }
-        p := m.store.AttachmentAbsPath(a)
-        if strings.TrimSpace(p) == "" {
+        if strings.TrimSpace(a.Path) == "" {
                 return func() tea.Msg { return attachmentOpenDoneMsg{err: errors.New("empty attachment path")} }
         }
+        st := m.store
 
         return func() tea.Msg {
+                // Fetches the file from an external blob store on first open, and checks its hash.
+                p, err := st.AttachmentLocalPath(context.Background(), a)
+                if err != nil {
+                        return attachmentOpenDoneMsg{err: err}
+                }
                 var cmd *exec.Cmd
                 switch runtime.GOOS {
                 case "darwin":
@@ -0,0 +1,70 @@
+package tui
+
+import (
+	"testing"
+	"time"
+
+	"clarity-cli/internal/model"
+	"clarity-cli/internal/store"
+)
+
+func outlineRowByID(t *testing.T, m appModel, id string) outlineRow {
+	t.Helper()
+	for _, li := range m.itemsList.Items() {
+		if it, ok := li.(outlineRowItem); ok && it.row.item.ID == id {
+			return it.row
+		}
+	}
+	t.Fatalf("row %s not found", id)
+	return outlineRow{}
+}
+
+func TestOutlineRows_BlockedBadgeCountsOnlyOpenBlockers(t *testing.T) {
+	now := time.Now().UTC()
+	db := &store.DB{
+		CurrentActorID: "act-test",
+		Actors:         []model.Actor{{ID: "act-test", Kind: model.ActorKindHuman, Name: "tester"}},
+		Projects:       []model.Project{{ID: "proj-a", Name: "Project", CreatedBy: "act-test", CreatedAt: now}},
+		Outlines:       []model.Outline{{ID: "out-a", ProjectID: "proj-a", StatusDefs: store.DefaultOutlineStatusDefs(), CreatedBy: "act-test", CreatedAt: now}},
+		Items: []model.Item{
+			{ID: "item-a", ProjectID: "proj-a", OutlineID: "out-a", Rank: "h", Title: "A", StatusID: "todo", OwnerActorID: "act-test", CreatedBy: "act-test", CreatedAt: now, UpdatedAt: now},
+			{ID: "item-b", ProjectID: "proj-a", OutlineID: "out-a", Rank: "i", Title: "B", StatusID: "todo", OwnerActorID: "act-test", CreatedBy: "act-test", CreatedAt: now, UpdatedAt: now},
+			{ID: "item-c", ProjectID: "proj-a", OutlineID: "out-a", Rank: "j", Title: "C", StatusID: "todo", OwnerActorID: "act-test", CreatedBy: "act-test", CreatedAt: now, UpdatedAt: now},
+		},
+		Deps: []model.Dependency{
+			{ID: "dep-1", FromItemID: "item-a", ToItemID: "item-b", Type: model.DependencyBlocks},
+			{ID: "dep-2", FromItemID: "item-b", ToItemID: "item-c", Type: model.DependencyBlocks},
+		},
+	}
+
+	m := newAppModel(t.TempDir(), db)
+	m.view = viewOutline
+	m.selectedProjectID = "proj-a"
+	m.selectedOutlineID = "out-a"
+	m.selectedOutline = &db.Outlines[0]
+	m.collapsed = map[string]bool{}
+	m.refreshItems(db.Outlines[0])
+
+	if got := outlineRowByID(t, m, "item-a").blockedBy; got != 2 {
+		t.Fatalf("expected item-a blocked by 2 open items, got %d", got)
+	}
+	if got := outlineRowByID(t, m, "item-c").blockedBy; got != 0 {
+		t.Fatalf("expected item-c not blocked, got %d", got)
+	}
+
+	// Finishing the direct blocker unblocks the item (its own blockers no longer matter).
+	db.Items[1].StatusID = "done"
+	m.refreshItems(db.Outlines[0])
+	if got := outlineRowByID(t, m, "item-a").blockedBy; got != 0 {
+		t.Fatalf("expected item-a unblocked after blocker is done, got %d", got)
+	}
+}
+
+func TestBlockedByLabel(t *testing.T) {
+	if got := blockedByLabel(1); got != "blocked by 1 open item" {
+		t.Fatalf("unexpected label: %q", got)
+	}
+	if got := blockedByLabel(3); got != "blocked by 3 open items" {
+		t.Fatalf("unexpected label: %q", got)
+	}
+}
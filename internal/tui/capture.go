@@ -1595,6 +1595,9 @@ func (m *captureModel) createDraftItems() (string, error) {
 	if actorID == "" {
 		return "", errors.New("no current actor; run `clarity identity use <actor-id>` (or pass --actor)")
 	}
+	if err := roleRequiredErr(db, actorID, out.ProjectID, out.ID, store.RoleContributor); err != nil {
+		return "", err
+	}
 
 	// Create items in a stable parent-before-child order (pre-order traversal by rank).
 	flat := flattenOutline(db, *out, m.draftItems, map[string]bool{})
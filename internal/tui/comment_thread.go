@@ -6,6 +6,17 @@ import (
         "clarity-cli/internal/model"
 )
 
+// commentEditedMarker is appended to comment headers so edits/redactions are visible.
+func commentEditedMarker(c model.Comment) string {
+        if c.Redacted {
+                return " (redacted)"
+        }
+        if c.EditedAt != nil {
+                return " (edited)"
+        }
+        return ""
+}
+
 type commentThreadRow struct {
         Comment model.Comment
         Depth   int
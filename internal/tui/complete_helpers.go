@@ -5,6 +5,7 @@ import (
         "strings"
 
         "clarity-cli/internal/model"
+        "clarity-cli/internal/readiness"
         "clarity-cli/internal/store"
 )
 
@@ -46,30 +47,7 @@ func hasIncompleteChildren(db *store.DB, taskID string) bool {
 }
 
 func isBlockedByUndoneDeps(db *store.DB, taskID string) bool {
-        taskID = strings.TrimSpace(taskID)
-        if db == nil || taskID == "" {
-                return false
-        }
-        for _, d := range db.Deps {
-                if d.Type != model.DependencyBlocks {
-                        continue
-                }
-                if strings.TrimSpace(d.FromItemID) != taskID {
-                        continue
-                }
-                dep, ok := db.FindItem(strings.TrimSpace(d.ToItemID))
-                if !ok || dep == nil {
-                        // If the dep target is missing, treat as blocked until cleaned up.
-                        return true
-                }
-                if dep.Archived {
-                        continue
-                }
-                if !isItemEndState(db, dep) {
-                        return true
-                }
-        }
-        return false
+        return readiness.IsBlocked(db, taskID)
 }
 
 func explainCompletionBlockers(db *store.DB, taskID string) string {
@@ -0,0 +1,331 @@
+package tui
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"clarity-cli/internal/mutate"
+	"clarity-cli/internal/store"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// conflictRowItem is one entry in the conflicts view.
+type conflictRowItem struct {
+	conflict store.Conflict
+	title    string
+}
+
+func (i conflictRowItem) FilterValue() string {
+	return strings.TrimSpace(i.title + " " + i.conflict.Field)
+}
+
+func (i conflictRowItem) Title() string {
+	if t := strings.TrimSpace(i.title); t != "" {
+		return t
+	}
+	return i.conflict.EntityID
+}
+
+// conflictDelegate renders a conflict as two lines: entity title + field, then the side count.
+type conflictDelegate struct {
+	normal   lipgloss.Style
+	selected lipgloss.Style
+	dim      lipgloss.Style
+}
+
+func newConflictDelegate() conflictDelegate {
+	return conflictDelegate{
+		normal:   lipgloss.NewStyle().Foreground(colorSurfaceFg),
+		selected: lipgloss.NewStyle().Foreground(colorSelectedFg).Background(colorSelectedBg),
+		dim:      lipgloss.NewStyle().Foreground(colorChromeSubtleFg),
+	}
+}
+
+func (d conflictDelegate) Height() int  { return 2 }
+func (d conflictDelegate) Spacing() int { return 0 }
+func (d conflictDelegate) Update(_ tea.Msg, _ *list.Model) tea.Cmd {
+	return nil
+}
+
+func (d conflictDelegate) Render(w io.Writer, m list.Model, index int, item list.Item) {
+	contentW := m.Width()
+	it, ok := item.(conflictRowItem)
+	if !ok || contentW < 4 {
+		fmt.Fprint(w, "\n")
+		return
+	}
+
+	base := d.normal
+	dim := d.dim
+	if index == m.Index() {
+		base = d.selected
+		dim = d.selected
+	}
+
+	title := base.Render("  ") + base.Bold(true).Render(it.Title()) + base.Render("  ") + dim.Render(it.conflict.EntityKind+" "+it.conflict.Field)
+	second := base.Render("  ") + dim.Render(fmt.Sprintf("%d concurrent edits", len(it.conflict.Sides)))
+
+	fmt.Fprint(w, padOrCut(title, contentW, base)+"\n"+padOrCut(second, contentW, base))
+}
+
+func (m *appModel) openConflicts() {
+	if m.view != viewConflicts {
+		m.conflictsReturnView = m.view
+		m.hasConflictsReturnView = true
+	}
+	m.view = viewConflicts
+	m.showPreview = false
+	m.pane = paneOutline
+	m.conflictsList.Select(0)
+	m.refreshConflicts()
+}
+
+func (m *appModel) refreshConflicts() {
+	if m == nil || m.db == nil {
+		return
+	}
+	prevID := ""
+	if it, ok := m.conflictsList.SelectedItem().(conflictRowItem); ok {
+		prevID = it.conflict.EntityID
+	}
+
+	cs, err := store.ListConflictsV1(m.dir)
+	if err != nil {
+		m.conflictsList.SetItems(nil)
+		return
+	}
+	items := make([]list.Item, 0, len(cs))
+	for _, c := range cs {
+		title := c.EntityID
+		switch c.EntityKind {
+		case "item":
+			if it, ok := m.db.FindItem(c.EntityID); ok && it != nil {
+				title = it.Title
+			}
+		case "outline":
+			if o, ok := m.db.FindOutline(c.EntityID); ok && o != nil && o.Name != nil {
+				title = *o.Name
+			}
+		}
+		items = append(items, conflictRowItem{conflict: c, title: title})
+	}
+	m.conflictsList.SetItems(items)
+	if prevID != "" {
+		for i, li := range items {
+			if li.(conflictRowItem).conflict.EntityID == prevID {
+				m.conflictsList.Select(i)
+				return
+			}
+		}
+	}
+	if m.conflictsList.Index() >= len(items) {
+		m.conflictsList.Select(0)
+	}
+}
+
+// takeConflictSide resolves the selected conflict with side n (1-based).
+func (m *appModel) takeConflictSide(n int) error {
+	it, ok := m.conflictsList.SelectedItem().(conflictRowItem)
+	if !ok {
+		return fmt.Errorf("no conflict selected")
+	}
+	if n < 1 || n > len(it.conflict.Sides) {
+		return fmt.Errorf("no side %d", n)
+	}
+	actorID := m.editActorID()
+	res, err := mutate.ResolveConflict(m.db, actorID, it.conflict, it.conflict.Sides[n-1].EventID, time.Now())
+	if err != nil {
+		switch e := err.(type) {
+		case mutate.OwnerOnlyError:
+			return fmt.Errorf("owner-only")
+		case mutate.RoleRequiredError:
+			return roleRequiredErr(m.db, actorID, e.ProjectID, e.OutlineID, e.Role)
+		}
+		return err
+	}
+	if err := m.appendEvent(actorID, res.EventType, it.conflict.EntityID, res.EventPayload); err != nil {
+		return err
+	}
+	if err := m.store.Save(m.db); err != nil {
+		return err
+	}
+	m.refreshEventsTail()
+	m.captureStoreModTimes()
+	m.refreshConflicts()
+	return nil
+}
+
+func (m *appModel) leaveConflicts() {
+	if m.hasConflictsReturnView {
+		m.view = m.conflictsReturnView
+		m.hasConflictsReturnView = false
+	} else {
+		m.view = viewProjects
+	}
+	switch m.view {
+	case viewProjects:
+		m.refreshProjects()
+	case viewOutlines:
+		m.refreshOutlines(m.selectedProjectID)
+	case viewAgenda:
+		m.refreshAgenda()
+	case viewArchived:
+		m.refreshArchived()
+	case viewSearch:
+		m.refreshSearch()
+	case viewNotifications:
+		m.refreshNotifications()
+	case viewOutline:
+		if o, ok := m.db.FindOutline(m.selectedOutlineID); ok {
+			m.refreshItems(*o)
+		}
+	case viewItem:
+		if m.openItemID == "" || m.selectedOutline == nil {
+			m.view = viewProjects
+			m.refreshProjects()
+			return
+		}
+		m.refreshItemSubtree(*m.selectedOutline, m.openItemID)
+	}
+}
+
+func (m appModel) updateConflicts(msg tea.Msg) (tea.Model, tea.Cmd) {
+	km, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+	switch k := km.String(); k {
+	case "ctrl+c", "q":
+		return m, m.quitWithStateCmd()
+	case "x", "?":
+		m.openActionPanel(actionPanelContext)
+		return m, nil
+	case "g":
+		m.openActionPanel(actionPanelNav)
+		return m, nil
+	case "a":
+		m.openActionPanel(actionPanelAgenda)
+		return m, nil
+	case "c":
+		m.openActionPanel(actionPanelCapture)
+		return m, nil
+	case "1", "2", "3", "4", "5", "6", "7", "8", "9":
+		n, _ := strconv.Atoi(k)
+		if err := (&m).takeConflictSide(n); err != nil {
+			m.showMinibuffer("Conflicts: " + err.Error())
+			return m, nil
+		}
+		m.showMinibuffer(fmt.Sprintf("Conflicts: took side %d", n))
+		return m, nil
+	case "backspace", "esc":
+		(&m).leaveConflicts()
+		return m, nil
+	case "enter":
+		it, ok := m.conflictsList.SelectedItem().(conflictRowItem)
+		if !ok || it.conflict.EntityKind != "item" {
+			return m, nil
+		}
+		snap := m.captureReturnSnapshot()
+		if err := (&m).jumpToItemByID(it.conflict.EntityID); err != nil {
+			m.showMinibuffer("Conflicts: " + err.Error())
+			return m, nil
+		}
+		(&m).applyReturnSnapshot(snap)
+		return m, nil
+	}
+	var cmd tea.Cmd
+	m.conflictsList, cmd = m.conflictsList.Update(msg)
+	return m, cmd
+}
+
+func (m *appModel) viewConflicts() string {
+	frameH := m.frameHeight()
+	if frameH < 8 {
+		frameH = 8
+	}
+	bodyHeight := frameH - (topPadLines + breadcrumbGap + 2)
+	if bodyHeight < 6 {
+		bodyHeight = 6
+	}
+
+	w := m.width
+	if w < 10 {
+		w = 10
+	}
+	contentW := w - 2*splitOuterMargin
+	if contentW < 10 {
+		contentW = w
+	}
+
+	crumb := lipgloss.NewStyle().Width(contentW).Foreground(colorChromeSubtleFg).Render(m.breadcrumbText())
+	var body string
+	if len(m.conflictsList.Items()) == 0 {
+		msg := "No conflicts. Concurrent edits to the same description show up here after a sync."
+		body = lipgloss.NewStyle().Width(contentW).Foreground(colorChromeSubtleFg).Render(msg)
+	} else {
+		// The list on top, both sides of the selected conflict below.
+		listH := bodyHeight / 3
+		if listH < 4 {
+			listH = 4
+		}
+		body = m.listBodyWithOverflowHint(&m.conflictsList, contentW, listH) + "\n\n" + m.conflictSidesBody(contentW, bodyHeight-listH-2)
+	}
+	main := strings.Repeat("\n", topPadLines) + crumb + strings.Repeat("\n", breadcrumbGap+1) + body
+	main = lipgloss.NewStyle().Width(w).Padding(0, splitOuterMargin).Render(main)
+	if m.modal == modalNone {
+		return main
+	}
+	bg := dimBackground(main)
+	fg := m.renderModal()
+	return overlayCenter(bg, fg, w, frameH)
+}
+
+// conflictSidesBody renders the selected conflict's sides next to each other.
+func (m *appModel) conflictSidesBody(width, height int) string {
+	it, ok := m.conflictsList.SelectedItem().(conflictRowItem)
+	if !ok || len(it.conflict.Sides) == 0 {
+		return ""
+	}
+	if height < 3 {
+		height = 3
+	}
+	n := len(it.conflict.Sides)
+	colW := (width - 2*(n-1)) / n
+	if colW < 12 {
+		colW = width
+	}
+	dim := lipgloss.NewStyle().Foreground(colorChromeSubtleFg)
+	cols := make([]string, 0, n)
+	for i, sd := range it.conflict.Sides {
+		head := fmt.Sprintf("[%d] %s  %s", i+1, actorNameOrID(m.db, sd.ActorID), fmtTS(sd.IssuedAt))
+		if sd.Value == it.conflict.Current {
+			head += "  (current)"
+		}
+		val := sd.Value
+		if strings.TrimSpace(val) == "" {
+			val = "(empty)"
+		}
+		col := lipgloss.NewStyle().Bold(true).Width(colW).Render(head) + "\n" +
+			dim.Width(colW).Render(sd.EventID) + "\n\n" +
+			lipgloss.NewStyle().Width(colW).MaxHeight(height-3).Render(val)
+		cols = append(cols, col)
+	}
+	if colW == width {
+		return strings.Join(cols, "\n\n")
+	}
+	for i := 0; i < len(cols)-1; i++ {
+		cols[i] = lipgloss.NewStyle().PaddingRight(2).Render(cols[i])
+	}
+	return lipgloss.JoinHorizontal(lipgloss.Top, cols...)
+}
+
+// conflictsBreadcrumb renders `conflicts (N)`.
+func (m *appModel) conflictsBreadcrumb() string {
+	return fmt.Sprintf("conflicts (%d)", len(m.conflictsList.Items()))
+}
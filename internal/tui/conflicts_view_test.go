@@ -0,0 +1,68 @@
+package tui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"clarity-cli/internal/store"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestConflictsView_ShowsBothSidesAndTakesOne(t *testing.T) {
+	dir := t.TempDir()
+	if _, err := store.EnsureGitBackedV1Layout(dir); err != nil {
+		t.Fatalf("layout: %v", err)
+	}
+	base := "" +
+		`{"eventId":"evt-1","workspaceId":"ws-1","replicaId":"rep-a","entityKind":"actor","entityId":"act-me","entitySeq":1,"type":"identity.create","issuedAt":"2025-12-31T00:00:00Z","actorId":"act-me","payload":{"id":"act-me","name":"me","kind":"human"}}` + "\n" +
+		`{"eventId":"evt-2","workspaceId":"ws-1","replicaId":"rep-a","entityKind":"project","entityId":"proj-a","entitySeq":1,"type":"project.create","issuedAt":"2025-12-31T00:00:01Z","actorId":"act-me","payload":{"id":"proj-a","name":"P","createdBy":"act-me","createdAt":"2025-12-31T00:00:01Z"}}` + "\n" +
+		`{"eventId":"evt-3","workspaceId":"ws-1","replicaId":"rep-a","entityKind":"outline","entityId":"out-a","entitySeq":1,"type":"outline.create","issuedAt":"2025-12-31T00:00:02Z","actorId":"act-me","payload":{"id":"out-a","projectId":"proj-a","statusDefs":[{"id":"todo","label":"Todo","isEndState":false}],"createdBy":"act-me","createdAt":"2025-12-31T00:00:02Z"}}` + "\n" +
+		`{"eventId":"evt-4","workspaceId":"ws-1","replicaId":"rep-a","entityKind":"item","entityId":"item-a","entitySeq":1,"type":"item.create","issuedAt":"2025-12-31T00:00:03Z","actorId":"act-me","payload":{"id":"item-a","projectId":"proj-a","outlineId":"out-a","rank":"h","title":"Ship it","status":"todo","ownerActorId":"act-me","createdBy":"act-me","createdAt":"2025-12-31T00:00:03Z","updatedAt":"2025-12-31T00:00:03Z"}}` + "\n" +
+		`{"eventId":"evt-a1","workspaceId":"ws-1","replicaId":"rep-a","entityKind":"item","entityId":"item-a","entitySeq":2,"type":"item.set_description","parents":["evt-4"],"issuedAt":"2025-12-31T00:00:04Z","actorId":"act-me","payload":{"description":"mine"}}` + "\n"
+	theirs := `{"eventId":"evt-b1","workspaceId":"ws-1","replicaId":"rep-b","entityKind":"item","entityId":"item-a","entitySeq":2,"type":"item.set_description","parents":["evt-4"],"issuedAt":"2025-12-31T00:00:05Z","actorId":"act-me","payload":{"description":"theirs"}}` + "\n"
+	if err := os.WriteFile(filepath.Join(dir, "events", "events.rep-a.jsonl"), []byte(base), 0o644); err != nil {
+		t.Fatalf("write shard: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "events", "events.rep-b.jsonl"), []byte(theirs), 0o644); err != nil {
+		t.Fatalf("write shard: %v", err)
+	}
+	db, err := (store.Store{Dir: dir}).Load()
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	db.CurrentActorID = "act-me"
+
+	m := newAppModel(dir, db)
+	m.width = 120
+	m.height = 40
+	m.view = viewProjects
+
+	(&m).openConflicts()
+	if m.view != viewConflicts || len(m.conflictsList.Items()) != 1 {
+		t.Fatalf("expected 1 conflict, got view=%v items=%d", m.view, len(m.conflictsList.Items()))
+	}
+	out := m.View()
+	for _, want := range []string{"Ship it", "[1]", "[2]", "mine", "theirs"} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("expected %q in view:\n%s", want, out)
+		}
+	}
+
+	mm, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'1'}})
+	m = mm.(appModel)
+	if got := len(m.conflictsList.Items()); got != 0 {
+		t.Fatalf("expected conflict to be resolved, got %d", got)
+	}
+	if it, _ := m.db.FindItem("item-a"); it.Description != "mine" {
+		t.Fatalf("description = %q, want mine", it.Description)
+	}
+
+	mm, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = mm.(appModel)
+	if m.view != viewProjects {
+		t.Fatalf("expected to return to projects, got %v", m.view)
+	}
+}
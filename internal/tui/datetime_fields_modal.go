@@ -6,6 +6,7 @@ import (
         "time"
 
         "clarity-cli/internal/model"
+        "clarity-cli/internal/repeat"
 )
 
 func parseIntDefault(s string, def int) int {
@@ -226,3 +227,44 @@ var (
 type dateTimeParseErr struct{ msg string }
 
 func (e *dateTimeParseErr) Error() string { return e.msg }
+
+// repeatPresets are the repeaters offered by j/k in the due/schedule modal ("" = none).
+var repeatPresets = []string{"", "+1d", "+1w", "+2w", "+1m", "+1y", "++1d", "++1w", "++1m", ".+1d", ".+1w", ".+2w", ".+1m"}
+
+// cycleRepeatPreset steps through repeatPresets; a custom repeater (e.g. "+3d" set via the CLI)
+// is kept as the starting point of the cycle.
+func cycleRepeatPreset(cur string, delta int) string {
+        cur = strings.TrimSpace(cur)
+        idx := -1
+        for i, p := range repeatPresets {
+                if p == cur {
+                        idx = i
+                        break
+                }
+        }
+        if idx < 0 {
+                idx = 0
+        }
+        n := len(repeatPresets)
+        return repeatPresets[((idx+delta)%n+n)%n]
+}
+
+func repeatDescription(spec string) string {
+        sp, err := repeat.Parse(spec)
+        if err != nil {
+                return "does not repeat"
+        }
+        unit := map[byte]string{'d': "day", 'w': "week", 'm': "month", 'y': "year"}[sp.Unit]
+        every := unit
+        if sp.N > 1 {
+                every = strconv.Itoa(sp.N) + " " + unit + "s"
+        }
+        switch sp.Kind {
+        case repeat.KindCatchUp:
+                return "every " + every + ", skipping missed ones"
+        case repeat.KindRestart:
+                return every + " after completion"
+        default:
+                return "every " + every
+        }
+}
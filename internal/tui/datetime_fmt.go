@@ -11,10 +11,19 @@ import (
 // formatDateTimeOutline matches outline.js rendering:
 // - date-only: "Jan 5"
 // - date+time: "Jan 5 14:30" (24h)
+// - repeating: "Jan 5 +1w"
 func formatDateTimeOutline(dt *model.DateTime) string {
         if dt == nil {
                 return ""
         }
+        txt := formatDateTimeOutlineNoRepeat(dt)
+        if txt != "" && strings.TrimSpace(dt.Repeat) != "" {
+                txt += " " + strings.TrimSpace(dt.Repeat)
+        }
+        return txt
+}
+
+func formatDateTimeOutlineNoRepeat(dt *model.DateTime) string {
         date := strings.TrimSpace(dt.Date)
         if date == "" {
                 return ""
@@ -49,3 +58,17 @@ func formatScheduleLabel(dt *model.DateTime) string {
         }
         return "on " + txt
 }
+
+// repeatNextLabel describes where a repeating item rolled to, e.g. "on Jan 12" or "due Jan 14".
+func repeatNextLabel(it *model.Item) string {
+        if it == nil {
+                return ""
+        }
+        if it.Schedule != nil && strings.TrimSpace(it.Schedule.Repeat) != "" {
+                return "on " + formatDateTimeOutlineNoRepeat(it.Schedule)
+        }
+        if it.Due != nil && strings.TrimSpace(it.Due.Repeat) != "" {
+                return "due " + formatDateTimeOutlineNoRepeat(it.Due)
+        }
+        return ""
+}
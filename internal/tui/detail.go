@@ -1,6 +1,7 @@
 package tui
 
 import (
+	"encoding/json"
 	"fmt"
 	"sort"
 	"strings"
@@ -375,7 +376,7 @@ func renderItemDetailInteractive(db *store.DB, outline model.Outline, it model.I
 			contentW := maxInt(10, innerW-indentCols)
 
 			author := authorStyle.Render(actorLabel(db, r.Comment.AuthorID))
-			ts := tsStyle.Render(fmtTS(r.Comment.CreatedAt))
+			ts := tsStyle.Render(fmtTS(r.Comment.CreatedAt) + commentEditedMarker(r.Comment))
 			metaPrefix := ""
 			if depth > 0 {
 				// Reply indicator: down-right arrow.
@@ -539,7 +540,7 @@ func renderItemDetailInteractive(db *store.DB, outline model.Outline, it model.I
 	} else {
 		for i := range history {
 			ev := history[i]
-			txt := fmt.Sprintf("%s  %s  %s", fmtTS(ev.TS), actorLabel(db, ev.ActorID), eventSummary(ev))
+			txt := fmt.Sprintf("%s  %s  %s%s", fmtTS(ev.TS), actorLabel(db, ev.ActorID), eventSigTag(ev), eventSummary(db, ev))
 			st := styleMuted()
 			if i == historyIdx && focus == itemFocusHistory {
 				st = st.Copy().Background(colorSelectedBg)
@@ -842,11 +843,11 @@ func eventIsForItem(ev model.Event, itemID string) bool {
 		return false
 	}
 	switch strings.TrimSpace(ev.Type) {
-	case "comment.add", "worklog.add":
+	case "comment.add", "comment.edit", "comment.redact", "worklog.add":
 		if v, ok := m["itemId"].(string); ok && strings.TrimSpace(v) == id {
 			return true
 		}
-	case "dep.add":
+	case "dep.add", "dep.remove", "dep.update":
 		if v, ok := m["fromItemId"].(string); ok && strings.TrimSpace(v) == id {
 			return true
 		}
@@ -857,7 +858,81 @@ func eventIsForItem(ev model.Event, itemID string) bool {
 	return false
 }
 
-func eventSummary(ev model.Event) string {
+// eventSigTag flags events whose signature didn't verify (store.VerifyEventsV1); verified and
+// unchecked events get none.
+func eventSigTag(ev model.Event) string {
+	switch store.SignatureStatus(ev.Signature) {
+	case store.SignatureInvalid:
+		return "[bad signature] "
+	case store.SignatureContested:
+		return "[contested signature] "
+	case store.SignatureMissing:
+		return "[missing signature] "
+	case store.SignatureUnsigned:
+		return "[unsigned] "
+	default:
+		return ""
+	}
+}
+
+// eventSigDetail explains ev's signature check for the event body ("" when unchecked).
+func eventSigDetail(ev model.Event) string {
+	switch store.SignatureStatus(ev.Signature) {
+	case store.SignatureVerified:
+		return "verified"
+	case store.SignatureInvalid:
+		return "INVALID: doesn't verify with the actor's keys (edited or forged)"
+	case store.SignatureContested:
+		return "contested: signed with a key that traces back to competing first keys of an actor (see clarity doctor)"
+	case store.SignatureMissing:
+		return "missing: the actor has signing keys, but this event is unsigned"
+	case store.SignatureUnsigned:
+		return "unsigned (the actor had no signing key)"
+	default:
+		return ""
+	}
+}
+
+// redactedCommentEvent reports whether ev adds or edits a comment that has since been redacted: its
+// payload still carries the body, which history must not show.
+func redactedCommentEvent(db *store.DB, ev model.Event) bool {
+	typ := strings.TrimSpace(ev.Type)
+	if db == nil || (typ != "comment.add" && typ != "comment.edit") {
+		return false
+	}
+	id := strings.TrimSpace(ev.EntityID)
+	if m, ok := ev.Payload.(map[string]any); ok {
+		if v, ok := m["id"].(string); ok && strings.TrimSpace(v) != "" {
+			id = strings.TrimSpace(v)
+		}
+	}
+	for i := range db.Comments {
+		if strings.TrimSpace(db.Comments[i].ID) == id {
+			return db.Comments[i].Redacted
+		}
+	}
+	return false
+}
+
+// eventPayloadJSON renders ev's payload for event details, without the body of a redacted comment.
+func eventPayloadJSON(db *store.DB, ev model.Event) string {
+	payload := ev.Payload
+	if m, ok := payload.(map[string]any); ok && redactedCommentEvent(db, ev) {
+		cp := make(map[string]any, len(m))
+		for k, v := range m {
+			cp[k] = v
+		}
+		cp["body"] = model.RedactedCommentBody
+		payload = cp
+	}
+	b, err := json.MarshalIndent(payload, "", "  ")
+	if err != nil {
+		return ""
+	}
+	return string(b)
+}
+
+func eventSummary(db *store.DB, ev model.Event) string {
 	typ := strings.TrimSpace(ev.Type)
 	if typ == "" {
 		typ = "(unknown)"
@@ -938,6 +1013,25 @@ func eventSummary(ev model.Event) string {
 			}
 			return "schedule: set"
 		}
+	case "item.repeat":
+		// Payload dates are decoded maps ({"date": ..., "time": ..., "repeat": ...}).
+		parts := []string{}
+		for _, k := range []string{"schedule", "due"} {
+			if dt, ok := m[k].(map[string]any); ok {
+				if d, ok := dt["date"].(string); ok && strings.TrimSpace(d) != "" {
+					parts = append(parts, k+" "+strings.TrimSpace(d))
+				}
+			}
+		}
+		to, _ := m["to"].(string)
+		out := "repeated: reopened"
+		if strings.TrimSpace(to) != "" {
+			out += " as " + strings.TrimSpace(to)
+		}
+		if len(parts) > 0 {
+			out += ", next " + strings.Join(parts, ", ")
+		}
+		return out
 	case "item.set_assign":
 		if v, ok := m["assignedActorId"]; ok {
 			if v == nil {
@@ -963,10 +1057,23 @@ func eventSummary(ev model.Event) string {
 			return "tags: updated"
 		}
 	case "comment.add":
+		if redactedCommentEvent(db, ev) {
+			return "comment redacted"
+		}
 		if v, ok := m["body"].(string); ok && strings.TrimSpace(v) != "" {
 			return "comment: " + truncateInline(v, 60)
 		}
 		return "comment added"
+	case "comment.edit":
+		if redactedCommentEvent(db, ev) {
+			return "comment redacted"
+		}
+		if v, ok := m["body"].(string); ok && strings.TrimSpace(v) != "" {
+			return "comment edited: " + truncateInline(v, 60)
+		}
+		return "comment edited"
+	case "comment.redact":
+		return "comment redacted"
 	case "worklog.add":
 		if v, ok := m["body"].(string); ok && strings.TrimSpace(v) != "" {
 			return "worklog: " + truncateInline(v, 60)
@@ -976,6 +1083,16 @@ func eventSummary(ev model.Event) string {
 		from, _ := m["fromItemId"].(string)
 		to, _ := m["toItemId"].(string)
 		return "dep: " + strings.TrimSpace(from) + " -> " + strings.TrimSpace(to)
+	case "dep.remove":
+		from, _ := m["fromItemId"].(string)
+		to, _ := m["toItemId"].(string)
+		return "dep removed: " + strings.TrimSpace(from) + " -> " + strings.TrimSpace(to)
+	case "dep.update":
+		from, _ := m["fromItemId"].(string)
+		to, _ := m["toItemId"].(string)
+		prev, _ := m["from"].(string)
+		next, _ := m["type"].(string)
+		return "dep: " + strings.TrimSpace(from) + " -> " + strings.TrimSpace(to) + " (" + strings.TrimSpace(prev) + " → " + strings.TrimSpace(next) + ")"
 	}
 
 	return typ
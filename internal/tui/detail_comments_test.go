@@ -119,3 +119,81 @@ func TestRenderItemDetailInteractive_CommentMetaAndBodyIndentMatch(t *testing.T)
 		t.Fatalf("expected comment meta/body indent to match; meta=%d body=%d\nmeta=%q\nbody=%q", got, want, metaPlain, bodyPlain)
 	}
 }
+
+func TestRenderItemDetailInteractive_EditedCommentShowsMarker(t *testing.T) {
+	actorID := "act-human"
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	db := &store.DB{
+		CurrentActorID: actorID,
+		Actors:         []model.Actor{{ID: actorID, Kind: model.ActorKindHuman, Name: "A"}},
+		Outlines: []model.Outline{{
+			ID:         "out-a",
+			ProjectID:  "proj-a",
+			StatusDefs: store.DefaultOutlineStatusDefs(),
+			CreatedBy:  actorID,
+			CreatedAt:  now,
+		}},
+		Items: []model.Item{{
+			ID:           "item-a",
+			ProjectID:    "proj-a",
+			OutlineID:    "out-a",
+			Rank:         "h",
+			Title:        "Title",
+			StatusID:     "todo",
+			OwnerActorID: actorID,
+			CreatedBy:    actorID,
+			CreatedAt:    now,
+			UpdatedAt:    now,
+		}},
+		Comments: []model.Comment{{
+			ID:        "c1",
+			ItemID:    "item-a",
+			AuthorID:  actorID,
+			Body:      "Teh typo",
+			CreatedAt: now.Add(1 * time.Minute),
+		}},
+	}
+	store.ApplyCommentEdit(&db.Comments[0], "The typo", now.Add(2*time.Minute))
+
+	out := stripANSIEscapes(renderItemDetailInteractive(
+		db,
+		db.Outlines[0],
+		db.Items[0],
+		80, 40,
+		itemFocusComments,
+		nil,  // events
+		0, 0, // childIdx/childOff
+		0,    // attachmentIdx
+		0,    // commentIdx
+		0, 0, // worklogIdx/historyIdx
+		0, // scroll
+	))
+	if !strings.Contains(out, "(edited)") || !strings.Contains(out, "The typo") {
+		t.Fatalf("expected latest body with edited marker, got:\n%s", out)
+	}
+	if strings.Contains(out, "Teh typo") {
+		t.Fatalf("expected prior revision to be hidden, got:\n%s", out)
+	}
+}
+
+func TestEventSummary_HidesRedactedCommentBodies(t *testing.T) {
+	db := &store.DB{Comments: []model.Comment{
+		{ID: "c1", ItemID: "item-a", AuthorID: "act-a", Body: model.RedactedCommentBody, Redacted: true},
+		{ID: "c2", ItemID: "item-a", AuthorID: "act-a", Body: "Still here"},
+	}}
+	add := model.Event{Type: "comment.add", EntityID: "c1", Payload: map[string]any{"id": "c1", "body": "token=abc"}}
+	edit := model.Event{Type: "comment.edit", EntityID: "c1", Payload: map[string]any{"id": "c1", "body": "token=def"}}
+	for _, ev := range []model.Event{add, edit} {
+		if got := eventSummary(db, ev); got != "comment redacted" {
+			t.Fatalf("expected %s of a redacted comment to be summarized as redacted, got %q", ev.Type, got)
+		}
+		if got := historyEventMarkdown(db, ev); strings.Contains(got, "token=") {
+			t.Fatalf("expected the redacted body to be left out of the event details, got %q", got)
+		}
+	}
+	kept := model.Event{Type: "comment.add", EntityID: "c2", Payload: map[string]any{"id": "c2", "body": "Still here"}}
+	if got := eventSummary(db, kept); got != "comment: Still here" {
+		t.Fatalf("unexpected summary: %q", got)
+	}
+}
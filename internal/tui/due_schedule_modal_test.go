@@ -283,3 +283,90 @@ func TestDueModal_JK_AdjustsDay(t *testing.T) {
                 t.Fatalf("expected day increment to roll over year; got y=%q m=%q d=%q", gotY, gotM, gotD)
         }
 }
+
+func TestScheduleModal_RepeatField_AndCompletingRollsForward(t *testing.T) {
+        dir := t.TempDir()
+        s := store.Store{Dir: dir}
+
+        actorID := "act-human"
+        now := time.Now().UTC()
+        db := &store.DB{
+                CurrentActorID: actorID,
+                Actors:         []model.Actor{{ID: actorID, Kind: model.ActorKindHuman, Name: "human"}},
+                Projects:       []model.Project{{ID: "proj-a", Name: "Project A", CreatedBy: actorID, CreatedAt: now}},
+                Outlines:       []model.Outline{{ID: "out-a", ProjectID: "proj-a", StatusDefs: store.DefaultOutlineStatusDefs(), CreatedBy: actorID, CreatedAt: now}},
+                Items: []model.Item{{
+                        ID:           "item-a",
+                        ProjectID:    "proj-a",
+                        OutlineID:    "out-a",
+                        Rank:         "h",
+                        Title:        "Weekly chore",
+                        StatusID:     "todo",
+                        OwnerActorID: actorID,
+                        CreatedBy:    actorID,
+                        CreatedAt:    now,
+                        UpdatedAt:    now,
+                }},
+        }
+        if err := s.Save(db); err != nil {
+                t.Fatalf("save db: %v", err)
+        }
+
+        m := newAppModel(dir, db)
+        m.view = viewOutline
+        m.selectedProjectID = "proj-a"
+        m.selectedOutlineID = "out-a"
+        m.selectedOutline = &db.Outlines[0]
+        m.refreshItems(db.Outlines[0])
+        selectListItemByID(&m.itemsList, "item-a")
+
+        mAny, _ := m.updateOutline(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'s'}})
+        m2 := mAny.(appModel)
+        if m2.modal != modalSetSchedule {
+                t.Fatalf("expected modalSetSchedule, got %v", m2.modal)
+        }
+        m2.yearInput.SetValue("2026")
+        m2.monthInput.SetValue("01")
+        m2.dayInput.SetValue("05")
+
+        // year -> month -> day -> time toggle -> repeat (time disabled)
+        for i := 0; i < 4; i++ {
+                mAny, _ = m2.updateOutline(tea.KeyMsg{Type: tea.KeyTab})
+                m2 = mAny.(appModel)
+        }
+        if m2.dateFocus != dateFocusRepeat {
+                t.Fatalf("expected repeat focus, got %v", m2.dateFocus)
+        }
+        for i := 0; i < 2; i++ {
+                mAny, _ = m2.updateOutline(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'k'}})
+                m2 = mAny.(appModel)
+        }
+        if m2.dateRepeat != "+1w" {
+                t.Fatalf("expected +1w after two presses, got %q", m2.dateRepeat)
+        }
+        m2.width = 120
+        if out := m2.renderDateTimeModal("Schedule"); !strings.Contains(out, "every week") {
+                t.Fatalf("expected repeat description in modal:\n%s", out)
+        }
+        mAny, _ = m2.updateOutline(tea.KeyMsg{Type: tea.KeyCtrlS})
+        m3 := mAny.(appModel)
+        if m3.modal != modalNone {
+                t.Fatalf("expected modal to close after save, got %v", m3.modal)
+        }
+
+        it, ok := m3.db.FindItem("item-a")
+        if !ok || it.Schedule == nil || it.Schedule.Date != "2026-01-05" || it.Schedule.Repeat != "+1w" {
+                t.Fatalf("expected repeating schedule, got %#v", it)
+        }
+
+        if err := (&m3).setStatusForItem("item-a", "done"); err != nil {
+                t.Fatalf("set status: %v", err)
+        }
+        it, _ = m3.db.FindItem("item-a")
+        if it.StatusID != "todo" || it.Schedule == nil || it.Schedule.Date != "2026-01-12" {
+                t.Fatalf("expected item reopened with next schedule, got status=%q schedule=%#v", it.StatusID, it.Schedule)
+        }
+        if !strings.Contains(m3.minibufferText, "repeats: on Jan 12") {
+                t.Fatalf("expected repeat note in minibuffer, got %q", m3.minibufferText)
+        }
+}
@@ -0,0 +1,138 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"clarity-cli/internal/model"
+	"clarity-cli/internal/store"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// historyScrubberField is one line of the scrubber: an item field as it was after a revision.
+type historyScrubberField struct {
+	label string
+	value string
+}
+
+// openHistoryScrubber loads the item's revisions (one per item.* event) and shows the latest.
+func (m *appModel) openHistoryScrubber(itemID string) {
+	if m == nil || m.db == nil {
+		return
+	}
+	itemID = strings.TrimSpace(itemID)
+	if itemID == "" {
+		return
+	}
+	revs, err := store.Store{Dir: m.dir}.ItemRevisions(itemID)
+	if err != nil {
+		m.showMinibuffer("History: " + err.Error())
+		return
+	}
+	if len(revs) == 0 {
+		m.showMinibuffer("History: no item events")
+		return
+	}
+	m.modal = modalHistoryScrubber
+	m.modalForID = itemID
+	m.scrubberRevisions = revs
+	m.scrubberIndex = len(revs) - 1
+}
+
+// stepHistoryScrubber moves the scrubber by delta revisions, clamped to the available range.
+func (m *appModel) stepHistoryScrubber(delta int) {
+	if m == nil || len(m.scrubberRevisions) == 0 {
+		return
+	}
+	i := m.scrubberIndex + delta
+	if i < 0 {
+		i = 0
+	}
+	if i > len(m.scrubberRevisions)-1 {
+		i = len(m.scrubberRevisions) - 1
+	}
+	m.scrubberIndex = i
+}
+
+func (m *appModel) historyScrubberFields(it *model.Item) []historyScrubberField {
+	if it == nil {
+		return nil
+	}
+	status := it.StatusID
+	if m.db != nil {
+		if o, ok := m.db.FindOutline(it.OutlineID); ok && o != nil {
+			status = statusLabel(*o, it.StatusID)
+		}
+	}
+	assignee := ""
+	if it.AssignedActorID != nil {
+		assignee = actorAtLabel(m.db, *it.AssignedActorID)
+	}
+	yesNo := func(b bool) string {
+		if b {
+			return "yes"
+		}
+		return "no"
+	}
+	return []historyScrubberField{
+		{label: "Title", value: it.Title},
+		{label: "Status", value: status},
+		{label: "Priority", value: yesNo(it.Priority)},
+		{label: "On hold", value: yesNo(it.OnHold)},
+		{label: "Owner", value: actorAtLabel(m.db, it.OwnerActorID)},
+		{label: "Assignee", value: assignee},
+		{label: "Due", value: formatDateTimeOutline(it.Due)},
+		{label: "Schedule", value: formatDateTimeOutline(it.Schedule)},
+		{label: "Tags", value: strings.Join(it.Tags, ", ")},
+		{label: "Archived", value: yesNo(it.Archived)},
+		{label: "Description", value: it.Description},
+	}
+}
+
+func (m *appModel) renderHistoryScrubberModal() string {
+	if m == nil || len(m.scrubberRevisions) == 0 {
+		return ""
+	}
+	i := m.scrubberIndex
+	rev := m.scrubberRevisions[i]
+	title := fmt.Sprintf("History — %s — %d/%d", m.modalForID, i+1, len(m.scrubberRevisions))
+
+	bodyW := modalBodyWidth(m.width)
+	muted := styleMuted()
+	changed := lipgloss.NewStyle().Foreground(colorAccent).Bold(true)
+
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("%s · %s · %s\n\n", rev.Event.Type, actorAtLabel(m.db, rev.Event.ActorID), fmtTS(rev.Event.IssuedAt)))
+	if rev.Item == nil {
+		b.WriteString(muted.Render("(item not created yet)"))
+	} else {
+		var prev map[string]string
+		if i > 0 && m.scrubberRevisions[i-1].Item != nil {
+			prev = map[string]string{}
+			for _, f := range m.historyScrubberFields(m.scrubberRevisions[i-1].Item) {
+				prev[f.label] = f.value
+			}
+		}
+		for _, f := range m.historyScrubberFields(rev.Item) {
+			value := f.value
+			if f.label == "Description" {
+				// Keep the scrubber compact: the first line is enough to see that it changed.
+				if first, _, more := strings.Cut(strings.TrimSpace(value), "\n"); more {
+					value = first + " …"
+				}
+			}
+			line := fmt.Sprintf("%-12s %s", f.label+":", value)
+			line = truncateText(line, bodyW)
+			if prev != nil && prev[f.label] != f.value {
+				b.WriteString(changed.Render("• " + line))
+			} else {
+				b.WriteString("  " + line)
+			}
+			b.WriteString("\n")
+		}
+	}
+	b.WriteString("\n")
+	b.WriteString(muted.Render("←/→ step  g/G first/last  esc close"))
+	return renderModalBox(m.width, title, b.String())
+}
@@ -0,0 +1,81 @@
+package tui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"clarity-cli/internal/store"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const scrubberEvents = "" +
+	`{"eventId":"evt-1","workspaceId":"ws-1","replicaId":"rep-a","entityKind":"actor","entityId":"act-1","entitySeq":0,"type":"identity.create","issuedAt":"2025-12-31T00:00:00Z","actorId":"act-1","payload":{"name":"A","kind":"human"}}` + "\n" +
+	`{"eventId":"evt-2","workspaceId":"ws-1","replicaId":"rep-a","entityKind":"project","entityId":"proj-1","entitySeq":0,"type":"project.create","issuedAt":"2025-12-31T00:00:01Z","actorId":"act-1","payload":{"id":"proj-1","name":"P","createdBy":"act-1","createdAt":"2025-12-31T00:00:01Z","archived":false}}` + "\n" +
+	`{"eventId":"evt-3","workspaceId":"ws-1","replicaId":"rep-a","entityKind":"outline","entityId":"out-1","entitySeq":0,"type":"outline.create","issuedAt":"2025-12-31T00:00:02Z","actorId":"act-1","payload":{"id":"out-1","projectId":"proj-1","statusDefs":[{"id":"todo","label":"Todo","isEndState":false},{"id":"done","label":"Done","isEndState":true}],"createdBy":"act-1","createdAt":"2025-12-31T00:00:02Z","archived":false}}` + "\n" +
+	`{"eventId":"evt-4","workspaceId":"ws-1","replicaId":"rep-a","entityKind":"item","entityId":"item-1","entitySeq":0,"type":"item.create","issuedAt":"2025-12-31T00:00:03Z","actorId":"act-1","payload":{"id":"item-1","projectId":"proj-1","outlineId":"out-1","rank":"h","title":"First","status":"todo","priority":false,"onHold":false,"archived":false,"ownerActorId":"act-1","createdBy":"act-1","createdAt":"2025-12-31T00:00:03Z","updatedAt":"2025-12-31T00:00:03Z"}}` + "\n" +
+	`{"eventId":"evt-5","workspaceId":"ws-1","replicaId":"rep-a","entityKind":"item","entityId":"item-1","entitySeq":0,"type":"item.set_title","issuedAt":"2025-12-31T00:00:04Z","actorId":"act-1","payload":{"title":"Second"}}` + "\n" +
+	`{"eventId":"evt-6","workspaceId":"ws-1","replicaId":"rep-a","entityKind":"item","entityId":"item-1","entitySeq":0,"type":"item.set_status","issuedAt":"2025-12-31T00:00:05Z","actorId":"act-1","payload":{"status":"done"}}` + "\n"
+
+func TestHistoryScrubber_StepsThroughItemEvents(t *testing.T) {
+	dir := t.TempDir()
+	if _, err := store.EnsureGitBackedV1Layout(dir); err != nil {
+		t.Fatalf("layout: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "events", "events.rep-a.jsonl"), []byte(scrubberEvents), 0o644); err != nil {
+		t.Fatalf("write events: %v", err)
+	}
+	db, err := store.Store{Dir: dir}.Load()
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+
+	m := newAppModel(dir, db)
+	m.view = viewItem
+	m.selectedProjectID = "proj-1"
+	m.selectedOutlineID = "out-1"
+	m.openItemID = "item-1"
+	m.width = 120
+	m.height = 60
+	m.pane = paneOutline
+	m.refreshItemSubtree(db.Outlines[0], "item-1")
+	selectListItemByID(&m.itemsList, "item-1")
+
+	key := func(m appModel, k string) appModel {
+		t.Helper()
+		mm, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)})
+		return mm.(appModel)
+	}
+
+	m = key(m, "T")
+	if m.modal != modalHistoryScrubber {
+		t.Fatalf("expected modalHistoryScrubber after T, got %v", m.modal)
+	}
+	if len(m.scrubberRevisions) != 3 || m.scrubberIndex != 2 {
+		t.Fatalf("expected 3 revisions at the latest, got %d at %d", len(m.scrubberRevisions), m.scrubberIndex)
+	}
+	if out := m.renderHistoryScrubberModal(); !strings.Contains(out, "item.set_status") || !strings.Contains(out, "Done") {
+		t.Fatalf("expected latest revision with status Done, got:\n%s", out)
+	}
+
+	m = key(m, "h")
+	if got := m.scrubberRevisions[m.scrubberIndex].Item.Title; got != "Second" {
+		t.Fatalf("title one step back = %q, want Second", got)
+	}
+	m = key(m, "g")
+	out := m.renderHistoryScrubberModal()
+	if !strings.Contains(out, "item.create") || !strings.Contains(out, "First") || !strings.Contains(out, "Todo") {
+		t.Fatalf("expected the create revision, got:\n%s", out)
+	}
+	m = key(m, "h")
+	if m.scrubberIndex != 0 {
+		t.Fatalf("expected to stay on the first revision, got %d", m.scrubberIndex)
+	}
+
+	mm, _ := m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if m = mm.(appModel); m.modal != modalNone || m.scrubberRevisions != nil {
+		t.Fatalf("expected esc to close the scrubber, got modal=%v", m.modal)
+	}
+}
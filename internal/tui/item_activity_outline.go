@@ -85,6 +85,7 @@ func buildItemActivityOutlineRows(db *store.DB, itemID string, collapsed map[str
 	// Deps.
 	type depEdge struct {
 		id        string
+		depID     string
 		otherID   string
 		label     string
 		sortGroup int
@@ -151,6 +152,7 @@ func buildItemActivityOutlineRows(db *store.DB, itemID string, collapsed map[str
 
 		depEdges = append(depEdges, depEdge{
 			id:        activityDepEdgeID(d.ID),
+			depID:     strings.TrimSpace(d.ID),
 			otherID:   otherID,
 			label:     prefix + otherLabel,
 			sortGroup: sortGroup,
@@ -184,6 +186,7 @@ func buildItemActivityOutlineRows(db *store.DB, itemID string, collapsed map[str
 					kind:           outlineActivityDepEdge,
 					depth:          baseDepth + 1,
 					label:          e.label,
+					depID:          e.depID,
 					depOtherItemID: e.otherID,
 				})
 			}
@@ -242,7 +245,7 @@ func buildItemActivityOutlineRows(db *store.DB, itemID string, collapsed map[str
 					}
 				}
 
-				label := fmt.Sprintf("%s %s", fmtTS(c.CreatedAt), actorAtLabel(db, c.AuthorID))
+				label := fmt.Sprintf("%s %s%s", fmtTS(c.CreatedAt), actorAtLabel(db, c.AuthorID), commentEditedMarker(c))
 				commentDepth := baseDepth + 1 + r.Depth
 
 				out = append(out, outlineActivityRowItem{
@@ -0,0 +1,46 @@
+package tui
+
+import (
+	"errors"
+	"strings"
+
+	"clarity-cli/internal/mutate"
+)
+
+// unlinkDependency removes a dependency edge (dep.remove) from the item view.
+// Permissions follow the dependent (from) item, like `clarity deps remove`.
+func (m *appModel) unlinkDependency(itemID, depID string) error {
+	depID = strings.TrimSpace(depID)
+	if m == nil || depID == "" {
+		return nil
+	}
+
+	db, err := m.store.Load()
+	if err != nil {
+		return err
+	}
+	m.db = db
+	actorID := m.editActorID()
+	if actorID == "" {
+		return errors.New("no current actor")
+	}
+
+	res, err := mutate.RemoveDependency(m.db, actorID, depID)
+	if err != nil {
+		if _, ok := err.(mutate.OwnerOnlyError); ok {
+			return errors.New("permission denied")
+		}
+		return err
+	}
+	if err := m.appendEvent(actorID, "dep.remove", res.Dep.ID, res.EventPayload); err != nil {
+		return err
+	}
+	m.refreshEventsTail()
+	if err := m.store.Save(m.db); err != nil {
+		return err
+	}
+	m.captureStoreModTimes()
+	m.showMinibuffer("Dependency removed")
+	m.refreshAfterItemChange(strings.TrimSpace(itemID))
+	return nil
+}
@@ -1,7 +1,6 @@
 package tui
 
 import (
-	"encoding/json"
 	"fmt"
 	"strings"
 
@@ -73,7 +72,20 @@ func renderItemSidePanelWithEvents(db *store.DB, it model.Item, width, height in
 		rows := buildAttachmentPanelRows(db, it)
 		lines = append(lines, headerStyle.Render(fmt.Sprintf("Attachments (%d)", len(rows))))
 		lines = append(lines, "")
-		lines = append(lines, renderAttachmentPanelRows(rows, attachmentIdx, innerW, height-2, scroll, focusRowStyle, moreStyle)...)
+		// With room to spare, the bottom half previews the selected attachment (once loaded; see
+		// loadAttachmentPreview).
+		listH := height - 2
+		var preview []string
+		if len(rows) > 0 && attachmentIdx >= 0 && attachmentIdx < len(rows) && height >= 16 {
+			a := rows[attachmentIdx].Attachment
+			if p := cachedAttachmentPreview(a); p != nil {
+				previewH := (height - 2) / 2
+				listH = height - 2 - previewH - 1
+				preview = append([]string{""}, renderAttachmentPreview(p, a, innerW, previewH)...)
+			}
+		}
+		lines = append(lines, renderAttachmentPanelRows(rows, attachmentIdx, innerW, listH, scroll, focusRowStyle, moreStyle)...)
+		lines = append(lines, preview...)
 	case itemSideComments:
 		comments := db.CommentsForItem(it.ID)
 		lines = append(lines, renderThreadedComments(db, comments, commentIdx, innerW, height, scroll, focusRowStyle, moreStyle)...)
@@ -572,19 +584,21 @@ func renderAccordionHistory(db *store.DB, events []model.Event, itemID string, s
 	ev := evs[selected]
 	actor := actorLabel(db, ev.ActorID)
 
-	payloadJSON := ""
-	if m, err := json.MarshalIndent(ev.Payload, "", "  "); err == nil {
-		payloadJSON = string(m)
+	payloadJSON := eventPayloadJSON(db, ev)
+	sig := ""
+	if d := eventSigDetail(ev); d != "" {
+		sig = "**Signature**: " + d + "\n\n"
 	}
-	md := strings.TrimSpace(fmt.Sprintf("**Type**: `%s`\n\n**When**: %s\n\n**Actor**: %s\n\n**Summary**: %s\n\n```json\n%s\n```",
+	md := strings.TrimSpace(fmt.Sprintf("**Type**: `%s`\n\n**When**: %s\n\n**Actor**: %s\n\n%s**Summary**: %s\n\n```json\n%s\n```",
 		strings.TrimSpace(ev.Type),
 		fmtTS(ev.TS),
 		actor,
-		eventSummary(ev),
+		sig,
+		eventSummary(db, ev),
 		payloadJSON,
 	))
 
-	title := fmt.Sprintf("%s  %s  %s", fmtTS(ev.TS), actor, eventSummary(ev))
+	title := fmt.Sprintf("%s  %s  %s%s", fmtTS(ev.TS), actor, eventSigTag(ev), eventSummary(db, ev))
 	mdLines := strings.Split(renderMarkdownComment(md, maxInt(10, width-2)), "\n")
 	if scroll > len(mdLines) {
 		scroll = len(mdLines)
@@ -663,7 +677,7 @@ func renderAccordionHistory(db *store.DB, events []model.Event, itemID string, s
 		}
 		ev := evs[i]
 		actor := actorLabel(db, ev.ActorID)
-		out = append(out, fmt.Sprintf("%s  %s  %s", fmtTS(ev.TS), actor, truncateInline(eventSummary(ev), maxInt(20, width-26))))
+		out = append(out, fmt.Sprintf("%s  %s  %s", fmtTS(ev.TS), actor, truncateInline(eventSigTag(ev)+eventSummary(db, ev), maxInt(20, width-26))))
 	}
 	if end < len(evs) {
 		out = append(out, moreStyle.Render(fmt.Sprintf("↓ %d more", len(evs)-end)))
@@ -77,3 +77,24 @@ func intToString(n int) string {
         }
         return string(buf[i:])
 }
+
+func TestRenderAccordionHistory_MarksUnverifiedEvents(t *testing.T) {
+        events := []model.Event{
+                {ID: "e1", TS: time.Unix(0, 0).UTC(), ActorID: "act-1", Type: "item.create", EntityID: "item-1", Signature: "verified"},
+                {ID: "e2", TS: time.Unix(60, 0).UTC(), ActorID: "act-1", Type: "item.set_description", EntityID: "item-1", Signature: "invalid"},
+                {ID: "e3", TS: time.Unix(120, 0).UTC(), ActorID: "act-1", Type: "item.archive", EntityID: "item-1", Signature: "missing"},
+        }
+
+        // Newest first: e3 is selected, the others are rows.
+        lines := renderAccordionHistory(nil, events, "item-1", 0, 100, 30, 0, lipgloss.NewStyle(), lipgloss.NewStyle())
+        out := strings.Join(lines, "\n")
+        if !strings.Contains(out, "[missing signature] item.archive") || !strings.Contains(out, "[bad signature] item.set_description") {
+                t.Fatalf("expected unverified events to be marked; got:\n%s", out)
+        }
+        if strings.Contains(out, "signature] item.create") {
+                t.Fatalf("expected the verified event to be unmarked; got:\n%s", out)
+        }
+        if !strings.Contains(out, "Signature") {
+                t.Fatalf("expected the selected event's body to explain its signature; got:\n%s", out)
+        }
+}
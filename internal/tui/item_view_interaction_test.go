@@ -388,6 +388,102 @@ func TestItemView_DepsActivityRows_EnterJumpsToItem(t *testing.T) {
 	}
 }
 
+func TestItemView_DepsActivityRows_RUnlinksDependency(t *testing.T) {
+	dir := t.TempDir()
+	s := store.Store{Dir: dir}
+
+	actorID := "act-human"
+	now := time.Now().UTC()
+	db := &store.DB{
+		CurrentActorID: actorID,
+		Actors:         []model.Actor{{ID: actorID, Kind: model.ActorKindHuman, Name: "human"}},
+		Projects: []model.Project{{
+			ID:        "proj-a",
+			Name:      "Project A",
+			CreatedBy: actorID,
+			CreatedAt: now,
+		}},
+		Outlines: []model.Outline{{
+			ID:         "out-a",
+			ProjectID:  "proj-a",
+			StatusDefs: store.DefaultOutlineStatusDefs(),
+			CreatedBy:  actorID,
+			CreatedAt:  now,
+		}},
+		Items: []model.Item{
+			{
+				ID:           "item-a",
+				ProjectID:    "proj-a",
+				OutlineID:    "out-a",
+				Rank:         "h",
+				Title:        "Item A",
+				StatusID:     "todo",
+				OwnerActorID: actorID,
+				CreatedBy:    actorID,
+				CreatedAt:    now,
+				UpdatedAt:    now,
+			},
+			{
+				ID:           "item-b",
+				ProjectID:    "proj-a",
+				OutlineID:    "out-a",
+				Rank:         "i",
+				Title:        "Item B",
+				StatusID:     "doing",
+				OwnerActorID: actorID,
+				CreatedBy:    actorID,
+				CreatedAt:    now,
+				UpdatedAt:    now,
+			},
+		},
+		Deps: []model.Dependency{
+			{ID: "dep-1", FromItemID: "item-a", ToItemID: "item-b", Type: model.DependencyBlocks},
+		},
+	}
+	if err := s.Save(db); err != nil {
+		t.Fatalf("save db: %v", err)
+	}
+
+	m := newAppModel(dir, db)
+	m.width = 120
+	m.height = 40
+	m.view = viewItem
+	m.pane = paneOutline
+	m.selectedProjectID = "proj-a"
+	m.selectedOutlineID = "out-a"
+	m.selectedOutline = &db.Outlines[0]
+	m.openItemID = "item-a"
+	m.itemCollapsed = map[string]bool{
+		"item-a":                     false,
+		activityDepsRootID("item-a"): false,
+	}
+	m.refreshItemSubtree(db.Outlines[0], "item-a")
+	selectListItemByID(&m.itemsList, activityDepEdgeID("dep-1"))
+
+	mAny, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'r'}})
+	m2 := mAny.(appModel)
+	if m2.modal != modalNone {
+		t.Fatalf("expected no archive confirm modal on a dep row, got modal=%v", m2.modal)
+	}
+	if got := strings.TrimSpace(m2.openItemID); got != "item-a" {
+		t.Fatalf("expected to stay on item-a, got %q", got)
+	}
+	if len(m2.db.Deps) != 0 {
+		t.Fatalf("expected dependency to be removed, got %#v", m2.db.Deps)
+	}
+	if it, ok := m2.db.FindItem("item-a"); !ok || it.Archived {
+		t.Fatalf("expected item-a to remain unarchived")
+	}
+
+	reloaded, err := s.Load()
+	if err != nil {
+		t.Fatalf("reload: %v", err)
+	}
+	if len(reloaded.Deps) != 0 {
+		t.Fatalf("expected dependency removal to be persisted, got %#v", reloaded.Deps)
+	}
+}
+
 func TestItemView_TabCollapse_IsIsolatedFromOutlineCollapse(t *testing.T) {
 	dir := t.TempDir()
 	s := store.Store{Dir: dir}
@@ -6,6 +6,7 @@ import (
 	"strings"
 	"time"
 
+	"clarity-cli/internal/agenda"
 	"clarity-cli/internal/model"
 
 	"github.com/charmbracelet/bubbles/list"
@@ -154,6 +155,8 @@ type outlineRow struct {
 	doneChildren   int
 	totalChildren  int
 	commentsCount  int
+	// blockedBy is the number of open items blocking this item (transitively; see internal/readiness).
+	blockedBy int
 	// assignedLabel is a cached display label for item.AssignedActorID (computed during refresh).
 	// It should not include the leading '@' so renderers can style/prefix consistently.
 	assignedLabel string
@@ -216,6 +219,7 @@ type outlineActivityRowItem struct {
 	commentID      string
 	worklogID      string
 	eventID        string
+	depID          string
 	depOtherItemID string
 
 	hasChildren bool
@@ -366,6 +370,14 @@ var (
 	progressEmptyFg lipgloss.TerminalColor = defaultProgressEmptyFg
 )
 
+// blockedByLabel renders the "blocked by N open items" badge for outline/agenda rows.
+func blockedByLabel(n int) string {
+	if n == 1 {
+		return "blocked by 1 open item"
+	}
+	return fmt.Sprintf("blocked by %d open items", n)
+}
+
 func renderProgressCookie(done, total int) string {
 	if total <= 0 {
 		return ""
@@ -522,6 +534,8 @@ func newList(title, help string, items []list.Item) list.Model {
 type agendaRowItem struct {
 	row     outlineRow
 	outline model.Outline
+	// entry is set for date-driven (day/week) agenda rows.
+	entry *agenda.Row
 }
 
 func (i agendaRowItem) FilterValue() string {
@@ -558,6 +572,9 @@ func (i agendaRowItem) Title() string {
 	if s := strings.TrimSpace(formatDueLabel(i.row.item.Due)); s != "" {
 		metaParts = append(metaParts, metaDueStyle.Render(s))
 	}
+	if i.row.blockedBy > 0 {
+		metaParts = append(metaParts, metaOnHoldStyle.Render(blockedByLabel(i.row.blockedBy)))
+	}
 	if lbl := strings.TrimSpace(i.row.assignedLabel); lbl != "" {
 		metaParts = append(metaParts, metaAssignStyle.Render("@"+lbl))
 	}
@@ -595,6 +612,9 @@ func (i agendaRowItem) Title() string {
 		}
 		return fmt.Sprintf("%s%s%s", lead, title, meta)
 	}
+	if i.entry != nil {
+		lead = agendaEntryLead(*i.entry)
+	}
 	if lead == "" {
 		return fmt.Sprintf("%s %s%s", status, title, meta)
 	}
@@ -603,6 +623,40 @@ func (i agendaRowItem) Title() string {
 
 func (i agendaRowItem) Description() string { return "" }
 
+// agendaEntryLead renders the Org-style "time  Label:" prefix for dated agenda rows.
+func agendaEntryLead(e agenda.Row) string {
+	when := strings.TrimSpace(e.Time)
+	if when == "" {
+		when = "     "
+	}
+	label := fmt.Sprintf("%-10s", strings.TrimSpace(e.Label)+":")
+	style := metaScheduleStyle
+	switch e.Kind {
+	case agenda.KindDeadline, agenda.KindDeadlineUpcoming:
+		style = metaDueStyle
+	case agenda.KindDeadlineOverdue:
+		style = metaPriorityStyle
+	}
+	return "  " + when + " " + style.Render(label) + " "
+}
+
+type agendaDayHeadingItem struct {
+	day agenda.Day
+}
+
+func (i agendaDayHeadingItem) FilterValue() string { return i.day.Date }
+func (i agendaDayHeadingItem) Title() string {
+	lbl := i.day.Weekday + " " + i.day.Date
+	if t, err := agenda.ParseDate(i.day.Date); err == nil {
+		lbl = t.Format("Monday 2 January 2006")
+	}
+	if i.day.Today {
+		lbl += "  (today)"
+	}
+	return lipgloss.NewStyle().Foreground(colorChromeMutedFg).Bold(true).Render(lbl)
+}
+func (i agendaDayHeadingItem) Description() string { return "" }
+
 type agendaHeadingItem struct {
 	projectName string
 	outlineName string
@@ -0,0 +1,375 @@
+package tui
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"clarity-cli/internal/mutate"
+	"clarity-cli/internal/notifications"
+	"clarity-cli/internal/store"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// notificationRowItem is one entry in the notifications view.
+type notificationRowItem struct {
+	entry notifications.Entry
+}
+
+func (i notificationRowItem) FilterValue() string {
+	return strings.TrimSpace(i.entry.Title + " " + i.entry.Summary)
+}
+
+func (i notificationRowItem) Title() string {
+	t := strings.TrimSpace(i.entry.Title)
+	if t == "" {
+		t = i.entry.ItemID
+	}
+	return t
+}
+
+// notificationDelegate renders an entry as two lines: unread marker + item title + time,
+// then the event summary.
+type notificationDelegate struct {
+	normal   lipgloss.Style
+	selected lipgloss.Style
+	dim      lipgloss.Style
+}
+
+func newNotificationDelegate() notificationDelegate {
+	return notificationDelegate{
+		normal:   lipgloss.NewStyle().Foreground(colorSurfaceFg),
+		selected: lipgloss.NewStyle().Foreground(colorSelectedFg).Background(colorSelectedBg),
+		dim:      lipgloss.NewStyle().Foreground(colorChromeSubtleFg),
+	}
+}
+
+func (d notificationDelegate) Height() int  { return 2 }
+func (d notificationDelegate) Spacing() int { return 0 }
+func (d notificationDelegate) Update(_ tea.Msg, _ *list.Model) tea.Cmd {
+	return nil
+}
+
+func (d notificationDelegate) Render(w io.Writer, m list.Model, index int, item list.Item) {
+	contentW := m.Width()
+	it, ok := item.(notificationRowItem)
+	if !ok || contentW < 4 {
+		fmt.Fprint(w, "\n")
+		return
+	}
+
+	base := d.normal
+	dim := d.dim
+	if index == m.Index() {
+		base = d.selected
+		dim = d.selected
+	}
+
+	marker := "  "
+	if it.entry.Unread {
+		marker = glyphBullet() + " "
+	}
+	title := base.Render(marker) + base.Bold(it.entry.Unread).Render(it.Title()) + base.Render("  ") + dim.Render(fmtTS(it.entry.TS))
+	second := base.Render("  ") + dim.Render(it.entry.Summary)
+
+	fmt.Fprint(w, padOrCut(title, contentW, base)+"\n"+padOrCut(second, contentW, base))
+}
+
+// notificationsCursor returns the actor's local last-seen cursor (nil if never marked read).
+func (m *appModel) notificationsCursor(actorID string) *store.NotificationCursor {
+	st, err := m.store.LoadNotificationsState()
+	if err != nil {
+		return nil
+	}
+	if c, ok := st.LastSeen[actorID]; ok {
+		return &c
+	}
+	return nil
+}
+
+func (m *appModel) openNotifications() {
+	if m.view != viewNotifications {
+		m.notificationsReturnView = m.view
+		m.hasNotificationsReturnView = true
+	}
+	m.view = viewNotifications
+	m.showPreview = false
+	m.pane = paneOutline
+	m.notificationsList.Select(0)
+	m.refreshNotifications()
+}
+
+func (m *appModel) refreshNotifications() {
+	if m == nil || m.db == nil {
+		return
+	}
+	prevID := ""
+	if it, ok := m.notificationsList.SelectedItem().(notificationRowItem); ok {
+		prevID = it.entry.EventID
+	}
+
+	actorID := m.editActorID()
+	events, err := store.ReadEventsTail(m.dir, notifications.DefaultWindow)
+	if err != nil {
+		m.notificationsList.SetItems(nil)
+		m.notificationsUnread = 0
+		return
+	}
+	res := notifications.Feed(m.db, events, notifications.Options{
+		ActorID: actorID,
+		Cursor:  m.notificationsCursor(actorID),
+	})
+	m.notificationsUnread = res.Unread
+
+	items := make([]list.Item, 0, len(res.Entries))
+	for _, e := range res.Entries {
+		items = append(items, notificationRowItem{entry: e})
+	}
+	m.notificationsList.SetItems(items)
+	if prevID != "" {
+		for i, li := range items {
+			if li.(notificationRowItem).entry.EventID == prevID {
+				m.notificationsList.Select(i)
+				return
+			}
+		}
+	}
+	if m.notificationsList.Index() >= len(items) {
+		m.notificationsList.Select(0)
+	}
+}
+
+// refreshNotificationsUnread updates the unread count shown in the Go to panel.
+func (m *appModel) refreshNotificationsUnread() {
+	if m == nil || m.db == nil {
+		return
+	}
+	actorID := m.editActorID()
+	if len(m.db.FollowsForActor(actorID)) == 0 {
+		m.notificationsUnread = 0
+		return
+	}
+	events, err := store.ReadEventsTail(m.dir, notifications.DefaultWindow)
+	if err != nil {
+		return
+	}
+	m.notificationsUnread = notifications.Feed(m.db, events, notifications.Options{
+		ActorID:    actorID,
+		Cursor:     m.notificationsCursor(actorID),
+		UnreadOnly: true,
+		Limit:      1,
+	}).Unread
+}
+
+// markNotificationsRead advances the actor's last-seen cursor to the newest event.
+func (m *appModel) markNotificationsRead() error {
+	actorID := m.editActorID()
+	if actorID == "" {
+		return errors.New("no current actor")
+	}
+	events, err := store.ReadEventsTail(m.dir, 1)
+	if err != nil {
+		return err
+	}
+	st, err := m.store.LoadNotificationsState()
+	if err != nil {
+		return err
+	}
+	st.LastSeen[actorID] = notifications.Latest(events)
+	if err := m.store.SaveNotificationsState(st); err != nil {
+		return err
+	}
+	m.refreshNotifications()
+	return nil
+}
+
+func (m *appModel) leaveNotifications() {
+	if m.hasNotificationsReturnView {
+		m.view = m.notificationsReturnView
+		m.hasNotificationsReturnView = false
+	} else {
+		m.view = viewProjects
+	}
+	switch m.view {
+	case viewProjects:
+		m.refreshProjects()
+	case viewOutlines:
+		m.refreshOutlines(m.selectedProjectID)
+	case viewAgenda:
+		m.refreshAgenda()
+	case viewArchived:
+		m.refreshArchived()
+	case viewSearch:
+		m.refreshSearch()
+	case viewOutline:
+		if o, ok := m.db.FindOutline(m.selectedOutlineID); ok {
+			m.refreshItems(*o)
+		}
+	case viewItem:
+		if m.openItemID == "" || m.selectedOutline == nil {
+			m.view = viewProjects
+			m.refreshProjects()
+			return
+		}
+		m.refreshItemSubtree(*m.selectedOutline, m.openItemID)
+	}
+}
+
+// toggleFollow follows (or unfollows) an outline or item for the current human actor.
+func (m *appModel) toggleFollow(targetID string) error {
+	actorID := m.editActorID()
+	if actorID == "" {
+		return errors.New("no current actor")
+	}
+	targetID = strings.TrimSpace(targetID)
+	if targetID == "" {
+		return errors.New("nothing selected")
+	}
+	kind, err := mutate.ResolveFollowTarget(m.db, targetID)
+	if err != nil {
+		return err
+	}
+
+	if m.db.FindFollow(actorID, kind, targetID) != nil {
+		res, err := mutate.Unfollow(m.db, actorID, targetID)
+		if err != nil {
+			return err
+		}
+		if res.Changed {
+			if err := m.appendEvent(actorID, "follow.remove", res.Follow.ID, res.EventPayload); err != nil {
+				return err
+			}
+		}
+		m.showMinibuffer("Unfollowed " + kind)
+	} else {
+		res, err := mutate.Follow(m.db, actorID, targetID, m.store.NextID(m.db, "fol"), time.Now())
+		if err != nil {
+			return err
+		}
+		if res.Changed {
+			if err := m.appendEvent(actorID, "follow.add", res.Follow.ID, res.EventPayload); err != nil {
+				return err
+			}
+		}
+		m.showMinibuffer("Following " + kind + " (g n: notifications)")
+	}
+
+	if err := m.store.Save(m.db); err != nil {
+		return err
+	}
+	m.refreshEventsTail()
+	m.captureStoreModTimes()
+	return nil
+}
+
+// followLabel returns "Follow <kind>" or "Unfollow <kind>" for action panel entries.
+func (m *appModel) followLabel(kind, targetID string) string {
+	if m.db != nil && m.db.FindFollow(m.editActorID(), kind, strings.TrimSpace(targetID)) != nil {
+		return "Unfollow " + kind
+	}
+	return "Follow " + kind
+}
+
+func (m appModel) updateNotifications(msg tea.Msg) (tea.Model, tea.Cmd) {
+	km, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+	switch km.String() {
+	case "ctrl+c", "q":
+		return m, m.quitWithStateCmd()
+	case "x", "?":
+		m.openActionPanel(actionPanelContext)
+		return m, nil
+	case "g":
+		m.openActionPanel(actionPanelNav)
+		return m, nil
+	case "a":
+		m.openActionPanel(actionPanelAgenda)
+		return m, nil
+	case "c":
+		m.openActionPanel(actionPanelCapture)
+		return m, nil
+	case "R":
+		if err := (&m).markNotificationsRead(); err != nil {
+			m.showMinibuffer("Notifications: " + err.Error())
+			return m, nil
+		}
+		m.showMinibuffer("Notifications: all marked read")
+		return m, nil
+	case "backspace", "esc":
+		(&m).leaveNotifications()
+		return m, nil
+	case "enter":
+		it, ok := m.notificationsList.SelectedItem().(notificationRowItem)
+		if !ok {
+			return m, nil
+		}
+		t, ok := m.db.FindItem(it.entry.ItemID)
+		if !ok || t == nil {
+			m.showMinibuffer("Notifications: item no longer exists")
+			return m, nil
+		}
+		if t.Archived {
+			m.showMinibuffer("Notifications: archived items open from the Archived view (g A)")
+			return m, nil
+		}
+		snap := m.captureReturnSnapshot()
+		if err := (&m).jumpToItemByID(t.ID); err != nil {
+			m.showMinibuffer("Notifications: " + err.Error())
+			return m, nil
+		}
+		(&m).applyReturnSnapshot(snap)
+		return m, nil
+	}
+	var cmd tea.Cmd
+	m.notificationsList, cmd = m.notificationsList.Update(msg)
+	return m, cmd
+}
+
+func (m *appModel) viewNotifications() string {
+	frameH := m.frameHeight()
+	if frameH < 8 {
+		frameH = 8
+	}
+	bodyHeight := frameH - (topPadLines + breadcrumbGap + 2)
+	if bodyHeight < 6 {
+		bodyHeight = 6
+	}
+
+	w := m.width
+	if w < 10 {
+		w = 10
+	}
+	contentW := w - 2*splitOuterMargin
+	if contentW < 10 {
+		contentW = w
+	}
+
+	crumb := lipgloss.NewStyle().Width(contentW).Foreground(colorChromeSubtleFg).Render(m.breadcrumbText())
+	var body string
+	if len(m.notificationsList.Items()) == 0 {
+		msg := "No notifications. Follow an outline (O f) or an item (F) to get notified about new items, status changes and comments."
+		body = lipgloss.NewStyle().Width(contentW).Foreground(colorChromeSubtleFg).Render(msg)
+	} else {
+		body = m.listBodyWithOverflowHint(&m.notificationsList, contentW, bodyHeight)
+	}
+	main := strings.Repeat("\n", topPadLines) + crumb + strings.Repeat("\n", breadcrumbGap+1) + body
+	main = lipgloss.NewStyle().Width(w).Padding(0, splitOuterMargin).Render(main)
+	if m.modal == modalNone {
+		return main
+	}
+	bg := dimBackground(main)
+	fg := m.renderModal()
+	return overlayCenter(bg, fg, w, frameH)
+}
+
+// notificationsBreadcrumb renders `notifications (N unread)`.
+func (m *appModel) notificationsBreadcrumb() string {
+	return fmt.Sprintf("notifications (%d unread)", m.notificationsUnread)
+}
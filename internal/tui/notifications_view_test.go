@@ -0,0 +1,95 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"clarity-cli/internal/model"
+	"clarity-cli/internal/store"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestNotificationsView_FollowOpenAndMarkRead(t *testing.T) {
+	now := time.Now().UTC()
+	db := &store.DB{
+		CurrentActorID: "act-me",
+		Actors: []model.Actor{
+			{ID: "act-me", Kind: model.ActorKindHuman, Name: "me"},
+			{ID: "act-bob", Kind: model.ActorKindHuman, Name: "bob"},
+		},
+		Projects: []model.Project{{ID: "proj-a", Name: "Project", CreatedBy: "act-me", CreatedAt: now}},
+		Outlines: []model.Outline{{ID: "out-a", ProjectID: "proj-a", StatusDefs: store.DefaultOutlineStatusDefs(), CreatedBy: "act-me", CreatedAt: now}},
+		Items: []model.Item{
+			{ID: "item-a", ProjectID: "proj-a", OutlineID: "out-a", Rank: "h", Title: "Ship it", StatusID: "todo", OwnerActorID: "act-bob", CreatedBy: "act-bob", CreatedAt: now, UpdatedAt: now},
+		},
+	}
+
+	dir := t.TempDir()
+	m := newAppModel(dir, db)
+	m.width = 100
+	m.height = 30
+	m.view = viewProjects
+
+	if err := (&m).toggleFollow("out-a"); err != nil {
+		t.Fatalf("follow: %v", err)
+	}
+	if db.FindFollow("act-me", model.FollowTargetOutline, "out-a") == nil {
+		t.Fatalf("expected outline follow")
+	}
+	if err := m.store.AppendEvent("act-bob", "item.set_status", "item-a", map[string]any{"from": "todo", "to": "doing"}); err != nil {
+		t.Fatalf("append: %v", err)
+	}
+	if err := m.store.AppendEvent("act-bob", "comment.add", "cmt-1", map[string]any{"id": "cmt-1", "itemId": "item-a", "body": "done soon"}); err != nil {
+		t.Fatalf("append: %v", err)
+	}
+	db.Comments = append(db.Comments, model.Comment{ID: "cmt-1", ItemID: "item-a", AuthorID: "act-bob", Body: "done soon", CreatedAt: now})
+
+	(&m).openNotifications()
+	if m.view != viewNotifications {
+		t.Fatalf("expected notifications view, got %v", m.view)
+	}
+	if got := len(m.notificationsList.Items()); got != 2 || m.notificationsUnread != 2 {
+		t.Fatalf("expected 2 unread entries, got %d (unread=%d)", got, m.notificationsUnread)
+	}
+	first := m.notificationsList.Items()[0].(notificationRowItem)
+	if first.entry.Type != "comment.add" {
+		t.Fatalf("expected newest first, got %s", first.entry.Type)
+	}
+	if !strings.Contains(m.View(), "bob commented: done soon") {
+		t.Fatalf("expected summary to render")
+	}
+
+	mm, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'R'}})
+	m = mm.(appModel)
+	if m.notificationsUnread != 0 {
+		t.Fatalf("expected all read, got %d", m.notificationsUnread)
+	}
+	if got := len(m.notificationsList.Items()); got != 2 {
+		t.Fatalf("expected read entries to stay listed, got %d", got)
+	}
+
+	mm, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = mm.(appModel)
+	if m.view != viewItem || m.openItemID != "item-a" {
+		t.Fatalf("expected item view for item-a, got view=%v open=%q", m.view, m.openItemID)
+	}
+	(&m).returnFromItemView()
+	if m.view != viewNotifications {
+		t.Fatalf("expected to be back on notifications view, got %v", m.view)
+	}
+
+	mm, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = mm.(appModel)
+	if m.view != viewProjects {
+		t.Fatalf("expected esc to leave notifications, got %v", m.view)
+	}
+
+	if err := (&m).toggleFollow("out-a"); err != nil {
+		t.Fatalf("unfollow: %v", err)
+	}
+	if len(db.Follows) != 0 {
+		t.Fatalf("expected unfollow, got %#v", db.Follows)
+	}
+}
@@ -259,6 +259,13 @@ func (d outlineItemDelegate) renderOutlineRow(width int, prefix string, it outli
 		}
 		metaParts = append(metaParts, st.Render("on hold"))
 	}
+	if it.row.blockedBy > 0 {
+		st := metaOnHoldStyle
+		if focused {
+			st = st.Background(bg)
+		}
+		metaParts = append(metaParts, st.Render(blockedByLabel(it.row.blockedBy)))
+	}
 	if s := strings.TrimSpace(formatScheduleLabel(it.row.item.Schedule)); s != "" {
 		st := metaScheduleStyle
 		if focused {
@@ -0,0 +1,162 @@
+package tui
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"clarity-cli/internal/readiness"
+	"clarity-cli/internal/search"
+	"clarity-cli/internal/store"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// refreshSavedViews reloads meta/views.json for the Agenda Commands panel.
+func (m *appModel) refreshSavedViews() {
+	if m == nil {
+		return
+	}
+	f, err := m.store.LoadViews()
+	if err != nil {
+		m.savedViews = nil
+		m.showMinibuffer("Views: " + err.Error())
+		return
+	}
+	m.savedViews = f.Views
+}
+
+// savedViewKeys assigns each saved view a key in the Agenda Commands panel: its own key when free,
+// otherwise the next free digit (1-9). Views that don't fit are left out.
+func savedViewKeys(views []store.SavedView, taken map[string]actionPanelAction) map[string]store.SavedView {
+	out := map[string]store.SavedView{}
+	used := func(k string) bool {
+		_, inTaken := taken[k]
+		_, inOut := out[k]
+		return inTaken || inOut
+	}
+	var rest []store.SavedView
+	for _, v := range views {
+		if k := strings.TrimSpace(v.Key); k != "" && !used(k) {
+			out[k] = v
+			continue
+		}
+		rest = append(rest, v)
+	}
+	digit := '1'
+	for _, v := range rest {
+		for digit <= '9' && used(string(digit)) {
+			digit++
+		}
+		if digit > '9' {
+			break
+		}
+		out[string(digit)] = v
+		digit++
+	}
+	return out
+}
+
+func savedViewLabel(v store.SavedView) string {
+	if d := strings.TrimSpace(v.Description); d != "" {
+		return "View: " + d
+	}
+	return "View: " + v.Name
+}
+
+func openSavedView(v store.SavedView) func(mm appModel) (appModel, tea.Cmd) {
+	return func(mm appModel) (appModel, tea.Cmd) {
+		if _, err := search.Parse(v.Query); err != nil {
+			mm.showMinibuffer("View " + v.Name + ": " + err.Error())
+			return mm, nil
+		}
+		if mm.view != viewAgenda {
+			mm.hasAgendaReturnView = true
+			mm.agendaReturnView = mm.view
+		}
+		mm.view = viewAgenda
+		mm.agendaSpan = ""
+		mm.agendaFrom = time.Time{}
+		view := v
+		mm.agendaView = &view
+		mm.agendaList.Select(0)
+		mm.refreshAgenda()
+		return mm, nil
+	}
+}
+
+// refreshSavedViewAgenda renders the agenda for a saved view: matching items in query order,
+// with a project/outline heading whenever the outline changes.
+func (m *appModel) refreshSavedViewAgenda() {
+	curID := ""
+	if it, ok := m.agendaList.SelectedItem().(agendaRowItem); ok {
+		curID = it.row.item.ID
+	}
+
+	q, err := search.Parse(m.agendaView.Query)
+	if err != nil {
+		m.agendaList.SetItems(nil)
+		m.showMinibuffer("View " + m.agendaView.Name + ": " + err.Error())
+		return
+	}
+	opts := search.Options{ActorID: strings.TrimSpace(m.db.CurrentActorID), Today: time.Now()}
+	if len(q.Terms) > 0 {
+		if ids, err := m.store.SearchCandidates(context.Background(), q.Terms); err == nil {
+			opts.Candidates = ids
+		}
+	}
+	res := search.Run(m.db, q, opts)
+	blockedBy := readiness.OpenBlockerCounts(m.db)
+
+	var items []list.Item
+	lastOutlineID := ""
+	for _, h := range res.Hits {
+		it, ok := m.db.FindItem(h.ItemID)
+		if !ok || it == nil {
+			continue
+		}
+		o, ok := m.db.FindOutline(it.OutlineID)
+		if !ok || o == nil {
+			continue
+		}
+		if o.ID != lastOutlineID {
+			lastOutlineID = o.ID
+			projectName := it.ProjectID
+			if p, ok := m.db.FindProject(it.ProjectID); ok && p != nil && strings.TrimSpace(p.Name) != "" {
+				projectName = strings.TrimSpace(p.Name)
+			}
+			outName := ""
+			if o.Name != nil {
+				outName = strings.TrimSpace(*o.Name)
+			}
+			items = append(items, agendaHeadingItem{projectName: projectName, outlineName: outName})
+		}
+		row := outlineRow{item: *it, blockedBy: blockedBy[it.ID]}
+		if it.AssignedActorID != nil && strings.TrimSpace(*it.AssignedActorID) != "" {
+			row.assignedLabel = actorCompactLabel(m.db, *it.AssignedActorID)
+		}
+		if len(it.Tags) > 0 {
+			cleaned := make([]string, 0, len(it.Tags))
+			for _, t := range it.Tags {
+				if t = normalizeTag(t); t != "" {
+					cleaned = append(cleaned, t)
+				}
+			}
+			row.item.Tags = uniqueSortedStrings(cleaned)
+		}
+		items = append(items, agendaRowItem{row: row, outline: *o})
+	}
+
+	m.agendaList.SetItems(items)
+	if curID != "" {
+		selectListItemByID(&m.agendaList, curID)
+		return
+	}
+	for i := 0; i < len(items); i++ {
+		if _, ok := items[i].(agendaRowItem); ok {
+			m.agendaList.Select(i)
+			break
+		}
+	}
+}
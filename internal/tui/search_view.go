@@ -0,0 +1,332 @@
+package tui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"clarity-cli/internal/search"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	xansi "github.com/charmbracelet/x/ansi"
+)
+
+const searchPlaceholder = "deploy status:doing tag:infra assignee:me due<2026-11-01"
+
+// searchHitRowItem is one result in the global search view.
+type searchHitRowItem struct {
+	hit         search.Hit
+	projectName string
+	outlineName string
+	archived    bool
+}
+
+func (i searchHitRowItem) FilterValue() string {
+	return strings.TrimSpace(i.hit.Title + " " + i.hit.ItemID)
+}
+
+func (i searchHitRowItem) Title() string {
+	t := strings.TrimSpace(i.hit.Title)
+	if t == "" {
+		t = "(untitled)"
+	}
+	return t
+}
+
+// location renders "project / outline" for the row's secondary text.
+func (i searchHitRowItem) location() string {
+	p := strings.TrimSpace(i.projectName)
+	if p == "" {
+		p = "(project)"
+	}
+	o := strings.TrimSpace(i.outlineName)
+	if o == "" {
+		o = "(outline)"
+	}
+	s := p + " / " + o
+	if i.archived {
+		s += " (archived)"
+	}
+	return s
+}
+
+// searchHitDelegate renders a result as two lines: title + location, then the best snippet
+// with matched terms highlighted.
+type searchHitDelegate struct {
+	normal   lipgloss.Style
+	selected lipgloss.Style
+	dim      lipgloss.Style
+}
+
+func newSearchHitDelegate() searchHitDelegate {
+	return searchHitDelegate{
+		normal:   lipgloss.NewStyle().Foreground(colorSurfaceFg),
+		selected: lipgloss.NewStyle().Foreground(colorSelectedFg).Background(colorSelectedBg),
+		dim:      lipgloss.NewStyle().Foreground(colorChromeSubtleFg),
+	}
+}
+
+func (d searchHitDelegate) Height() int  { return 2 }
+func (d searchHitDelegate) Spacing() int { return 0 }
+func (d searchHitDelegate) Update(_ tea.Msg, _ *list.Model) tea.Cmd {
+	return nil
+}
+
+func (d searchHitDelegate) Render(w io.Writer, m list.Model, index int, item list.Item) {
+	contentW := m.Width()
+	it, ok := item.(searchHitRowItem)
+	if !ok || contentW < 4 {
+		fmt.Fprint(w, "\n")
+		return
+	}
+
+	base := d.normal
+	dim := d.dim
+	if index == m.Index() {
+		base = d.selected
+		dim = d.selected
+	}
+	hl := base.Bold(true).Underline(true)
+
+	title := base.Bold(index == m.Index()).Render(it.Title()) + base.Render("  ") + dim.Render(it.location())
+
+	second := base.Render("  ")
+	if len(it.hit.Matches) == 0 {
+		second += dim.Render(it.hit.ItemID)
+	} else {
+		mt := it.hit.Matches[0]
+		second += dim.Render(string(mt.Field) + ": ")
+		pos := 0
+		for _, sp := range mt.Highlights {
+			if sp.Start < pos || sp.End > len(mt.Snippet) {
+				continue
+			}
+			second += base.Render(mt.Snippet[pos:sp.Start]) + hl.Render(mt.Snippet[sp.Start:sp.End])
+			pos = sp.End
+		}
+		second += base.Render(mt.Snippet[pos:])
+	}
+
+	fmt.Fprint(w, padOrCut(title, contentW, base)+"\n"+padOrCut(second, contentW, base))
+}
+
+func padOrCut(line string, width int, pad lipgloss.Style) string {
+	lineW := xansi.StringWidth(line)
+	if lineW < width {
+		return line + pad.Render(strings.Repeat(" ", width-lineW))
+	}
+	if lineW > width {
+		return xansi.Cut(line, 0, width)
+	}
+	return line
+}
+
+// openSearchModal prompts for a global search query (prefilled with the last one).
+func (m *appModel) openSearchModal() {
+	m.modalForKey = ""
+	m.openInputModal(modalSearch, "", searchPlaceholder, m.searchQuery)
+}
+
+// runSearch searches all projects and switches to the search results view.
+func (m *appModel) runSearch(raw string) error {
+	q, err := search.Parse(raw)
+	if err != nil {
+		return err
+	}
+	if q.Empty() {
+		return errors.New("empty query")
+	}
+	if m.view != viewSearch {
+		m.searchReturnView = m.view
+		m.hasSearchReturnView = true
+	}
+	m.searchQuery = strings.TrimSpace(raw)
+	m.view = viewSearch
+	m.showPreview = false
+	m.pane = paneOutline
+	m.searchList.Select(0)
+	m.refreshSearch()
+	return nil
+}
+
+func (m *appModel) refreshSearch() {
+	if m == nil || m.db == nil {
+		return
+	}
+	prevID := ""
+	if it, ok := m.searchList.SelectedItem().(searchHitRowItem); ok {
+		prevID = it.hit.ItemID
+	}
+
+	q, err := search.Parse(m.searchQuery)
+	if err != nil {
+		m.searchList.SetItems(nil)
+		m.searchTotal = 0
+		return
+	}
+	opts := search.Options{ActorID: strings.TrimSpace(m.db.CurrentActorID), Today: time.Now()}
+	if len(q.Terms) > 0 {
+		// The FTS5 index only narrows the scan; nil means "not built yet", so scan everything.
+		if ids, err := m.store.SearchCandidates(context.Background(), q.Terms); err == nil {
+			opts.Candidates = ids
+		}
+	}
+	res := search.Run(m.db, q, opts)
+	m.searchTotal = res.Total
+
+	items := make([]list.Item, 0, len(res.Hits))
+	for _, h := range res.Hits {
+		row := searchHitRowItem{hit: h}
+		if p, ok := m.db.FindProject(h.ProjectID); ok && p != nil {
+			row.projectName = p.Name
+			row.archived = p.Archived
+		}
+		if o, ok := m.db.FindOutline(h.OutlineID); ok && o != nil {
+			if o.Name != nil {
+				row.outlineName = *o.Name
+			}
+			row.archived = row.archived || o.Archived
+		}
+		if it, ok := m.db.FindItem(h.ItemID); ok && it != nil && it.Archived {
+			row.archived = true
+		}
+		items = append(items, row)
+	}
+	m.searchList.SetItems(items)
+	if prevID != "" {
+		for i, li := range items {
+			if li.(searchHitRowItem).hit.ItemID == prevID {
+				m.searchList.Select(i)
+				return
+			}
+		}
+	}
+	if m.searchList.Index() >= len(items) {
+		m.searchList.Select(0)
+	}
+}
+
+func (m *appModel) leaveSearch() {
+	if m.hasSearchReturnView {
+		m.view = m.searchReturnView
+		m.hasSearchReturnView = false
+	} else {
+		m.view = viewProjects
+	}
+	switch m.view {
+	case viewProjects:
+		m.refreshProjects()
+	case viewOutlines:
+		m.refreshOutlines(m.selectedProjectID)
+	case viewAgenda:
+		m.refreshAgenda()
+	case viewArchived:
+		m.refreshArchived()
+	case viewOutline:
+		if o, ok := m.db.FindOutline(m.selectedOutlineID); ok {
+			m.refreshItems(*o)
+		}
+	case viewItem:
+		// The item view state is left untouched while searching.
+		if m.openItemID == "" || m.selectedOutline == nil {
+			m.view = viewProjects
+			m.refreshProjects()
+			return
+		}
+		m.refreshItemSubtree(*m.selectedOutline, m.openItemID)
+	}
+}
+
+func (m appModel) updateSearch(msg tea.Msg) (tea.Model, tea.Cmd) {
+	km, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+	switch km.String() {
+	case "ctrl+c", "q":
+		return m, m.quitWithStateCmd()
+	case "x", "?":
+		m.openActionPanel(actionPanelContext)
+		return m, nil
+	case "g":
+		m.openActionPanel(actionPanelNav)
+		return m, nil
+	case "a":
+		m.openActionPanel(actionPanelAgenda)
+		return m, nil
+	case "c":
+		m.openActionPanel(actionPanelCapture)
+		return m, nil
+	case "/", "f":
+		(&m).openSearchModal()
+		return m, nil
+	case "backspace", "esc":
+		(&m).leaveSearch()
+		return m, nil
+	case "enter":
+		it, ok := m.searchList.SelectedItem().(searchHitRowItem)
+		if !ok {
+			return m, nil
+		}
+		if it.archived {
+			m.showMinibuffer("Search: archived items open from the Archived view (g A)")
+			return m, nil
+		}
+		snap := m.captureReturnSnapshot()
+		if err := (&m).jumpToItemByID(it.hit.ItemID); err != nil {
+			m.showMinibuffer("Search: " + err.Error())
+			return m, nil
+		}
+		(&m).applyReturnSnapshot(snap)
+		return m, nil
+	}
+	var cmd tea.Cmd
+	m.searchList, cmd = m.searchList.Update(msg)
+	return m, cmd
+}
+
+func (m *appModel) viewSearch() string {
+	frameH := m.frameHeight()
+	if frameH < 8 {
+		frameH = 8
+	}
+	bodyHeight := frameH - (topPadLines + breadcrumbGap + 2)
+	if bodyHeight < 6 {
+		bodyHeight = 6
+	}
+
+	w := m.width
+	if w < 10 {
+		w = 10
+	}
+	contentW := w - 2*splitOuterMargin
+	if contentW < 10 {
+		contentW = w
+	}
+
+	crumb := lipgloss.NewStyle().Width(contentW).Foreground(colorChromeSubtleFg).Render(m.breadcrumbText())
+	var body string
+	if len(m.searchList.Items()) == 0 {
+		body = lipgloss.NewStyle().Foreground(colorChromeSubtleFg).Render("No matches. Press / to refine the query.")
+	} else {
+		body = m.listBodyWithOverflowHint(&m.searchList, contentW, bodyHeight)
+	}
+	main := strings.Repeat("\n", topPadLines) + crumb + strings.Repeat("\n", breadcrumbGap+1) + body
+	main = lipgloss.NewStyle().Width(w).Padding(0, splitOuterMargin).Render(main)
+	if m.modal == modalNone {
+		return main
+	}
+	bg := dimBackground(main)
+	fg := m.renderModal()
+	return overlayCenter(bg, fg, w, frameH)
+}
+
+// searchBreadcrumb renders `search > "query" (N)`.
+func (m *appModel) searchBreadcrumb() string {
+	return fmt.Sprintf("search > %q (%d)", m.searchQuery, m.searchTotal)
+}
@@ -0,0 +1,85 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"clarity-cli/internal/model"
+	"clarity-cli/internal/store"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestSearchView_RunOpenAndReturn(t *testing.T) {
+	now := time.Now().UTC()
+	db := &store.DB{
+		CurrentActorID: "act-test",
+		Actors:         []model.Actor{{ID: "act-test", Kind: model.ActorKindHuman, Name: "tester"}},
+		Projects:       []model.Project{{ID: "proj-a", Name: "Project", CreatedBy: "act-test", CreatedAt: now}},
+		Outlines:       []model.Outline{{ID: "out-a", ProjectID: "proj-a", StatusDefs: store.DefaultOutlineStatusDefs(), CreatedBy: "act-test", CreatedAt: now}},
+		Items: []model.Item{
+			{ID: "item-a", ProjectID: "proj-a", OutlineID: "out-a", Rank: "h", Title: "Deploy pipeline", StatusID: "todo", OwnerActorID: "act-test", CreatedBy: "act-test", CreatedAt: now, UpdatedAt: now},
+			{ID: "item-b", ProjectID: "proj-a", OutlineID: "out-a", Rank: "i", Title: "Docs", StatusID: "doing", OwnerActorID: "act-test", CreatedBy: "act-test", CreatedAt: now, UpdatedAt: now},
+		},
+		Comments: []model.Comment{{ID: "c-1", ItemID: "item-b", AuthorID: "act-test", Body: "remember to deploy the docs", CreatedAt: now}},
+	}
+
+	m := newAppModel(t.TempDir(), db)
+	m.width = 100
+	m.height = 30
+	m.view = viewProjects
+
+	if err := (&m).runSearch("deploy"); err != nil {
+		t.Fatalf("runSearch: %v", err)
+	}
+	if m.view != viewSearch {
+		t.Fatalf("expected search view, got %v", m.view)
+	}
+	if got := len(m.searchList.Items()); got != 2 {
+		t.Fatalf("expected 2 hits, got %d", got)
+	}
+	first := m.searchList.Items()[0].(searchHitRowItem)
+	if first.hit.ItemID != "item-a" {
+		t.Fatalf("expected title hit first, got %s", first.hit.ItemID)
+	}
+	if !strings.Contains(m.View(), "Deploy pipeline") {
+		t.Fatalf("expected results to render")
+	}
+
+	// Qualifiers narrow the results.
+	if err := (&m).runSearch("deploy status:doing"); err != nil {
+		t.Fatalf("runSearch: %v", err)
+	}
+	if got := len(m.searchList.Items()); got != 1 {
+		t.Fatalf("expected 1 hit for status:doing, got %d", got)
+	}
+	second := m.searchList.Items()[0].(searchHitRowItem)
+	if len(second.hit.Matches) == 0 || second.hit.Matches[0].Field != "comment" {
+		t.Fatalf("expected comment snippet, got %+v", second.hit.Matches)
+	}
+
+	mm, _ := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = mm.(appModel)
+	if m.view != viewItem || m.openItemID != "item-b" {
+		t.Fatalf("expected item view for item-b, got view=%v open=%q", m.view, m.openItemID)
+	}
+	if !m.hasReturnView || m.returnView != viewSearch {
+		t.Fatalf("expected return to search view")
+	}
+
+	(&m).returnFromItemView()
+	if m.view != viewSearch {
+		t.Fatalf("expected to be back on search view, got %v", m.view)
+	}
+
+	mm, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = mm.(appModel)
+	if m.view != viewProjects {
+		t.Fatalf("expected esc to leave search, got %v", m.view)
+	}
+
+	if err := (&m).runSearch("   "); err == nil {
+		t.Fatalf("expected empty query error")
+	}
+}
@@ -0,0 +1,84 @@
+package tui
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"clarity-cli/internal/store"
+)
+
+// undoLast undoes (or redoes) the current actor's most recent item edit from this session, via
+// compensating events. Edits made before the TUI started are left to `clarity undo`.
+func (m *appModel) undoLast(redo bool) {
+	if m == nil {
+		return
+	}
+	label := "Undo"
+	if redo {
+		label = "Redo"
+	}
+	if err := m.applyUndo(redo, label); err != nil {
+		m.showMinibuffer(label + ": " + err.Error())
+	}
+}
+
+func (m *appModel) applyUndo(redo bool, label string) error {
+	db, err := m.store.Load()
+	if err != nil {
+		return err
+	}
+	m.db = db
+	actorID := m.editActorID()
+	if actorID == "" {
+		return errors.New("no current actor")
+	}
+
+	var p store.UndoPlan
+	if redo {
+		p, err = m.store.PlanRedo(actorID, m.sessionStart)
+	} else {
+		p, err = m.store.PlanUndo(actorID, m.sessionStart)
+	}
+	switch {
+	case errors.Is(err, store.ErrNothingToUndo), errors.Is(err, store.ErrNothingToRedo):
+		m.showMinibuffer(label + ": nothing from this session")
+		return nil
+	case err != nil:
+		return err
+	}
+
+	it, ok := m.db.FindItem(p.Of.EntityID)
+	if !ok {
+		return fmt.Errorf("item not found: %s", p.Of.EntityID)
+	}
+	if !canEditItem(m.db, actorID, it) {
+		return errors.New("permission denied")
+	}
+	for _, pe := range p.Events {
+		if err := m.appendEvent(actorID, pe.Type, pe.EntityID, pe.Payload); err != nil {
+			return err
+		}
+	}
+	if err := p.Apply(m.db); err != nil {
+		return err
+	}
+	if err := m.store.Save(m.db); err != nil {
+		return err
+	}
+	m.refreshEventsTail()
+	m.captureStoreModTimes()
+	m.previewCacheForID = ""
+
+	title := p.Of.EntityID
+	if it, ok := m.db.FindItem(p.Of.EntityID); ok && strings.TrimSpace(it.Title) != "" {
+		title = strings.TrimSpace(it.Title)
+	}
+	m.showMinibuffer(fmt.Sprintf("%s: %s (%s)", label, strings.TrimPrefix(p.Of.Type, "item."), title))
+
+	m.refreshAfterItemChange(p.Of.EntityID)
+	if m.view == viewAgenda {
+		m.refreshAgenda()
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+package tui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"clarity-cli/internal/store"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestUndo_CtrlZRevertsOnlyThisSessionsEdits(t *testing.T) {
+	dir := t.TempDir()
+	if _, err := store.EnsureGitBackedV1Layout(dir); err != nil {
+		t.Fatalf("layout: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "events", "events.rep-a.jsonl"), []byte(scrubberEvents), 0o644); err != nil {
+		t.Fatalf("write events: %v", err)
+	}
+	s := store.Store{Dir: dir}
+	db, err := s.Load()
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	db.CurrentActorID = "act-1"
+	if err := s.Save(db); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+
+	m := newAppModel(dir, db)
+	m.view = viewOutline
+	m.selectedProjectID = "proj-1"
+	m.selectedOutlineID = "out-1"
+	m.selectedOutline = &db.Outlines[0]
+	m.refreshItems(db.Outlines[0])
+
+	key := func(m appModel, msg tea.KeyMsg) appModel {
+		t.Helper()
+		mm, _ := m.Update(msg)
+		return mm.(appModel)
+	}
+	priority := func(m appModel) bool {
+		t.Helper()
+		it, ok := m.db.FindItem("item-1")
+		if !ok {
+			t.Fatalf("item-1 missing")
+		}
+		return it.Priority
+	}
+
+	// Edits from before the session (the title/status events above) are out of reach.
+	m = key(m, tea.KeyMsg{Type: tea.KeyCtrlZ})
+	if !strings.Contains(m.minibufferText, "nothing from this session") {
+		t.Fatalf("expected nothing to undo, got %q", m.minibufferText)
+	}
+
+	if err := m.togglePriority("item-1"); err != nil {
+		t.Fatalf("toggle priority: %v", err)
+	}
+	if !priority(m) {
+		t.Fatalf("expected priority on")
+	}
+
+	m = key(m, tea.KeyMsg{Type: tea.KeyCtrlZ})
+	if priority(m) {
+		t.Fatalf("expected ctrl+z to turn priority back off (minibuffer=%q)", m.minibufferText)
+	}
+	m = key(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("u")})
+	if !strings.Contains(m.minibufferText, "nothing from this session") {
+		t.Fatalf("expected nothing left to undo, got %q", m.minibufferText)
+	}
+
+	m = key(m, tea.KeyMsg{Type: tea.KeyCtrlR})
+	if !priority(m) {
+		t.Fatalf("expected ctrl+r to redo (minibuffer=%q)", m.minibufferText)
+	}
+	reloaded, err := s.Load()
+	if err != nil {
+		t.Fatalf("reload: %v", err)
+	}
+	if it, _ := reloaded.FindItem("item-1"); !it.Priority {
+		t.Fatalf("expected redo to be saved")
+	}
+}